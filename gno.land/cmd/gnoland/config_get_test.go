@@ -545,6 +545,13 @@ func TestConfig_Get_RPC(t *testing.T) {
 				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.RPC.MaxOpenConnections))
 			},
 		},
+		{
+			"rpc max subscriptions per client updated",
+			"rpc.max_subscriptions_per_client",
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.RPC.MaxSubscriptionsPerClient))
+			},
+		},
 		{
 			"tx commit broadcast timeout updated",
 			"rpc.timeout_broadcast_tx_commit",
@@ -596,6 +603,13 @@ func TestConfig_Get_Mempool(t *testing.T) {
 				assert.Equal(t, value, loadedCfg.Mempool.RootDir)
 			},
 		},
+		{
+			"type updated",
+			"mempool.type",
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.Mempool.Type)
+			},
+		},
 		{
 			"recheck flag updated",
 			"mempool.recheck",
@@ -759,6 +759,16 @@ func TestConfig_Set_RPC(t *testing.T) {
 				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.RPC.MaxOpenConnections))
 			},
 		},
+		{
+			"rpc max subscriptions per client updated",
+			[]string{
+				"rpc.max_subscriptions_per_client",
+				"10",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.RPC.MaxSubscriptionsPerClient))
+			},
+		},
 		{
 			"tx commit broadcast timeout updated",
 			[]string{
@@ -828,6 +838,16 @@ func TestConfig_Set_Mempool(t *testing.T) {
 				assert.Equal(t, value, loadedCfg.Mempool.RootDir)
 			},
 		},
+		{
+			"type updated",
+			[]string{
+				"mempool.type",
+				"priority",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.Mempool.Type)
+			},
+		},
 		{
 			"recheck flag updated",
 			[]string{
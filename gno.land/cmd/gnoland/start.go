@@ -16,16 +16,20 @@ import (
 	"github.com/gnolang/gno/telemetry"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	mempoolcfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
 	"github.com/gnolang/gno/tm2/pkg/bft/node"
 	"github.com/gnolang/gno/tm2/pkg/bft/privval"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/file"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/null"
 	eventstorecfg "github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/types"
 	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
 	osm "github.com/gnolang/gno/tm2/pkg/os"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -158,6 +162,7 @@ func (c *startCfg) RegisterFlags(fs *flag.FlagSet) {
 				[]string{
 					null.EventStoreType,
 					file.EventStoreType,
+					kv.EventStoreType,
 				},
 				", ",
 			),
@@ -168,7 +173,11 @@ func (c *startCfg) RegisterFlags(fs *flag.FlagSet) {
 		&c.txEventStorePath,
 		"tx-event-store-path",
 		"",
-		fmt.Sprintf("path for the file tx event store (required if event store is '%s')", file.EventStoreType),
+		fmt.Sprintf(
+			"path for the file or kv tx event store (required if event store is '%s' or '%s')",
+			file.EventStoreType,
+			kv.EventStoreType,
+		),
 	)
 
 	fs.StringVar(
@@ -202,12 +211,6 @@ func execStart(c *startCfg, io commands.IO) error {
 		loadCfgErr error
 	)
 
-	// Attempt to initialize telemetry. If the environment variables required to initialize
-	// telemetry are not set, then the initialization will do nothing.
-	if err := initTelemetry(); err != nil {
-		return fmt.Errorf("error initializing telemetry: %w", err)
-	}
-
 	// Set the node configuration
 	if c.nodeConfigPath != "" {
 		// Load the node configuration
@@ -222,6 +225,12 @@ func execStart(c *startCfg, io commands.IO) error {
 		return fmt.Errorf("unable to load node configuration, %w", loadCfgErr)
 	}
 
+	// Attempt to initialize telemetry. If the environment variables required to initialize
+	// telemetry are not set, then the initialization will do nothing.
+	if err := initTelemetry(cfg); err != nil {
+		return fmt.Errorf("error initializing telemetry: %w", err)
+	}
+
 	// Initialize the log level
 	logLevel, err := zapcore.ParseLevel(c.logLevel)
 	if err != nil {
@@ -262,7 +271,24 @@ func execStart(c *startCfg, io commands.IO) error {
 	cfg.TxEventStore = txEventStoreCfg
 
 	// Create application and node.
-	gnoApp, err := gnoland.NewApp(dataDir, c.skipFailingGenesisTxs, logger, c.genesisMaxVMCycles)
+	appOpts := gnoland.NewAppOptions()
+	appOpts.Logger = logger
+	appOpts.SkipFailingGenesisTxs = c.skipFailingGenesisTxs
+	appOpts.SnapshotDir = filepath.Join(dataDir, "data", "snapshots")
+	appOpts.SnapshotOptions = snapshots.Options{
+		Interval:   cfg.StateSync.SnapshotInterval,
+		KeepRecent: cfg.StateSync.SnapshotKeepRecent,
+	}
+	if cfg.Mempool.Type == mempoolcfg.TypePriority {
+		appOpts.ReplaceTxs = true
+		appOpts.FeeDenom = "ugnot"
+	}
+	appOpts.DB, err = dbm.NewDB("gnolang", dbm.GoLevelDBBackend, filepath.Join(dataDir, "data"))
+	if err != nil {
+		return fmt.Errorf("error initializing database %q using path %q: %w", dbm.GoLevelDBBackend, dataDir, err)
+	}
+
+	gnoApp, err := gnoland.NewAppWithOptions(appOpts)
 	if err != nil {
 		return fmt.Errorf("error in creating new app: %w", err)
 	}
@@ -289,6 +315,13 @@ func execStart(c *startCfg, io commands.IO) error {
 			_ = gnoNode.Stop()
 		}
 
+		// Flush the metrics, and stop the metrics server
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := telemetry.Shutdown(ctx); err != nil {
+			logger.Error("unable to shutdown telemetry", "err", err)
+		}
+
 		// Sync the logger before exiting
 		_ = zapLogger.Sync()
 	})
@@ -374,6 +407,18 @@ func getTxEventStoreConfig(c *startCfg) (*eventstorecfg.Config, error) {
 				file.Path: c.txEventStorePath,
 			},
 		}
+	case kv.EventStoreType:
+		if c.txEventStorePath == "" {
+			return nil, errors.New("unspecified kv transaction indexer path")
+		}
+
+		// Fill out the configuration
+		cfg = &eventstorecfg.Config{
+			EventStoreType: kv.EventStoreType,
+			Params: map[string]any{
+				kv.Path: c.txEventStorePath,
+			},
+		}
 	default:
 		cfg = eventstorecfg.DefaultEventStoreConfig()
 	}
@@ -381,7 +426,9 @@ func getTxEventStoreConfig(c *startCfg) (*eventstorecfg.Config, error) {
 	return cfg, nil
 }
 
-func initTelemetry() error {
+// initTelemetry initializes telemetry from the environment variables.
+// The service instance ID defaults to the node moniker.
+func initTelemetry(cfg *config.Config) error {
 	var options []telemetry.Option
 
 	if os.Getenv("TELEM_METRICS_ENABLED") == "true" {
@@ -393,6 +440,9 @@ func initTelemetry() error {
 	options = append(options, telemetry.WithOptionMeterName(os.Getenv("TELEM_METER_NAME")))
 	options = append(options, telemetry.WithOptionExporterEndpoint(os.Getenv("TELEM_EXPORTER_ENDPOINT")))
 	options = append(options, telemetry.WithOptionServiceName(os.Getenv("TELEM_SERVICE_NAME")))
+	options = append(options, telemetry.WithOptionServiceInstanceID(cfg.Moniker))
+	options = append(options, telemetry.WithOptionServiceInstanceID(os.Getenv("TELEM_SERVICE_INSTANCE_ID")))
+	options = append(options, telemetry.WithOptionPrometheusAddr(os.Getenv("TELEM_PROMETHEUS_ADDR")))
 
 	return telemetry.Init(options...)
 }
@@ -0,0 +1,138 @@
+package gnoclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/query"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Subscriber subscribes to the events of a node over a websocket connection.
+// When the connection is lost, it reconnects and subscribes again.
+type Subscriber struct {
+	WS *rpcclient.WS // Websocket client
+}
+
+// NewSubscriber creates a subscriber to the events of the node at the given
+// remote address, ie. tcp://127.0.0.1:26657. Start must be called before
+// subscribing.
+func NewSubscriber(remote string) *Subscriber {
+	return &Subscriber{
+		WS: rpcclient.NewWS(remote, "/websocket"),
+	}
+}
+
+// Start connects to the node.
+func (s *Subscriber) Start() error {
+	return s.WS.Start()
+}
+
+// Stop closes the connection and all the subscriptions.
+func (s *Subscriber) Stop() error {
+	return s.WS.Stop()
+}
+
+// Subscribe subscribes to the events matching a raw query,
+// ie. "tm.event = 'NewBlock'".
+func (s *Subscriber) Subscribe(ctx context.Context, query string) (*rpcclient.Subscription, error) {
+	return s.WS.Subscribe(ctx, query)
+}
+
+// Unsubscribe cancels a subscription.
+func (s *Subscriber) Unsubscribe(ctx context.Context, query string) error {
+	return s.WS.Unsubscribe(ctx, query)
+}
+
+// TxFilter selects the transactions of a subscription.
+// Empty fields match any transaction.
+type TxFilter struct {
+	Signer  crypto.Address // Signer of the transaction
+	MsgType string         // Type of one of the messages, ie. "exec" or "send"
+	PkgPath string         // Package path targeted by one of the messages
+}
+
+// Query returns the subscription query of the filter.
+func (f TxFilter) Query() string {
+	conds := []string{fmt.Sprintf("%s = 'Tx'", query.TagEvent)}
+	if !f.Signer.IsZero() {
+		conds = append(conds, fmt.Sprintf("%s = '%s'", query.TagSigner, f.Signer))
+	}
+	if f.MsgType != "" {
+		conds = append(conds, fmt.Sprintf("%s = '%s'", query.TagMsgType, f.MsgType))
+	}
+	if f.PkgPath != "" {
+		conds = append(conds, fmt.Sprintf("%s = '%s'", query.TagPath, f.PkgPath))
+	}
+
+	return strings.Join(conds, " AND ")
+}
+
+// TxEvent is a transaction included in a block.
+type TxEvent struct {
+	Result types.TxResult // Result of the transaction
+	Tx     std.Tx         // Decoded transaction
+}
+
+// TxSubscription receives the transactions matching its filter.
+type TxSubscription struct {
+	Filter TxFilter
+
+	sub *rpcclient.Subscription
+	txs chan TxEvent
+}
+
+// SubscribeTxs subscribes to the transactions matching the filter.
+func (s *Subscriber) SubscribeTxs(ctx context.Context, filter TxFilter) (*TxSubscription, error) {
+	sub, err := s.WS.Subscribe(ctx, filter.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	txSub := &TxSubscription{
+		Filter: filter,
+		sub:    sub,
+		txs:    make(chan TxEvent),
+	}
+	go txSub.run()
+
+	return txSub, nil
+}
+
+// Txs returns the channel of the transactions of the subscription, which is
+// closed when the subscription is cancelled.
+func (s *TxSubscription) Txs() <-chan TxEvent {
+	return s.txs
+}
+
+// Err returns the reason why the subscription was cancelled.
+// See rpcclient.Subscription.Err.
+func (s *TxSubscription) Err() error {
+	return s.sub.Err()
+}
+
+func (s *TxSubscription) run() {
+	defer close(s.txs)
+
+	for result := range s.sub.Events() {
+		event, ok := result.Event.(types.EventTx)
+		if !ok {
+			continue
+		}
+
+		var tx std.Tx
+		if err := amino.Unmarshal(event.Result.Tx, &tx); err != nil {
+			continue
+		}
+
+		s.txs <- TxEvent{
+			Result: event.Result,
+			Tx:     tx,
+		}
+	}
+}
@@ -6,11 +6,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -1079,3 +1083,174 @@ func TestAddPackageErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestNewTx(t *testing.T) {
+	t.Parallel()
+
+	caller, _ := crypto.AddressFromBech32("g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5")
+	toAddress, _ := crypto.AddressFromBech32("g14a0y9a64dugh3l7hneshdxr4w0rfkkww9ls35p")
+
+	cfg := BaseTxCfg{
+		GasWanted: 0, // to be simulated
+		GasFee:    "10000ugnot",
+		Memo:      "Test memo",
+	}
+
+	tx, err := NewTx(cfg, caller,
+		MsgCall{
+			PkgPath:  "gno.land/r/demo/deep/very/deep",
+			FuncName: "Render",
+			Args:     []string{""},
+			Send:     "100ugnot",
+		},
+		MsgSend{
+			ToAddress: toAddress,
+			Send:      "10ugnot",
+		},
+	)
+	require.NoError(t, err)
+
+	expected := &std.Tx{
+		Msgs: []std.Msg{
+			vm.MsgCall{
+				Caller:  caller,
+				PkgPath: "gno.land/r/demo/deep/very/deep",
+				Func:    "Render",
+				Args:    []string{""},
+				Send:    std.NewCoins(std.NewCoin("ugnot", 100)),
+			},
+			bank.MsgSend{
+				FromAddress: caller,
+				ToAddress:   toAddress,
+				Amount:      std.NewCoins(std.NewCoin("ugnot", 10)),
+			},
+		},
+		Fee:  std.NewFee(0, std.NewCoin("ugnot", 10000)),
+		Memo: "Test memo",
+	}
+	assert.Equal(t, expected, tx)
+	assert.Equal(t, []crypto.Address{caller}, tx.GetSigners())
+
+	// Errors
+	_, err = NewTx(BaseTxCfg{GasWanted: -1, GasFee: "10000ugnot"}, caller, MsgSend{ToAddress: toAddress})
+	assert.ErrorIs(t, err, ErrInvalidGasWanted)
+	_, err = NewTx(BaseTxCfg{GasFee: ""}, caller, MsgSend{ToAddress: toAddress})
+	assert.ErrorIs(t, err, ErrInvalidGasFee)
+	_, err = NewTx(cfg, caller, MsgSend{ToAddress: toAddress}, MsgCall{PkgPath: "gno.land/r/demo/deep/very/deep"})
+	assert.ErrorIs(t, err, ErrEmptyFuncName)
+	_, err = NewTx(cfg, caller, MsgRun{})
+	assert.ErrorIs(t, err, ErrEmptyPackage)
+}
+
+func TestSimulate(t *testing.T) {
+	t.Parallel()
+
+	caller, _ := crypto.AddressFromBech32("g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5")
+	tx, err := NewTx(BaseTxCfg{GasFee: "10000ugnot"}, caller, MsgCall{
+		PkgPath:  "gno.land/r/demo/deep/very/deep",
+		FuncName: "Render",
+	})
+	require.NoError(t, err)
+
+	simulate := func(res abci.ResponseDeliverTx) func(path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+		return func(path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+			assert.Equal(t, ".app/simulate", path)
+
+			// the simulated tx has a zero signature for the caller.
+			var simTx std.Tx
+			require.NoError(t, amino.Unmarshal(data, &simTx))
+			assert.Equal(t, []std.Signature{{}}, simTx.Signatures)
+
+			return &ctypes.ResultABCIQuery{
+				Response: abci.ResponseQuery{
+					Value: amino.MustMarshal(res),
+				},
+			}, nil
+		}
+	}
+
+	client := Client{
+		RPCClient: &mockRPCClient{
+			abciQuery: simulate(abci.ResponseDeliverTx{GasUsed: 12345}),
+		},
+	}
+	res, err := client.Simulate(*tx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12345), res.GasUsed)
+
+	client = Client{
+		RPCClient: &mockRPCClient{
+			abciQuery: simulate(abci.ResponseDeliverTx{
+				ResponseBase: abci.ResponseBase{
+					Error: abci.StringError("out of luck"),
+					Log:   "failed",
+				},
+				GasUsed: 100,
+			}),
+		},
+	}
+	res, err = client.Simulate(*tx)
+	assert.ErrorContains(t, err, "out of luck")
+	require.NotNil(t, res)
+	assert.Equal(t, int64(100), res.GasUsed)
+
+	_, err = (&Client{}).Simulate(*tx)
+	assert.ErrorIs(t, err, ErrMissingRPCClient)
+}
+
+func TestSignAndBroadcast(t *testing.T) {
+	t.Parallel()
+
+	caller, _ := crypto.AddressFromBech32("g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5")
+	tx, err := NewTx(BaseTxCfg{GasWanted: 100000, GasFee: "10000ugnot"}, caller, MsgCall{
+		PkgPath:  "gno.land/r/demo/deep/very/deep",
+		FuncName: "Render",
+	})
+	require.NoError(t, err)
+
+	var broadcasted types.Tx
+	client := Client{
+		Signer: &mockSigner{
+			sign: func(cfg SignCfg) (*std.Tx, error) {
+				assert.Equal(t, uint64(1), cfg.AccountNumber)
+				assert.Equal(t, uint64(2), cfg.SequenceNumber)
+				signed := cfg.UnsignedTX
+				signed.Signatures = []std.Signature{{Signature: []byte("signature")}}
+				return &signed, nil
+			},
+		},
+		RPCClient: &mockRPCClient{
+			broadcastTxSync: func(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+				broadcasted = tx
+				return &ctypes.ResultBroadcastTx{Hash: []byte("hash")}, nil
+			},
+			broadcastTxAsync: func(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+				return nil, errors.New("connection refused")
+			},
+		},
+	}
+
+	signedTx, err := client.Sign(SignCfg{UnsignedTX: *tx, AccountNumber: 1, SequenceNumber: 2})
+	require.NoError(t, err)
+
+	res, err := client.BroadcastTxSync(*signedTx)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hash"), res.Hash)
+	assert.Equal(t, types.Tx(amino.MustMarshal(signedTx)), broadcasted)
+
+	_, err = client.BroadcastTxAsync(*signedTx)
+	assert.ErrorContains(t, err, "connection refused")
+
+	// CheckTx errors are returned.
+	client.RPCClient = &mockRPCClient{
+		broadcastTxSync: func(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+			return &ctypes.ResultBroadcastTx{Error: abci.StringError("invalid sequence"), Log: "check failed"}, nil
+		},
+	}
+	res, err = client.BroadcastTxSync(*signedTx)
+	assert.ErrorContains(t, err, "invalid sequence")
+	assert.NotNil(t, res)
+
+	_, err = (&Client{}).Sign(SignCfg{UnsignedTX: *tx})
+	assert.ErrorIs(t, err, ErrMissingSigner)
+}
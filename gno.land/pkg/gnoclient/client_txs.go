@@ -4,6 +4,7 @@ import (
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/gnovm/pkg/transpiler"
 	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/errors"
@@ -32,12 +33,20 @@ type BaseTxCfg struct {
 	Memo           string // Memo
 }
 
+// Msg is a message of a transaction built with NewTx: MsgCall, MsgSend,
+// MsgRun or MsgAddPackage.
+type Msg interface {
+	validate() error
+	stdMsg(caller crypto.Address) (std.Msg, error)
+}
+
 // MsgCall - syntax sugar for vm.MsgCall
 type MsgCall struct {
-	PkgPath  string   // Package path
-	FuncName string   // Function name
-	Args     []string // Function arguments
-	Send     string   // Send amount
+	PkgPath    string   // Package path
+	FuncName   string   // Function name
+	Args       []string // Function arguments
+	Send       string   // Send amount
+	MaxDeposit string   // Max storage deposit
 }
 
 // MsgSend - syntax sugar for bank.MsgSend
@@ -48,117 +57,138 @@ type MsgSend struct {
 
 // MsgRun - syntax sugar for vm.MsgRun
 type MsgRun struct {
-	Package *std.MemPackage // Package to run
-	Send    string          // Send amount
+	Package    *std.MemPackage // Package to run
+	Send       string          // Send amount
+	MaxDeposit string          // Max storage deposit
 }
 
 // MsgAddPackage - syntax sugar for vm.MsgAddPackage
 type MsgAddPackage struct {
-	Package *std.MemPackage // Package to add
-	Deposit string          // Coin deposit
+	Package    *std.MemPackage // Package to add
+	Deposit    string          // Coin deposit
+	MaxDeposit string          // Max storage deposit
 }
 
-// Call executes one or more MsgCall calls on the blockchain
-func (c *Client) Call(cfg BaseTxCfg, msgs ...MsgCall) (*ctypes.ResultBroadcastTxCommit, error) {
-	// Validate required client fields.
-	if err := c.validateSigner(); err != nil {
+func (msg MsgCall) stdMsg(caller crypto.Address) (std.Msg, error) {
+	// Parse send coins
+	send, err := std.ParseCoins(msg.Send)
+	if err != nil {
 		return nil, err
 	}
-	if err := c.validateRPCClient(); err != nil {
+
+	// Parse max storage deposit
+	maxDeposit, err := std.ParseCoins(msg.MaxDeposit)
+	if err != nil {
 		return nil, err
 	}
 
-	// Validate base transaction config
-	if err := cfg.validateBaseTxConfig(); err != nil {
+	// Unwrap syntax sugar to vm.MsgCall
+	return vm.MsgCall{
+		Caller:     caller,
+		PkgPath:    msg.PkgPath,
+		Func:       msg.FuncName,
+		Args:       msg.Args,
+		Send:       send,
+		MaxDeposit: maxDeposit,
+	}, nil
+}
+
+func (msg MsgSend) stdMsg(caller crypto.Address) (std.Msg, error) {
+	// Parse send coins
+	send, err := std.ParseCoins(msg.Send)
+	if err != nil {
 		return nil, err
 	}
 
-	// Parse MsgCall slice
-	vmMsgs := make([]std.Msg, 0, len(msgs))
-	for _, msg := range msgs {
-		// Validate MsgCall fields
-		if err := msg.validateMsgCall(); err != nil {
-			return nil, err
-		}
-
-		// Parse send coins
-		send, err := std.ParseCoins(msg.Send)
-		if err != nil {
-			return nil, err
-		}
+	// Unwrap syntax sugar to bank.MsgSend
+	return bank.MsgSend{
+		FromAddress: caller,
+		ToAddress:   msg.ToAddress,
+		Amount:      send,
+	}, nil
+}
 
-		// Unwrap syntax sugar to vm.MsgCall slice
-		vmMsgs = append(vmMsgs, std.Msg(vm.MsgCall{
-			Caller:  c.Signer.Info().GetAddress(),
-			PkgPath: msg.PkgPath,
-			Func:    msg.FuncName,
-			Args:    msg.Args,
-			Send:    send,
-		}))
+func (msg MsgRun) stdMsg(caller crypto.Address) (std.Msg, error) {
+	// Parse send coins
+	send, err := std.ParseCoins(msg.Send)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse gas fee
-	gasFeeCoins, err := std.ParseCoin(cfg.GasFee)
+	// Parse max storage deposit
+	maxDeposit, err := std.ParseCoins(msg.MaxDeposit)
 	if err != nil {
 		return nil, err
 	}
 
-	// Pack transaction
-	tx := std.Tx{
-		Msgs:       vmMsgs,
-		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
-		Signatures: nil,
-		Memo:       cfg.Memo,
+	// Transpile and validate Gno syntax
+	if err = transpiler.TranspileAndCheckMempkg(msg.Package); err != nil {
+		return nil, err
 	}
 
-	return c.signAndBroadcastTxCommit(tx, cfg.AccountNumber, cfg.SequenceNumber)
+	msg.Package.Name = "main"
+	msg.Package.Path = ""
+
+	// Unwrap syntax sugar to vm.MsgRun
+	return vm.MsgRun{
+		Caller:     caller,
+		Package:    msg.Package,
+		Send:       send,
+		MaxDeposit: maxDeposit,
+	}, nil
 }
 
-// Run executes one or more MsgRun calls on the blockchain
-func (c *Client) Run(cfg BaseTxCfg, msgs ...MsgRun) (*ctypes.ResultBroadcastTxCommit, error) {
-	// Validate required client fields.
-	if err := c.validateSigner(); err != nil {
+func (msg MsgAddPackage) stdMsg(caller crypto.Address) (std.Msg, error) {
+	// Parse deposit coins
+	deposit, err := std.ParseCoins(msg.Deposit)
+	if err != nil {
 		return nil, err
 	}
-	if err := c.validateRPCClient(); err != nil {
+
+	// Parse max storage deposit
+	maxDeposit, err := std.ParseCoins(msg.MaxDeposit)
+	if err != nil {
 		return nil, err
 	}
 
-	// Validate base transaction config
-	if err := cfg.validateBaseTxConfig(); err != nil {
+	// Transpile and validate Gno syntax
+	if err = transpiler.TranspileAndCheckMempkg(msg.Package); err != nil {
+		return nil, err
+	}
+
+	// Unwrap syntax sugar to vm.MsgAddPackage
+	return vm.MsgAddPackage{
+		Creator:    caller,
+		Package:    msg.Package,
+		Deposit:    deposit,
+		MaxDeposit: maxDeposit,
+	}, nil
+}
+
+// NewTx builds the unsigned transaction of msgs, sent by caller, which can be
+// any mix of MsgCall, MsgSend, MsgRun and MsgAddPackage. It doesn't need a
+// Client, so it can be used to prepare a transaction for an offline signer.
+// cfg.GasWanted may be left to 0, and set from the gas used reported by
+// Client.Simulate. cfg.AccountNumber and cfg.SequenceNumber are not used.
+func NewTx(cfg BaseTxCfg, caller crypto.Address, msgs ...Msg) (*std.Tx, error) {
+	// Validate transaction config
+	if err := cfg.validateTxConfig(); err != nil {
 		return nil, err
 	}
 
-	// Parse MsgRun slice
-	vmMsgs := make([]std.Msg, 0, len(msgs))
+	// Parse msgs
+	stdMsgs := make([]std.Msg, 0, len(msgs))
 	for _, msg := range msgs {
-		// Validate MsgCall fields
-		if err := msg.validateMsgRun(); err != nil {
+		// Validate msg fields
+		if err := msg.validate(); err != nil {
 			return nil, err
 		}
 
-		// Parse send coins
-		send, err := std.ParseCoins(msg.Send)
+		stdMsg, err := msg.stdMsg(caller)
 		if err != nil {
 			return nil, err
 		}
-
-		caller := c.Signer.Info().GetAddress()
-
-		// Transpile and validate Gno syntax
-		if err = transpiler.TranspileAndCheckMempkg(msg.Package); err != nil {
-			return nil, err
-		}
-
-		msg.Package.Name = "main"
-		msg.Package.Path = ""
-
-		// Unwrap syntax sugar to vm.MsgCall slice
-		vmMsgs = append(vmMsgs, std.Msg(vm.MsgRun{
-			Caller:  caller,
-			Package: msg.Package,
-			Send:    send,
-		}))
+		stdMsgs = append(stdMsgs, stdMsg)
 	}
 
 	// Parse gas fee
@@ -168,18 +198,53 @@ func (c *Client) Run(cfg BaseTxCfg, msgs ...MsgRun) (*ctypes.ResultBroadcastTxCo
 	}
 
 	// Pack transaction
-	tx := std.Tx{
-		Msgs:       vmMsgs,
+	return &std.Tx{
+		Msgs:       stdMsgs,
 		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
 		Signatures: nil,
 		Memo:       cfg.Memo,
+	}, nil
+}
+
+// Call executes one or more MsgCall calls on the blockchain
+func (c *Client) Call(cfg BaseTxCfg, msgs ...MsgCall) (*ctypes.ResultBroadcastTxCommit, error) {
+	txMsgs := make([]Msg, 0, len(msgs))
+	for _, msg := range msgs {
+		txMsgs = append(txMsgs, msg)
 	}
+	return c.signAndBroadcastMsgs(cfg, txMsgs)
+}
 
-	return c.signAndBroadcastTxCommit(tx, cfg.AccountNumber, cfg.SequenceNumber)
+// Run executes one or more MsgRun calls on the blockchain
+func (c *Client) Run(cfg BaseTxCfg, msgs ...MsgRun) (*ctypes.ResultBroadcastTxCommit, error) {
+	txMsgs := make([]Msg, 0, len(msgs))
+	for _, msg := range msgs {
+		txMsgs = append(txMsgs, msg)
+	}
+	return c.signAndBroadcastMsgs(cfg, txMsgs)
 }
 
 // Send executes one or more MsgSend calls on the blockchain
 func (c *Client) Send(cfg BaseTxCfg, msgs ...MsgSend) (*ctypes.ResultBroadcastTxCommit, error) {
+	txMsgs := make([]Msg, 0, len(msgs))
+	for _, msg := range msgs {
+		txMsgs = append(txMsgs, msg)
+	}
+	return c.signAndBroadcastMsgs(cfg, txMsgs)
+}
+
+// AddPackage executes one or more AddPackage calls on the blockchain
+func (c *Client) AddPackage(cfg BaseTxCfg, msgs ...MsgAddPackage) (*ctypes.ResultBroadcastTxCommit, error) {
+	txMsgs := make([]Msg, 0, len(msgs))
+	for _, msg := range msgs {
+		txMsgs = append(txMsgs, msg)
+	}
+	return c.signAndBroadcastMsgs(cfg, txMsgs)
+}
+
+// signAndBroadcastMsgs builds the transaction of msgs, signs it and
+// broadcasts it, returning the result
+func (c *Client) signAndBroadcastMsgs(cfg BaseTxCfg, msgs []Msg) (*ctypes.ResultBroadcastTxCommit, error) {
 	// Validate required client fields.
 	if err := c.validateSigner(); err != nil {
 		return nil, err
@@ -193,130 +258,99 @@ func (c *Client) Send(cfg BaseTxCfg, msgs ...MsgSend) (*ctypes.ResultBroadcastTx
 		return nil, err
 	}
 
-	// Parse MsgSend slice
-	vmMsgs := make([]std.Msg, 0, len(msgs))
+	// Validate msgs before getting the caller from the signer
 	for _, msg := range msgs {
-		// Validate MsgSend fields
-		if err := msg.validateMsgSend(); err != nil {
-			return nil, err
-		}
-
-		// Parse send coins
-		send, err := std.ParseCoins(msg.Send)
-		if err != nil {
+		if err := msg.validate(); err != nil {
 			return nil, err
 		}
-
-		// Unwrap syntax sugar to vm.MsgSend slice
-		vmMsgs = append(vmMsgs, std.Msg(bank.MsgSend{
-			FromAddress: c.Signer.Info().GetAddress(),
-			ToAddress:   msg.ToAddress,
-			Amount:      send,
-		}))
 	}
 
-	// Parse gas fee
-	gasFeeCoins, err := std.ParseCoin(cfg.GasFee)
+	tx, err := NewTx(cfg, c.Signer.Info().GetAddress(), msgs...)
 	if err != nil {
 		return nil, err
 	}
 
-	// Pack transaction
-	tx := std.Tx{
-		Msgs:       vmMsgs,
-		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
-		Signatures: nil,
-		Memo:       cfg.Memo,
+	signedTx, err := c.Sign(SignCfg{
+		UnsignedTX:     *tx,
+		SequenceNumber: cfg.SequenceNumber,
+		AccountNumber:  cfg.AccountNumber,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return c.signAndBroadcastTxCommit(tx, cfg.AccountNumber, cfg.SequenceNumber)
+	return c.BroadcastTxCommit(*signedTx)
 }
 
-// AddPackage executes one or more AddPackage calls on the blockchain
-func (c *Client) AddPackage(cfg BaseTxCfg, msgs ...MsgAddPackage) (*ctypes.ResultBroadcastTxCommit, error) {
-	// Validate required client fields.
-	if err := c.validateSigner(); err != nil {
-		return nil, err
-	}
+// Simulate runs tx on the blockchain without committing it, with the
+// ".app/simulate" query, and returns the result. The gas used of the result
+// is an estimate of the gas wanted by tx. tx doesn't need to be signed.
+func (c *Client) Simulate(tx std.Tx) (*abci.ResponseDeliverTx, error) {
 	if err := c.validateRPCClient(); err != nil {
 		return nil, err
 	}
 
-	// Validate base transaction config
-	if err := cfg.validateBaseTxConfig(); err != nil {
-		return nil, err
+	// Simulated txs have zero signatures.
+	if len(tx.Signatures) == 0 {
+		tx.Signatures = make([]std.Signature, len(tx.GetSigners()))
 	}
 
-	// Parse MsgRun slice
-	vmMsgs := make([]std.Msg, 0, len(msgs))
-	for _, msg := range msgs {
-		// Validate MsgCall fields
-		if err := msg.validateMsgAddPackage(); err != nil {
-			return nil, err
-		}
-
-		// Parse deposit coins
-		deposit, err := std.ParseCoins(msg.Deposit)
-		if err != nil {
-			return nil, err
-		}
-
-		caller := c.Signer.Info().GetAddress()
-
-		// Transpile and validate Gno syntax
-		if err = transpiler.TranspileAndCheckMempkg(msg.Package); err != nil {
-			return nil, err
-		}
-
-		// Unwrap syntax sugar to vm.MsgCall slice
-		vmMsgs = append(vmMsgs, std.Msg(vm.MsgAddPackage{
-			Creator: caller,
-			Package: msg.Package,
-			Deposit: deposit,
-		}))
+	bz, err := amino.Marshal(tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling tx binary bytes")
 	}
 
-	// Parse gas fee
-	gasFeeCoins, err := std.ParseCoin(cfg.GasFee)
+	qres, err := c.RPCClient.ABCIQuery(".app/simulate", bz)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "simulate tx")
+	}
+	if qres.Response.Error != nil {
+		return nil, errors.Wrap(qres.Response.Error, "simulate tx failed: log:%s", qres.Response.Log)
 	}
 
-	// Pack transaction
-	tx := std.Tx{
-		Msgs:       vmMsgs,
-		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
-		Signatures: nil,
-		Memo:       cfg.Memo,
+	var res abci.ResponseDeliverTx
+	if err := amino.Unmarshal(qres.Response.Value, &res); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling simulate result")
+	}
+	if res.IsErr() {
+		return &res, errors.Wrap(res.Error, "simulate transaction failed: log:%s", res.Log)
 	}
 
-	return c.signAndBroadcastTxCommit(tx, cfg.AccountNumber, cfg.SequenceNumber)
+	return &res, nil
 }
 
-// signAndBroadcastTxCommit signs a transaction and broadcasts it, returning the result
-func (c *Client) signAndBroadcastTxCommit(tx std.Tx, accountNumber, sequenceNumber uint64) (*ctypes.ResultBroadcastTxCommit, error) {
-	caller := c.Signer.Info().GetAddress()
+// Sign signs cfg.UnsignedTX with the Signer. If cfg.AccountNumber or
+// cfg.SequenceNumber is 0, both are queried from the blockchain.
+func (c *Client) Sign(cfg SignCfg) (*std.Tx, error) {
+	if err := c.validateSigner(); err != nil {
+		return nil, err
+	}
 
-	if sequenceNumber == 0 || accountNumber == 0 {
-		account, _, err := c.QueryAccount(caller)
+	if cfg.SequenceNumber == 0 || cfg.AccountNumber == 0 {
+		account, _, err := c.QueryAccount(c.Signer.Info().GetAddress())
 		if err != nil {
 			return nil, errors.Wrap(err, "query account")
 		}
-		accountNumber = account.AccountNumber
-		sequenceNumber = account.Sequence
+		cfg.AccountNumber = account.AccountNumber
+		cfg.SequenceNumber = account.Sequence
 	}
 
-	signCfg := SignCfg{
-		UnsignedTX:     tx,
-		SequenceNumber: sequenceNumber,
-		AccountNumber:  accountNumber,
-	}
-	signedTx, err := c.Signer.Sign(signCfg)
+	signedTx, err := c.Signer.Sign(cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, "sign")
 	}
 
-	bz, err := amino.Marshal(signedTx)
+	return signedTx, nil
+}
+
+// BroadcastTxCommit broadcasts a signed transaction, and waits for it to be
+// committed in a block.
+func (c *Client) BroadcastTxCommit(tx std.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, err
+	}
+
+	bz, err := amino.Marshal(tx)
 	if err != nil {
 		return nil, errors.Wrap(err, "marshaling tx binary bytes")
 	}
@@ -336,4 +370,48 @@ func (c *Client) signAndBroadcastTxCommit(tx std.Tx, accountNumber, sequenceNumb
 	return bres, nil
 }
 
+// BroadcastTxSync broadcasts a signed transaction, and returns once it has
+// been checked, before it is committed.
+func (c *Client) BroadcastTxSync(tx std.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, err
+	}
+
+	bz, err := amino.Marshal(tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling tx binary bytes")
+	}
+
+	bres, err := c.RPCClient.BroadcastTxSync(bz)
+	if err != nil {
+		return nil, errors.Wrap(err, "broadcasting bytes")
+	}
+
+	if bres.Error != nil {
+		return bres, errors.Wrap(bres.Error, "check transaction failed: log:%s", bres.Log)
+	}
+
+	return bres, nil
+}
+
+// BroadcastTxAsync broadcasts a signed transaction, and returns without
+// waiting for it to be checked.
+func (c *Client) BroadcastTxAsync(tx std.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, err
+	}
+
+	bz, err := amino.Marshal(tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling tx binary bytes")
+	}
+
+	bres, err := c.RPCClient.BroadcastTxAsync(bz)
+	if err != nil {
+		return nil, errors.Wrap(err, "broadcasting bytes")
+	}
+
+	return bres, nil
+}
+
 // TODO: Add more functionality, examples, and unit tests.
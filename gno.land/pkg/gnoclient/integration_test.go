@@ -1,13 +1,16 @@
 package gnoclient
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/gnolang/gno/gnovm/pkg/gnolang"
 
 	"github.com/gnolang/gno/tm2/pkg/std"
 
 	"github.com/gnolang/gno/gno.land/pkg/integration"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
 	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
@@ -263,7 +266,8 @@ func main() {
 				},
 			},
 		},
-		Send: "",
+		Send:       "",
+		MaxDeposit: "10000000ugnot",
 	}
 
 	res, err := client.Run(baseCfg, msg)
@@ -331,7 +335,8 @@ func main() {
 				},
 			},
 		},
-		Send: "",
+		Send:       "",
+		MaxDeposit: "10000000ugnot",
 	}
 	msg2 := MsgRun{
 		Package: &std.MemPackage{
@@ -342,7 +347,8 @@ func main() {
 				},
 			},
 		},
-		Send: "",
+		Send:       "",
+		MaxDeposit: "10000000ugnot",
 	}
 
 	expected := "- before: 0\n- after: 10\nhi gnoclient!\n"
@@ -354,8 +360,9 @@ func main() {
 }
 
 func TestAddPackageSingle_Integration(t *testing.T) {
-	// Set up in-memory node
-	config, _ := integration.TestingNodeConfig(t, gnoenv.RootDir())
+	// Set up in-memory node, without the examples: the test packages are
+	// published under gno.land/p/demo, a namespace test1 doesn't own
+	config := integration.TestingMinimalNodeConfig(t, gnoenv.RootDir())
 	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
 	defer node.Stop()
 
@@ -400,7 +407,8 @@ func Echo(str string) string {
 				},
 			},
 		},
-		Deposit: deposit,
+		Deposit:    deposit,
+		MaxDeposit: "10000000ugnot",
 	}
 
 	// Execute AddPackage
@@ -422,8 +430,9 @@ func Echo(str string) string {
 }
 
 func TestAddPackageMultiple_Integration(t *testing.T) {
-	// Set up in-memory node
-	config, _ := integration.TestingNodeConfig(t, gnoenv.RootDir())
+	// Set up in-memory node, without the examples: the test packages are
+	// published under gno.land/p/demo, a namespace test1 doesn't own
+	config := integration.TestingMinimalNodeConfig(t, gnoenv.RootDir())
 	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
 	defer node.Stop()
 
@@ -473,7 +482,8 @@ func Hello(str string) string {
 				},
 			},
 		},
-		Deposit: "",
+		Deposit:    "",
+		MaxDeposit: "10000000ugnot",
 	}
 
 	msg2 := MsgAddPackage{
@@ -491,7 +501,8 @@ func Hello(str string) string {
 				},
 			},
 		},
-		Deposit: deposit,
+		Deposit:    deposit,
+		MaxDeposit: "10000000ugnot",
 	}
 
 	// Execute AddPackage
@@ -526,10 +537,135 @@ func Hello(str string) string {
 	assert.Equal(t, baseAcc.GetCoins().String(), deposit)
 }
 
+func TestTxBuilder_Integration(t *testing.T) {
+	// Set up in-memory node
+	config, _ := integration.TestingNodeConfig(t, gnoenv.RootDir())
+	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
+	defer node.Stop()
+
+	// Init Signer & RPCClient
+	signer := newInMemorySigner(t, "tendermint_test")
+	rpcClient := rpcclient.NewHTTP(remoteAddr, "/websocket")
+
+	// Setup Client
+	client := Client{
+		Signer:    signer,
+		RPCClient: rpcClient,
+	}
+
+	// Build an unsigned tx with a MsgCall and a MsgSend, without gas wanted
+	toAddress, _ := crypto.AddressFromBech32("g14a0y9a64dugh3l7hneshdxr4w0rfkkww9ls35p")
+	tx, err := NewTx(BaseTxCfg{GasFee: "10000ugnot"}, signer.Info().GetAddress(),
+		MsgCall{
+			PkgPath:  "gno.land/r/demo/deep/very/deep",
+			FuncName: "Render",
+			Args:     []string{"test argument"},
+		},
+		MsgSend{
+			ToAddress: toAddress,
+			Send:      "10ugnot",
+		},
+	)
+	require.NoError(t, err)
+
+	// Simulate it to get the gas wanted
+	sim, err := client.Simulate(*tx)
+	require.NoError(t, err)
+	assert.Equal(t, "(\"hi test argument\" string)", string(sim.Data))
+	require.Greater(t, sim.GasUsed, int64(0))
+	tx.Fee.GasWanted = sim.GasUsed * 11 / 10
+
+	// A failing tx can't be simulated
+	failing, err := NewTx(BaseTxCfg{GasFee: "10000ugnot"}, signer.Info().GetAddress(), MsgCall{
+		PkgPath:  "gno.land/r/demo/deep/very/deep",
+		FuncName: "NotAFunction",
+	})
+	require.NoError(t, err)
+	_, err = client.Simulate(*failing)
+	assert.Error(t, err)
+
+	// Sign and broadcast it
+	signedTx, err := client.Sign(SignCfg{UnsignedTX: *tx})
+	require.NoError(t, err)
+	res, err := client.BroadcastTxCommit(*signedTx)
+	require.NoError(t, err)
+	assert.Equal(t, "(\"hi test argument\" string)", string(res.DeliverTx.Data))
+	assert.LessOrEqual(t, res.DeliverTx.GasUsed, tx.Fee.GasWanted)
+
+	// Get the new account balance
+	account, _, err := client.QueryAccount(toAddress)
+	require.NoError(t, err)
+	assert.Equal(t, std.Coins{{"ugnot", 10}}, account.GetCoins())
+}
+
 // todo add more integration tests:
 // MsgCall with Send field populated (single/multiple)
 // MsgRun with Send field populated (single/multiple)
 
+func TestSubscribeTxs_Integration(t *testing.T) {
+	// Set up in-memory node
+	config, _ := integration.TestingNodeConfig(t, gnoenv.RootDir())
+	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
+	defer node.Stop()
+
+	// Init Signer, RPCClient & Subscriber
+	signer := newInMemorySigner(t, "tendermint_test")
+	rpcClient := rpcclient.NewHTTP(remoteAddr, "/websocket")
+	client := Client{
+		Signer:    signer,
+		RPCClient: rpcClient,
+	}
+	subscriber := NewSubscriber(remoteAddr)
+	require.NoError(t, subscriber.Start())
+	defer subscriber.Stop()
+
+	// Subscribe to the calls of a realm
+	filter := TxFilter{
+		Signer:  signer.Info().GetAddress(),
+		MsgType: "exec",
+		PkgPath: "gno.land/r/demo/deep/very/deep",
+	}
+	sub, err := subscriber.SubscribeTxs(context.Background(), filter)
+	require.NoError(t, err)
+
+	baseCfg := BaseTxCfg{
+		GasFee:    "10000ugnot",
+		GasWanted: 8000000,
+	}
+
+	// Send a transaction which doesn't match
+	_, err = client.Send(baseCfg, MsgSend{
+		ToAddress: crypto.MustAddressFromString("g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5"),
+		Send:      "1ugnot",
+	})
+	require.NoError(t, err)
+
+	// Call the realm
+	baseCfg.SequenceNumber = 1
+	res, err := client.Call(baseCfg, MsgCall{
+		PkgPath:  "gno.land/r/demo/deep/very/deep",
+		FuncName: "Render",
+		Args:     []string{"test argument"},
+	})
+	require.NoError(t, err)
+
+	select {
+	case txEvent, ok := <-sub.Txs():
+		require.True(t, ok, "subscription cancelled: %v", sub.Err())
+		assert.Equal(t, res.Height, txEvent.Result.Height)
+		assert.Equal(t, res.DeliverTx.Data, txEvent.Result.Response.Data)
+		require.Len(t, txEvent.Tx.Msgs, 1)
+		assert.Equal(t, "Render", txEvent.Tx.Msgs[0].(vm.MsgCall).Func)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the transaction")
+	}
+
+	require.NoError(t, subscriber.Unsubscribe(context.Background(), filter.Query()))
+	for range sub.Txs() {
+	}
+	assert.NoError(t, sub.Err())
+}
+
 func newInMemorySigner(t *testing.T, chainid string) *SignerFromKeybase {
 	t.Helper()
 
@@ -115,6 +115,8 @@ type (
 	mockBlockResults         func(height *int64) (*ctypes.ResultBlockResults, error)
 	mockCommit               func(height *int64) (*ctypes.ResultCommit, error)
 	mockValidators           func(height *int64) (*ctypes.ResultValidators, error)
+	mockTx                   func(hash []byte, prove bool) (*ctypes.ResultTx, error)
+	mockTxSearch             func(query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error)
 	mockStatus               func() (*ctypes.ResultStatus, error)
 	mockUnconfirmedTxs       func(limit int) (*ctypes.ResultUnconfirmedTxs, error)
 	mockNumUnconfirmedTxs    func() (*ctypes.ResultUnconfirmedTxs, error)
@@ -138,6 +140,8 @@ type mockRPCClient struct {
 	blockResults         mockBlockResults
 	commit               mockCommit
 	validators           mockValidators
+	tx                   mockTx
+	txSearch             mockTxSearch
 	status               mockStatus
 	unconfirmedTxs       mockUnconfirmedTxs
 	numUnconfirmedTxs    mockNumUnconfirmedTxs
@@ -262,6 +266,20 @@ func (m *mockRPCClient) Validators(height *int64) (*ctypes.ResultValidators, err
 	return nil, nil
 }
 
+func (m *mockRPCClient) Tx(hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	if m.tx != nil {
+		return m.tx(hash, prove)
+	}
+	return nil, nil
+}
+
+func (m *mockRPCClient) TxSearch(query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error) {
+	if m.txSearch != nil {
+		return m.txSearch(query, prove, page, perPage)
+	}
+	return nil, nil
+}
+
 func (m *mockRPCClient) Status() (*ctypes.ResultStatus, error) {
 	if m.status != nil {
 		return m.status()
@@ -13,7 +13,20 @@ func (cfg BaseTxCfg) validateBaseTxConfig() error {
 	return nil
 }
 
-func (msg MsgCall) validateMsgCall() error {
+// validateTxConfig is like validateBaseTxConfig, but allows a zero
+// GasWanted, for transactions which are built to be simulated.
+func (cfg BaseTxCfg) validateTxConfig() error {
+	if cfg.GasWanted < 0 {
+		return ErrInvalidGasWanted
+	}
+	if cfg.GasFee == "" {
+		return ErrInvalidGasFee
+	}
+
+	return nil
+}
+
+func (msg MsgCall) validate() error {
 	if msg.PkgPath == "" {
 		return ErrEmptyPkgPath
 	}
@@ -24,7 +37,7 @@ func (msg MsgCall) validateMsgCall() error {
 	return nil
 }
 
-func (msg MsgSend) validateMsgSend() error {
+func (msg MsgSend) validate() error {
 	if msg.ToAddress.IsZero() {
 		return ErrInvalidToAddress
 	}
@@ -36,7 +49,7 @@ func (msg MsgSend) validateMsgSend() error {
 	return nil
 }
 
-func (msg MsgRun) validateMsgRun() error {
+func (msg MsgRun) validate() error {
 	if msg.Package == nil || len(msg.Package.Files) == 0 {
 		return ErrEmptyPackage
 	}
@@ -44,7 +57,7 @@ func (msg MsgRun) validateMsgRun() error {
 	return nil
 }
 
-func (msg MsgAddPackage) validateMsgAddPackage() error {
+func (msg MsgAddPackage) validate() error {
 	if msg.Package == nil || len(msg.Package.Files) == 0 {
 		return ErrEmptyPackage
 	}
@@ -14,10 +14,12 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
 	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
 	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 
 	// Only goleveldb is supported for now.
 	_ "github.com/gnolang/gno/tm2/pkg/db/goleveldb"
@@ -32,6 +34,17 @@ type AppOptions struct {
 	SkipFailingGenesisTxs bool
 	Logger                *slog.Logger
 	MaxCycles             int64
+	// State sync snapshots are stored in `SnapshotDir`, if set,
+	// and taken according to `SnapshotOptions`.
+	SnapshotDir     string
+	SnapshotOptions snapshots.Options
+	// If `ReplaceTxs` is set, CheckTx accepts txs replacing pending txs with
+	// the same sequence; it must only be set with the priority mempool,
+	// which evicts the replaced txs.
+	ReplaceTxs bool
+	// If `FeeDenom` is set, CheckTx rejects txs paying their fee in another
+	// denom, so that the priority mempool can compare their fees.
+	FeeDenom string
 }
 
 func NewAppOptions() *AppOptions {
@@ -65,7 +78,11 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	baseKey := store.NewStoreKey("base")
 
 	// Create BaseApp.
-	baseApp := sdk.NewBaseApp("gnoland", cfg.Logger, cfg.DB, baseKey, mainKey)
+	var baseOptions []func(*sdk.BaseApp)
+	if cfg.SnapshotDir != "" {
+		baseOptions = append(baseOptions, sdk.SetSnapshotOptions(cfg.SnapshotDir, cfg.SnapshotOptions))
+	}
+	baseApp := sdk.NewBaseApp("gnoland", cfg.Logger, cfg.DB, baseKey, mainKey, baseOptions...)
 	baseApp.SetAppVersion("dev")
 
 	// Set mounts for BaseApp's MultiStore.
@@ -75,17 +92,20 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	// Construct keepers.
 	acctKpr := auth.NewAccountKeeper(mainKey, ProtoGnoAccount)
 	bankKpr := bank.NewBankKeeper(acctKpr)
+	paramsKpr := params.NewParamsKeeper(mainKey, "pv")
 
 	// XXX: Embed this ?
 	stdlibsDir := filepath.Join(cfg.GnoRootDir, "gnovm", "stdlibs")
-	vmKpr := vm.NewVMKeeper(baseKey, mainKey, acctKpr, bankKpr, stdlibsDir, cfg.MaxCycles)
+	vmKpr := vm.NewVMKeeper(baseKey, mainKey, acctKpr, bankKpr, paramsKpr, stdlibsDir, cfg.MaxCycles)
 
 	// Set InitChainer
-	baseApp.SetInitChainer(InitChainer(baseApp, acctKpr, bankKpr, cfg.SkipFailingGenesisTxs))
+	baseApp.SetInitChainer(InitChainer(baseApp, acctKpr, bankKpr, paramsKpr, vmKpr, cfg.SkipFailingGenesisTxs))
 
 	// Set AnteHandler
 	authOptions := auth.AnteOptions{
 		VerifyGenesisSignatures: false, // for development
+		FeeDenom:                cfg.FeeDenom,
+		ReplaceTxs:              cfg.ReplaceTxs,
 	}
 	authAnteHandler := auth.NewAnteHandler(
 		acctKpr, bankKpr, auth.DefaultSigVerificationGasConsumer, authOptions)
@@ -96,7 +116,7 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 		) {
 			// Override auth params.
 			ctx = ctx.WithValue(
-				auth.AuthParamsContextKey{}, auth.DefaultParams())
+				auth.AuthParamsContextKey{}, auth.LoadParams(ctx, paramsKpr))
 			// Continue on with default auth ante handler.
 			newCtx, res, abort = authAnteHandler(ctx, tx, simulate)
 			return
@@ -106,10 +126,18 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	// Set EndBlocker
 	baseApp.SetEndBlocker(EndBlocker(vmKpr))
 
+	// Set SnapshotRestorer
+	baseApp.SetSnapshotRestorer(func(ctx sdk.Context) error {
+		// The VMKeeper was initialized with the state replaced by the snapshot.
+		vmKpr.Reinitialize(ctx.MultiStore())
+		return nil
+	})
+
 	// Set a handler Route.
 	baseApp.Router().AddRoute("auth", auth.NewHandler(acctKpr))
-	baseApp.Router().AddRoute("bank", bank.NewHandler(bankKpr))
+	baseApp.Router().AddRoute("bank", bank.NewHandler(bankKpr, paramsKpr))
 	baseApp.Router().AddRoute("vm", vm.NewHandler(vmKpr))
+	baseApp.Router().AddRoute("params", params.NewHandler(paramsKpr))
 
 	// Load latest version.
 	if err := baseApp.LoadLatestVersion(); err != nil {
@@ -141,7 +169,7 @@ func NewApp(dataRootDir string, skipFailingGenesisTxs bool, logger *slog.Logger,
 }
 
 // InitChainer returns a function that can initialize the chain with genesis.
-func InitChainer(baseApp *sdk.BaseApp, acctKpr auth.AccountKeeperI, bankKpr bank.BankKeeperI, skipFailingGenesisTxs bool) func(sdk.Context, abci.RequestInitChain) abci.ResponseInitChain {
+func InitChainer(baseApp *sdk.BaseApp, acctKpr auth.AccountKeeperI, bankKpr bank.BankKeeperI, paramsKpr params.ParamsKeeperI, vmKpr vm.VMKeeperI, skipFailingGenesisTxs bool) func(sdk.Context, abci.RequestInitChain) abci.ResponseInitChain {
 	return func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
 		// Get genesis state.
 		genState := req.AppState.(GnoGenesisState)
@@ -154,6 +182,14 @@ func InitChainer(baseApp *sdk.BaseApp, acctKpr auth.AccountKeeperI, bankKpr bank
 				panic(err)
 			}
 		}
+		// Record the genesis validators, updated by the VM.
+		vmKpr.InitValidators(ctx, req.Validators)
+		// Set genesis state params.
+		for _, param := range genState.Params {
+			if err := param.Register(ctx, paramsKpr); err != nil {
+				panic(err)
+			}
+		}
 		// Run genesis txs.
 		for i, tx := range genState.Txs {
 			res := baseApp.Deliver(tx)
@@ -176,9 +212,12 @@ func InitChainer(baseApp *sdk.BaseApp, acctKpr auth.AccountKeeperI, bankKpr bank
 	}
 }
 
-// XXX not used yet.
+// EndBlocker returns a function that applies the validator set changes
+// recorded by the VM during the block.
 func EndBlocker(vmk vm.VMKeeperI) func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 	return func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
-		return abci.ResponseEndBlock{}
+		return abci.ResponseEndBlock{
+			ValidatorUpdates: vmk.ValidatorUpdates(ctx),
+		}
 	}
 }
@@ -0,0 +1,96 @@
+package gnoland
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	tu "github.com/gnolang/gno/tm2/pkg/sdk/testutils"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/jaekwon/testify/assert"
+	"github.com/jaekwon/testify/require"
+)
+
+// Test the replacement of a pending tx by a tx with the same sequence, as
+// done by the priority mempool.
+func TestAppCheckTxReplacement(t *testing.T) {
+	const chainID = "test-chain"
+
+	priv := secp256k1.GenPrivKey()
+	addr := priv.PubKey().Address()
+
+	opts := NewAppOptions()
+	opts.ReplaceTxs = true
+	opts.FeeDenom = "ugnot"
+	app, err := NewAppWithOptions(opts)
+	require.NoError(t, err)
+	app.InitChain(abci.RequestInitChain{
+		ChainID: chainID,
+		ConsensusParams: &abci.ConsensusParams{
+			Block: &abci.BlockParams{
+				MaxTxBytes:   1_000_000,
+				MaxDataBytes: 2_000_000,
+				MaxGas:       100_000_000,
+				TimeIotaMS:   100,
+			},
+		},
+		AppState: GnoGenesisState{
+			Balances: []Balance{{Address: addr, Amount: std.MustParseCoins("10000000ugnot")}},
+		},
+	})
+	commitBlock := func(height int64, txs ...std.Tx) {
+		t.Helper()
+
+		app.BeginBlock(abci.RequestBeginBlock{Header: &bft.Header{ChainID: chainID, Height: height}})
+		for _, tx := range txs {
+			res := app.DeliverTx(abci.RequestDeliverTx{Tx: amino.MustMarshal(tx)})
+			require.Nil(t, res.Error, res.Log)
+		}
+		app.EndBlock(abci.RequestEndBlock{Height: height})
+		app.Commit()
+	}
+	commitBlock(1)
+
+	newTx := func(seq uint64, fee std.Fee) std.Tx {
+		msgs := []std.Msg{bank.NewMsgSend(addr, crypto.AddressFromPreimage([]byte("to")), std.MustParseCoins("1ugnot"))}
+		return tu.NewTestTx(chainID, msgs, []crypto.PrivKey{priv}, []uint64{0}, []uint64{seq}, fee)
+	}
+	checkTx := func(tx std.Tx) abci.ResponseCheckTx {
+		return app.CheckTx(abci.RequestCheckTx{Tx: amino.MustMarshal(tx)})
+	}
+
+	tx1 := newTx(0, std.NewFee(100_000, std.MustParseCoin("1000ugnot")))
+	res := checkTx(tx1)
+	require.Nil(t, res.Error, res.Log)
+	assert.Equal(t, addr, res.Sender)
+	assert.Equal(t, uint64(0), res.Sequence)
+	assert.Equal(t, int64(1000), res.GasFee)
+
+	// the next pending tx.
+	res = checkTx(newTx(1, std.NewFee(100_000, std.MustParseCoin("1000ugnot"))))
+	require.Nil(t, res.Error, res.Log)
+	assert.Equal(t, uint64(1), res.Sequence)
+
+	// the first pending tx is replaced, with a higher fee.
+	tx2 := newTx(0, std.NewFee(100_000, std.MustParseCoin("2000ugnot")))
+	res = checkTx(tx2)
+	require.Nil(t, res.Error, res.Log)
+	assert.Equal(t, uint64(0), res.Sequence)
+	assert.Equal(t, int64(2000), res.GasFee)
+
+	// the fee must be paid in ugnot.
+	res = checkTx(newTx(2, std.NewFee(100_000, std.MustParseCoin("1000foo"))))
+	require.IsType(t, std.InvalidCoinsError{}, res.Error)
+
+	// once the replacement is committed, its sequence can't be reused.
+	commitBlock(2, tx2)
+	res = checkTx(newTx(0, std.NewFee(100_000, std.MustParseCoin("3000ugnot"))))
+	require.IsType(t, std.UnauthorizedError{}, res.Error)
+	res = checkTx(newTx(1, std.NewFee(100_000, std.MustParseCoin("1000ugnot"))))
+	require.Nil(t, res.Error, res.Log)
+	assert.Equal(t, uint64(1), res.Sequence)
+}
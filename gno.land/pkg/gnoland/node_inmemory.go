@@ -8,6 +8,7 @@ import (
 
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	tmcfg "github.com/gnolang/gno/tm2/pkg/bft/config"
+	mempoolcfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
 	"github.com/gnolang/gno/tm2/pkg/bft/node"
 	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
 	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
@@ -111,13 +112,18 @@ func NewInMemoryNode(logger *slog.Logger, cfg *InMemoryNodeConfig) (*node.Node,
 	}
 
 	// Initialize the application with the provided options
-	gnoApp, err := NewAppWithOptions(&AppOptions{
+	appOpts := &AppOptions{
 		Logger:                logger,
 		GnoRootDir:            cfg.TMConfig.RootDir,
 		SkipFailingGenesisTxs: cfg.SkipFailingGenesisTxs,
 		MaxCycles:             cfg.GenesisMaxVMCycles,
 		DB:                    memdb.NewMemDB(),
-	})
+	}
+	if cfg.TMConfig.Mempool.Type == mempoolcfg.TypePriority {
+		appOpts.ReplaceTxs = true
+		appOpts.FeeDenom = "ugnot"
+	}
+	gnoApp, err := NewAppWithOptions(appOpts)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing new app: %w", err)
 	}
@@ -1,18 +1,23 @@
 package gnoland
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 var (
 	ErrBalanceEmptyAddress = errors.New("balance address is empty")
 	ErrBalanceEmptyAmount  = errors.New("balance amount is empty")
+	ErrParamEmptyKey       = errors.New("param key is empty")
 )
 
 type GnoAccount struct {
@@ -25,6 +30,7 @@ func ProtoGnoAccount() std.Account {
 
 type GnoGenesisState struct {
 	Balances []Balance `json:"balances"`
+	Params   []Param   `json:"params"`
 	Txs      []std.Tx  `json:"txs"`
 }
 
@@ -77,3 +83,88 @@ func (b Balance) MarshalAmino() (string, error) {
 func (b Balance) String() string {
 	return fmt.Sprintf("%s=%s", b.Address.String(), b.Amount.String())
 }
+
+// Param is a genesis parameter, set in the params keeper.
+// The kind of the value is given by the suffix of the key.
+type Param struct {
+	Key   string
+	Value string
+}
+
+func (p *Param) Verify() error {
+	if p.Key == "" {
+		return ErrParamEmptyKey
+	}
+
+	kind, err := params.KeyKind(p.Key)
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case params.KindBool:
+		_, err = strconv.ParseBool(p.Value)
+	case params.KindInt64:
+		_, err = strconv.ParseInt(p.Value, 10, 64)
+	case params.KindUint64:
+		_, err = strconv.ParseUint(p.Value, 10, 64)
+	case params.KindBytes:
+		_, err = hex.DecodeString(p.Value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for param %q: %w", p.Value, p.Key, err)
+	}
+
+	return nil
+}
+
+func (p *Param) Parse(entry string) error {
+	parts := strings.SplitN(strings.TrimSpace(entry), "=", 2) // <key>.<kind>=<value>
+	if len(parts) != 2 {
+		return fmt.Errorf("malformed entry: %q", entry)
+	}
+
+	p.Key, p.Value = parts[0], parts[1]
+
+	return p.Verify()
+}
+
+// Register sets the parameter in the params keeper.
+// Bytes values are hex encoded.
+func (p Param) Register(ctx sdk.Context, prmk params.ParamsKeeperI) error {
+	if err := p.Verify(); err != nil {
+		return err
+	}
+
+	kind, _ := params.KeyKind(p.Key)
+	switch kind {
+	case params.KindString:
+		prmk.SetString(ctx, p.Key, p.Value)
+	case params.KindBool:
+		v, _ := strconv.ParseBool(p.Value)
+		prmk.SetBool(ctx, p.Key, v)
+	case params.KindInt64:
+		v, _ := strconv.ParseInt(p.Value, 10, 64)
+		prmk.SetInt64(ctx, p.Key, v)
+	case params.KindUint64:
+		v, _ := strconv.ParseUint(p.Value, 10, 64)
+		prmk.SetUint64(ctx, p.Key, v)
+	case params.KindBytes:
+		v, _ := hex.DecodeString(p.Value)
+		prmk.SetBytes(ctx, p.Key, v)
+	}
+
+	return nil
+}
+
+func (p *Param) UnmarshalAmino(rep string) error {
+	return p.Parse(rep)
+}
+
+func (p Param) MarshalAmino() (string, error) {
+	return p.String(), nil
+}
+
+func (p Param) String() string {
+	return fmt.Sprintf("%s=%s", p.Key, p.Value)
+}
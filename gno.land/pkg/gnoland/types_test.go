@@ -96,3 +96,48 @@ func TestBalance_AminoMarshalJSON(t *testing.T) {
 	require.NoError(t, err)
 	require.JSONEq(t, expectedJSON, string(balancesJSON))
 }
+
+func TestParam_Parse(t *testing.T) {
+	tests := []struct {
+		name      string
+		entry     string
+		expected  Param
+		expectErr bool
+	}{
+		{"valid int64", "vm.max_cycles.int64=1000", Param{Key: "vm.max_cycles.int64", Value: "1000"}, false},
+		{"valid string with equal sign", "foo.bar.string=a=b", Param{Key: "foo.bar.string", Value: "a=b"}, false},
+		{"valid bytes", "foo.bar.bytes=cafe", Param{Key: "foo.bar.bytes", Value: "cafe"}, false},
+		{"missing kind", "vm.max_cycles=1000", Param{}, true},
+		{"unknown kind", "vm.max_cycles.float=1000", Param{}, true},
+		{"invalid int64", "vm.max_cycles.int64=abc", Param{}, true},
+		{"invalid bytes", "foo.bar.bytes=xyz", Param{}, true},
+		{"incomplete entry", "vm.max_cycles.int64", Param{}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			param := Param{}
+			err := param.Parse(tc.entry)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, param)
+			}
+		})
+	}
+}
+
+func TestParam_AminoJSON(t *testing.T) {
+	expected := Param{Key: "auth.max_memo_bytes.int64", Value: "100"}
+	expectedJSON := fmt.Sprintf("[%q]", expected.String())
+
+	paramsJSON, err := amino.MarshalJSON([]Param{expected})
+	require.NoError(t, err)
+	require.JSONEq(t, expectedJSON, string(paramsJSON))
+
+	var params []Param
+	err = amino.UnmarshalJSON(paramsJSON, &params)
+	require.NoError(t, err)
+	require.Equal(t, []Param{expected}, params)
+}
@@ -19,9 +19,10 @@ import (
 type MakeAddPkgCfg struct {
 	RootCfg *client.MakeTxCfg
 
-	PkgPath string
-	PkgDir  string
-	Deposit string
+	PkgPath    string
+	PkgDir     string
+	Deposit    string
+	MaxDeposit string
 }
 
 func NewMakeAddPkgCmd(rootCfg *client.MakeTxCfg, io commands.IO) *commands.Command {
@@ -63,6 +64,13 @@ func (c *MakeAddPkgCfg) RegisterFlags(fs *flag.FlagSet) {
 		"",
 		"deposit coins",
 	)
+
+	fs.StringVar(
+		&c.MaxDeposit,
+		"max-deposit",
+		"",
+		"max storage deposit (no storage growth if empty)",
+	)
 }
 
 func execMakeAddPkg(cfg *MakeAddPkgCfg, args []string, io commands.IO) error {
@@ -96,6 +104,12 @@ func execMakeAddPkg(cfg *MakeAddPkgCfg, args []string, io commands.IO) error {
 		panic(err)
 	}
 
+	// parse max storage deposit.
+	maxDeposit, err := std.ParseCoins(cfg.MaxDeposit)
+	if err != nil {
+		panic(err)
+	}
+
 	// open files in directory as MemPackage.
 	memPkg := gno.ReadMemPackage(cfg.PkgDir, cfg.PkgPath)
 	if memPkg.IsEmpty() {
@@ -116,9 +130,10 @@ func execMakeAddPkg(cfg *MakeAddPkgCfg, args []string, io commands.IO) error {
 	}
 	// construct msg & tx and marshal.
 	msg := vm.MsgAddPackage{
-		Creator: creator,
-		Package: memPkg,
-		Deposit: deposit,
+		Creator:    creator,
+		Package:    memPkg,
+		Deposit:    deposit,
+		MaxDeposit: maxDeposit,
 	}
 	tx := std.Tx{
 		Msgs:       []std.Msg{msg},
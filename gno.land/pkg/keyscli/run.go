@@ -20,6 +20,8 @@ import (
 
 type MakeRunCfg struct {
 	RootCfg *client.MakeTxCfg
+
+	MaxDeposit string
 }
 
 func NewMakeRunCmd(rootCfg *client.MakeTxCfg, cmdio commands.IO) *commands.Command {
@@ -40,7 +42,14 @@ func NewMakeRunCmd(rootCfg *client.MakeTxCfg, cmdio commands.IO) *commands.Comma
 	)
 }
 
-func (c *MakeRunCfg) RegisterFlags(fs *flag.FlagSet) {}
+func (c *MakeRunCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.MaxDeposit,
+		"max-deposit",
+		"",
+		"max storage deposit (no storage growth if empty)",
+	)
+}
 
 func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 	if len(args) != 2 {
@@ -74,6 +83,12 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 		return errors.Wrap(err, "parsing gas fee coin")
 	}
 
+	// Parse max storage deposit.
+	maxDeposit, err := std.ParseCoins(cfg.MaxDeposit)
+	if err != nil {
+		return errors.Wrap(err, "parsing max deposit coins")
+	}
+
 	memPkg := &std.MemPackage{}
 	if sourcePath == "-" { // stdin
 		data, err := io.ReadAll(cmdio.In())
@@ -120,8 +135,9 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 
 	// construct msg & tx and marshal.
 	msg := vm.MsgRun{
-		Caller:  caller,
-		Package: memPkg,
+		Caller:     caller,
+		Package:    memPkg,
+		MaxDeposit: maxDeposit,
 	}
 	tx := std.Tx{
 		Msgs:       []std.Msg{msg},
@@ -1,8 +1,10 @@
 package vm
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/stdlibs"
@@ -92,3 +94,95 @@ func (bnk *SDKBanker) RemoveCoin(b32addr crypto.Bech32Address, denom string, amo
 		panic(err)
 	}
 }
+
+// ----------------------------------------
+// SDKParams
+
+// sysParamsRealmPath is the realm allowed to set the params of the chain
+// modules. Keys set by other realms are scoped to their realm path. Like
+// other system packages, it can only be added at genesis.
+const sysParamsRealmPath = "gno.land/r/system/params"
+
+type SDKParams struct {
+	vmk *VMKeeper
+	ctx sdk.Context
+}
+
+func NewSDKParams(vmk *VMKeeper, ctx sdk.Context) *SDKParams {
+	return &SDKParams{
+		vmk: vmk,
+		ctx: ctx,
+	}
+}
+
+func (prm *SDKParams) SetString(key, value string) {
+	key = prm.assertValidParam(key, value)
+	prm.vmk.prmk.SetString(prm.ctx, key, value)
+}
+
+func (prm *SDKParams) SetBool(key string, value bool) {
+	key = prm.assertValidParam(key, value)
+	prm.vmk.prmk.SetBool(prm.ctx, key, value)
+}
+
+func (prm *SDKParams) SetInt64(key string, value int64) {
+	key = prm.assertValidParam(key, value)
+	prm.vmk.prmk.SetInt64(prm.ctx, key, value)
+}
+
+func (prm *SDKParams) SetUint64(key string, value uint64) {
+	key = prm.assertValidParam(key, value)
+	prm.vmk.prmk.SetUint64(prm.ctx, key, value)
+}
+
+func (prm *SDKParams) SetBytes(key string, value []byte) {
+	key = prm.assertValidParam(key, value)
+	prm.vmk.prmk.SetBytes(prm.ctx, key, value)
+}
+
+func (prm *SDKParams) GetInt64(key string, ptr *int64) bool {
+	return prm.vmk.prmk.GetInt64(prm.ctx, key, ptr)
+}
+
+// assertValidParam returns the key under which the param is stored, and
+// panics if it is a param of the chain modules and value isn't valid for it,
+// or if it is unknown.
+func (prm *SDKParams) assertValidParam(key string, value interface{}) string {
+	mkey := moduleParamKey(key)
+	if mkey == key {
+		// scoped to the realm setting it.
+		return key
+	}
+	if err := validateModuleParam(prm.ctx, prm.vmk.prmk, mkey, value); err != nil {
+		panic(err)
+	}
+	return mkey
+}
+
+// moduleParamKey strips the realm prefix of keys set by the system params
+// realm, so that they match the keys read by the chain modules.
+func moduleParamKey(key string) string {
+	return strings.TrimPrefix(key, sysParamsRealmPath+":")
+}
+
+// ----------------------------------------
+// SDKValidators
+
+type SDKValidators struct {
+	vmk *VMKeeper
+	ctx sdk.Context
+}
+
+func NewSDKValidators(vmk *VMKeeper, ctx sdk.Context) *SDKValidators {
+	return &SDKValidators{
+		vmk: vmk,
+		ctx: ctx,
+	}
+}
+
+func (vals *SDKValidators) UpdateValidator(realmPath string, pubKey crypto.PubKey, power int64) error {
+	if realmPath != sysValidatorsRealmPath {
+		return fmt.Errorf("validators can only be updated by %s", sysValidatorsRealmPath)
+	}
+	return vals.vmk.updateValidator(vals.ctx, pubKey, power)
+}
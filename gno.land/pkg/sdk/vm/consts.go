@@ -4,3 +4,11 @@ const (
 	ModuleName = "vm"
 	RouterKey  = ModuleName
 )
+
+// ParamMaxCycles is the param overriding the max allowed cycles on VM
+// executions, set in the node config.
+const ParamMaxCycles = "vm.max_cycles.int64"
+
+// ParamStoragePrice is the param setting the price of a persisted byte of
+// realm state, ie. "10ugnot".
+const ParamStoragePrice = "vm.storage_price.string"
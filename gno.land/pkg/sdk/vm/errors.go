@@ -10,14 +10,16 @@ func (abciError) AssertABCIError() {}
 // declare all script errors.
 // NOTE: these are meant to be used in conjunction with pkgs/errors.
 type (
-	InvalidPkgPathError struct{ abciError }
-	InvalidStmtError    struct{ abciError }
-	InvalidExprError    struct{ abciError }
+	InvalidPkgPathError   struct{ abciError }
+	InvalidStmtError      struct{ abciError }
+	InvalidExprError      struct{ abciError }
+	UnauthorizedUserError struct{ abciError }
 )
 
-func (e InvalidPkgPathError) Error() string { return "invalid package path" }
-func (e InvalidStmtError) Error() string    { return "invalid statement" }
-func (e InvalidExprError) Error() string    { return "invalid expression" }
+func (e InvalidPkgPathError) Error() string   { return "invalid package path" }
+func (e InvalidStmtError) Error() string      { return "invalid statement" }
+func (e InvalidExprError) Error() string      { return "invalid expression" }
+func (e UnauthorizedUserError) Error() string { return "unauthorized user" }
 
 func ErrInvalidPkgPath(msg string) error {
 	return errors.Wrap(InvalidPkgPathError{}, msg)
@@ -30,3 +32,7 @@ func ErrInvalidStmt(msg string) error {
 func ErrInvalidExpr(msg string) error {
 	return errors.Wrap(InvalidExprError{}, msg)
 }
+
+func ErrUnauthorizedUser(msg string) error {
+	return errors.Wrap(UnauthorizedUserError{}, msg)
+}
@@ -0,0 +1,307 @@
+package vm
+
+import (
+	"testing"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gasTestPkgPath = "gno.land/r/gastest"
+
+// setupGasTestEnv deploys a realm with a cheap and an expensive function.
+func setupGasTestEnv(t *testing.T) (testEnv, crypto.Address) {
+	t.Helper()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	files := []*std.MemFile{
+		{
+			Name: "gastest.gno",
+			Body: `package gastest
+
+func Cheap() int {
+	return 1
+}
+
+func Expensive() int {
+	s := 0
+	for i := 0; i < 1000; i++ {
+		s += i
+	}
+	return s
+}
+
+func Alloc() int {
+	x := make([]int, 0)
+	for i := 0; i < 1000; i++ {
+		x = append(x, i)
+	}
+	return len(x)
+}`,
+		},
+	}
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, gasTestPkgPath, files)))
+
+	return env, addr
+}
+
+// callGasUsed calls the given function with a fresh gas meter,
+// and returns the amount of gas consumed.
+func callGasUsed(t *testing.T, env testEnv, addr crypto.Address, fn string) int64 {
+	t.Helper()
+
+	ctx := env.ctx.WithGasMeter(store.NewInfiniteGasMeter())
+	_, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, gasTestPkgPath, fn, []string{}))
+	require.NoError(t, err)
+
+	return ctx.GasMeter().GasConsumed()
+}
+
+func TestVMKeeperCallGas(t *testing.T) {
+	env, addr := setupGasTestEnv(t)
+
+	cheap := callGasUsed(t, env, addr, "Cheap")
+	expensive := callGasUsed(t, env, addr, "Expensive")
+	alloc := callGasUsed(t, env, addr, "Alloc")
+
+	assert.Greater(t, cheap, int64(0))
+	assert.Greater(t, expensive, cheap)
+	assert.Greater(t, alloc, expensive)
+}
+
+func TestVMKeeperCallReleasesGasMeter(t *testing.T) {
+	env, addr := setupGasTestEnv(t)
+
+	// The allocator is shared by the messages of a block: the gas meter of
+	// a message isn't charged once it is done.
+	ctx := env.ctx.WithGasMeter(store.NewInfiniteGasMeter())
+	_, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, gasTestPkgPath, "Alloc", []string{}))
+	require.NoError(t, err)
+
+	gasUsed := ctx.GasMeter().GasConsumed()
+	env.vmk.gnoStore.GetAllocator().Allocate(1000)
+	assert.Equal(t, gasUsed, ctx.GasMeter().GasConsumed())
+}
+
+func TestVMKeeperCallOutOfGas(t *testing.T) {
+	env, addr := setupGasTestEnv(t)
+
+	// Give enough gas for the cheap call overhead,
+	// but not enough to complete the loop.
+	cheap := callGasUsed(t, env, addr, "Cheap")
+	expensive := callGasUsed(t, env, addr, "Expensive")
+	ctx := env.ctx.WithGasMeter(store.NewGasMeter(cheap + (expensive-cheap)/2))
+
+	assert.PanicsWithValue(t, store.OutOfGasException{Descriptor: gno.GasCPUCyclesDesc}, func() {
+		env.vmk.Call(ctx, NewMsgCall(addr, nil, gasTestPkgPath, "Expensive", []string{}))
+	})
+}
+
+func TestVMKeeperAddPackageOutOfGas(t *testing.T) {
+	env := setupTestEnv()
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(env.ctx, addr)
+	env.acck.SetAccount(env.ctx, acc)
+
+	ctx := env.ctx.WithGasMeter(store.NewGasMeter(100))
+
+	files := []*std.MemFile{
+		{
+			Name: "test.gno",
+			Body: `package test
+
+var x = make([]int, 1000)
+`,
+		},
+	}
+
+	assert.Panics(t, func() {
+		defer func() {
+			r := recover()
+			_, ok := r.(store.OutOfGasException)
+			assert.True(t, ok, "expected out of gas exception, got %v", r)
+			panic(r)
+		}()
+
+		env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, "gno.land/r/test", files))
+	})
+}
+
+func TestVMKeeperVerifySignatureGas(t *testing.T) {
+	env, addr := setupGasTestEnv(t)
+	ctx := env.ctx
+
+	const pkgPath = "gno.land/r/verifytest"
+	files := []*std.MemFile{
+		{
+			Name: "verifytest.gno",
+			Body: `package verifytest
+
+import (
+	"encoding/hex"
+	"std"
+)
+
+const (
+	pubKey = "gpub1pgfj7ard9eg82cjtv4u4xetrwqer2dntxyfzxz3pqtvxr7ykpy4yh87vhjxysjmzm7x7qaa7veufz06p8zas49rsjcy8j50m6kp"
+	sigHex = "cd12db722e8c3db7e9b7150dda6f48fac8fd384fce8e833432bf185088af478f6818f9d1621b34c8f9462c63627298dfe9ed5d2d7b8d4e032baa09d61b34c0a1"
+)
+
+func Decode() bool {
+	_, err := hex.DecodeString(sigHex)
+	return err == nil
+}
+
+func Verify() bool {
+	sig, _ := hex.DecodeString(sigHex)
+	return std.VerifySignature(pubKey, []byte("hello gno"), sig)
+}
+
+func ForgeEmpty() bool {
+	sig, _ := hex.DecodeString(sigHex)
+	msg := make([]byte, 10000)
+	return !std.VerifySignature(pubKey, msg[:0], sig)
+}
+
+func Forge() bool {
+	sig, _ := hex.DecodeString(sigHex)
+	msg := make([]byte, 10000)
+	return !std.VerifySignature(pubKey, msg, sig)
+}`,
+		},
+	}
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files)))
+
+	gasUsed := func(fn string) int64 {
+		ctx := env.ctx.WithGasMeter(store.NewInfiniteGasMeter())
+		res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, fn, []string{}))
+		require.NoError(t, err)
+		assert.Equal(t, "(true bool)", res)
+
+		return ctx.GasMeter().GasConsumed()
+	}
+
+	// the verification of a secp256k1 signature costs 1000 gas by default,
+	// plus 1 gas for each byte of the message.
+	assert.GreaterOrEqual(t, gasUsed("Verify")-gasUsed("Decode"), int64(1000))
+	assert.InDelta(t, 10000, gasUsed("Forge")-gasUsed("ForgeEmpty"), 100)
+
+	// the cost of a signature is the auth param.
+	verify := gasUsed("Verify")
+	env.prmk.SetInt64(ctx, auth.ParamSigVerifyCostSecp256k1, 100000)
+	assert.InDelta(t, 100000-1000, gasUsed("Verify")-verify, 100)
+}
+
+func TestVMKeeperEmitGas(t *testing.T) {
+	env, addr := setupGasTestEnv(t)
+	ctx := env.ctx
+
+	const pkgPath = "gno.land/r/emittest"
+	files := []*std.MemFile{
+		{
+			Name: "emittest.gno",
+			Body: `package emittest
+
+import (
+	"std"
+	"strings"
+)
+
+func EmitEmpty(size int) {
+	_ = strings.Repeat("x", size)
+	std.Emit("Event", "key", "")
+}
+
+func Emit(size int) {
+	std.Emit("Event", "key", strings.Repeat("x", size))
+}`,
+		},
+	}
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files)))
+
+	gasUsed := func(fn string) int64 {
+		ctx := env.ctx.WithGasMeter(store.NewInfiniteGasMeter())
+		_, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, fn, []string{"1000"}))
+		require.NoError(t, err)
+
+		return ctx.GasMeter().GasConsumed()
+	}
+
+	// each byte of the attributes costs 10 gas.
+	assert.InDelta(t, 1000*10, gasUsed("Emit")-gasUsed("EmitEmpty"), 100)
+}
+
+func TestVMKeeperBigGas(t *testing.T) {
+	env, addr := setupGasTestEnv(t)
+	ctx := env.ctx
+
+	const pkgPath = "gno.land/r/bigtest"
+	files := []*std.MemFile{
+		{
+			Name: "bigtest.gno",
+			Body: `package bigtest
+
+import "math/big"
+
+func Square(words int) bool {
+	x := new(big.Int).Lsh(big.NewInt(1), uint(64*words))
+	x.Mul(x, x)
+	return x.Sign() > 0
+}
+
+func Pow(n int64) bool {
+	x := new(big.Int).Exp(big.NewInt(3), big.NewInt(n), nil)
+	return x.Sign() > 0
+}
+
+func Shift(n uint) bool {
+	x := new(big.Int).Lsh(big.NewInt(1), n)
+	return x.Sign() > 0
+}`,
+		},
+	}
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files)))
+
+	gasUsed := func(fn, arg string) int64 {
+		ctx := env.ctx.WithGasMeter(store.NewInfiniteGasMeter())
+		res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, fn, []string{arg}))
+		require.NoError(t, err)
+		assert.Equal(t, "(true bool)", res)
+
+		return ctx.GasMeter().GasConsumed()
+	}
+
+	// the multiplication of two 257-word operands costs at least 257*257 gas.
+	assert.GreaterOrEqual(t, gasUsed("Square", "256")-gasUsed("Square", "1"), int64(257*257))
+
+	// a huge exponentiation runs out of gas before it is computed.
+	assert.Panics(t, func() {
+		defer func() {
+			r := recover()
+			_, ok := r.(store.OutOfGasException)
+			assert.True(t, ok, "expected out of gas exception, got %v", r)
+			panic(r)
+		}()
+
+		ctx := env.ctx.WithGasMeter(store.NewGasMeter(10_000_000))
+		env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Pow", []string{"1000000000000"}))
+	})
+
+	// a huge shift exceeds the allocation limit before it is computed.
+	ctx = env.ctx.WithGasMeter(store.NewInfiniteGasMeter())
+	_, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Shift", []string{"4294967296"}))
+	assert.ErrorContains(t, err, "allocation limit exceeded")
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/gnolang/gno/telemetry"
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
@@ -22,6 +24,10 @@ func NewHandler(vm *VMKeeper) vmHandler {
 }
 
 func (vh vmHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
+	if telemetry.MetricsEnabled() {
+		defer recordMsgMetrics(ctx, msg, ctx.GasMeter().GasConsumed())
+	}
+
 	switch msg := msg.(type) {
 	case MsgAddPackage:
 		return vh.handleMsgAddPackage(ctx, msg)
@@ -36,7 +42,7 @@ func (vh vmHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
 }
 
 // Handle MsgAddPackage.
-func (vh vmHandler) handleMsgAddPackage(ctx sdk.Context, msg MsgAddPackage) sdk.Result {
+func (vh vmHandler) handleMsgAddPackage(ctx sdk.Context, msg MsgAddPackage) (res sdk.Result) {
 	amount, err := std.ParseCoins("1000000ugnot") // XXX calculate
 	if err != nil {
 		return abciResult(err)
@@ -49,7 +55,8 @@ func (vh vmHandler) handleMsgAddPackage(ctx sdk.Context, msg MsgAddPackage) sdk.
 	if err != nil {
 		return abciResult(err)
 	}
-	return sdk.Result{}
+	res.Events = ctx.EventLogger().Events()
+	return
 }
 
 // Handle MsgCall.
@@ -68,15 +75,8 @@ func (vh vmHandler) handleMsgCall(ctx sdk.Context, msg MsgCall) (res sdk.Result)
 		return abciResult(err)
 	}
 	res.Data = []byte(resstr)
+	res.Events = ctx.EventLogger().Events()
 	return
-	/* TODO handle events.
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			sdk.EventTypeMessage,
-			sdk.NewAttribute(sdk.AttributeKeyXXX, types.AttributeValueXXX),
-		),
-	)
-	*/
 }
 
 // Handle MsgRun.
@@ -95,6 +95,7 @@ func (vh vmHandler) handleMsgRun(ctx sdk.Context, msg MsgRun) (res sdk.Result) {
 		return abciResult(err)
 	}
 	res.Data = []byte(resstr)
+	res.Events = ctx.EventLogger().Events()
 	return
 }
 
@@ -109,6 +110,8 @@ const (
 	QueryFuncs   = "qfuncs"
 	QueryEval    = "qeval"
 	QueryFile    = "qfile"
+	QueryLatest  = "qlatest"
+	QueryStorage = "qstorage"
 )
 
 func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
@@ -125,6 +128,10 @@ func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.Resp
 		return vh.queryEval(ctx, req)
 	case QueryFile:
 		return vh.queryFile(ctx, req)
+	case QueryLatest:
+		return vh.queryLatest(ctx, req)
+	case QueryStorage:
+		return vh.queryStorage(ctx, req)
 	default:
 		res = sdk.ABCIResponseQueryFromError(
 			std.ErrUnknownRequest(fmt.Sprintf(
@@ -214,6 +221,30 @@ func (vh vmHandler) queryFile(ctx sdk.Context, req abci.RequestQuery) (res abci.
 	return
 }
 
+// queryLatest returns the path of the latest version of a package.
+func (vh vmHandler) queryLatest(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	pkgPath := string(req.Data)
+	result, err := vh.vm.QueryLatestVersion(ctx, pkgPath)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(err)
+		return
+	}
+	res.Data = []byte(result)
+	return
+}
+
+// queryStorage returns the storage usage of a realm, and its deposit.
+func (vh vmHandler) queryStorage(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	pkgPath := string(req.Data)
+	result, err := vh.vm.QueryStorage(ctx, pkgPath)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(err)
+		return
+	}
+	res.Data = amino.MustMarshalJSON(result)
+	return
+}
+
 //----------------------------------------
 // misc
 
@@ -11,10 +11,13 @@ import (
 
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/stdlibs"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
 )
@@ -30,6 +33,8 @@ type VMKeeperI interface {
 	AddPackage(ctx sdk.Context, msg MsgAddPackage) error
 	Call(ctx sdk.Context, msg MsgCall) (res string, err error)
 	Run(ctx sdk.Context, msg MsgRun) (res string, err error)
+	InitValidators(ctx sdk.Context, vals []abci.ValidatorUpdate)
+	ValidatorUpdates(ctx sdk.Context) []abci.ValidatorUpdate
 }
 
 var _ VMKeeperI = &VMKeeper{}
@@ -40,6 +45,7 @@ type VMKeeper struct {
 	iavlKey    store.StoreKey
 	acck       auth.AccountKeeper
 	bank       bank.BankKeeper
+	prmk       params.ParamsKeeperI
 	stdlibsDir string
 
 	// cached, the DeliverTx persistent state.
@@ -54,6 +60,7 @@ func NewVMKeeper(
 	iavlKey store.StoreKey,
 	acck auth.AccountKeeper,
 	bank bank.BankKeeper,
+	prmk params.ParamsKeeperI,
 	stdlibsDir string,
 	maxCycles int64,
 ) *VMKeeper {
@@ -63,6 +70,7 @@ func NewVMKeeper(
 		iavlKey:    iavlKey,
 		acck:       acck,
 		bank:       bank,
+		prmk:       prmk,
 		stdlibsDir: stdlibsDir,
 		maxCycles:  maxCycles,
 	}
@@ -95,6 +103,22 @@ func (vm *VMKeeper) Initialize(ms store.MultiStore) {
 	}
 }
 
+// Reinitialize discards the gnoStore and initializes it again from ms, whose
+// state replaced the one the VMKeeper was initialized with, e.g. when it was
+// restored from a state sync snapshot.
+func (vm *VMKeeper) Reinitialize(ms store.MultiStore) {
+	vm.gnoStore = nil
+	vm.Initialize(ms)
+}
+
+// getMaxCycles returns the max allowed cycles on VM executions, which can be
+// changed with the "vm.max_cycles.int64" param.
+func (vm *VMKeeper) getMaxCycles(ctx sdk.Context) int64 {
+	maxCycles := vm.maxCycles
+	vm.prmk.GetInt64(ctx, ParamMaxCycles, &maxCycles)
+	return maxCycles
+}
+
 func (vm *VMKeeper) getGnoStore(ctx sdk.Context) gno.Store {
 	// construct main gnoStore if nil.
 	if vm.gnoStore == nil {
@@ -131,7 +155,80 @@ func (vm *VMKeeper) getGnoStore(ctx sdk.Context) gno.Store {
 	}
 }
 
-var reRunPath = regexp.MustCompile(`gno\.land/r/g[a-z0-9]+/run`)
+// releaseGasMeter detaches the gas meter of a message from the allocator of
+// the store, which in deliver mode is shared by the messages of a block.
+func releaseGasMeter(store gno.Store) {
+	store.GetAllocator().SetGasMeter(nil)
+}
+
+var (
+	reRunPath   = regexp.MustCompile(`gno\.land/r/g[a-z0-9]+/run`)
+	reNamespace = regexp.MustCompile(`^gno\.land/(?:r|p)/([\.~_a-zA-Z0-9]+)`)
+	// system packages, such as the names and params realms, are privileged
+	// by their path, so they are reserved to the genesis.
+	reSystemPath = regexp.MustCompile(`^gno\.land/(?:r|p)/system(?:/|$)`)
+)
+
+// namesRealmPath is the path of the realm managing namespace permissions.
+const namesRealmPath = "gno.land/r/system/names"
+
+// isNamespaceManaged returns true if pkgPath is under a {r,p}/NAMESPACE
+// whose permissions are managed by the names realm.
+func isNamespaceManaged(store gno.Store, pkgPath string) bool {
+	return reNamespace.MatchString(pkgPath) &&
+		store.GetPackage(namesRealmPath, false) != nil
+}
+
+// checkNamespacePermission verifies that the creator is allowed to publish
+// packages under the {r,p}/NAMESPACE of the given path, by querying the
+// names realm. If the names realm is not deployed, the check is skipped.
+func (vm *VMKeeper) checkNamespacePermission(ctx sdk.Context, store gno.Store, creator crypto.Address, pkgPath string) error {
+	match := reNamespace.FindStringSubmatch(pkgPath)
+	if len(match) != 2 {
+		// not under a gno.land namespace.
+		return nil
+	}
+	namespace := match[1]
+
+	if pv := store.GetPackage(namesRealmPath, false); pv == nil {
+		return nil
+	}
+
+	msgCtx := stdlibs.ExecContext{
+		ChainID:     ctx.ChainID(),
+		Height:      ctx.BlockHeight(),
+		Timestamp:   ctx.BlockTime().Unix(),
+		OrigCaller:  creator.Bech32(),
+		OrigPkgAddr: gno.DerivePkgAddr(namesRealmPath).Bech32(),
+		Banker:      NewSDKBanker(vm, ctx),
+	}
+	m := gno.NewMachineWithOptions(
+		gno.MachineOptions{
+			PkgPath:   namesRealmPath,
+			Output:    os.Stdout, // XXX
+			Store:     store,
+			Context:   msgCtx,
+			Alloc:     store.GetAllocator(),
+			MaxCycles: vm.getMaxCycles(ctx),
+			GasMeter:  ctx.GasMeter(),
+		})
+	defer m.Release()
+
+	x := gno.Call(
+		gno.Nx("IsAuthorizedAddressForNamespace"),
+		gno.Str(creator.String()),
+		gno.Str(namespace),
+	)
+	rtvs := m.Eval(x)
+	if len(rtvs) != 1 || rtvs[0].T.Kind() != gno.BoolKind {
+		panic("names realm: invalid IsAuthorizedAddressForNamespace result")
+	}
+	if !rtvs[0].GetBool() {
+		return ErrUnauthorizedUser(fmt.Sprintf(
+			"%s is not authorized to publish under namespace %q", creator, namespace))
+	}
+	return nil
+}
 
 // AddPackage adds a package with given fileset.
 func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) error {
@@ -140,6 +237,7 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) error {
 	memPkg := msg.Package
 	deposit := msg.Deposit
 	store := vm.getGnoStore(ctx)
+	defer releaseGasMeter(store)
 
 	// Validate arguments.
 	if creator.IsZero() {
@@ -159,17 +257,23 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) error {
 	if reRunPath.MatchString(pkgPath) {
 		return ErrInvalidPkgPath("reserved package name: " + pkgPath)
 	}
+	if reSystemPath.MatchString(pkgPath) && ctx.BlockHeight() > 0 {
+		return ErrInvalidPkgPath("system packages can only be added at genesis: " + pkgPath)
+	}
+
+	// Check the creator can publish under the package namespace.
+	if err := vm.checkNamespacePermission(ctx, store, creator, pkgPath); err != nil {
+		return err
+	}
+
+	// Check the creator can publish a new version of the package.
+	if err := vm.checkPackageVersion(ctx, store, creator, pkgPath); err != nil {
+		return err
+	}
 
 	// Pay deposit from creator.
 	pkgAddr := gno.DerivePkgAddr(pkgPath)
 
-	// TODO: ACLs.
-	// - if r/system/names does not exists -> skip validation.
-	// - loads r/system/names data state.
-	// - lookup r/system/names.namespaces for `{r,p}/NAMES`.
-	// - check if caller is in Admins or Editors.
-	// - check if namespace is not in pause.
-
 	err := vm.bank.SendCoins(ctx, creator, pkgAddr, deposit)
 	if err != nil {
 		return err
@@ -186,6 +290,9 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) error {
 		OrigSendSpent: new(std.Coins),
 		OrigPkgAddr:   pkgAddr.Bech32(),
 		Banker:        NewSDKBanker(vm, ctx),
+		Params:        NewSDKParams(vm, ctx),
+		Validators:    NewSDKValidators(vm, ctx),
+		EventLogger:   ctx.EventLogger(),
 	}
 	// Parse and run the files, construct *PV.
 	m2 := gno.NewMachineWithOptions(
@@ -195,12 +302,26 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) error {
 			Store:     store,
 			Alloc:     store.GetAllocator(),
 			Context:   msgCtx,
-			MaxCycles: vm.maxCycles,
+			MaxCycles: vm.getMaxCycles(ctx),
+			GasMeter:  ctx.GasMeter(),
 		})
 	defer m2.Release()
+	defer func() {
+		if r := recover(); r != nil {
+			checkOutOfGas(ctx, r)
+			panic(r)
+		}
+	}()
 	m2.RunMemPackage(memPkg, true)
+	vm.setPackageVersion(ctx, creator, pkgPath)
+
+	// Lock the storage deposit of the new package.
+	if err := vm.processStorageDeposit(ctx, creator, msg.MaxDeposit, store); err != nil {
+		return err
+	}
 
 	ctx.Logger().Info("CPUCYCLES", "addpkg", m2.Cycles)
+	recordCPUCycles(msg, m2.Cycles)
 	return nil
 }
 
@@ -209,6 +330,7 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 	pkgPath := msg.PkgPath // to import
 	fnc := msg.Func
 	store := vm.getGnoStore(ctx)
+	defer releaseGasMeter(store)
 	// Get the package and function type.
 	pv := store.GetPackage(pkgPath, false)
 	pl := gno.PackageNodeLocation(pkgPath)
@@ -264,6 +386,9 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 		OrigSendSpent: new(std.Coins),
 		OrigPkgAddr:   pkgAddr.Bech32(),
 		Banker:        NewSDKBanker(vm, ctx),
+		Params:        NewSDKParams(vm, ctx),
+		Validators:    NewSDKValidators(vm, ctx),
+		EventLogger:   ctx.EventLogger(),
 	}
 	// Construct machine and evaluate.
 	m := gno.NewMachineWithOptions(
@@ -273,11 +398,13 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 			Store:     store,
 			Context:   msgCtx,
 			Alloc:     store.GetAllocator(),
-			MaxCycles: vm.maxCycles,
+			MaxCycles: vm.getMaxCycles(ctx),
+			GasMeter:  ctx.GasMeter(),
 		})
 	m.SetActivePackage(mpv)
 	defer func() {
 		if r := recover(); r != nil {
+			checkOutOfGas(ctx, r)
 			err = errors.Wrap(fmt.Errorf("%v", r), "VM call panic: %v\n%s\n",
 				r, m.String())
 			return
@@ -286,14 +413,19 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 	}()
 	rtvs := m.Eval(xn)
 	ctx.Logger().Info("CPUCYCLES call", "num-cycles", m.Cycles)
+	recordCPUCycles(msg, m.Cycles)
 	for i, rtv := range rtvs {
 		res = res + rtv.String()
 		if i < len(rtvs)-1 {
 			res += "\n"
 		}
 	}
+
+	// Lock or refund the storage deposit of the updated realms.
+	if err := vm.processStorageDeposit(ctx, caller, msg.MaxDeposit, store); err != nil {
+		return "", err
+	}
 	return res, nil
-	// TODO pay for gas? TODO see context?
 }
 
 // Run executes arbitrary Gno code in the context of the caller's realm.
@@ -301,6 +433,7 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 	caller := msg.Caller
 	pkgAddr := caller
 	store := vm.getGnoStore(ctx)
+	defer releaseGasMeter(store)
 	send := msg.Send
 	memPkg := msg.Package
 
@@ -335,6 +468,9 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 		OrigSendSpent: new(std.Coins),
 		OrigPkgAddr:   pkgAddr.Bech32(),
 		Banker:        NewSDKBanker(vm, ctx),
+		Params:        NewSDKParams(vm, ctx),
+		Validators:    NewSDKValidators(vm, ctx),
+		EventLogger:   ctx.EventLogger(),
 	}
 	// Parse and run the files, construct *PV.
 	buf := new(bytes.Buffer)
@@ -345,9 +481,16 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 			Store:     store,
 			Alloc:     store.GetAllocator(),
 			Context:   msgCtx,
-			MaxCycles: vm.maxCycles,
+			MaxCycles: vm.getMaxCycles(ctx),
+			GasMeter:  ctx.GasMeter(),
 		})
 	defer m.Release()
+	defer func() {
+		if r := recover(); r != nil {
+			checkOutOfGas(ctx, r)
+			panic(r)
+		}
+	}()
 	_, pv := m.RunMemPackage(memPkg, false)
 	ctx.Logger().Info("CPUCYCLES", "addpkg", m.Cycles)
 
@@ -358,11 +501,13 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 			Store:     store,
 			Alloc:     store.GetAllocator(),
 			Context:   msgCtx,
-			MaxCycles: vm.maxCycles,
+			MaxCycles: vm.getMaxCycles(ctx),
+			GasMeter:  ctx.GasMeter(),
 		})
 	m2.SetActivePackage(pv)
 	defer func() {
 		if r := recover(); r != nil {
+			checkOutOfGas(ctx, r)
 			err = errors.Wrap(fmt.Errorf("%v", r), "VM call panic: %v\n%s\n",
 				r, m2.String())
 			return
@@ -373,10 +518,29 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 	ctx.Logger().Info("CPUCYCLES call",
 		"cycles", m2.Cycles,
 	)
+	recordCPUCycles(msg, m.Cycles+m2.Cycles)
 	res = buf.String()
+
+	// Lock or refund the storage deposit of the updated realms.
+	if err := vm.processStorageDeposit(ctx, caller, msg.MaxDeposit, store); err != nil {
+		return "", err
+	}
 	return res, nil
 }
 
+// checkOutOfGas re-panics with an out of gas exception if the recovered
+// value was caused by the transaction running out of gas, so that it is
+// handled by the baseapp. This also catches exceptions wrapped by the VM
+// (ie. during preprocessing), by looking at the state of the gas meter.
+func checkOutOfGas(ctx sdk.Context, r any) {
+	if ex, ok := r.(store.OutOfGasException); ok {
+		panic(ex)
+	}
+	if ctx.GasMeter().IsPastLimit() {
+		panic(store.OutOfGasException{Descriptor: fmt.Sprintf("VM execution: %v", r)})
+	}
+}
+
 // QueryFuncs returns public facing function signatures.
 func (vm *VMKeeper) QueryFuncs(ctx sdk.Context, pkgPath string) (fsigs FunctionSignatures, err error) {
 	store := vm.getGnoStore(ctx)
@@ -466,7 +630,9 @@ func (vm *VMKeeper) QueryEval(ctx sdk.Context, pkgPath string, expr string) (res
 		// OrigSend:      send,
 		// OrigSendSpent: nil,
 		OrigPkgAddr: pkgAddr.Bech32(),
-		Banker:      NewSDKBanker(vm, ctx), // safe as long as ctx is a fork to be discarded.
+		Banker:      NewSDKBanker(vm, ctx),     // safe as long as ctx is a fork to be discarded.
+		Params:      NewSDKParams(vm, ctx),     // safe as long as ctx is a fork to be discarded.
+		Validators:  NewSDKValidators(vm, ctx), // safe as long as ctx is a fork to be discarded.
 	}
 	m := gno.NewMachineWithOptions(
 		gno.MachineOptions{
@@ -475,7 +641,7 @@ func (vm *VMKeeper) QueryEval(ctx sdk.Context, pkgPath string, expr string) (res
 			Store:     store,
 			Context:   msgCtx,
 			Alloc:     alloc,
-			MaxCycles: vm.maxCycles,
+			MaxCycles: vm.getMaxCycles(ctx),
 		})
 	defer func() {
 		if r := recover(); r != nil {
@@ -526,7 +692,9 @@ func (vm *VMKeeper) QueryEvalString(ctx sdk.Context, pkgPath string, expr string
 		// OrigSend:      jsend,
 		// OrigSendSpent: nil,
 		OrigPkgAddr: pkgAddr.Bech32(),
-		Banker:      NewSDKBanker(vm, ctx), // safe as long as ctx is a fork to be discarded.
+		Banker:      NewSDKBanker(vm, ctx),     // safe as long as ctx is a fork to be discarded.
+		Params:      NewSDKParams(vm, ctx),     // safe as long as ctx is a fork to be discarded.
+		Validators:  NewSDKValidators(vm, ctx), // safe as long as ctx is a fork to be discarded.
 	}
 	m := gno.NewMachineWithOptions(
 		gno.MachineOptions{
@@ -535,7 +703,7 @@ func (vm *VMKeeper) QueryEvalString(ctx sdk.Context, pkgPath string, expr string
 			Store:     store,
 			Context:   msgCtx,
 			Alloc:     alloc,
-			MaxCycles: vm.maxCycles,
+			MaxCycles: vm.getMaxCycles(ctx),
 		})
 	defer func() {
 		if r := recover(); r != nil {
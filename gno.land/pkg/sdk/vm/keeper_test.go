@@ -3,6 +3,7 @@ package vm
 // TODO: move most of the logic in ROOT/gno.land/...
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"strings"
@@ -10,8 +11,17 @@ import (
 
 	"github.com/jaekwon/testify/assert"
 
+	gnostd "github.com/gnolang/gno/gnovm/stdlibs/std"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
 )
 
 func TestVMKeeperAddPackage(t *testing.T) {
@@ -53,6 +63,180 @@ func Echo() string {
 	assert.True(t, errors.Is(err, InvalidPkgPathError{}))
 }
 
+func TestVMKeeperAddPackageNamespacePermission(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "admin" and "other" some gnots.
+	admin := crypto.AddressFromPreimage([]byte("admin"))
+	other := crypto.AddressFromPreimage([]byte("other"))
+	for _, addr := range []crypto.Address{admin, other} {
+		acc := env.acck.NewAccountWithAddress(ctx, addr)
+		env.acck.SetAccount(ctx, acc)
+		env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+	}
+
+	// Without names realm, anyone can publish.
+	files := []*std.MemFile{
+		{"test.gno", "package test\n\nfunc Echo() string { return \"hello\" }"},
+	}
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(other, "gno.land/r/demo/test", files))
+	assert.NoError(t, err)
+
+	// Deploy names realm, only "admin" can publish under "demo".
+	namesFiles := []*std.MemFile{
+		{"names.gno", fmt.Sprintf(`
+package names
+
+import "std"
+
+func IsAuthorizedAddressForNamespace(address std.Address, namespace string) bool {
+	if namespace != "demo" {
+		return true
+	}
+	return address == std.Address(%q)
+}`, admin.String())},
+	}
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(admin, namesRealmPath, namesFiles))
+	assert.NoError(t, err)
+
+	// "other" is now rejected.
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(other, "gno.land/r/demo/test2", files))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, UnauthorizedUserError{}))
+	assert.Nil(t, env.vmk.gnoStore.GetPackage("gno.land/r/demo/test2", false))
+
+	// "admin" can publish under "demo", and "other" under any other namespace.
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(admin, "gno.land/p/demo/test2", files))
+	assert.NoError(t, err)
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(other, "gno.land/r/other/test", files))
+	assert.NoError(t, err)
+}
+
+func TestVMKeeperAddPackageVersions(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "creator" and "other" some gnots.
+	creator := crypto.AddressFromPreimage([]byte("creator"))
+	other := crypto.AddressFromPreimage([]byte("other"))
+	for _, addr := range []crypto.Address{creator, other} {
+		acc := env.acck.NewAccountWithAddress(ctx, addr)
+		env.acck.SetAccount(ctx, acc)
+		env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+	}
+
+	const basePath = "gno.land/r/test"
+	files := func(body string) []*std.MemFile {
+		return []*std.MemFile{{"test.gno", "package test\n\n" + body}}
+	}
+
+	// A new version can't be published before the first one.
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(creator, basePath+"/v2", files("")))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, InvalidPkgPathError{}))
+
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(creator, basePath, files("var Counter = 1")))
+	assert.NoError(t, err)
+	latest, err := env.vmk.QueryLatestVersion(ctx, basePath)
+	assert.NoError(t, err)
+	assert.Equal(t, basePath, latest)
+
+	// Only the creator of the previous version can publish a new one.
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(other, basePath+"/v2", files("")))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, UnauthorizedUserError{}))
+
+	// Versions can't be skipped.
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(creator, basePath+"/v3", files("")))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, InvalidPkgPathError{}))
+
+	// The new version can use the previous version state.
+	v2Body := `import v1 "gno.land/r/test"
+
+func Counter() int { return v1.Counter + 1 }`
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(creator, basePath+"/v2", files(v2Body)))
+	assert.NoError(t, err)
+	res, err := env.vmk.QueryEval(ctx, basePath+"/v2", "Counter()")
+	assert.NoError(t, err)
+	assert.Equal(t, "(2 int)", res)
+
+	// "<base>/v1" is a new version of "<base>", and doesn't become the
+	// latest version.
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(other, basePath+"/v1", files("")))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, UnauthorizedUserError{}))
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(creator, basePath+"/v1", files("")))
+	assert.NoError(t, err)
+
+	for _, path := range []string{basePath, basePath + "/v1", basePath + "/v2"} {
+		latest, err = env.vmk.QueryLatestVersion(ctx, path)
+		assert.NoError(t, err)
+		assert.Equal(t, basePath+"/v2", latest)
+	}
+
+	_, err = env.vmk.QueryLatestVersion(ctx, "gno.land/r/unknown")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, InvalidPkgPathError{}))
+}
+
+func TestVMKeeperAddPackageVersionsGenesis(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "deployer", "admin" and "other" some gnots.
+	deployer := crypto.AddressFromPreimage([]byte("deployer"))
+	admin := crypto.AddressFromPreimage([]byte("admin"))
+	other := crypto.AddressFromPreimage([]byte("other"))
+	for _, addr := range []crypto.Address{deployer, admin, other} {
+		acc := env.acck.NewAccountWithAddress(ctx, addr)
+		env.acck.SetAccount(ctx, acc)
+		env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+	}
+
+	// Publish a genesis package, whose creator isn't recorded as it was
+	// deployed before the creators of packages were tracked.
+	const basePath = "gno.land/r/demo/test"
+	files := []*std.MemFile{{"test.gno", "package test"}}
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(deployer, basePath, files))
+	assert.NoError(t, err)
+	ctx.Store(env.vmk.iavlKey).Delete([]byte(pkgCreatorKeyPrefix + basePath))
+
+	// Without names realm, nobody can publish a new version.
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(deployer, basePath+"/v2", files))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, UnauthorizedUserError{}))
+
+	// Deploy names realm, only "admin" can publish under "demo".
+	namesFiles := []*std.MemFile{
+		{"names.gno", fmt.Sprintf(`
+package names
+
+import "std"
+
+func IsAuthorizedAddressForNamespace(address std.Address, namespace string) bool {
+	if namespace != "demo" {
+		return true
+	}
+	return address == std.Address(%q)
+}`, admin.String())},
+	}
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(admin, namesRealmPath, namesFiles))
+	assert.NoError(t, err)
+
+	// The namespace admin can publish a new version.
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(other, basePath+"/v2", files))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, UnauthorizedUserError{}))
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(admin, basePath+"/v2", files))
+	assert.NoError(t, err)
+
+	latest, err := env.vmk.QueryLatestVersion(ctx, basePath)
+	assert.NoError(t, err)
+	assert.Equal(t, basePath+"/v2", latest)
+}
+
 // Sending total send amount succeeds.
 func TestVMKeeperOrigSend1(t *testing.T) {
 	env := setupTestEnv()
@@ -89,6 +273,9 @@ func Echo(msg string) string {
 	err := env.vmk.AddPackage(ctx, msg1)
 	assert.NoError(t, err)
 
+	// Refill "addr1" after paying the storage deposit of the package.
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
 	// Run Echo function.
 	coins := std.MustParseCoins("10000000ugnot")
 	msg2 := NewMsgCall(addr, coins, pkgPath, "Echo", []string{"hello world"})
@@ -232,6 +419,9 @@ func Echo(msg string) string {
 	err := env.vmk.AddPackage(ctx, msg1)
 	assert.NoError(t, err)
 
+	// Refill "addr1" after paying the storage deposit of the package.
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
 	// Run Echo function.
 	coins := std.MustParseCoins("10000000ugnot")
 	msg2 := NewMsgCall(addr, coins, pkgPath, "Echo", []string{"hello world"})
@@ -338,6 +528,162 @@ func GetAdmin() string {
 	assert.Equal(t, res, addrString)
 }
 
+// Generic types are persisted in realm state.
+func TestVMKeeperGenerics(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create test package.
+	files := []*std.MemFile{
+		{"stack.gno", `
+package test
+
+type Stack[T any] struct {
+	items []T
+}
+
+func (s *Stack[T]) Push(v T) {
+	s.items = append(s.items, v)
+}
+
+func (s *Stack[T]) Top() T {
+	return s.items[len(s.items)-1]
+}
+
+var stack = &Stack[string]{}
+
+func Push(v string) {
+	stack.Push(v)
+}
+
+func Top() string {
+	return stack.Top()
+}
+`},
+	}
+	pkgPath := "gno.land/r/test"
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	assert.NoError(t, err)
+	assert.NotNil(t, env.vmk.gnoStore.GetTypeSafe("gno.land/r/test.Stack[string]"))
+
+	// Push "hello", then call Top().
+	coins := std.MustParseCoins("")
+	msg2 := NewMsgCall(addr, coins, pkgPath, "Push", []string{"hello"})
+	_, err = env.vmk.Call(ctx, msg2)
+	assert.NoError(t, err)
+	msg3 := NewMsgCall(addr, coins, pkgPath, "Top", []string{})
+	res, err := env.vmk.Call(ctx, msg3)
+	assert.NoError(t, err)
+	assert.Equal(t, `("hello" string)`, res)
+}
+
+// Generic types persisted by a realm are usable after a restart, and can be
+// instantiated again by other packages.
+func TestVMKeeperGenericsRestart(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create the generic package, and a realm using it.
+	files := []*std.MemFile{
+		{"stack.gno", `
+package stack
+
+type Stack[T any] struct {
+	items []T
+}
+
+func (s *Stack[T]) Push(v T) {
+	s.items = append(s.items, v)
+}
+
+func (s *Stack[T]) Top() T {
+	return s.items[len(s.items)-1]
+}
+`},
+	}
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, "gno.land/p/stack", files))
+	assert.NoError(t, err)
+	files = []*std.MemFile{
+		{"test.gno", `
+package test
+
+import "gno.land/p/stack"
+
+var s = &stack.Stack[string]{}
+
+func Push(v string) {
+	s.Push(v)
+}
+
+func Top() string {
+	return s.Top()
+}
+`},
+	}
+	pkgPath := "gno.land/r/test"
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files))
+	assert.NoError(t, err)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Push", []string{"hello"}))
+	assert.NoError(t, err)
+
+	// Restart: commit, and rebuild the keeper over the same store.
+	ms := ctx.MultiStore().(store.CommitMultiStore)
+	ms.Commit()
+	env.vmk = NewVMKeeper(env.vmk.baseKey, env.vmk.iavlKey, env.acck, env.bank, env.prmk, env.vmk.stdlibsDir, 10_000_000)
+	env.vmk.Initialize(ms.MultiCacheWrap())
+
+	// Call the methods of the persisted Stack[string].
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Push", []string{"world"}))
+	assert.NoError(t, err)
+	res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Top", []string{}))
+	assert.NoError(t, err)
+	assert.Equal(t, `("world" string)`, res)
+
+	// Instantiate the generic type again from a second realm.
+	files = []*std.MemFile{
+		{"other.gno", `
+package other
+
+import "gno.land/p/stack"
+
+var (
+	strs = &stack.Stack[string]{}
+	ints = &stack.Stack[int]{}
+)
+
+func Push(v string) {
+	strs.Push(v)
+	ints.Push(len(v))
+}
+
+func Top() (string, int) {
+	return strs.Top(), ints.Top()
+}
+`},
+	}
+	otherPath := "gno.land/r/other"
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, otherPath, files))
+	assert.NoError(t, err)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, otherPath, "Push", []string{"hello"}))
+	assert.NoError(t, err)
+	res, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, otherPath, "Top", []string{}))
+	assert.NoError(t, err)
+	assert.Equal(t, "(\"hello\" string)\n(5 int)", res)
+}
+
 // Call Run without imports, without variables.
 func TestVMKeeperRunSimple(t *testing.T) {
 	env := setupTestEnv()
@@ -396,6 +742,388 @@ func main() {
 	assert.Equal(t, res, expectedString)
 }
 
+func TestVMKeeperEmitEvents(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create test package.
+	files := []*std.MemFile{
+		{"events.gno", `
+package events
+
+import "std"
+
+func Transfer(to string) {
+	std.Emit("Transfer", "from", std.GetOrigCaller().String(), "to", to)
+}
+
+func Invalid() {
+	std.Emit("Invalid", "key")
+}`},
+	}
+	pkgPath := "gno.land/r/events"
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files))
+	assert.NoError(t, err)
+
+	h := NewHandler(env.vmk)
+
+	// Events are attached to the result.
+	ctx = ctx.WithEventLogger(sdk.NewEventLogger())
+	res := h.Process(ctx, NewMsgCall(addr, nil, pkgPath, "Transfer", []string{"g1to"}))
+	assert.True(t, res.IsOK(), res.Log)
+	assert.Equal(t, []abci.Event{
+		gnostd.GnoEvent{
+			Type:    "Transfer",
+			PkgPath: pkgPath,
+			Func:    "Transfer",
+			Attributes: []gnostd.GnoEventAttribute{
+				{Key: "from", Value: addr.String()},
+				{Key: "to", Value: "g1to"},
+			},
+		},
+	}, res.Events)
+
+	// An odd number of attributes panics.
+	ctx = ctx.WithEventLogger(sdk.NewEventLogger())
+	res = h.Process(ctx, NewMsgCall(addr, nil, pkgPath, "Invalid", []string{}))
+	assert.False(t, res.IsOK())
+	assert.Empty(t, res.Events)
+}
+
+func TestVMKeeperParams(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create test packages.
+	body := `
+package params
+
+import "std"
+
+func SetName(name string) {
+	std.SetParamString("name.string", name)
+}
+
+func SetMaxCycles(n int64) {
+	std.SetParamInt64("vm.max_cycles.int64", n)
+}
+
+func SetTxSigLimit(n int64) {
+	std.SetParamInt64("auth.tx_sig_limit.int64", n)
+}
+
+func SetStoragePrice(price string) {
+	std.SetParamString("vm.storage_price.string", price)
+}
+
+func SetUnknown(n int64) {
+	std.SetParamInt64("vm.unknown.int64", n)
+}`
+	files := []*std.MemFile{{"params.gno", body}}
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, "gno.land/r/params", files))
+	assert.NoError(t, err)
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, sysParamsRealmPath, files))
+	assert.NoError(t, err)
+
+	// System realms can only be added at genesis.
+	hctx := ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 1})
+	err = env.vmk.AddPackage(hctx, NewMsgAddPackage(addr, "gno.land/r/system/params/v2", files))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, InvalidPkgPathError{}))
+
+	// Params set by a realm are scoped to its path.
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, "gno.land/r/params", "SetName", []string{"foo"}))
+	assert.NoError(t, err)
+	var name string
+	assert.True(t, env.prmk.GetString(ctx, "gno.land/r/params:name.string", &name))
+	assert.Equal(t, "foo", name)
+
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, "gno.land/r/params", "SetMaxCycles", []string{"1000"}))
+	assert.NoError(t, err)
+	assert.False(t, env.prmk.Has(ctx, ParamMaxCycles))
+	assert.Equal(t, int64(10_000_000), env.vmk.getMaxCycles(ctx))
+
+	// Params set by the system realm are read by the modules.
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, sysParamsRealmPath, "SetMaxCycles", []string{"20000000"}))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20_000_000), env.vmk.getMaxCycles(ctx))
+
+	// Invalid or unknown module params are rejected.
+	for _, call := range []struct{ fn, arg string }{
+		{"SetMaxCycles", "1"},
+		{"SetTxSigLimit", "0"},
+		{"SetStoragePrice", "ugnot"},
+		{"SetUnknown", "1"},
+	} {
+		_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, sysParamsRealmPath, call.fn, []string{call.arg}))
+		assert.Error(t, err, call.fn)
+	}
+	assert.Equal(t, int64(20_000_000), env.vmk.getMaxCycles(ctx))
+	assert.False(t, env.prmk.Has(ctx, auth.ParamTxSigLimit))
+	assert.False(t, env.prmk.Has(ctx, ParamStoragePrice))
+	assert.False(t, env.prmk.Has(ctx, "vm.unknown.int64"))
+}
+
+func TestVMKeeperValidatorUpdates(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx.WithConsensusParams(&abci.ConsensusParams{
+		Validator: &abci.ValidatorParams{
+			PubKeyTypeURLs: []string{amino.GetTypeURL(ed25519.PubKeyEd25519{})},
+		},
+	})
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	val1 := ed25519.GenPrivKeyFromSecret([]byte("val1")).PubKey()
+	val2 := ed25519.GenPrivKeyFromSecret([]byte("val2")).PubKey()
+	val3 := secp256k1.GenPrivKeySecp256k1([]byte("val3")).PubKey()
+	env.vmk.InitValidators(ctx, []abci.ValidatorUpdate{{Address: val1.Address(), PubKey: val1, Power: 10}})
+
+	// Create test packages.
+	body := `
+package validators
+
+import "std"
+
+func Update(pubKey string, power int64) {
+	std.UpdateValidator(pubKey, power)
+}`
+	files := []*std.MemFile{{"validators.gno", body}}
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, "gno.land/r/validators", files))
+	assert.NoError(t, err)
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, sysValidatorsRealmPath, files))
+	assert.NoError(t, err)
+
+	update := func(pkgPath string, pubKey crypto.PubKey, power int64) error {
+		msg := NewMsgCall(addr, nil, pkgPath, "Update", []string{pubKey.String(), fmt.Sprint(power)})
+		_, err := env.vmk.Call(ctx, msg)
+		return err
+	}
+
+	// Only the system realm can update validators.
+	err = update("gno.land/r/validators", val2, 10)
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "validators can only be updated by "+sysValidatorsRealmPath))
+
+	// Invalid changes fail the transaction.
+	err = update(sysValidatorsRealmPath, val3, 10)
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "unsupported for consensus"))
+	err = update(sysValidatorsRealmPath, val2, 0)
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "not found"))
+
+	// Changes are returned once, at the end of the block.
+	assert.NoError(t, update(sysValidatorsRealmPath, val2, 10))
+	assert.NoError(t, update(sysValidatorsRealmPath, val1, 0))
+	err = update(sysValidatorsRealmPath, val2, 0)
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "cannot remove the last validator"))
+
+	expected := []abci.ValidatorUpdate{
+		{Address: val1.Address(), PubKey: val1, Power: 0},
+		{Address: val2.Address(), PubKey: val2, Power: 10},
+	}
+	if bytes.Compare(val1.Address().Bytes(), val2.Address().Bytes()) > 0 {
+		expected[0], expected[1] = expected[1], expected[0]
+	}
+	assert.Equal(t, expected, env.vmk.ValidatorUpdates(ctx))
+	assert.Empty(t, env.vmk.ValidatorUpdates(ctx))
+}
+
+func TestVMKeeperStorageDeposit(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create test package.
+	pkgPath := "gno.land/r/storage"
+	body := `
+package storage
+
+var items []string
+
+func Add(n int) {
+	for i := 0; i < n; i++ {
+		items = append(items, "some item")
+	}
+}
+
+func Clear() {
+	items = nil
+}`
+	files := []*std.MemFile{{"storage.gno", body}}
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files))
+	assert.NoError(t, err)
+
+	// The deposit of the package, including its files, is locked from the
+	// creator.
+	rs, err := env.vmk.QueryStorage(ctx, pkgPath)
+	assert.NoError(t, err)
+	assert.True(t, rs.Bytes > int64(len(body)))
+	assert.Equal(t, std.MustParseCoins(fmt.Sprintf("%dugnot", rs.Bytes*10)), rs.Deposit)
+	assert.Equal(t, rs.Deposit, env.bank.GetCoins(ctx, storageDepositAddr))
+	balance := env.bank.GetCoins(ctx, addr)
+	assert.Equal(t, std.MustParseCoins("10000000ugnot").Sub(rs.Deposit), balance)
+
+	// After genesis, the deposit is limited by the max deposit of the message.
+	ctx = ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 1})
+
+	// Growing the realm locks more.
+	msg := NewMsgCall(addr, nil, pkgPath, "Add", []string{"10"})
+	msg.MaxDeposit = std.MustParseCoins("1000000ugnot")
+	_, err = env.vmk.Call(ctx, msg)
+	assert.NoError(t, err)
+	grown, err := env.vmk.QueryStorage(ctx, pkgPath)
+	assert.NoError(t, err)
+	assert.True(t, grown.Bytes > rs.Bytes)
+	locked := grown.Deposit.Sub(rs.Deposit)
+	assert.Equal(t, balance.Sub(locked), env.bank.GetCoins(ctx, addr))
+
+	// The deposit can't exceed the max deposit of the message.
+	msg.MaxDeposit = std.MustParseCoins("1ugnot")
+	cctx, _ := ctx.CacheContext() // discard the failed call.
+	_, err = env.vmk.Call(cctx, msg)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, std.InsufficientFundsError{}))
+
+	// Without a max deposit, the realm can't grow.
+	msg.MaxDeposit = nil
+	cctx, _ = ctx.CacheContext()
+	_, err = env.vmk.Call(cctx, msg)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, std.InsufficientFundsError{}))
+
+	// Freeing storage refunds the caller.
+	balance = env.bank.GetCoins(ctx, addr)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Clear", []string{}))
+	assert.NoError(t, err)
+	cleared, err := env.vmk.QueryStorage(ctx, pkgPath)
+	assert.NoError(t, err)
+	assert.True(t, cleared.Bytes < grown.Bytes)
+	refund := grown.Deposit.Sub(cleared.Deposit)
+	assert.Equal(t, balance.Add(refund), env.bank.GetCoins(ctx, addr))
+	assert.Equal(t, cleared.Deposit, env.bank.GetCoins(ctx, storageDepositAddr))
+
+	// Unknown packages have no storage.
+	_, err = env.vmk.QueryStorage(ctx, "gno.land/r/unknown")
+	assert.Error(t, err)
+}
+
+func TestVMKeeperStoragePrice(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	assert.Equal(t, std.MustParseCoin(DefaultStoragePrice), env.vmk.getStoragePrice(ctx))
+
+	env.prmk.SetString(ctx, ParamStoragePrice, "20ugnot")
+	assert.Equal(t, std.MustParseCoin("20ugnot"), env.vmk.getStoragePrice(ctx))
+
+	// A malformed price falls back to the default price.
+	env.prmk.SetString(ctx, ParamStoragePrice, "ugnot")
+	assert.Equal(t, std.MustParseCoin(DefaultStoragePrice), env.vmk.getStoragePrice(ctx))
+}
+
+func TestVMKeeperStorageDepositOtherCaller(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" and "addr2" some gnots.
+	addr1 := crypto.AddressFromPreimage([]byte("addr1"))
+	addr2 := crypto.AddressFromPreimage([]byte("addr2"))
+	for _, addr := range []crypto.Address{addr1, addr2} {
+		acc := env.acck.NewAccountWithAddress(ctx, addr)
+		env.acck.SetAccount(ctx, acc)
+		env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+	}
+
+	// Create test package.
+	pkgPath := "gno.land/r/storage"
+	body := `
+package storage
+
+var items []string
+
+func Add(n int) {
+	for i := 0; i < n; i++ {
+		items = append(items, "some item")
+	}
+}
+
+func Clear() {
+	items = nil
+}`
+	files := []*std.MemFile{{"storage.gno", body}}
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr1, pkgPath, files))
+	assert.NoError(t, err)
+
+	ctx = ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 1})
+	maxDeposit := std.MustParseCoins("1000000ugnot")
+	add := func(caller crypto.Address) error {
+		msg := NewMsgCall(caller, nil, pkgPath, "Add", []string{"10"})
+		msg.MaxDeposit = maxDeposit
+		_, err := env.vmk.Call(ctx, msg)
+		return err
+	}
+
+	// addr1 grows the realm.
+	assert.NoError(t, add(addr1))
+	grown, err := env.vmk.QueryStorage(ctx, pkgPath)
+	assert.NoError(t, err)
+
+	// addr2 frees the storage paid by addr1, and is not refunded.
+	balance1 := env.bank.GetCoins(ctx, addr1)
+	balance2 := env.bank.GetCoins(ctx, addr2)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr2, nil, pkgPath, "Clear", []string{}))
+	assert.NoError(t, err)
+	cleared, err := env.vmk.QueryStorage(ctx, pkgPath)
+	assert.NoError(t, err)
+	assert.True(t, cleared.Bytes < grown.Bytes)
+	assert.Equal(t, grown.Deposit, cleared.Deposit)
+	assert.Equal(t, balance1, env.bank.GetCoins(ctx, addr1))
+	assert.Equal(t, balance2, env.bank.GetCoins(ctx, addr2))
+	assert.Equal(t, grown.Deposit, env.bank.GetCoins(ctx, storageDepositAddr))
+
+	// addr2 grows the realm again, and addr1 frees it: addr1 is refunded
+	// from its own deposit, and the deposit of addr2 stays locked.
+	assert.NoError(t, add(addr2))
+	balance2 = env.bank.GetCoins(ctx, addr2)
+	regrown, err := env.vmk.QueryStorage(ctx, pkgPath)
+	assert.NoError(t, err)
+	deposit2 := regrown.Deposit.Sub(cleared.Deposit)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr1, nil, pkgPath, "Clear", []string{}))
+	assert.NoError(t, err)
+	final, err := env.vmk.QueryStorage(ctx, pkgPath)
+	assert.NoError(t, err)
+	refund := regrown.Deposit.Sub(final.Deposit)
+	assert.False(t, refund.IsZero())
+	assert.Equal(t, balance1.Add(refund), env.bank.GetCoins(ctx, addr1))
+	assert.Equal(t, balance2, env.bank.GetCoins(ctx, addr2))
+	assert.True(t, final.Deposit.IsAllGTE(deposit2))
+	assert.Equal(t, final.Deposit, env.bank.GetCoins(ctx, storageDepositAddr))
+}
+
 func TestNumberOfArgsError(t *testing.T) {
 	env := setupTestEnv()
 	ctx := env.ctx
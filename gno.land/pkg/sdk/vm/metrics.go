@@ -0,0 +1,37 @@
+package vm
+
+import (
+	"context"
+
+	"github.com/gnolang/gno/telemetry"
+	"github.com/gnolang/gno/telemetry/metrics"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// msgAttributes returns the attributes of the metrics of a VM message: its
+// type. The package path is not used, as the number of packages, and so of
+// metric series, is unbounded.
+func msgAttributes(msg std.Msg) metric.MeasurementOption {
+	return metric.WithAttributes(attribute.String("msg_type", msg.Type()))
+}
+
+// recordMsgMetrics records the execution of a VM message, and the gas it
+// used since gasStart.
+func recordMsgMetrics(ctx sdk.Context, msg std.Msg, gasStart int64) {
+	attrs := msgAttributes(msg)
+
+	metrics.VMExecMsgs.Add(context.Background(), 1, attrs)
+	metrics.VMGasUsed.Record(context.Background(), ctx.GasMeter().GasConsumed()-gasStart, attrs)
+}
+
+// recordCPUCycles records the CPU cycles of a VM message execution.
+func recordCPUCycles(msg std.Msg, cycles int64) {
+	if !telemetry.MetricsEnabled() {
+		return
+	}
+
+	metrics.VMCPUCycles.Record(context.Background(), cycles, msgAttributes(msg))
+}
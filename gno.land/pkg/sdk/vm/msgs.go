@@ -19,6 +19,9 @@ type MsgAddPackage struct {
 	Creator crypto.Address  `json:"creator" yaml:"creator"`
 	Package *std.MemPackage `json:"package" yaml:"package"`
 	Deposit std.Coins       `json:"deposit" yaml:"deposit"`
+	// MaxDeposit limits the storage deposit locked from the creator.
+	// No limit if empty.
+	MaxDeposit std.Coins `json:"max_deposit,omitempty" yaml:"max_deposit"`
 }
 
 var _ std.Msg = MsgAddPackage{}
@@ -59,6 +62,9 @@ func (msg MsgAddPackage) ValidateBasic() error {
 	if !msg.Deposit.IsValid() {
 		return std.ErrTxDecode("invalid deposit")
 	}
+	if !msg.MaxDeposit.IsValid() {
+		return std.ErrTxDecode("invalid max deposit")
+	}
 	// XXX validate files.
 	return nil
 }
@@ -78,6 +84,15 @@ func (msg MsgAddPackage) GetReceived() std.Coins {
 	return msg.Deposit
 }
 
+// GetPkgPath returns the path of the added package,
+// used for indexing the transaction.
+func (msg MsgAddPackage) GetPkgPath() string {
+	if msg.Package == nil {
+		return ""
+	}
+	return msg.Package.Path
+}
+
 //----------------------------------------
 // MsgCall
 
@@ -88,6 +103,9 @@ type MsgCall struct {
 	PkgPath string         `json:"pkg_path" yaml:"pkg_path"`
 	Func    string         `json:"func" yaml:"func"`
 	Args    []string       `json:"args" yaml:"args"`
+	// MaxDeposit limits the storage deposit locked from the caller.
+	// No limit if empty.
+	MaxDeposit std.Coins `json:"max_deposit,omitempty" yaml:"max_deposit"`
 }
 
 var _ std.Msg = MsgCall{}
@@ -119,6 +137,9 @@ func (msg MsgCall) ValidateBasic() error {
 	if msg.Func == "" { // XXX
 		return ErrInvalidExpr("missing function to call")
 	}
+	if !msg.MaxDeposit.IsValid() {
+		return std.ErrTxDecode("invalid max deposit")
+	}
 	return nil
 }
 
@@ -137,6 +158,12 @@ func (msg MsgCall) GetReceived() std.Coins {
 	return msg.Send
 }
 
+// GetPkgPath returns the path of the called package,
+// used for indexing the transaction.
+func (msg MsgCall) GetPkgPath() string {
+	return msg.PkgPath
+}
+
 //----------------------------------------
 // MsgRun
 
@@ -145,6 +172,9 @@ type MsgRun struct {
 	Caller  crypto.Address  `json:"caller" yaml:"caller"`
 	Send    std.Coins       `json:"send" yaml:"send"`
 	Package *std.MemPackage `json:"package" yaml:"package"`
+	// MaxDeposit limits the storage deposit locked from the caller.
+	// No limit if empty.
+	MaxDeposit std.Coins `json:"max_deposit,omitempty" yaml:"max_deposit"`
 }
 
 var _ std.Msg = MsgRun{}
@@ -186,6 +216,9 @@ func (msg MsgRun) ValidateBasic() error {
 	if path := msg.Package.Path; path != "" && path != wantPath {
 		return ErrInvalidPkgPath(fmt.Sprintf("invalid pkgpath for MsgRun: %q", path))
 	}
+	if !msg.MaxDeposit.IsValid() {
+		return std.ErrTxDecode("invalid max deposit")
+	}
 
 	return nil
 }
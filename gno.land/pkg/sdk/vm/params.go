@@ -0,0 +1,66 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// MinMaxCycles is the lowest value of the "vm.max_cycles.int64" param, so
+// that the params can still be changed by a call to r/system/params.
+const MinMaxCycles = 1_000_000
+
+// validateModuleParam returns an error if key isn't a param of the chain
+// modules, or if value isn't a valid value for it. The auth params are
+// validated along with the other params of the module, as they would be
+// loaded once value is set.
+func validateModuleParam(ctx sdk.Context, prmk params.ParamsKeeperI, key string, value interface{}) error {
+	switch key {
+	case auth.ParamMaxMemoBytes, auth.ParamTxSigLimit, auth.ParamTxSizeCostPerByte,
+		auth.ParamSigVerifyCostED25519, auth.ParamSigVerifyCostSecp256k1:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("invalid value for param %q: %v", key, value)
+		}
+		p := auth.LoadParams(ctx, prmk)
+		switch key {
+		case auth.ParamMaxMemoBytes:
+			p.MaxMemoBytes = v
+		case auth.ParamTxSigLimit:
+			p.TxSigLimit = v
+		case auth.ParamTxSizeCostPerByte:
+			p.TxSizeCostPerByte = v
+		case auth.ParamSigVerifyCostED25519:
+			p.SigVerifyCostED25519 = v
+		case auth.ParamSigVerifyCostSecp256k1:
+			p.SigVerifyCostSecp256k1 = v
+		}
+		return p.Validate()
+	case bank.ParamSendEnabled:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("invalid value for param %q: %v", key, value)
+		}
+		return nil
+	case ParamMaxCycles:
+		v, ok := value.(int64)
+		if !ok || v < MinMaxCycles {
+			return fmt.Errorf("invalid value for param %q: %v, must be at least %d", key, value, MinMaxCycles)
+		}
+		return nil
+	case ParamStoragePrice:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("invalid value for param %q: %v", key, value)
+		}
+		if _, err := std.ParseCoin(v); err != nil {
+			return fmt.Errorf("invalid value for param %q: %w", key, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown param %q", key)
+	}
+}
@@ -0,0 +1,194 @@
+package vm
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/overflow"
+)
+
+// Packages and realms pay for the state they persist. When a transaction
+// grows the persisted objects of a realm, a storage deposit proportional to
+// the byte difference is locked from the caller, at the price per byte set by
+// the "vm.storage_price.string" param. When a transaction deletes objects of
+// a realm, the caller is refunded the deposit it paid for the freed bytes;
+// freeing bytes paid by others refunds nothing, and their deposits stay locked
+// until they free bytes of the realm themselves.
+//
+// The storage of a realm counts the persisted objects of the realm, as well
+// as the files and declared types of its package, which are stored when the
+// package is added. Block nodes are not persisted, and small entries such as
+// the package index and the hashes of escaped objects are not counted.
+//
+// The deposits are held by storageDepositAddr. The storage usage of each
+// realm, and the share paid by each depositor, are kept in the iavl store.
+
+// DefaultStoragePrice is the default price of a persisted byte.
+const DefaultStoragePrice = "10ugnot"
+
+const (
+	storageKeyPrefix        = "storage:"
+	storageDepositKeyPrefix = "storage_deposit:"
+)
+
+// storageDepositAddr holds the storage deposits of all realms.
+var storageDepositAddr = crypto.AddressFromPreimage([]byte("vm/storage_deposit"))
+
+// RealmStorage is the storage usage of a realm, or the share of it paid by a
+// depositor.
+type RealmStorage struct {
+	Bytes   int64     `json:"bytes" yaml:"bytes"`
+	Deposit std.Coins `json:"deposit" yaml:"deposit"`
+}
+
+// processStorageDeposit locks or refunds the storage deposits of the realms
+// updated by the last transaction on store, from or to the caller. The total
+// deposit locked must not exceed maxDeposit: a message without a max deposit
+// can't grow the state of a realm. Genesis transactions, chosen by the chain
+// operators, are not limited. The caller is only refunded from its own
+// deposits.
+func (vm *VMKeeper) processStorageDeposit(ctx sdk.Context, caller crypto.Address, maxDeposit std.Coins, store gno.Store) error {
+	diffs := store.RealmStorageDiffs()
+	paths := make([]string, 0, len(diffs))
+	for path, diff := range diffs {
+		// only packages and realms under a namespace pay for storage.
+		if diff != 0 && reNamespace.MatchString(path) {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	sort.Strings(paths)
+
+	price := vm.getStoragePrice(ctx)
+	var locked std.Coins
+	for _, path := range paths {
+		diff := diffs[path]
+		rs := vm.getRealmStorage(ctx, path)
+		ds := vm.getStorageDeposit(ctx, path, caller)
+
+		if diff > 0 {
+			amount, ok := overflow.Mul64(price.Amount, diff)
+			if !ok {
+				return std.ErrInsufficientFunds(fmt.Sprintf(
+					"storage deposit overflow for %d bytes", diff))
+			}
+			deposit := std.Coins{std.NewCoin(price.Denom, amount)}
+			locked = locked.Add(deposit)
+			if ctx.BlockHeight() > 0 && !maxDeposit.IsAllGTE(locked) {
+				return std.ErrInsufficientFunds(fmt.Sprintf(
+					"storage deposit %s exceeds max deposit %s", locked, maxDeposit))
+			}
+			if err := vm.bank.SendCoins(ctx, caller, storageDepositAddr, deposit); err != nil {
+				return err
+			}
+			rs.Deposit = rs.Deposit.Add(deposit)
+			rs.Bytes += diff
+			ds.Deposit = ds.Deposit.Add(deposit)
+			ds.Bytes += diff
+		} else {
+			freed := min(-diff, rs.Bytes)
+			// only the bytes paid by the caller are refunded.
+			own := min(freed, ds.Bytes)
+			refund := ds.refund(own)
+			if !refund.IsZero() {
+				if err := vm.bank.SendCoins(ctx, storageDepositAddr, caller, refund); err != nil {
+					return err
+				}
+				rs.Deposit = rs.Deposit.Sub(refund)
+				ds.Deposit = ds.Deposit.Sub(refund)
+			}
+			rs.Bytes -= freed
+			ds.Bytes -= own
+		}
+
+		vm.setRealmStorage(ctx, path, rs)
+		vm.setStorageDeposit(ctx, path, caller, ds)
+	}
+	return nil
+}
+
+// refund returns the part of the deposit paid for the given bytes.
+func (rs RealmStorage) refund(bytes int64) std.Coins {
+	if bytes <= 0 || rs.Bytes <= 0 {
+		return nil
+	}
+	if bytes == rs.Bytes {
+		return rs.Deposit
+	}
+
+	var refund std.Coins
+	for _, coin := range rs.Deposit {
+		// amount * bytes / rs.Bytes, without overflowing.
+		amount := new(big.Int).Mul(big.NewInt(coin.Amount), big.NewInt(bytes))
+		amount.Quo(amount, big.NewInt(rs.Bytes))
+		if amount.Sign() > 0 {
+			refund = append(refund, std.NewCoin(coin.Denom, amount.Int64()))
+		}
+	}
+	return refund
+}
+
+// getStoragePrice returns the price of a persisted byte, which can be
+// changed with the "vm.storage_price.string" param. The param is validated
+// when it is set, but the default price is used if it can't be parsed anyway,
+// rather than failing every transaction growing the state of a realm.
+func (vm *VMKeeper) getStoragePrice(ctx sdk.Context) std.Coin {
+	price := DefaultStoragePrice
+	vm.prmk.GetString(ctx, ParamStoragePrice, &price)
+	coin, err := std.ParseCoin(price)
+	if err != nil {
+		ctx.Logger().Error("invalid storage price param, using the default price", "price", price, "err", err)
+		return std.MustParseCoin(DefaultStoragePrice)
+	}
+	return coin
+}
+
+func (vm *VMKeeper) getRealmStorage(ctx sdk.Context, pkgPath string) RealmStorage {
+	var rs RealmStorage
+	if bz := ctx.Store(vm.iavlKey).Get([]byte(storageKeyPrefix + pkgPath)); bz != nil {
+		amino.MustUnmarshal(bz, &rs)
+	}
+	return rs
+}
+
+func (vm *VMKeeper) setRealmStorage(ctx sdk.Context, pkgPath string, rs RealmStorage) {
+	ctx.Store(vm.iavlKey).Set([]byte(storageKeyPrefix+pkgPath), amino.MustMarshal(rs))
+}
+
+func storageDepositKey(pkgPath string, depositor crypto.Address) []byte {
+	return []byte(storageDepositKeyPrefix + pkgPath + ":" + depositor.String())
+}
+
+func (vm *VMKeeper) getStorageDeposit(ctx sdk.Context, pkgPath string, depositor crypto.Address) RealmStorage {
+	var ds RealmStorage
+	if bz := ctx.Store(vm.iavlKey).Get(storageDepositKey(pkgPath, depositor)); bz != nil {
+		amino.MustUnmarshal(bz, &ds)
+	}
+	return ds
+}
+
+func (vm *VMKeeper) setStorageDeposit(ctx sdk.Context, pkgPath string, depositor crypto.Address, ds RealmStorage) {
+	key := storageDepositKey(pkgPath, depositor)
+	if ds.Bytes == 0 && ds.Deposit.IsZero() {
+		ctx.Store(vm.iavlKey).Delete(key)
+		return
+	}
+	ctx.Store(vm.iavlKey).Set(key, amino.MustMarshal(ds))
+}
+
+// QueryStorage returns the storage usage of the package at pkgPath.
+func (vm *VMKeeper) QueryStorage(ctx sdk.Context, pkgPath string) (RealmStorage, error) {
+	if pv := vm.getGnoStore(ctx).GetPackage(pkgPath, false); pv == nil {
+		return RealmStorage{}, ErrInvalidPkgPath(fmt.Sprintf(
+			"package not found: %s", pkgPath))
+	}
+	return vm.getRealmStorage(ctx, pkgPath), nil
+}
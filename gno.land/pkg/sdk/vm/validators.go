@@ -0,0 +1,121 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// Changes of the validator set are requested by the system validators realm,
+// through std.UpdateValidator. They are recorded during the block, and
+// returned by ValidatorUpdates at the end of the block, to be applied by the
+// consensus.
+//
+// The keeper keeps track of the current validator set, starting from the
+// genesis validators, so that an invalid change (ie. removing an unknown
+// validator, or the last one) fails the transaction instead of halting the
+// chain.
+
+// sysValidatorsRealmPath is the realm allowed to update the validator set.
+const sysValidatorsRealmPath = "gno.land/r/system/validators"
+
+const (
+	validatorKeyPrefix       = "validator:"
+	validatorUpdateKeyPrefix = "valupdate:"
+)
+
+// InitValidators records the genesis validators of the chain.
+func (vm *VMKeeper) InitValidators(ctx sdk.Context, vals []abci.ValidatorUpdate) {
+	stor := ctx.Store(vm.iavlKey)
+	for _, val := range vals {
+		addr := val.Address
+		if addr.IsZero() {
+			addr = val.PubKey.Address()
+		}
+		stor.Set(validatorKey(addr), amino.MustMarshal(val))
+	}
+}
+
+// updateValidator records a change of the validator set, applied at the end
+// of the block. A power of 0 removes the validator.
+func (vm *VMKeeper) updateValidator(ctx sdk.Context, pubKey crypto.PubKey, power int64) error {
+	if power < 0 {
+		return fmt.Errorf("voting power can't be negative: %d", power)
+	}
+	if params := ctx.ConsensusParams(); params != nil && params.Validator != nil {
+		if typeURL := amino.GetTypeURL(pubKey); !params.Validator.IsValidPubKeyTypeURL(typeURL) {
+			return fmt.Errorf("pubkey type %s is unsupported for consensus", typeURL)
+		}
+	}
+
+	stor := ctx.Store(vm.iavlKey)
+	addr := pubKey.Address()
+	update := abci.ValidatorUpdate{
+		Address: addr,
+		PubKey:  pubKey,
+		Power:   power,
+	}
+
+	if power == 0 {
+		if !stor.Has(validatorKey(addr)) {
+			return fmt.Errorf("validator %s not found", addr)
+		}
+		if vm.countValidators(ctx) == 1 {
+			return errors.New("cannot remove the last validator")
+		}
+		stor.Delete(validatorKey(addr))
+	} else {
+		stor.Set(validatorKey(addr), amino.MustMarshal(update))
+	}
+	stor.Set(validatorUpdateKey(addr), amino.MustMarshal(update))
+	return nil
+}
+
+// ValidatorUpdates returns the changes of the validator set recorded during
+// the block, sorted by address, and clears them.
+func (vm *VMKeeper) ValidatorUpdates(ctx sdk.Context) []abci.ValidatorUpdate {
+	stor := ctx.Store(vm.iavlKey)
+
+	var (
+		updates []abci.ValidatorUpdate
+		keys    [][]byte
+	)
+	iter := store.PrefixIterator(stor, []byte(validatorUpdateKeyPrefix))
+	for ; iter.Valid(); iter.Next() {
+		var update abci.ValidatorUpdate
+		amino.MustUnmarshal(iter.Value(), &update)
+		updates = append(updates, update)
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+
+	for _, key := range keys {
+		stor.Delete(key)
+	}
+	return updates
+}
+
+// countValidators returns the number of validators in the current set.
+func (vm *VMKeeper) countValidators(ctx sdk.Context) int {
+	iter := store.PrefixIterator(ctx.Store(vm.iavlKey), []byte(validatorKeyPrefix))
+	defer iter.Close()
+
+	n := 0
+	for ; iter.Valid(); iter.Next() {
+		n++
+	}
+	return n
+}
+
+func validatorKey(addr crypto.Address) []byte {
+	return append([]byte(validatorKeyPrefix), addr.Bytes()...)
+}
+
+func validatorUpdateKey(addr crypto.Address) []byte {
+	return append([]byte(validatorUpdateKeyPrefix), addr.Bytes()...)
+}
@@ -0,0 +1,142 @@
+package vm
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+)
+
+// Deployed packages can't be modified. Instead, a new major version of a
+// package can be published at "<base>/vN", where N is the next version
+// number, by the creator of the previous version. The first version of a
+// package is either "<base>" or "<base>/v1"; once one of them is published,
+// the other one can only be published as if it were a new version.
+//
+// Each version is a separate package: a new version of a realm is a new
+// realm, with its own state, and nothing is migrated from the previous
+// version. A new version can import the previous one to read or move its
+// state, through the functions the previous version exposes. The keeper
+// keeps track of the latest version of each base path, so clients can find
+// out where a package was upgraded to.
+
+var reVersionPath = regexp.MustCompile(`^(.+)/v([1-9][0-9]*)$`)
+
+const (
+	pkgCreatorKeyPrefix = "pkgcreator:"
+	pkgLatestKeyPrefix  = "pkglatest:"
+)
+
+// parseVersionPath returns the base path and major version of pkgPath.
+// Paths without a version suffix are the first version of their base path.
+func parseVersionPath(pkgPath string) (basePath string, version int) {
+	match := reVersionPath.FindStringSubmatch(pkgPath)
+	if match == nil {
+		return pkgPath, 1
+	}
+	version, err := strconv.Atoi(match[2])
+	if err != nil {
+		return pkgPath, 1
+	}
+	return match[1], version
+}
+
+// previousVersionPath returns the path of the version preceding pkgPath,
+// or an empty string if pkgPath is the first version. As "<base>" and
+// "<base>/v1" are both first versions, the one published first precedes
+// the other.
+func previousVersionPath(store gno.Store, pkgPath string) string {
+	basePath, version := parseVersionPath(pkgPath)
+	switch version {
+	case 1:
+		otherPath := basePath
+		if pkgPath == basePath {
+			otherPath = basePath + "/v1"
+		}
+		if store.GetPackage(otherPath, false) != nil {
+			return otherPath
+		}
+		return ""
+	case 2:
+		v1Path := basePath + "/v1"
+		if store.GetPackage(v1Path, false) != nil {
+			return v1Path
+		}
+		return basePath
+	default:
+		return fmt.Sprintf("%s/v%d", basePath, version-1)
+	}
+}
+
+// checkPackageVersion verifies that the creator can publish pkgPath, if
+// it is a new version of an existing package: the previous version must
+// exist, and must have been published by the same creator. Packages
+// published before their creator was recorded can be upgraded by the
+// addresses allowed to publish under their namespace.
+func (vm *VMKeeper) checkPackageVersion(ctx sdk.Context, store gno.Store, creator crypto.Address, pkgPath string) error {
+	prevPath := previousVersionPath(store, pkgPath)
+	if prevPath == "" {
+		return nil
+	}
+	if pv := store.GetPackage(prevPath, false); pv == nil {
+		return ErrInvalidPkgPath(fmt.Sprintf(
+			"cannot publish %s, previous version %s does not exist", pkgPath, prevPath))
+	}
+	prevCreator, ok := vm.getPackageCreator(ctx, prevPath)
+	if !ok {
+		if !isNamespaceManaged(store, prevPath) {
+			return ErrUnauthorizedUser(fmt.Sprintf(
+				"creator of %s is unknown, and its namespace is not managed", prevPath))
+		}
+		return vm.checkNamespacePermission(ctx, store, creator, prevPath)
+	}
+	if prevCreator != creator {
+		return ErrUnauthorizedUser(fmt.Sprintf(
+			"%s is not the creator of %s", creator, prevPath))
+	}
+	return nil
+}
+
+// setPackageVersion records the creator of the newly published pkgPath,
+// and updates the latest version of its base path if pkgPath is newer.
+func (vm *VMKeeper) setPackageVersion(ctx sdk.Context, creator crypto.Address, pkgPath string) {
+	stor := ctx.Store(vm.iavlKey)
+	stor.Set([]byte(pkgCreatorKeyPrefix+pkgPath), creator.Bytes())
+
+	basePath, version := parseVersionPath(pkgPath)
+	latestKey := []byte(pkgLatestKeyPrefix + basePath)
+	if bz := stor.Get(latestKey); bz != nil {
+		if _, latest := parseVersionPath(string(bz)); latest >= version {
+			return
+		}
+	}
+	stor.Set(latestKey, []byte(pkgPath))
+}
+
+// getPackageCreator returns the creator of pkgPath, and false if it is
+// unknown.
+func (vm *VMKeeper) getPackageCreator(ctx sdk.Context, pkgPath string) (crypto.Address, bool) {
+	bz := ctx.Store(vm.iavlKey).Get([]byte(pkgCreatorKeyPrefix + pkgPath))
+	if bz == nil {
+		return crypto.Address{}, false
+	}
+	return crypto.AddressFromBytes(bz), true
+}
+
+// QueryLatestVersion returns the path of the latest version of the
+// package at pkgPath, which can be any of its versions.
+func (vm *VMKeeper) QueryLatestVersion(ctx sdk.Context, pkgPath string) (string, error) {
+	basePath, _ := parseVersionPath(pkgPath)
+	if bz := ctx.Store(vm.iavlKey).Get([]byte(pkgLatestKeyPrefix + basePath)); bz != nil {
+		return string(bz), nil
+	}
+	if pv := vm.getGnoStore(ctx).GetPackage(pkgPath, false); pv == nil {
+		return "", ErrInvalidPkgPath(fmt.Sprintf(
+			"package not found: %s", pkgPath))
+	}
+	// published before versions were tracked.
+	return pkgPath, nil
+}
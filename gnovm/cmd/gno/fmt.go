@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+type fmtCfg struct {
+	list    bool
+	diff    bool
+	imports bool
+	rootDir string
+}
+
+func newFmtCmd(io commands.IO) *commands.Command {
+	cfg := &fmtCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "fmt",
+			ShortUsage: "fmt [flags] <file or directory> [<file or directory>...]",
+			ShortHelp:  "formats gno source files",
+			LongHelp: `Formats the specified gno files in place, or all the .gno files found in
+the specified directories, recursively.
+
+With the 'imports' flag, imports are also fixed: unused imports are removed,
+and missing imports are added by looking up the package name in the gno
+standard libraries, the packages of the examples directory, and the packages
+required by the gno.mod of the file.
+
+The 'l' and 'd' flags don't modify the files: they list the files, or print
+the diffs of the files, whose formatting differs, and make the command fail
+if there are any, so that they can be used to check formatting in CI.`,
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execFmt(cfg, args, io)
+		},
+	)
+}
+
+func (c *fmtCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.BoolVar(
+		&c.list,
+		"l",
+		false,
+		"list files whose formatting differs, instead of formatting them",
+	)
+
+	fs.BoolVar(
+		&c.diff,
+		"d",
+		false,
+		"display diffs instead of formatting files",
+	)
+
+	fs.BoolVar(
+		&c.imports,
+		"imports",
+		false,
+		"add missing and remove unused imports",
+	)
+
+	fs.StringVar(
+		&c.rootDir,
+		"root-dir",
+		"",
+		"clone location of github.com/gnolang/gno (gno tries to guess it)",
+	)
+}
+
+// errFmtDiff is returned when checking the formatting of files which are not
+// formatted.
+var errFmtDiff = errors.New("some files are not formatted")
+
+func execFmt(cfg *fmtCfg, args []string, io commands.IO) error {
+	if len(args) == 0 {
+		return flag.ErrHelp
+	}
+
+	if cfg.rootDir == "" {
+		cfg.rootDir = gnoenv.RootDir()
+	}
+
+	files, err := gnoFilesFromArgs(args)
+	if err != nil {
+		return fmt.Errorf("list files from args: %w", err)
+	}
+
+	var resolver *importResolver
+	if cfg.imports {
+		resolver = newImportResolver(cfg.rootDir)
+	}
+
+	unformatted := false
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		res, err := formatGnoFile(file, src, resolver)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(src, res) {
+			continue
+		}
+
+		switch {
+		case cfg.list || cfg.diff:
+			unformatted = true
+			if cfg.list {
+				io.Println(file)
+			}
+			if cfg.diff {
+				diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+					A:        difflib.SplitLines(string(src)),
+					B:        difflib.SplitLines(string(res)),
+					FromFile: file + ".orig",
+					ToFile:   file,
+					Context:  3,
+				})
+				if err != nil {
+					return err
+				}
+				io.Printf("diff %s\n%s", file, diff)
+			}
+		default:
+			if err := os.WriteFile(file, res, 0o644); err != nil {
+				return err
+			}
+		}
+	}
+
+	if unformatted {
+		return errFmtDiff
+	}
+	return nil
+}
+
+// formatGnoFile returns the formatted source of the file, with its imports
+// fixed if resolver is not nil.
+func formatGnoFile(file string, src []byte, resolver *importResolver) ([]byte, error) {
+	if resolver == nil {
+		res, err := format.Source(src)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		return res, nil
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := resolver.fixImports(fset, f, file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	res := buf.Bytes()
+	if changed {
+		res = groupImports(res)
+	}
+	// format again, to fix the layout of the edited import declarations.
+	return format.Source(res)
+}
+
+// declaredNames returns the names declared at the top level of the .gno files
+// of package pkgName in dir, but file.
+func declaredNames(dir, file, pkgName string) map[string]bool {
+	names := make(map[string]bool)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return names
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if !isGnoFile(entry) || filepath.Clean(path) == filepath.Clean(file) {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil || f.Name.Name != pkgName {
+			continue
+		}
+		for name := range topLevelNames(f) {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// topLevelNames returns the names declared at the top level of f, with
+// whether they are exported.
+func topLevelNames(f *ast.File) map[string]bool {
+	names := make(map[string]bool)
+	add := func(id *ast.Ident) {
+		names[id.Name] = id.IsExported()
+	}
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				add(decl.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					add(spec.Name)
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						add(name)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
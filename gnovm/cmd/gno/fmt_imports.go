@@ -0,0 +1,341 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// importResolver resolves the package names used in gno files to import
+// paths, for gno fmt -imports.
+type importResolver struct {
+	rootDir string
+	byName  map[string][]*importCandidate // nil until indexed.
+	byPath  map[string]*importCandidate
+}
+
+// kinds of import candidates, in order of preference.
+const (
+	candidateRequire = iota // required by gno.mod.
+	candidateStdlib
+	candidateExample
+)
+
+type importCandidate struct {
+	path    string // import path.
+	name    string // package name.
+	dir     string
+	kind    int
+	exports map[string]bool // loaded lazily.
+}
+
+func newImportResolver(rootDir string) *importResolver {
+	return &importResolver{rootDir: rootDir}
+}
+
+// index looks up the packages of the stdlibs and examples directories.
+func (r *importResolver) index() {
+	if r.byName != nil {
+		return
+	}
+	r.byName = make(map[string][]*importCandidate)
+	r.byPath = make(map[string]*importCandidate)
+	r.indexDir(filepath.Join(r.rootDir, "gnovm", "stdlibs"), "", candidateStdlib)
+	r.indexDir(filepath.Join(r.rootDir, "examples"), "", candidateExample)
+}
+
+// indexDir adds the packages found in root, with import paths relative to
+// root prefixed by prefix.
+func (r *importResolver) indexDir(root, prefix string, kind int) {
+	filepath.WalkDir(root, func(dir string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == "." {
+			return nil
+		}
+		name := packageNameOf(dir)
+		if name == "" {
+			return nil
+		}
+		r.add(&importCandidate{
+			path: path.Join(prefix, filepath.ToSlash(rel)),
+			name: name,
+			dir:  dir,
+			kind: kind,
+		})
+		return nil
+	})
+}
+
+func (r *importResolver) add(c *importCandidate) {
+	if _, exists := r.byPath[c.path]; exists {
+		return
+	}
+	r.byPath[c.path] = c
+	r.byName[c.name] = append(r.byName[c.name], c)
+}
+
+// addRequires adds the packages required by the gno.mod of dir, if any.
+func (r *importResolver) addRequires(dir string) {
+	gm, err := gnomod.ParseAt(dir)
+	if err != nil {
+		return
+	}
+	for _, req := range gm.Require {
+		p := req.Mod.Path
+		if c, exists := r.byPath[p]; exists {
+			c.kind = candidateRequire
+			continue
+		}
+		// downloaded with gno mod download.
+		pkgDir := filepath.Join(gnomod.GetGnoModPath(), filepath.FromSlash(p))
+		name := packageNameOf(pkgDir)
+		if name == "" {
+			continue
+		}
+		r.add(&importCandidate{
+			path: p,
+			name: name,
+			dir:  pkgDir,
+			kind: candidateRequire,
+		})
+	}
+}
+
+// fixImports removes the unused imports of the file f, and adds the imports
+// of the packages used by f that are not imported yet. It returns whether the
+// imports were changed.
+func (r *importResolver) fixImports(fset *token.FileSet, f *ast.File, file string) (changed bool, err error) {
+	r.index()
+	dir := filepath.Dir(file)
+	r.addRequires(dir)
+
+	// the package names used by the file, with the symbols used in each.
+	declared := declaredNames(dir, file, f.Name.Name)
+	used := make(map[string]map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		x, ok := sel.X.(*ast.Ident)
+		if !ok || x.Obj != nil || declared[x.Name] {
+			return true
+		}
+		if used[x.Name] == nil {
+			used[x.Name] = make(map[string]bool)
+		}
+		used[x.Name][sel.Sel.Name] = true
+		return true
+	})
+
+	// remove unused imports.
+	imported := make(map[string]bool)
+	for _, spec := range f.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return false, err
+		}
+		name := r.importName(spec, p)
+		if name == "_" || name == "." || used[name] != nil {
+			imported[name] = true
+			continue
+		}
+		specName := ""
+		if spec.Name != nil {
+			specName = spec.Name.Name
+		}
+		astutil.DeleteNamedImport(fset, f, specName, p)
+		changed = true
+	}
+
+	// add missing imports.
+	names := make([]string, 0, len(used))
+	for name := range used {
+		if !imported[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c := r.lookup(name, used[name], dir)
+		if c == nil {
+			continue
+		}
+		if path.Base(c.path) == c.name {
+			astutil.AddImport(fset, f, c.path)
+		} else {
+			astutil.AddNamedImport(fset, f, c.name, c.path)
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// groupImports lays out the imports of src as a single declaration, with the
+// imports of the standard libraries first, then the other imports, in a
+// separate group. src is left as is if it has several import declarations,
+// or comments in its imports.
+func groupImports(src []byte) []byte {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil || len(f.Imports) == 0 {
+		return src
+	}
+	var decl *ast.GenDecl
+	for _, d := range f.Decls {
+		if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.IMPORT {
+			if decl != nil {
+				return src
+			}
+			decl = gd
+		}
+	}
+	for _, cg := range f.Comments {
+		if decl.Pos() <= cg.Pos() && cg.End() <= decl.End() {
+			return src
+		}
+	}
+
+	var std, others []string
+	for _, spec := range f.Imports {
+		line := spec.Path.Value
+		if spec.Name != nil {
+			line = spec.Name.Name + " " + line
+		}
+		p, _ := strconv.Unquote(spec.Path.Value)
+		if strings.Contains(strings.Split(p, "/")[0], ".") {
+			others = append(others, line)
+		} else {
+			std = append(std, line)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(others)
+
+	var buf strings.Builder
+	if len(f.Imports) == 1 {
+		buf.WriteString("import " + append(std, others...)[0])
+	} else {
+		buf.WriteString("import (\n")
+		for _, line := range std {
+			buf.WriteString("\t" + line + "\n")
+		}
+		if len(std) > 0 && len(others) > 0 {
+			buf.WriteString("\n")
+		}
+		for _, line := range others {
+			buf.WriteString("\t" + line + "\n")
+		}
+		buf.WriteString(")")
+	}
+
+	tf := fset.File(f.Pos())
+	start, end := tf.Offset(decl.Pos()), tf.Offset(decl.End())
+	res := make([]byte, 0, len(src))
+	res = append(res, src[:start]...)
+	res = append(res, buf.String()...)
+	return append(res, src[end:]...)
+}
+
+// importName returns the name under which the package of spec is imported.
+func (r *importResolver) importName(spec *ast.ImportSpec, p string) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	if c, ok := r.byPath[p]; ok {
+		return c.name
+	}
+	return path.Base(p)
+}
+
+// lookup returns the preferred package named name which exports all the
+// symbols syms, or nil if there is none.
+func (r *importResolver) lookup(name string, syms map[string]bool, dir string) *importCandidate {
+	var found []*importCandidate
+	for _, c := range r.byName[name] {
+		if c.dir == dir || !c.exportsAll(syms) {
+			continue
+		}
+		found = append(found, c)
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	sort.Slice(found, func(i, j int) bool {
+		ci, cj := found[i], found[j]
+		if ci.kind != cj.kind {
+			return ci.kind < cj.kind
+		}
+		if len(ci.path) != len(cj.path) {
+			return len(ci.path) < len(cj.path)
+		}
+		return ci.path < cj.path
+	})
+	return found[0]
+}
+
+func (c *importCandidate) exportsAll(syms map[string]bool) bool {
+	if c.exports == nil {
+		c.exports = make(map[string]bool)
+		for _, f := range parsePackageFiles(c.dir, 0) {
+			for name, exported := range topLevelNames(f) {
+				if exported {
+					c.exports[name] = true
+				}
+			}
+		}
+	}
+	for sym := range syms {
+		if !c.exports[sym] {
+			return false
+		}
+	}
+	return true
+}
+
+// packageNameOf returns the name of the package in dir, or "" if dir
+// doesn't contain any non-test .gno file.
+func packageNameOf(dir string) string {
+	files := parsePackageFiles(dir, parser.PackageClauseOnly)
+	if len(files) == 0 {
+		return ""
+	}
+	return files[0].Name.Name
+}
+
+// parsePackageFiles parses the non-test .gno files of dir.
+func parsePackageFiles(dir string, mode parser.Mode) []*ast.File {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if !isGnoFile(entry) || strings.HasSuffix(name, "_test.gno") || strings.HasSuffix(name, "_filetest.gno") {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, mode)
+		if err != nil {
+			continue
+		}
+		files = append(files, f)
+		if mode == parser.PackageClauseOnly {
+			break
+		}
+	}
+	return files
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
@@ -19,6 +20,10 @@ type runCfg struct {
 	verbose bool
 	rootDir string
 	expr    string
+	seed    int64
+
+	debug     bool
+	debugAddr string
 }
 
 func newRunCmd(io commands.IO) *commands.Command {
@@ -58,6 +63,27 @@ func (c *runCfg) RegisterFlags(fs *flag.FlagSet) {
 		"main()",
 		"value of expression to evaluate. Defaults to executing function main() with no args",
 	)
+
+	fs.Int64Var(
+		&c.seed,
+		"seed",
+		0,
+		"seed of the goroutine scheduler; a given seed always interleaves goroutines the same way",
+	)
+
+	fs.BoolVar(
+		&c.debug,
+		"debug",
+		false,
+		"run the program with the interactive debugger",
+	)
+
+	fs.StringVar(
+		&c.debugAddr,
+		"debug-addr",
+		"",
+		"address the debugger listens on for a client (ie. localhost:2345), instead of the standard input; implies -debug",
+	)
 }
 
 func execRun(cfg *runCfg, args []string, io commands.IO) error {
@@ -86,7 +112,11 @@ func execRun(cfg *runCfg, args []string, io commands.IO) error {
 	}
 
 	// read files
-	files, err := parseFiles(args)
+	fnames, err := listFiles(args)
+	if err != nil {
+		return err
+	}
+	files, err := parseFiles(fnames)
 	if err != nil {
 		return err
 	}
@@ -96,13 +126,32 @@ func execRun(cfg *runCfg, args []string, io commands.IO) error {
 	}
 
 	m := gno.NewMachineWithOptions(gno.MachineOptions{
-		PkgPath: string(files[0].PkgName),
-		Output:  stdout,
-		Store:   testStore,
+		PkgPath:    string(files[0].PkgName),
+		Output:     stdout,
+		Store:      testStore,
+		Goroutines: true,
+		Seed:       cfg.seed,
 	})
 
 	defer m.Release()
 
+	if cfg.debug || cfg.debugAddr != "" {
+		d, closer, err := newDebugger(cfg.debugAddr, io)
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		for i, fname := range fnames {
+			body, err := os.ReadFile(fname)
+			if err != nil {
+				return err
+			}
+			d.AddSource(string(files[i].Name), string(body))
+		}
+		m.Debugger = d
+	}
+
 	// run files
 	m.RunFiles(files...)
 	runExpr(m, cfg.expr)
@@ -110,25 +159,31 @@ func execRun(cfg *runCfg, args []string, io commands.IO) error {
 	return nil
 }
 
-func parseFiles(fnames []string) ([]*gno.FileNode, error) {
-	files := make([]*gno.FileNode, 0, len(fnames))
-	for _, fname := range fnames {
+// listFiles returns the files to run: the given files, and the non-test
+// files of the given directories.
+func listFiles(args []string) ([]string, error) {
+	fnames := make([]string, 0, len(args))
+	for _, fname := range args {
 		if s, err := os.Stat(fname); err == nil && s.IsDir() {
 			subFns, err := listNonTestFiles(fname)
 			if err != nil {
 				return nil, err
 			}
-			subFiles, err := parseFiles(subFns)
-			if err != nil {
-				return nil, err
-			}
-			files = append(files, subFiles...)
+			fnames = append(fnames, subFns...)
 			continue
 		} else if err != nil {
 			// either not found or some other kind of error --
 			// in either case not a file we can parse.
 			return nil, err
 		}
+		fnames = append(fnames, fname)
+	}
+	return fnames, nil
+}
+
+func parseFiles(fnames []string) ([]*gno.FileNode, error) {
+	files := make([]*gno.FileNode, 0, len(fnames))
+	for _, fname := range fnames {
 		files = append(files, gno.MustReadFile(fname))
 	}
 	return files, nil
@@ -165,3 +220,28 @@ func runExpr(m *gno.Machine, expr string) {
 	}
 	m.Eval(ex)
 }
+
+// newDebugger returns a debugger reading its commands from the standard
+// input, or from the first client connecting to addr, if set. The returned
+// function closes the connection of the client.
+func newDebugger(addr string, io commands.IO) (*gno.Debugger, func(), error) {
+	if addr == "" {
+		io.Println("Welcome to the Gno debugger. Type 'help' for the list of commands.")
+		return gno.NewDebugger(io.In(), io.Out()), func() {}, nil
+	}
+
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to listen for the debugger: %w", err)
+	}
+	defer l.Close()
+
+	io.ErrPrintfln("Waiting for a debugger client to connect to %s", l.Addr())
+	conn, err := l.Accept()
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to accept a debugger client: %w", err)
+	}
+	fmt.Fprintln(conn, "Welcome to the Gno debugger. Type 'help' for the list of commands.")
+
+	return gno.NewDebugger(conn, conn), func() { conn.Close() }, nil
+}
@@ -7,10 +7,12 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime/debug"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
@@ -33,11 +35,19 @@ type testCfg struct {
 	verbose             bool
 	rootDir             string
 	run                 string
+	bench               string
+	benchTime           benchTimeFlag
+	cover               bool
+	coverProfile        string
 	timeout             time.Duration
 	transpile           bool // TODO: transpile should be the default, but it needs to automatically transpile dependencies in memory.
 	updateGoldenTests   bool
 	printRuntimeMetrics bool
 	withNativeFallback  bool
+	debug               bool
+	debugAddr           string
+
+	debugger *gno.Debugger
 }
 
 func newTestCmd(io commands.IO) *commands.Command {
@@ -56,9 +66,20 @@ file pattern "*_test.gno" or "*_filetest.gno".
 The <package> can be directory or file path (relative or absolute).
 
 - "*_test.gno" files work like "*_test.go" files, but they contain only test
-functions. Benchmark and fuzz functions aren't supported yet. Similarly, only
+and benchmark functions. Fuzz functions aren't supported yet. Similarly, only
 tests that belong to the same package are supported for now (no "xxx_test").
 
+Benchmarks are run when the 'bench' flag is set. For each benchmark, the
+wall time, the VM cycles, and the bytes and number of allocations per
+iteration are printed on stdout, in a format that benchstat can read.
+
+The 'cover' flag enables statement coverage of the "*_test.gno" tests: the
+percentage of the statements of each package executed by its tests is
+printed along with the test results. The 'coverprofile' flag writes a
+coverage profile for all packages, in the format of 'go test -coverprofile'.
+Statements are identified by their line, so all the statements starting on
+the same line are covered together.
+
 The package path used to execute the "*_test.gno" file is fetched from the
 module name found in 'gno.mod', or else it is randomly generated like
 "gno.land/r/XXXXXXXX".
@@ -138,6 +159,34 @@ func (c *testCfg) RegisterFlags(fs *flag.FlagSet) {
 		"test name filtering pattern",
 	)
 
+	fs.StringVar(
+		&c.bench,
+		"bench",
+		"",
+		"run only those benchmarks matching a regular expression",
+	)
+
+	c.benchTime = benchTimeFlag{d: time.Second}
+	fs.Var(
+		&c.benchTime,
+		"benchtime",
+		"run each benchmark for duration d, or N times if the value is of the form Nx",
+	)
+
+	fs.BoolVar(
+		&c.cover,
+		"cover",
+		false,
+		"enable coverage analysis",
+	)
+
+	fs.StringVar(
+		&c.coverProfile,
+		"coverprofile",
+		"",
+		"write a coverage profile to the file after all tests have passed; implies -cover",
+	)
+
 	fs.DurationVar(
 		&c.timeout,
 		"timeout",
@@ -158,6 +207,20 @@ func (c *testCfg) RegisterFlags(fs *flag.FlagSet) {
 		false,
 		"print runtime metrics (gas, memory, cpu cycles)",
 	)
+
+	fs.BoolVar(
+		&c.debug,
+		"debug",
+		false,
+		"run the unit tests with the interactive debugger",
+	)
+
+	fs.StringVar(
+		&c.debugAddr,
+		"debug-addr",
+		"",
+		"address the debugger listens on for a client (ie. localhost:2345), instead of the standard input; implies -debug",
+	)
 }
 
 func execTest(cfg *testCfg, args []string, io commands.IO) error {
@@ -206,6 +269,20 @@ func execTest(cfg *testCfg, args []string, io commands.IO) error {
 		return fmt.Errorf("list sub packages: %w", err)
 	}
 
+	if cfg.coverProfile != "" {
+		cfg.cover = true
+	}
+	var covs []*gno.Coverage
+
+	if cfg.debug || cfg.debugAddr != "" {
+		d, closer, err := newDebugger(cfg.debugAddr, io)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		cfg.debugger = d
+	}
+
 	buildErrCount := 0
 	testErrCount := 0
 	for _, pkg := range subPkgs {
@@ -254,10 +331,19 @@ func execTest(cfg *testCfg, args []string, io commands.IO) error {
 		sort.Strings(pkg.TestGnoFiles)
 		sort.Strings(pkg.FiletestGnoFiles)
 
+		var cov *gno.Coverage
+		if cfg.cover {
+			cov = gno.NewCoverage()
+			covs = append(covs, cov)
+		}
+
 		startedAt := time.Now()
-		err = gnoTestPkg(pkg.Dir, pkg.TestGnoFiles, pkg.FiletestGnoFiles, cfg, io)
+		err = gnoTestPkg(pkg.Dir, pkg.TestGnoFiles, pkg.FiletestGnoFiles, cfg, cov, io)
 		duration := time.Since(startedAt)
 		dstr := fmtDuration(duration)
+		if cov != nil {
+			dstr += fmt.Sprintf(" \tcoverage: %.1f%% of statements", cov.Percent())
+		}
 
 		if err != nil {
 			io.ErrPrintfln("%s: test pkg: %v", pkg.Dir, err)
@@ -274,6 +360,12 @@ func execTest(cfg *testCfg, args []string, io commands.IO) error {
 		return fmt.Errorf("FAIL: %d build errors, %d test errors", buildErrCount, testErrCount)
 	}
 
+	if cfg.coverProfile != "" {
+		if err := writeCoverProfile(cfg.coverProfile, covs); err != nil {
+			return fmt.Errorf("write coverage profile: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -282,6 +374,7 @@ func gnoTestPkg(
 	unittestFiles,
 	filetestFiles []string,
 	cfg *testCfg,
+	cov *gno.Coverage,
 	io commands.IO,
 ) error {
 	var (
@@ -328,6 +421,15 @@ func gnoTestPkg(
 		tfiles, ifiles := parseMemPackageTests(memPkg)
 		testPkgName := getPkgNameFromFileset(ifiles)
 
+		var bench *benchOptions
+		if cfg.bench != "" {
+			bench = &benchOptions{
+				filter:  cfg.bench,
+				time:    cfg.benchTime,
+				pkgPath: gnoPkgPath,
+			}
+		}
+
 		// run test files in pkg
 		if len(tfiles.Files) > 0 {
 			testStore := tests.TestStore(
@@ -347,8 +449,20 @@ func gnoTestPkg(
 
 				m.Alloc = gno.NewAllocator(maxAllocTx)
 			}
-			m.RunMemPackage(memPkg, true)
-			err := runTestFiles(m, tfiles, memPkg.Name, verbose, printRuntimeMetrics, runFlag, io)
+			if bench != nil && m.Alloc == nil {
+				// benchmarks measure allocations, but don't limit them.
+				m.Alloc = gno.NewAllocator(math.MaxInt64)
+			}
+			pn, _ := m.RunMemPackage(memPkg, true)
+			if cov != nil {
+				addCoverFiles(cov, pn, pkgPath)
+				m.Coverage = cov
+			}
+			if cfg.debugger != nil {
+				addDebugSources(cfg.debugger, memPkg)
+				m.Debugger = cfg.debugger
+			}
+			err := runTestFiles(m, tfiles, memPkg.Name, verbose, printRuntimeMetrics, runFlag, bench, io)
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			}
@@ -366,6 +480,9 @@ func gnoTestPkg(
 			}
 
 			m := tests.TestMachine(testStore, stdout, testPkgName)
+			if bench != nil {
+				m.Alloc = gno.NewAllocator(math.MaxInt64)
+			}
 
 			memFiles := make([]*std.MemFile, 0, len(ifiles.FileNames())+1)
 			for _, f := range memPkg.Files {
@@ -381,8 +498,22 @@ func gnoTestPkg(
 			memPkg.Name = testPkgName
 			memPkg.Path = memPkg.Path + "_test"
 			m.RunMemPackage(memPkg, true)
+			if cov != nil {
+				// load the tested package, which is imported by
+				// the test files, to cover its statements.
+				if testStore.GetPackage(gnoPkgPath, true) != nil {
+					loc := gno.PackageNodeLocation(gnoPkgPath)
+					pn := testStore.GetBlockNode(loc).(*gno.PackageNode)
+					addCoverFiles(cov, pn, pkgPath)
+				}
+				m.Coverage = cov
+			}
+			if cfg.debugger != nil {
+				addDebugSources(cfg.debugger, memPkg)
+				m.Debugger = cfg.debugger
+			}
 
-			err := runTestFiles(m, ifiles, testPkgName, verbose, printRuntimeMetrics, runFlag, io)
+			err := runTestFiles(m, ifiles, testPkgName, verbose, printRuntimeMetrics, runFlag, bench, io)
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			}
@@ -473,6 +604,7 @@ func runTestFiles(
 	verbose bool,
 	printRuntimeMetrics bool,
 	runFlag string,
+	bench *benchOptions,
 	io commands.IO,
 ) (errs error) {
 	defer func() {
@@ -486,6 +618,11 @@ func runTestFiles(
 		Verbose:     verbose,
 		RunFlag:     runFlag,
 	}
+	if bench != nil {
+		testFuncs.BenchFlag = bench.filter
+		testFuncs.BenchTime = int64(bench.time.d)
+		testFuncs.BenchN = bench.time.n
+	}
 	loadTestFuncs(pkgName, testFuncs, files)
 
 	// before/after statistics
@@ -498,6 +635,9 @@ func runTestFiles(
 
 	m.RunFiles(files.Files...)
 	n := gno.MustParseFile("main_test.gno", testmain)
+	if m.Debugger != nil {
+		m.Debugger.AddSource(string(n.Name), testmain)
+	}
 	m.RunFiles(n)
 
 	for _, test := range testFuncs.Tests {
@@ -547,6 +687,34 @@ func runTestFiles(
 		}
 	}
 
+	if bench == nil {
+		return errs
+	}
+	for _, b := range testFuncs.Benchmarks {
+		eval := m.Eval(gno.Call("runbench", fmt.Sprintf("%q", b.Name)))
+
+		var rep benchReport
+		err = json.Unmarshal([]byte(eval[0].GetString()), &rep)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			io.ErrPrintfln("--- FAIL: %s [internal gno testing error]", b.Name)
+			continue
+		}
+
+		if rep.Failed {
+			err := errors.New("failed: %q", b.Name)
+			errs = multierr.Append(errs, err)
+		}
+
+		for _, res := range rep.Results {
+			if !bench.printedPkg {
+				io.Printfln("pkg: %s", bench.pkgPath)
+				bench.printedPkg = true
+			}
+			io.Println(res.String())
+		}
+	}
+
 	return errs
 }
 
@@ -556,6 +724,115 @@ type report struct {
 	Skipped bool
 }
 
+// mirror of stdlibs/testing.BenchmarkReport
+type benchReport struct {
+	Failed  bool
+	Results []benchResult
+}
+
+// mirror of stdlibs/testing.BenchmarkResult
+type benchResult struct {
+	Name   string
+	N      int
+	T      int64
+	Cycles int64
+	Bytes  int64
+	Allocs int64
+	Extra  []struct {
+		Value float64
+		Unit  string
+	}
+}
+
+// String returns a summary of the benchmark result, in the format of the
+// benchmark results of go test.
+func (r benchResult) String() string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s\t%8d", r.Name, r.N)
+	if r.N <= 0 {
+		return buf.String()
+	}
+	n := float64(r.N)
+	buf.WriteByte('\t')
+	prettyPrint(&buf, float64(r.T)/n, "ns/op")
+	buf.WriteByte('\t')
+	prettyPrint(&buf, float64(r.Cycles)/n, "cycles/op")
+	fmt.Fprintf(&buf, "\t%8d B/op\t%8d allocs/op", r.Bytes/int64(r.N), r.Allocs/int64(r.N))
+	for _, m := range r.Extra {
+		buf.WriteByte('\t')
+		prettyPrint(&buf, m.Value, m.Unit)
+	}
+	return buf.String()
+}
+
+// prettyPrint is copied from go's testing package.
+func prettyPrint(w *strings.Builder, x float64, unit string) {
+	// Print all numbers with 10 places before the decimal point
+	// and small numbers with four sig figs. Field widths are
+	// chosen to fit the whole part in 10 places while aligning
+	// the decimal point of all fractional formats.
+	var format string
+	switch y := math.Abs(x); {
+	case y == 0 || y >= 999.95:
+		format = "%10.0f %s"
+	case y >= 99.995:
+		format = "%12.1f %s"
+	case y >= 9.9995:
+		format = "%13.2f %s"
+	case y >= 0.99995:
+		format = "%14.3f %s"
+	case y >= 0.099995:
+		format = "%15.4f %s"
+	case y >= 0.0099995:
+		format = "%16.5f %s"
+	case y >= 0.00099995:
+		format = "%17.6f %s"
+	default:
+		format = "%18.7f %s"
+	}
+	fmt.Fprintf(w, format, x, unit)
+}
+
+// benchOptions holds the benchmark flags, and the state of the benchmark
+// output of a package.
+type benchOptions struct {
+	filter     string
+	time       benchTimeFlag
+	pkgPath    string
+	printedPkg bool
+}
+
+// benchTimeFlag is the value of the -benchtime flag: a duration, or a number
+// of iterations when of the form Nx.
+type benchTimeFlag struct {
+	d time.Duration
+	n int
+}
+
+func (f *benchTimeFlag) String() string {
+	if f.n > 0 {
+		return fmt.Sprintf("%dx", f.n)
+	}
+	return f.d.String()
+}
+
+func (f *benchTimeFlag) Set(s string) error {
+	if strings.HasSuffix(s, "x") {
+		n, err := strconv.ParseInt(s[:len(s)-1], 10, 0)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid count %q", s)
+		}
+		*f = benchTimeFlag{n: int(n)}
+		return nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid duration %q", s)
+	}
+	*f = benchTimeFlag{d: d}
+	return nil
+}
+
 var testmainTmpl = template.Must(template.New("testmain").Parse(`
 package {{ .PackageName }}
 
@@ -569,6 +846,12 @@ var tests = []testing.InternalTest{
 {{end}}
 }
 
+var benchmarks = []testing.InternalBenchmark{
+{{range .Benchmarks}}
+    {"{{.Name}}", {{.Name}}},
+{{end}}
+}
+
 func runtest(name string) (report string) {
 	for _, test := range tests {
 		if test.Name == name {
@@ -578,13 +861,27 @@ func runtest(name string) (report string) {
 	panic("no such test: " + name)
 	return ""
 }
+
+func runbench(name string) (report string) {
+	for _, bench := range benchmarks {
+		if bench.Name == name {
+			return testing.RunBenchmark({{printf "%q" .BenchFlag}}, {{.Verbose}}, {{.BenchTime}}, {{.BenchN}}, bench)
+		}
+	}
+	panic("no such benchmark: " + name)
+	return ""
+}
 `))
 
 type testFuncs struct {
 	Tests       []testFunc
+	Benchmarks  []testFunc
 	PackageName string
 	Verbose     bool
 	RunFlag     string
+	BenchFlag   string
+	BenchTime   int64 // nanoseconds.
+	BenchN      int
 }
 
 type testFunc struct {
@@ -619,6 +916,13 @@ func loadTestFuncs(pkgName string, t *testFuncs, tfiles *gno.FileSet) *testFuncs
 					}
 					t.Tests = append(t.Tests, tf)
 				}
+				if strings.HasPrefix(fname, "Benchmark") {
+					bf := testFunc{
+						Package: pkgName,
+						Name:    fname,
+					}
+					t.Benchmarks = append(t.Benchmarks, bf)
+				}
 			}
 		}
 	}
@@ -670,3 +974,53 @@ func shouldRun(filter filterMatch, path string) bool {
 	ok, _ := filter.matches(elem, matchString)
 	return ok
 }
+
+// addCoverFiles adds the files of pn, found in dir, to cov.
+func addCoverFiles(cov *gno.Coverage, pn *gno.PackageNode, dir string) {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	for _, fn := range pn.FileSet.Files {
+		cov.AddFile(filepath.Join(dir, string(fn.Name)), fn)
+	}
+}
+
+// writeCoverProfile writes the coverage profile of covs to the file at path,
+// in the format of go test -coverprofile, so that it can be read by go tool
+// cover. Each coverage block spans a line, from its first non-blank
+// character.
+// addDebugSources registers the sources of the files of memPkg, including the
+// test files, in the debugger.
+func addDebugSources(d *gno.Debugger, memPkg *std.MemPackage) {
+	for _, f := range memPkg.Files {
+		d.AddSource(f.Name, f.Body)
+	}
+}
+
+func writeCoverProfile(path string, covs []*gno.Coverage) error {
+	var buf bytes.Buffer
+	buf.WriteString("mode: count\n")
+	sources := make(map[string][]string)
+	for _, cov := range covs {
+		for _, b := range cov.Blocks() {
+			lines, ok := sources[b.File]
+			if !ok {
+				body, err := os.ReadFile(b.File)
+				if err != nil {
+					return err
+				}
+				lines = strings.Split(string(body), "\n")
+				sources[b.File] = lines
+			}
+			startCol, endCol := 1, 1
+			if b.Line <= len(lines) {
+				line := lines[b.Line-1]
+				startCol += len(line) - len(strings.TrimLeft(line, " \t"))
+				endCol += len(strings.TrimRight(line, " \t\r"))
+			}
+			fmt.Fprintf(&buf, "%s:%d.%d,%d.%d %d %d\n",
+				b.File, b.Line, startCol, b.Line, endCol, b.NumStmts, b.Count)
+		}
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
@@ -1,14 +1,21 @@
 package gnolang
 
-import "reflect"
+import (
+	"reflect"
+
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/overflow"
+)
 
 // Keeps track of in-memory allocations.
 // In the future, allocations within realm boundaries will be
 // (optionally?) condensed (objects to be GC'd will be discarded),
 // but for now, allocations strictly increment across the whole tx.
 type Allocator struct {
-	maxBytes int64
-	bytes    int64
+	maxBytes  int64
+	bytes     int64
+	numAllocs int64
+	gasMeter  store.GasMeter
 }
 
 // for gonative, which doesn't consider the allocator.
@@ -26,6 +33,7 @@ const (
 	_allocSliceValue       = 40
 	_allocFuncValue        = 136
 	_allocMapValue         = 144
+	_allocChanValue        = 104
 	_allocBoundMethodValue = 176
 	_allocBlock            = 464
 	_allocNativeValue      = 48
@@ -53,6 +61,8 @@ const (
 	allocFunc        = _allocBase + _allocPointer + _allocFuncValue
 	allocMap         = _allocBase + _allocPointer + _allocMapValue
 	allocMapItem     = _allocTypedValue * 3 // XXX
+	allocChan        = _allocBase + _allocPointer + _allocChanValue
+	allocChanItem    = _allocTypedValue
 	allocBoundMethod = _allocBase + _allocPointer + _allocBoundMethodValue
 	allocBlock       = _allocBase + _allocPointer + _allocBlock
 	allocBlockItem   = _allocTypedValue
@@ -73,15 +83,41 @@ func NewAllocator(maxBytes int64) *Allocator {
 	}
 }
 
+// GasFactorAlloc is the amount of gas consumed per allocated byte,
+// when the allocator has a gas meter.
+const GasFactorAlloc int64 = 1
+
+// GasAllocDesc is the descriptor of the gas consumed by allocations.
+const GasAllocDesc = "AllocBytes"
+
 func (alloc *Allocator) Status() (maxBytes int64, bytes int64) {
 	return alloc.maxBytes, alloc.bytes
 }
 
+// NumAllocs returns the number of allocations made, for benchmarks.
+func (alloc *Allocator) NumAllocs() int64 {
+	if alloc == nil {
+		return 0
+	}
+	return alloc.numAllocs
+}
+
+// SetGasMeter sets the gas meter on which allocations are charged.
+// A nil gas meter detaches it, as does resetting the allocator.
+func (alloc *Allocator) SetGasMeter(gasMeter store.GasMeter) {
+	if alloc == nil {
+		return
+	}
+	alloc.gasMeter = gasMeter
+}
+
 func (alloc *Allocator) Reset() *Allocator {
 	if alloc == nil {
 		return nil
 	}
 	alloc.bytes = 0
+	alloc.numAllocs = 0
+	alloc.gasMeter = nil
 	return alloc
 }
 
@@ -90,8 +126,9 @@ func (alloc *Allocator) Fork() *Allocator {
 		return nil
 	}
 	return &Allocator{
-		maxBytes: alloc.maxBytes,
-		bytes:    alloc.bytes,
+		maxBytes:  alloc.maxBytes,
+		bytes:     alloc.bytes,
+		numAllocs: alloc.numAllocs,
 	}
 }
 
@@ -100,7 +137,12 @@ func (alloc *Allocator) Allocate(size int64) {
 		// this can happen for map items just prior to assignment.
 		return
 	}
+	if alloc.gasMeter != nil {
+		gasAlloc := overflow.Mul64p(size, GasFactorAlloc)
+		alloc.gasMeter.ConsumeGas(gasAlloc, GasAllocDesc)
+	}
 	alloc.bytes += size
+	alloc.numAllocs++
 	if alloc.bytes > alloc.maxBytes {
 		panic("allocation limit exceeded")
 	}
@@ -147,6 +189,10 @@ func (alloc *Allocator) AllocateMapItem() {
 	alloc.Allocate(allocMapItem)
 }
 
+func (alloc *Allocator) AllocateChan(items int64) {
+	alloc.Allocate(allocChan + allocChanItem*items)
+}
+
 func (alloc *Allocator) AllocateBoundMethod() {
 	alloc.Allocate(allocBoundMethod)
 }
@@ -274,6 +320,14 @@ func (alloc *Allocator) NewMap(size int) *MapValue {
 	return mv
 }
 
+func (alloc *Allocator) NewChan(size int) *ChanValue {
+	alloc.AllocateChan(int64(size))
+	return &ChanValue{
+		Buffer: make([]TypedValue, 0, size),
+		Cap:    size,
+	}
+}
+
 func (alloc *Allocator) NewBlock(source BlockNode, parent *Block) *Block {
 	alloc.AllocateBlock(int64(source.GetNumNames()))
 	return NewBlock(source, parent)
@@ -0,0 +1,130 @@
+package gnolang
+
+import (
+	"sort"
+)
+
+// Coverage records which statements of a set of files are executed, for
+// gno test -cover. Set it as Machine.Coverage to record the statements
+// executed by the machine.
+//
+// Statements are only known by their line, so coverage is recorded per
+// line: all the statements starting on the same line of a file form a
+// single CoverBlock.
+type Coverage struct {
+	files  map[string]map[int]*CoverBlock // file name -> line -> block
+	blocks []*CoverBlock
+	stmts  map[Stmt]*CoverBlock
+}
+
+// CoverBlock is the coverage of the statements starting on a line.
+type CoverBlock struct {
+	File     string
+	Line     int
+	NumStmts int
+	Count    int64 // number of times statements of the block were executed.
+}
+
+func NewCoverage() *Coverage {
+	return &Coverage{
+		files: make(map[string]map[int]*CoverBlock),
+		stmts: make(map[Stmt]*CoverBlock),
+	}
+}
+
+// AddFile registers the statements of the preprocessed file node fn, which
+// are reported under the given file name. A file may be added more than
+// once, for instance if the same package is loaded by two stores; its
+// statements are only counted once.
+func (c *Coverage) AddFile(name string, fn *FileNode) {
+	lines, added := c.files[name]
+	if !added {
+		lines = make(map[int]*CoverBlock)
+		c.files[name] = lines
+	}
+	Transcribe(fn, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage != TRANS_ENTER {
+			return n, TRANS_CONTINUE
+		}
+		s, ok := n.(Stmt)
+		if !ok || !isCoverStmt(ns, s) {
+			return n, TRANS_CONTINUE
+		}
+		line := s.GetLine()
+		b := lines[line]
+		if b == nil {
+			if added {
+				return n, TRANS_CONTINUE
+			}
+			b = &CoverBlock{File: name, Line: line}
+			lines[line] = b
+			c.blocks = append(c.blocks, b)
+		}
+		if !added {
+			b.NumStmts++
+		}
+		c.stmts[s] = b
+		return n, TRANS_CONTINUE
+	})
+}
+
+// isCoverStmt returns whether s is a statement executed on its own, within
+// a function body.
+func isCoverStmt(ns []Node, s Stmt) bool {
+	if s.GetLine() == 0 {
+		// added by the preprocessor.
+		return false
+	}
+	if len(ns) > 0 {
+		if _, ok := ns[len(ns)-1].(*FileNode); ok {
+			// top level declaration.
+			return false
+		}
+	}
+	switch s.(type) {
+	case *BlockStmt, *EmptyStmt, *IfCaseStmt, *SwitchClauseStmt, *SelectCaseStmt:
+		// blocks and clauses are not executed as statements.
+		return false
+	default:
+		return true
+	}
+}
+
+// hit records the execution of s.
+func (c *Coverage) hit(s Stmt) {
+	if b := c.stmts[s]; b != nil {
+		b.Count++
+	}
+}
+
+// Blocks returns the coverage blocks of all the added files, ordered by
+// file name and line.
+func (c *Coverage) Blocks() []CoverBlock {
+	blocks := make([]CoverBlock, len(c.blocks))
+	for i, b := range c.blocks {
+		blocks[i] = *b
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		if blocks[i].File != blocks[j].File {
+			return blocks[i].File < blocks[j].File
+		}
+		return blocks[i].Line < blocks[j].Line
+	})
+	return blocks
+}
+
+// Percent returns the percentage of statements executed at least once, or
+// 0 if there are no statements.
+func (c *Coverage) Percent() float64 {
+	var covered, total int
+	for _, b := range c.blocks {
+		total += b.NumStmts
+		if b.Count > 0 {
+			covered += b.NumStmts
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(covered) / float64(total)
+}
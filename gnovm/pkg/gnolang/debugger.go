@@ -0,0 +1,407 @@
+package gnolang
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Debugger is an interactive debugger of the statements executed by a
+// machine, for gno run -debug and gno test -debug. Set it as
+// Machine.Debugger to debug the machine.
+//
+// The debugger pauses the machine before the first statement, at the
+// breakpoints, and after each step; while paused, it reads commands from its
+// input and writes to its output. Type "help" for the list of commands.
+type Debugger struct {
+	in  *bufio.Scanner
+	out io.Writer
+
+	sources     map[string][]string // file name -> lines
+	breakpoints []debugLocation
+	lastCmd     string
+
+	mode      debugMode
+	depth     int           // call depth of the last pause, for next and stepout
+	prev      debugLocation // location of the last executed statement
+	prevDepth int           // call depth of the last executed statement
+	frame     int           // selected frame: 0 is the innermost call frame
+}
+
+type debugMode int
+
+const (
+	debugStep     debugMode = iota // pause at the next line
+	debugNext                      // pause at the next line of the same frame, or of a caller
+	debugStepOut                   // pause in the caller
+	debugContinue                  // pause at breakpoints
+	debugDetached                  // don't pause
+)
+
+// debugLocation is the position of a statement.
+type debugLocation struct {
+	PkgPath string
+	File    string
+	Line    int
+}
+
+func (l debugLocation) String() string {
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
+// NewDebugger returns a debugger reading its commands from in, and writing
+// to out.
+func NewDebugger(in io.Reader, out io.Writer) *Debugger {
+	return &Debugger{
+		in:      bufio.NewScanner(in),
+		out:     out,
+		sources: make(map[string][]string),
+	}
+}
+
+// AddSource registers the source of a file, to be listed by the debugger.
+// The name must be the name of the FileNode. Sources of the packages in the
+// store don't need to be added.
+func (d *Debugger) AddSource(name, body string) {
+	d.sources[name] = strings.Split(body, "\n")
+}
+
+// hit is called before the execution of s; it pauses the machine if
+// required.
+func (d *Debugger) hit(m *Machine, s Stmt) {
+	if d.mode == debugDetached || s.GetLine() == 0 {
+		return
+	}
+
+	frames := callFrames(m)
+	loc := debugLocation{PkgPath: m.Package.PkgPath, Line: s.GetLine()}
+	if len(frames) > 0 {
+		loc.PkgPath = frames[0].Func.PkgPath
+		loc.File = string(frames[0].Func.FileName)
+	}
+	depth := len(frames)
+
+	// only the first statement executed on a line is considered.
+	if loc == d.prev && depth == d.prevDepth {
+		return
+	}
+	d.prev, d.prevDepth = loc, depth
+
+	switch {
+	case d.isBreakpoint(loc):
+	case d.mode == debugStep:
+	case d.mode == debugNext && depth <= d.depth:
+	case d.mode == debugStepOut && depth < d.depth:
+	default:
+		return
+	}
+
+	d.depth = depth
+	d.frame = 0
+	d.printLocation(m, 2)
+	d.repl(m)
+}
+
+func (d *Debugger) isBreakpoint(loc debugLocation) bool {
+	for _, bp := range d.breakpoints {
+		if bp.Line == loc.Line && matchFile(bp.File, loc) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchFile checks if the file of a breakpoint is the file of the location:
+// either its name, or a path ending with its name.
+func matchFile(file string, loc debugLocation) bool {
+	if file == loc.File {
+		return true
+	}
+	if path.Base(file) != path.Base(loc.File) {
+		return false
+	}
+	if !strings.Contains(file, "/") {
+		return true
+	}
+	full := loc.PkgPath + "/" + path.Base(loc.File)
+	return strings.HasSuffix("/"+full, "/"+file) || strings.HasSuffix(loc.File, "/"+file)
+}
+
+// repl reads and executes commands, until one of them resumes the machine.
+func (d *Debugger) repl(m *Machine) {
+	for {
+		fmt.Fprint(d.out, "dbg> ")
+		if !d.in.Scan() {
+			// end of input: run to completion.
+			fmt.Fprintln(d.out)
+			d.mode = debugDetached
+			return
+		}
+
+		line := strings.TrimSpace(d.in.Text())
+		if line == "" {
+			line = d.lastCmd
+		}
+		d.lastCmd = line
+		if line == "" {
+			continue
+		}
+
+		cmd, arg, _ := strings.Cut(line, " ")
+		if d.exec(m, cmd, strings.TrimSpace(arg)) {
+			return
+		}
+	}
+}
+
+// exec executes a command, and returns true if it resumes the machine.
+func (d *Debugger) exec(m *Machine, cmd, arg string) bool {
+	switch cmd {
+	case "break", "b":
+		d.cmdBreak(m, arg)
+	case "breakpoints", "bp":
+		for i, bp := range d.breakpoints {
+			fmt.Fprintf(d.out, "Breakpoint %d at %s\n", i, bp)
+		}
+	case "clear":
+		d.cmdClear(arg)
+	case "continue", "c":
+		d.mode = debugContinue
+		return true
+	case "next", "n":
+		d.mode = debugNext
+		return true
+	case "step", "s":
+		d.mode = debugStep
+		return true
+	case "stepout", "so":
+		d.mode = debugStepOut
+		return true
+	case "stack", "bt":
+		d.cmdStack(m)
+	case "up":
+		d.selectFrame(m, d.frame+intArg(arg, 1))
+	case "down":
+		d.selectFrame(m, d.frame-intArg(arg, 1))
+	case "frame":
+		d.selectFrame(m, intArg(arg, 0))
+	case "print", "p":
+		d.cmdPrint(m, arg)
+	case "locals":
+		d.cmdLocals(m)
+	case "list", "l":
+		d.printLocation(m, 5)
+	case "quit", "q":
+		fmt.Fprintln(d.out, "Detached, running to completion.")
+		d.mode = debugDetached
+		return true
+	case "help", "h":
+		fmt.Fprint(d.out, debuggerHelp)
+	default:
+		fmt.Fprintf(d.out, "Unknown command %q, type help for the list of commands.\n", cmd)
+	}
+	return false
+}
+
+const debuggerHelp = `Commands:
+  break, b [<file>:]<line>  set a breakpoint
+  breakpoints, bp           list the breakpoints
+  clear [<id>]              clear a breakpoint, or all of them
+  continue, c               run until the next breakpoint
+  next, n                   step over to the next line
+  step, s                   step into the next line
+  stepout, so               step out of the current function
+  stack, bt                 print the call stack
+  up [<n>], down [<n>]      select the caller, or the callee, frame
+  frame <n>                 select a frame of the stack
+  print, p <name>           print a variable of the selected frame
+  locals                    print the local variables of the selected frame
+  list, l                   list the source of the selected frame
+  quit, q                   detach the debugger and run to completion
+  help, h                   print this help
+An empty command repeats the previous one.
+`
+
+func intArg(arg string, def int) int {
+	if n, err := strconv.Atoi(arg); err == nil {
+		return n
+	}
+	return def
+}
+
+func (d *Debugger) cmdBreak(m *Machine, arg string) {
+	loc := d.frameLocation(m, d.frame)
+	spec := arg
+	if i := strings.LastIndexByte(arg, ':'); i >= 0 {
+		loc.File, spec = arg[:i], arg[i+1:]
+	}
+	line, err := strconv.Atoi(spec)
+	if err != nil || line <= 0 || loc.File == "" {
+		fmt.Fprintf(d.out, "Invalid breakpoint %q: expected [<file>:]<line>.\n", arg)
+		return
+	}
+	loc.Line = line
+	d.breakpoints = append(d.breakpoints, loc)
+	fmt.Fprintf(d.out, "Breakpoint %d at %s\n", len(d.breakpoints)-1, loc)
+}
+
+func (d *Debugger) cmdClear(arg string) {
+	if arg == "" {
+		d.breakpoints = nil
+		fmt.Fprintln(d.out, "Cleared all breakpoints.")
+		return
+	}
+	id, err := strconv.Atoi(arg)
+	if err != nil || id < 0 || id >= len(d.breakpoints) {
+		fmt.Fprintf(d.out, "Invalid breakpoint id %q.\n", arg)
+		return
+	}
+	fmt.Fprintf(d.out, "Cleared breakpoint %d at %s\n", id, d.breakpoints[id])
+	d.breakpoints = append(d.breakpoints[:id], d.breakpoints[id+1:]...)
+}
+
+func (d *Debugger) cmdStack(m *Machine) {
+	frames := callFrames(m)
+	for i, fr := range frames {
+		marker := " "
+		if i == d.frame {
+			marker = "*"
+		}
+		fmt.Fprintf(d.out, "%s%d\t%s\n\t  at %s\n", marker, i, funcName(fr.Func), d.frameLocation(m, i))
+	}
+}
+
+func (d *Debugger) selectFrame(m *Machine, i int) {
+	if n := len(callFrames(m)); i < 0 || i >= n {
+		fmt.Fprintf(d.out, "Invalid frame %d: the stack has %d frames.\n", i, n)
+		return
+	}
+	d.frame = i
+	d.printLocation(m, 2)
+}
+
+func (d *Debugger) cmdPrint(m *Machine, name string) {
+	if name == "" {
+		fmt.Fprintln(d.out, "Missing variable name.")
+		return
+	}
+	for b := d.frameBlock(m, d.frame); b != nil; b = b.GetParent(m.Store) {
+		names := b.GetSource(m.Store).GetBlockNames()
+		for i, n := range names {
+			if string(n) == name && i < len(b.Values) {
+				fmt.Fprintf(d.out, "%s = %s\n", name, b.Values[i].String())
+				return
+			}
+		}
+	}
+	fmt.Fprintf(d.out, "Undefined variable %q.\n", name)
+}
+
+func (d *Debugger) cmdLocals(m *Machine) {
+	seen := make(map[Name]bool)
+	var lines []string
+	for b := d.frameBlock(m, d.frame); b != nil; b = b.GetParent(m.Store) {
+		source := b.GetSource(m.Store)
+		if _, ok := source.(*FileNode); ok {
+			break
+		}
+		if _, ok := source.(*PackageNode); ok {
+			break
+		}
+		for i, n := range source.GetBlockNames() {
+			// inner declarations shadow outer ones.
+			if seen[n] || n == "_" || strings.HasPrefix(string(n), ".") || i >= len(b.Values) {
+				continue
+			}
+			seen[n] = true
+			lines = append(lines, fmt.Sprintf("%s = %s", n, b.Values[i].String()))
+		}
+	}
+	sort.Strings(lines)
+	for _, line := range lines {
+		fmt.Fprintln(d.out, line)
+	}
+}
+
+// printLocation prints the location of the selected frame, with n lines of
+// source around it.
+func (d *Debugger) printLocation(m *Machine, n int) {
+	loc := d.frameLocation(m, d.frame)
+	fname := "?"
+	if frames := callFrames(m); d.frame < len(frames) {
+		fname = funcName(frames[d.frame].Func)
+	}
+	fmt.Fprintf(d.out, "> %s %s\n", fname, loc)
+
+	lines := d.source(m, loc)
+	if lines == nil {
+		return
+	}
+	for i := max(loc.Line-n, 1); i <= min(loc.Line+n, len(lines)); i++ {
+		marker := "  "
+		if i == loc.Line {
+			marker = "=>"
+		}
+		fmt.Fprintf(d.out, "%s %4d\t%s\n", marker, i, lines[i-1])
+	}
+}
+
+func (d *Debugger) source(m *Machine, loc debugLocation) []string {
+	if lines, ok := d.sources[loc.File]; ok {
+		return lines
+	}
+	if mf := m.Store.GetMemFile(loc.PkgPath, loc.File); mf != nil {
+		lines := strings.Split(mf.Body, "\n")
+		d.sources[loc.File] = lines
+		return lines
+	}
+	return nil
+}
+
+// frameLocation returns the location of the i-th call frame: the current
+// statement for the innermost frame, and the call for the others.
+func (d *Debugger) frameLocation(m *Machine, i int) debugLocation {
+	frames := callFrames(m)
+	if i == 0 || i >= len(frames) {
+		return d.prev
+	}
+	fv := frames[i].Func
+	return debugLocation{
+		PkgPath: fv.PkgPath,
+		File:    string(fv.FileName),
+		Line:    frames[i-1].Source.GetLine(),
+	}
+}
+
+// frameBlock returns the innermost block of the i-th call frame.
+func (d *Debugger) frameBlock(m *Machine, i int) *Block {
+	frames := callFrames(m)
+	if i == 0 || i >= len(frames) {
+		return m.LastBlock()
+	}
+	return m.Blocks[frames[i-1].NumBlocks-1]
+}
+
+// callFrames returns the frames of the calls of gno functions, the innermost
+// first.
+func callFrames(m *Machine) []*Frame {
+	var frames []*Frame
+	for i := len(m.Frames) - 1; i >= 0; i-- {
+		if fr := m.Frames[i]; fr.Func != nil {
+			frames = append(frames, fr)
+		}
+	}
+	return frames
+}
+
+func funcName(fv *FuncValue) string {
+	name := string(fv.Name)
+	if name == "" {
+		name = "func"
+	}
+	return fv.PkgPath + "." + name
+}
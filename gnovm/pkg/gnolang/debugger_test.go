@@ -0,0 +1,104 @@
+package gnolang
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	stypes "github.com/gnolang/gno/tm2/pkg/store/types"
+	"github.com/jaekwon/testify/assert"
+)
+
+const debugMain = `package main
+
+func add(a, b int) int {
+	c := a + b
+	return c
+}
+
+func main() {
+	x := 1
+	for i := 0; i < 2; i++ {
+		x = add(x, i)
+	}
+	println(x)
+}
+`
+
+// runDebugger runs debugMain with a debugger reading the commands, and
+// returns the output of the debugger.
+func runDebugger(t *testing.T, commands ...string) string {
+	t.Helper()
+
+	db := memdb.NewMemDB()
+	baseStore := dbadapter.StoreConstructor(db, stypes.StoreOptions{})
+	iavlStore := iavl.StoreConstructor(db, stypes.StoreOptions{})
+	store := NewStore(nil, baseStore, iavlStore)
+
+	var output, dbgOutput bytes.Buffer
+	m := NewMachineWithOptions(MachineOptions{
+		PkgPath: "main",
+		Store:   store,
+		Output:  &output,
+	})
+	defer m.Release()
+
+	d := NewDebugger(strings.NewReader(strings.Join(commands, "\n")+"\n"), &dbgOutput)
+	d.AddSource("main.gno", debugMain)
+	m.Debugger = d
+
+	m.RunFiles(MustParseFile("main.gno", debugMain))
+	m.RunMain()
+
+	assert.Equal(t, "2\n", output.String())
+	return dbgOutput.String()
+}
+
+func TestDebugger(t *testing.T) {
+	t.Parallel()
+
+	t.Run("breakpoints", func(t *testing.T) {
+		t.Parallel()
+
+		out := runDebugger(t, "b 4", "c", "bt", "p a", "p x", "up", "p x", "locals", "clear", "c")
+		assert.Contains(t, out, "> main.main main.gno:9\n")
+		assert.Contains(t, out, "Breakpoint 0 at main.gno:4\n")
+		assert.Contains(t, out, "> main.add main.gno:4\n")
+		assert.Contains(t, out, "=>    4\t\tc := a + b\n")
+		assert.Contains(t, out, "*0\tmain.add\n\t  at main.gno:4\n 1\tmain.main\n\t  at main.gno:11\n")
+		assert.Contains(t, out, "a = (1 int)\n")
+		assert.Contains(t, out, `Undefined variable "x".`)
+		assert.Contains(t, out, "x = (1 int)\n")
+		assert.Contains(t, out, "i = (0 int)\nx = (1 int)\n")
+		assert.Contains(t, out, "Cleared all breakpoints.\n")
+		// the breakpoint is hit only once.
+		assert.Equal(t, 1, strings.Count(out, "> main.add main.gno:4\n"))
+	})
+
+	t.Run("steps", func(t *testing.T) {
+		t.Parallel()
+
+		out := runDebugger(t, "n", "n", "s", "s", "so", "n", "", "q")
+		locs := []string{
+			"> main.main main.gno:9\n",  // start
+			"> main.main main.gno:10\n", // n
+			"> main.main main.gno:11\n", // n
+			"> main.add main.gno:4\n",   // s
+			"> main.add main.gno:5\n",   // s
+			"> main.main main.gno:10\n", // so
+			"> main.main main.gno:11\n", // n
+			"> main.main main.gno:10\n", // repeated n
+		}
+		for _, loc := range locs {
+			i := strings.Index(out, loc)
+			if !assert.True(t, i >= 0, "missing %q in:\n%s", loc, out) {
+				return
+			}
+			out = out[i+len(loc):]
+		}
+		assert.Contains(t, out, "Detached, running to completion.\n")
+	})
+}
@@ -0,0 +1,916 @@
+package gnolang
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Generic functions and types are monomorphized: they are not preprocessed
+// where declared, but each instantiation with distinct type arguments
+// preprocesses a copy of the generic declaration, where the type parameters
+// are replaced by the type arguments, in the context of the declaring file.
+//
+// Instantiated types are named after their type arguments, e.g.
+// List[int], and are saved in the store like other declared types.
+// Instantiated functions are referred to by a *ConstExpr.
+//
+// Known limitations:
+//   - type parameters cannot be shadowed within generic declarations.
+//   - generic functions cannot be native, and generic types cannot be
+//     aliases.
+//   - constraint type sets are only checked upon instantiation, and a type
+//     parameter cannot be used as a union term.
+
+// genericDecls holds the generic declarations of a package.
+type genericDecls struct {
+	decls       map[Name]*genericDecl
+	methods     map[Name][]genericMethod // methods of generic types, by type name
+	predefining int                      // see beginPredefine()
+	pending     []func()                 // deferred until predefined
+}
+
+// genericDecl is a generic function or type declaration, along with its
+// instances.
+type genericDecl struct {
+	file  *FileNode
+	decl  Decl // *FuncDecl or *TypeDecl, never preprocessed
+	insts map[Name]*genericInst
+}
+
+type genericMethod struct {
+	file *FileNode
+	decl *FuncDecl
+}
+
+type genericInst struct {
+	targs []Type
+	tv    TypedValue // instantiated func, or type value
+}
+
+// extractGenerics removes the generic declarations from fn, registering
+// them in x.  It is a no-op if fn was already extracted.
+func (x *PackageNode) extractGenerics(fn *FileNode) {
+	gs := &x.generics
+	decls := fn.Decls[:0]
+	for _, d := range fn.Decls {
+		switch d := d.(type) {
+		case *FuncDecl:
+			if d.IsMethod {
+				if tn, ok := genericRecvName(d.Recv.Type); ok {
+					if gs.methods == nil {
+						gs.methods = make(map[Name][]genericMethod)
+					}
+					gs.methods[tn] = append(gs.methods[tn], genericMethod{file: fn, decl: d})
+					continue
+				}
+			} else if len(d.TypeParams) > 0 {
+				if d.Name == "init" || d.Name == "main" {
+					panic(fmt.Sprintf("func %s must have no type parameters", d.Name))
+				}
+				if d.Body == nil {
+					panic(fmt.Sprintf("generic function %s must have a body", d.Name))
+				}
+				x.addGeneric(fn, d, d.Name)
+				continue
+			}
+		case *TypeDecl:
+			if len(d.TypeParams) > 0 {
+				if d.IsAlias {
+					panic(fmt.Sprintf("generic type alias %s not supported", d.Name))
+				}
+				x.addGeneric(fn, d, d.Name)
+				continue
+			}
+		}
+		decls = append(decls, d)
+	}
+	fn.Decls = decls
+}
+
+func (x *PackageNode) addGeneric(fn *FileNode, d Decl, n Name) {
+	if isUverseName(n) {
+		panic(fmt.Sprintf(
+			"builtin identifiers cannot be shadowed: %s", n))
+	}
+	gs := &x.generics
+	if gs.decls == nil {
+		gs.decls = make(map[Name]*genericDecl)
+	}
+	if _, exists := gs.decls[n]; exists {
+		panic(fmt.Sprintf("%s redeclared in this block", n))
+	}
+	gs.decls[n] = &genericDecl{
+		file:  fn,
+		decl:  d,
+		insts: make(map[Name]*genericInst),
+	}
+}
+
+// beginPredefine and endPredefine delimit the predefinition of the
+// package.  In between, the preprocessing of the bodies of instantiated
+// functions and methods, and the checking of type constraints, are
+// deferred as they may depend on declarations not yet predefined.
+func (x *PackageNode) beginPredefine() {
+	x.generics.predefining++
+}
+
+func (x *PackageNode) endPredefine() {
+	gs := &x.generics
+	gs.predefining--
+	if gs.predefining > 0 {
+		return
+	}
+	for len(gs.pending) > 0 {
+		f := gs.pending[0]
+		gs.pending = gs.pending[1:]
+		f()
+	}
+}
+
+func (x *PackageNode) afterPredefine(f func()) {
+	if x.generics.predefining > 0 {
+		x.generics.pending = append(x.generics.pending, f)
+	} else {
+		f()
+	}
+}
+
+// genericRecvName returns the name of the generic receiver type of a
+// method, e.g. List for (l *List[T]).
+func genericRecvName(rx Expr) (Name, bool) {
+	if sx, ok := rx.(*StarExpr); ok {
+		rx = sx.X
+	}
+	var x Expr
+	switch rx := rx.(type) {
+	case *IndexExpr:
+		x = rx.X
+	case *IndexListExpr:
+		x = rx.X
+	default:
+		return "", false
+	}
+	if nx, ok := x.(*NameExpr); ok {
+		return nx.Name, true
+	}
+	return "", false
+}
+
+// lookupGeneric returns the generic declaration named by x in the context
+// of last, or nil if x does not name one.
+func lookupGeneric(store Store, last BlockNode, x Expr) *genericDecl {
+	switch x := x.(type) {
+	case *NameExpr:
+		if last.GetValueRef(store, x.Name) != nil {
+			return nil // not generic, or shadowed.
+		}
+		return packageOf(last).generics.decls[x.Name]
+	case *SelectorExpr:
+		nx, ok := x.X.(*NameExpr)
+		if !ok {
+			return nil
+		}
+		tv := last.GetValueRef(store, nx.Name)
+		if tv == nil {
+			return nil
+		}
+		pv, ok := tv.V.(*PackageValue)
+		if !ok {
+			return nil
+		}
+		gd := pv.GetPackageNode(store).generics.decls[x.Sel]
+		if gd != nil && !isUpper(string(x.Sel)) {
+			panic(fmt.Sprintf("name %s not exported by package %s", x.Sel, pv.PkgPath))
+		}
+		return gd
+	default:
+		return nil
+	}
+}
+
+func (gd *genericDecl) name() Name {
+	switch d := gd.decl.(type) {
+	case *FuncDecl:
+		return d.Name
+	case *TypeDecl:
+		return d.Name
+	default:
+		panic("should not happen")
+	}
+}
+
+func (gd *genericDecl) typeParams() FieldTypeExprs {
+	switch d := gd.decl.(type) {
+	case *FuncDecl:
+		return d.TypeParams
+	case *TypeDecl:
+		return d.TypeParams
+	default:
+		panic("should not happen")
+	}
+}
+
+func (gd *genericDecl) isFunc() bool {
+	_, ok := gd.decl.(*FuncDecl)
+	return ok
+}
+
+// instName returns the name of the instance of gd with targs, e.g.
+// Map[int,string].
+func (gd *genericDecl) instName(targs []Type) Name {
+	ids := make([]string, len(targs))
+	for i, t := range targs {
+		ids[i] = t.TypeID().String()
+	}
+	return Name(fmt.Sprintf("%s[%s]", gd.name(), strings.Join(ids, ",")))
+}
+
+// instOf returns the instance of gd which has type t, if any.
+func (gd *genericDecl) instOf(t Type) *genericInst {
+	for _, inst := range gd.insts {
+		if inst.tv.T.Kind() == TypeKind && inst.tv.GetType() == t {
+			return inst
+		}
+	}
+	return nil
+}
+
+// substitutions maps the type parameters of gd to targs.
+func (gd *genericDecl) substitutions(targs []Type) map[Name]Type {
+	tparams := gd.typeParams()
+	subst := make(map[Name]Type, len(tparams))
+	for i, tp := range tparams {
+		subst[tp.Name] = targs[i]
+	}
+	return subst
+}
+
+// instantiateExpr returns the expression replacing x, the instantiation of
+// gd with the type arguments ixs evaluated in the context of last.
+func (gd *genericDecl) instantiateExpr(store Store, last BlockNode, x Expr, ixs []Expr) Expr {
+	targs := make([]Type, len(ixs))
+	for i, ix := range ixs {
+		ix = Preprocess(store, last, ix).(Expr)
+		targs[i] = evalStaticType(store, last, ix)
+	}
+	return gd.instanceExpr(x, gd.instantiate(store, targs))
+}
+
+func (gd *genericDecl) instanceExpr(source Expr, tv TypedValue) Expr {
+	if tv.T.Kind() == TypeKind {
+		return constType(source, tv.GetType())
+	}
+	cx := &ConstExpr{
+		Source:     source,
+		TypedValue: tv,
+	}
+	cx.SetAttribute(ATTR_PREPROCESSED, true)
+	setConstAttrs(cx)
+	return cx
+}
+
+// instantiate returns the instance of gd with targs, instantiating it if
+// needed.
+func (gd *genericDecl) instantiate(store Store, targs []Type) TypedValue {
+	tparams := gd.typeParams()
+	if len(targs) != len(tparams) {
+		panic(fmt.Sprintf(
+			"got %d type arguments but %s has %d type parameters",
+			len(targs), gd.name(), len(tparams)))
+	}
+	name := gd.instName(targs)
+	if inst, ok := gd.insts[name]; ok {
+		return inst.tv
+	}
+	inst := &genericInst{targs: targs}
+	gd.insts[name] = inst
+	subst := gd.substitutions(targs)
+	pn := packageOf(gd.file)
+	pn.afterPredefine(func() {
+		gd.checkConstraints(store, targs, subst)
+	})
+	switch d := gd.decl.(type) {
+	case *FuncDecl:
+		gd.instantiateFunc(store, inst, d, name, subst)
+	case *TypeDecl:
+		gd.instantiateType(store, inst, d, name, subst)
+	}
+	return inst.tv
+}
+
+func (gd *genericDecl) instantiateFunc(store Store, inst *genericInst, d *FuncDecl, name Name, subst map[Name]Type) {
+	pn := packageOf(gd.file)
+	fd := substituteTypeParams(copyWithLines(d), subst).(*FuncDecl)
+	fd.Name = name
+	fd.TypeParams = nil
+	SetNodeLocations(pn.PkgPath, gd.instFileName(name), fd)
+	// fd is only preprocessed, not defined in the package block.
+	fd.SetAttribute(ATTR_PREDEFINED, true)
+	ft := &FuncType{}
+	inst.tv = TypedValue{
+		T: ft,
+		V: &FuncValue{
+			Type:     ft,
+			IsMethod: false,
+			Source:   fd,
+			Name:     name,
+			Closure:  nil, // set lazily.
+			FileName: fileNameOf(gd.file),
+			PkgPath:  pn.PkgPath,
+			body:     fd.Body,
+		},
+	}
+	predefineDeps(store, gd.file, &fd.Type)
+	fd.Type = *Preprocess(store, gd.file, &fd.Type).(*FuncTypeExpr)
+	*ft = *evalStaticType(store, gd.file, &fd.Type).(*FuncType)
+	pn.afterPredefine(func() {
+		preprocessInstance(store, gd.file, fd)
+	})
+}
+
+func (gd *genericDecl) instantiateType(store Store, inst *genericInst, d *TypeDecl, name Name, subst map[Name]Type) {
+	pn := packageOf(gd.file)
+	if t := store.GetTypeSafe(DeclaredTypeID(pn.PkgPath, name)); t != nil {
+		// already instantiated, e.g. before a restart.
+		inst.tv = asValue(t)
+	} else {
+		tx := substituteTypeParams(copyWithLines(d.Type), subst).(Expr)
+		// predefine an empty base type for recursive references.
+		base := emptyTypeOf(tx)
+		var dt *DeclaredType
+		if base != nil {
+			dt = declareWith(pn.PkgPath, name, base)
+			inst.tv = asValue(dt)
+		}
+		predefineDeps(store, gd.file, tx)
+		tx = Preprocess(store, gd.file, tx).(Expr)
+		tmp := evalStaticType(store, gd.file, tx)
+		if base == nil {
+			dt = declareWith(pn.PkgPath, name, tmp)
+			inst.tv = asValue(dt)
+		} else {
+			fillEmptyType(base, tmp)
+		}
+		dt.Seal()
+		store.SetType(dt)
+	}
+	dt := inst.tv.GetType().(*DeclaredType)
+	// instantiate the methods.
+	for _, gm := range pn.generics.methods[d.Name] {
+		fd := copyWithLines(gm.decl).(*FuncDecl)
+		rx := &fd.Recv.Type
+		if sx, ok := (*rx).(*StarExpr); ok {
+			rx = &sx.X
+		}
+		var ixs Exprs
+		switch ix := (*rx).(type) {
+		case *IndexExpr:
+			ixs = Exprs{ix.Index}
+		case *IndexListExpr:
+			ixs = ix.Indices
+		}
+		if len(ixs) != len(inst.targs) {
+			panic(fmt.Sprintf(
+				"got %d type parameters in receiver of method %s but %s has %d type parameters",
+				len(ixs), fd.Name, d.Name, len(inst.targs)))
+		}
+		msubst := make(map[Name]Type, len(ixs))
+		for i, ix := range ixs {
+			nx, ok := ix.(*NameExpr)
+			if !ok {
+				panic(fmt.Sprintf(
+					"invalid type parameter %s in receiver of method %s", ix, fd.Name))
+			}
+			if nx.Name != "_" {
+				msubst[nx.Name] = inst.targs[i]
+			}
+		}
+		*rx = constType(*rx, dt)
+		fd = substituteTypeParams(fd, msubst).(*FuncDecl)
+		SetNodeLocations(pn.PkgPath, gd.instFileName(name), fd)
+		predefineNow(store, gm.file, fd)
+		mfile := gm.file
+		pn.afterPredefine(func() {
+			preprocessInstance(store, mfile, fd)
+		})
+	}
+}
+
+// instFileName is the file name of the locations of the instance name.
+func (gd *genericDecl) instFileName(name Name) string {
+	return fmt.Sprintf("%s@%s", gd.file.Name, name)
+}
+
+// preprocessInstance preprocesses the body of an instantiated function or
+// method, and saves its block nodes.
+func preprocessInstance(store Store, file *FileNode, fd *FuncDecl) {
+	Preprocess(store, file, fd)
+	Transcribe(fd, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage != TRANS_ENTER {
+			return n, TRANS_CONTINUE
+		}
+		if bn, ok := n.(BlockNode); ok {
+			store.SetBlockNode(bn)
+		}
+		return n, TRANS_CONTINUE
+	})
+}
+
+// predefineDeps predefines the package declarations x depends on.
+func predefineDeps(store Store, file *FileNode, x Expr) {
+	pn := packageOf(file)
+	for {
+		un := findUndefined(store, file, x)
+		if un == "" {
+			return
+		}
+		dfile, decl := pn.FileSet.GetDeclFor(un)
+		*decl, _ = predefineNow(store, dfile, *decl)
+	}
+}
+
+// emptyTypeOf returns an empty type of the kind of the type literal tx,
+// or nil if tx is not a type literal.
+func emptyTypeOf(tx Expr) Type {
+	switch tx.(type) {
+	case *FuncTypeExpr:
+		return &FuncType{}
+	case *ArrayTypeExpr:
+		return &ArrayType{}
+	case *SliceTypeExpr:
+		return &SliceType{}
+	case *InterfaceTypeExpr:
+		return &InterfaceType{}
+	case *ChanTypeExpr:
+		return &ChanType{}
+	case *MapTypeExpr:
+		return &MapType{}
+	case *StructTypeExpr:
+		return &StructType{}
+	case *StarExpr:
+		return &PointerType{}
+	default:
+		return nil
+	}
+}
+
+// fillEmptyType copies src into dst, an empty type from emptyTypeOf().
+func fillEmptyType(dst, src Type) {
+	switch dst := dst.(type) {
+	case *FuncType:
+		*dst = *(src.(*FuncType))
+	case *ArrayType:
+		*dst = *(src.(*ArrayType))
+	case *SliceType:
+		*dst = *(src.(*SliceType))
+	case *InterfaceType:
+		*dst = *(src.(*InterfaceType))
+	case *ChanType:
+		*dst = *(src.(*ChanType))
+	case *MapType:
+		*dst = *(src.(*MapType))
+	case *StructType:
+		*dst = *(src.(*StructType))
+	case *PointerType:
+		*dst = *(src.(*PointerType))
+	default:
+		panic("should not happen")
+	}
+}
+
+// copyWithLines is like n.Copy(), but also copies the line, label and iota
+// attributes of n and its children.
+func copyWithLines(n Node) Node {
+	var orig []Node
+	Transcribe(n, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage == TRANS_ENTER {
+			orig = append(orig, n)
+		}
+		return n, TRANS_CONTINUE
+	})
+	cn := n.Copy()
+	i := 0
+	Transcribe(cn, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage != TRANS_ENTER || i >= len(orig) {
+			return n, TRANS_CONTINUE
+		}
+		on := orig[i]
+		i++
+		n.SetLine(on.GetLine())
+		n.SetLabel(on.GetLabel())
+		if iota := on.GetAttribute(ATTR_IOTA); iota != nil {
+			n.SetAttribute(ATTR_IOTA, iota)
+		}
+		return n, TRANS_CONTINUE
+	})
+	return cn
+}
+
+// substituteTypeParams replaces the type parameters in n by their type
+// arguments.
+func substituteTypeParams(n Node, subst map[Name]Type) Node {
+	if len(subst) == 0 {
+		return n
+	}
+	return Transcribe(n, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage != TRANS_ENTER || ftype == TRANS_COMPOSITE_KEY {
+			return n, TRANS_CONTINUE
+		}
+		if nx, ok := n.(*NameExpr); ok {
+			if t, ok := subst[nx.Name]; ok {
+				return constType(nx, t), TRANS_SKIP
+			}
+		}
+		return n, TRANS_CONTINUE
+	})
+}
+
+// ----------------------------------------
+// Constraints
+
+func (gd *genericDecl) checkConstraints(store Store, targs []Type, subst map[Name]Type) {
+	for i, tp := range gd.typeParams() {
+		if !satisfies(store, gd.file, targs[i], tp.Type, subst) {
+			cs := "comparable"
+			if !isComparableConstraint(tp.Type) {
+				cs = evalTypeExpr(store, gd.file, tp.Type, subst).String()
+			}
+			panic(fmt.Sprintf("%s does not satisfy %s", targs[i].String(), cs))
+		}
+	}
+}
+
+func isComparableConstraint(cx Expr) bool {
+	nx, ok := cx.(*NameExpr)
+	return ok && nx.Name == "comparable"
+}
+
+// satisfies returns true if t satisfies the constraint cx, declared in
+// file.
+func satisfies(store Store, file *FileNode, t Type, cx Expr, subst map[Name]Type) bool {
+	if isComparableConstraint(cx) {
+		return isComparableType(t)
+	}
+	ct := evalTypeExpr(store, file, cx, subst)
+	it, ok := baseOf(ct).(*InterfaceType)
+	if !ok {
+		// e.g. [T int]
+		return t.TypeID() == ct.TypeID()
+	}
+	if !it.IsImplementedBy(t) {
+		return false
+	}
+	if cfile, itx := constraintExpr(store, file, cx); itx != nil {
+		if cfile != file {
+			subst = nil
+		}
+		return satisfiesTypeSet(store, cfile, t, itx, subst)
+	}
+	return true
+}
+
+// satisfiesTypeSet returns true if t is in the type set of the constraint
+// interface itx, excluding its methods.
+func satisfiesTypeSet(store Store, file *FileNode, t Type, itx *InterfaceTypeExpr, subst map[Name]Type) bool {
+	for _, ux := range itx.Unions {
+		ok := false
+		for _, term := range ux.Terms {
+			if satisfiesTerm(store, file, t, term, subst) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	// embedded constraints.
+	for _, m := range itx.Methods {
+		if m.Name != "" {
+			continue
+		}
+		if cfile, citx := constraintExpr(store, file, m.Type); citx != nil {
+			if !satisfiesTypeSet(store, cfile, t, citx, nil) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func satisfiesTerm(store Store, file *FileNode, t Type, term TypeTermExpr, subst map[Name]Type) bool {
+	if !term.Tilde {
+		if _, itx := constraintExpr(store, file, term.Type); itx != nil {
+			// e.g. Signed | Unsigned
+			return satisfies(store, file, t, term.Type, subst)
+		}
+	}
+	tt := evalTypeExpr(store, file, term.Type, subst)
+	if term.Tilde {
+		return baseOf(t).TypeID() == baseOf(tt).TypeID()
+	}
+	return t.TypeID() == tt.TypeID()
+}
+
+// constraintExpr returns the interface type expression of the constraint
+// cx, and the file it is declared in, or nil if cx is not an interface
+// type expression nor the name of one.
+func constraintExpr(store Store, file *FileNode, cx Expr) (*FileNode, *InterfaceTypeExpr) {
+	switch cx := cx.(type) {
+	case *InterfaceTypeExpr:
+		return file, cx
+	case *constTypeExpr:
+		if cx.Source != nil {
+			return constraintExpr(store, file, cx.Source)
+		}
+	case *NameExpr:
+		return constraintDecl(store, packageOf(file), cx.Name)
+	case *SelectorExpr:
+		nx, ok := cx.X.(*NameExpr)
+		if !ok {
+			return nil, nil
+		}
+		tv := file.GetValueRef(store, nx.Name)
+		if tv == nil {
+			return nil, nil
+		}
+		if pv, ok := tv.V.(*PackageValue); ok {
+			return constraintDecl(store, pv.GetPackageNode(store), cx.Sel)
+		}
+	}
+	return nil, nil
+}
+
+func constraintDecl(store Store, pn *PackageNode, n Name) (*FileNode, *InterfaceTypeExpr) {
+	if pn.FileSet == nil {
+		return nil, nil
+	}
+	file, decl, ok := pn.FileSet.GetDeclForSafe(n)
+	if !ok {
+		return nil, nil
+	}
+	if td, ok := (*decl).(*TypeDecl); ok {
+		return constraintExpr(store, file, td.Type)
+	}
+	return nil, nil
+}
+
+// evalTypeExpr evaluates the type of a copy of the unpreprocessed type
+// expression tx, with the type parameters substituted.
+func evalTypeExpr(store Store, file *FileNode, tx Expr, subst map[Name]Type) Type {
+	tx = substituteTypeParams(copyWithLines(tx), subst).(Expr)
+	tx = Preprocess(store, file, tx).(Expr)
+	return evalStaticType(store, file, tx)
+}
+
+// isComparableType returns true if values of type t can be compared
+// with ==.
+func isComparableType(t Type) bool {
+	switch bt := baseOf(t).(type) {
+	case *SliceType, *MapType, *FuncType:
+		return false
+	case *ArrayType:
+		return isComparableType(bt.Elt)
+	case *StructType:
+		for _, f := range bt.Fields {
+			if !isComparableType(f.Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+// ----------------------------------------
+// Inference
+
+// inferTypeArgs infers the type arguments of the generic function gd
+// called by n, of which the first type arguments may be explicit.  The
+// arguments of n are preprocessed.
+func (gd *genericDecl) inferTypeArgs(store Store, last BlockNode, n *CallExpr, explicit []Type) []Type {
+	fd := gd.decl.(*FuncDecl)
+	tparams := fd.TypeParams
+	if len(explicit) > len(tparams) {
+		panic(fmt.Sprintf(
+			"got %d type arguments but %s has %d type parameters",
+			len(explicit), fd.Name, len(tparams)))
+	}
+	inf := &inference{
+		store:  store,
+		gd:     gd,
+		tnames: make(map[Name]struct{}, len(tparams)),
+		targs:  make(map[Name]Type, len(tparams)),
+	}
+	for i, tp := range tparams {
+		inf.tnames[tp.Name] = struct{}{}
+		if i < len(explicit) {
+			inf.targs[tp.Name] = explicit[i]
+		}
+	}
+	// argument types.
+	for i, arg := range n.Args {
+		n.Args[i] = Preprocess(store, last, arg).(Expr)
+	}
+	var ats []Type
+	if len(n.Args) == 1 {
+		if tt, ok := n.Args[0].GetAttribute(ATTR_TYPEOF_VALUE).(*tupleType); ok && len(tt.Elts) > 1 {
+			// f(g()) with multiple results.
+			ats = tt.Elts
+		}
+	}
+	if ats == nil {
+		ats = make([]Type, len(n.Args))
+		for i, arg := range n.Args {
+			ats[i] = evalStaticTypeOf(store, last, arg)
+		}
+	}
+	// parameter type expressions, by argument.
+	params := fd.Type.Params
+	pxs := make([]Expr, len(ats))
+	for i := range ats {
+		if i < len(params)-1 {
+			pxs[i] = params[i].Type
+		} else if len(params) > 0 {
+			px := params[len(params)-1].Type
+			if sx, ok := px.(*SliceTypeExpr); ok && sx.Vrd && !n.Varg {
+				px = sx.Elt
+			} else if i >= len(params) {
+				continue
+			}
+			pxs[i] = px
+		}
+	}
+	// typed arguments first, then untyped constants.
+	for i, at := range ats {
+		if pxs[i] != nil && at != nil && !isUntyped(at) {
+			inf.unify(pxs[i], at)
+		}
+	}
+	inf.inferCoreTypes()
+	for i, at := range ats {
+		if pxs[i] != nil && at != nil && isUntyped(at) && at != UntypedBoolType {
+			inf.unifyUntyped(pxs[i], at)
+		}
+	}
+	inf.inferCoreTypes()
+	targs := make([]Type, len(tparams))
+	for i, tp := range tparams {
+		t := inf.targs[tp.Name]
+		if t == nil {
+			panic(fmt.Sprintf("cannot infer %s in call to %s", tp.Name, fd.Name))
+		}
+		targs[i] = t
+	}
+	return targs
+}
+
+type inference struct {
+	store   Store
+	gd      *genericDecl
+	tnames  map[Name]struct{}
+	targs   map[Name]Type
+	untyped map[Name]Type // default types of untyped constants
+}
+
+// unify infers type arguments by matching the parameter type expression
+// px to the argument type t.
+func (inf *inference) unify(px Expr, t Type) {
+	switch px := px.(type) {
+	case *NameExpr:
+		if _, ok := inf.tnames[px.Name]; ok {
+			if inf.targs[px.Name] == nil {
+				inf.targs[px.Name] = t
+			}
+		}
+	case *StarExpr:
+		if pt, ok := baseOf(t).(*PointerType); ok {
+			inf.unify(px.X, pt.Elt)
+		}
+	case *SliceTypeExpr:
+		if st, ok := baseOf(t).(*SliceType); ok {
+			inf.unify(px.Elt, st.Elt)
+		}
+	case *ArrayTypeExpr:
+		if at, ok := baseOf(t).(*ArrayType); ok {
+			inf.unify(px.Elt, at.Elt)
+		}
+	case *MapTypeExpr:
+		if mt, ok := baseOf(t).(*MapType); ok {
+			inf.unify(px.Key, mt.Key)
+			inf.unify(px.Value, mt.Value)
+		}
+	case *ChanTypeExpr:
+		if ct, ok := baseOf(t).(*ChanType); ok {
+			inf.unify(px.Value, ct.Elt)
+		}
+	case *FuncTypeExpr:
+		if ft, ok := baseOf(t).(*FuncType); ok {
+			for i := 0; i < len(px.Params) && i < len(ft.Params); i++ {
+				inf.unify(px.Params[i].Type, ft.Params[i].Type)
+			}
+			for i := 0; i < len(px.Results) && i < len(ft.Results); i++ {
+				inf.unify(px.Results[i].Type, ft.Results[i].Type)
+			}
+		}
+	case *IndexExpr:
+		inf.unifyInstance(px.X, Exprs{px.Index}, t)
+	case *IndexListExpr:
+		inf.unifyInstance(px.X, px.Indices, t)
+	}
+}
+
+func (inf *inference) unifyInstance(x Expr, ixs Exprs, t Type) {
+	gd := lookupGeneric(inf.store, inf.gd.file, x)
+	if gd == nil {
+		return
+	}
+	inst := gd.instOf(t)
+	if inst == nil || len(inst.targs) != len(ixs) {
+		return
+	}
+	for i, ix := range ixs {
+		inf.unify(ix, inst.targs[i])
+	}
+}
+
+// unifyUntyped binds the type parameter px, if not already bound, to the
+// default type of the untyped constant type t; the default type of the
+// largest untyped numeric kind wins, as in 1 and 2.5 => float64.
+func (inf *inference) unifyUntyped(px Expr, t Type) {
+	nx, ok := px.(*NameExpr)
+	if !ok {
+		return
+	}
+	if _, ok := inf.tnames[nx.Name]; !ok {
+		return
+	}
+	if inf.untyped == nil {
+		inf.untyped = make(map[Name]Type)
+	}
+	if prev, ok := inf.untyped[nx.Name]; ok {
+		if untypedRank(t) > untypedRank(prev) {
+			inf.untyped[nx.Name] = t
+			inf.targs[nx.Name] = defaultTypeOf(t)
+		}
+		return
+	}
+	if inf.targs[nx.Name] == nil {
+		inf.untyped[nx.Name] = t
+		inf.targs[nx.Name] = defaultTypeOf(t)
+	}
+}
+
+func untypedRank(t Type) int {
+	switch t {
+	case UntypedBigintType:
+		return 1
+	case UntypedRuneType:
+		return 2
+	case UntypedBigdecType:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// inferCoreTypes infers type arguments from the single term constraints
+// of type parameters, e.g. E from S ~[]E, or S from E.
+func (inf *inference) inferCoreTypes() {
+	tparams := inf.gd.typeParams()
+	for changed := true; changed; {
+		changed = false
+		for _, tp := range tparams {
+			file, itx := constraintExpr(inf.store, inf.gd.file, tp.Type)
+			if itx == nil || file != inf.gd.file ||
+				len(itx.Unions) != 1 || len(itx.Unions[0].Terms) != 1 {
+				continue
+			}
+			core := itx.Unions[0].Terms[0].Type
+			if t := inf.targs[tp.Name]; t != nil {
+				n := len(inf.targs)
+				inf.unify(core, t)
+				changed = changed || len(inf.targs) > n
+			} else if inf.bound(core) {
+				inf.targs[tp.Name] = evalTypeExpr(inf.store, file, core, inf.targs)
+				changed = true
+			}
+		}
+	}
+}
+
+// bound returns true if all type parameters in x are bound.
+func (inf *inference) bound(x Expr) bool {
+	bound := true
+	Transcribe(copyWithLines(x), func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if nx, ok := n.(*NameExpr); ok && stage == TRANS_ENTER {
+			if _, ok := inf.tnames[nx.Name]; ok && inf.targs[nx.Name] == nil {
+				bound = false
+				return n, TRANS_EXIT
+			}
+		}
+		return n, TRANS_CONTINUE
+	})
+	return bound
+}
@@ -175,6 +175,11 @@ func Go2Gno(fs *token.FileSet, gon ast.Node) (n Node) {
 			X:     toExpr(fs, gon.X),
 			Index: toExpr(fs, gon.Index),
 		}
+	case *ast.IndexListExpr:
+		return &IndexListExpr{
+			X:       toExpr(fs, gon.X),
+			Indices: toExprs(fs, gon.Indices),
+		}
 	case *ast.SelectorExpr:
 		return &SelectorExpr{
 			X:   toExpr(fs, gon.X),
@@ -267,9 +272,7 @@ func Go2Gno(fs *token.FileSet, gon ast.Node) (n Node) {
 			Vrd: true,
 		}
 	case *ast.InterfaceType:
-		return &InterfaceTypeExpr{
-			Methods: toFieldsFromList(fs, gon.Methods),
-		}
+		return toInterfaceTypeExpr(fs, gon)
 	case *ast.ChanType:
 		var dir ChanDir
 		if gon.Dir&ast.SEND > 0 {
@@ -320,6 +323,16 @@ func Go2Gno(fs *token.FileSet, gon ast.Node) (n Node) {
 		return &DeferStmt{
 			Call: *cx,
 		}
+	case *ast.GoStmt:
+		cx := toExpr(fs, gon.Call).(*CallExpr)
+		return &GoStmt{
+			Call: *cx,
+		}
+	case *ast.SendStmt:
+		return &SendStmt{
+			Chan:  toExpr(fs, gon.Chan),
+			Value: toExpr(fs, gon.Value),
+		}
 	case *ast.ExprStmt:
 		if cx, ok := gon.X.(*ast.CallExpr); ok {
 			if ix, ok := cx.Fun.(*ast.Ident); ok && ix.Name == "panic" {
@@ -422,6 +435,10 @@ func Go2Gno(fs *token.FileSet, gon ast.Node) (n Node) {
 			IsTypeSwitch: false,
 			Clauses:      toClauses(fs, gon.Body.List),
 		}
+	case *ast.SelectStmt:
+		return &SelectStmt{
+			Cases: toSelectCases(fs, gon.Body.List),
+		}
 	case *ast.FuncDecl:
 		isMethod := gon.Recv != nil
 		recv := FieldTypeExpr{}
@@ -438,11 +455,12 @@ func Go2Gno(fs *token.FileSet, gon ast.Node) (n Node) {
 			body = Go2Gno(fs, gon.Body).(*BlockStmt).Body
 		}
 		return &FuncDecl{
-			IsMethod: isMethod,
-			Recv:     recv,
-			NameExpr: NameExpr{Name: name},
-			Type:     *type_,
-			Body:     body,
+			IsMethod:   isMethod,
+			Recv:       recv,
+			NameExpr:   NameExpr{Name: name},
+			Type:       *type_,
+			Body:       body,
+			TypeParams: toTypeParams(fs, gon.Type.TypeParams),
 		}
 	case *ast.GenDecl:
 		panic("unexpected *ast.GenDecl; use toDecls(fs,) instead")
@@ -631,9 +649,10 @@ func toDecls(fs *token.FileSet, gd *ast.GenDecl) (ds Decls) {
 			tipe := toExpr(fs, s.Type)
 			alias := s.Assign != 0
 			ds = append(ds, &TypeDecl{
-				NameExpr: NameExpr{Name: name},
-				Type:     tipe,
-				IsAlias:  alias,
+				NameExpr:   NameExpr{Name: name},
+				Type:       tipe,
+				IsAlias:    alias,
+				TypeParams: toTypeParams(fs, s.TypeParams),
 			})
 		case *ast.ValueSpec:
 			if gd.Tok == token.CONST {
@@ -745,6 +764,91 @@ func toFields(fs *token.FileSet, fields ...*ast.Field) (ftxs []FieldTypeExpr) {
 	return
 }
 
+// toTypeParams converts a type parameter list, where type set constraints
+// like [T ~int | ~string] become constraint interfaces.
+func toTypeParams(fs *token.FileSet, fl *ast.FieldList) (ftxs []FieldTypeExpr) {
+	if fl == nil {
+		return nil
+	}
+	for _, f := range fl.List {
+		for _, n := range f.Names {
+			var tx Expr
+			if isTypeSetElem(f.Type) {
+				tx = &InterfaceTypeExpr{
+					Unions: []TypeUnionExpr{{
+						Terms: toTypeTerms(fs, f.Type),
+					}},
+				}
+			} else {
+				tx = toExpr(fs, f.Type)
+			}
+			ftxs = append(ftxs, FieldTypeExpr{
+				Name: toName(n),
+				Type: tx,
+			})
+		}
+	}
+	return
+}
+
+// toInterfaceTypeExpr converts an interface type, splitting the type set
+// elements of constraint interfaces (like ~int | ~string) from its
+// methods and embedded interfaces.
+func toInterfaceTypeExpr(fs *token.FileSet, it *ast.InterfaceType) *InterfaceTypeExpr {
+	itx := &InterfaceTypeExpr{}
+	if it.Methods == nil {
+		return itx
+	}
+	for _, f := range it.Methods.List {
+		if len(f.Names) == 0 && isTypeSetElem(f.Type) {
+			itx.Unions = append(itx.Unions, TypeUnionExpr{
+				Terms: toTypeTerms(fs, f.Type),
+			})
+		} else {
+			itx.Methods = append(itx.Methods, toFields(fs, f)...)
+		}
+	}
+	return itx
+}
+
+// isTypeSetElem returns true if the embedded interface element x
+// denotes a type set rather than an embedded interface.
+func isTypeSetElem(x ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.BinaryExpr:
+		return x.Op == token.OR
+	case *ast.UnaryExpr:
+		return x.Op == token.TILDE
+	case *ast.Ident:
+		// predeclared non-interface types, e.g. interface{ int }.
+		return x.Name != "any" && x.Name != "error" && x.Name != "comparable" &&
+			isUverseName(Name(x.Name))
+	case *ast.ParenExpr:
+		return isTypeSetElem(x.X)
+	case *ast.ArrayType, *ast.MapType, *ast.ChanType, *ast.FuncType,
+		*ast.StructType, *ast.StarExpr:
+		return true
+	default:
+		return false
+	}
+}
+
+func toTypeTerms(fs *token.FileSet, x ast.Expr) []TypeTermExpr {
+	switch x := x.(type) {
+	case *ast.BinaryExpr:
+		if x.Op == token.OR {
+			return append(toTypeTerms(fs, x.X), toTypeTerms(fs, x.Y)...)
+		}
+	case *ast.UnaryExpr:
+		if x.Op == token.TILDE {
+			return []TypeTermExpr{{Tilde: true, Type: toExpr(fs, x.X)}}
+		}
+	case *ast.ParenExpr:
+		return toTypeTerms(fs, x.X)
+	}
+	return []TypeTermExpr{{Type: toExpr(fs, x)}}
+}
+
 func toKeyValueExprs(fs *token.FileSet, elts []ast.Expr) (kvxs KeyValueExprs) {
 	kvxs = make([]KeyValueExpr, len(elts))
 	for i, x := range elts {
@@ -784,6 +888,19 @@ func toClauses(fs *token.FileSet, csz []ast.Stmt) []SwitchClauseStmt {
 	return res
 }
 
+func toSelectCases(fs *token.FileSet, ccs []ast.Stmt) []SelectCaseStmt {
+	res := make([]SelectCaseStmt, len(ccs))
+	for i, cs := range ccs {
+		cc := cs.(*ast.CommClause)
+		res[i] = SelectCaseStmt{
+			Comm: toStmt(fs, cc.Comm),
+			Body: toStmts(fs, cc.Body),
+		}
+		setLoc(fs, cc.Pos(), &res[i])
+	}
+	return res
+}
+
 func toSwitchClauseStmt(fs *token.FileSet, cc *ast.CaseClause) SwitchClauseStmt {
 	return SwitchClauseStmt{
 		Cases: toExprs(fs, cc.List),
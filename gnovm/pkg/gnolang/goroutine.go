@@ -0,0 +1,426 @@
+package gnolang
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Goroutines are only available off-chain (gno run, gno test), see
+// MachineOptions.Goroutines; the preprocessor rejects go statements and
+// channel operations in realm code, and in any package whose files were not
+// run by a Machine with goroutines, like every package on-chain.
+//
+// All goroutines run on the same Machine. Each goroutine owns its op,
+// value, expression, statement, block and frame stacks, which the
+// scheduler swaps in and out of the Machine between two ops of the
+// outermost Run. Scheduling is preemptive but deterministic: the number
+// of ops a goroutine runs before being preempted, the next goroutine to
+// run, and the case chosen by a select when several are ready are all
+// drawn from a PRNG seeded with MachineOptions.Seed, so that a program
+// run with a given seed always interleaves the same way.
+//
+// A channel operation that cannot proceed parks the current goroutine:
+// the op pushes itself back on the op stack (leaving its operands on the
+// value stack), enqueues a chanWaiter on the channel, and the scheduler
+// switches away. The goroutine that later completes the operation (by
+// sending, receiving or closing) fills in the waiter and makes the parked
+// goroutine runnable again, and the op, executed again, consumes the
+// waiter with unpark().
+
+// assertConcurrencyAllowed panics if n, a goroutine or channel construct,
+// is in realm code, or in a package whose files were not run by a Machine
+// with goroutines: goroutines are not (yet) part of the deterministic
+// on-chain execution, and channels are never persisted.
+func assertConcurrencyAllowed(pn *PackageNode, n Node) {
+	realm := IsRealmPath(pn.PkgPath)
+	if !realm && pn.goroutines {
+		return
+	}
+	var what string
+	switch n.(type) {
+	case *GoStmt:
+		what = "go statements"
+	case *SendStmt:
+		what = "channel sends"
+	case *UnaryExpr:
+		what = "channel receives"
+	case *SelectStmt:
+		what = "select statements"
+	case *ChanTypeExpr:
+		what = "channel types"
+	default:
+		panic("should not happen")
+	}
+	if realm {
+		panic(fmt.Sprintf("%s are not allowed in realm code", what))
+	}
+	panic(fmt.Sprintf("%s are not allowed without goroutines, which are only available off-chain", what))
+}
+
+// maxQuantum is the maximum number of ops a goroutine runs before it may
+// be preempted.
+const maxQuantum = 64
+
+// goroutineStackSize is the initial size of the op and value stacks of
+// new goroutines; they grow as needed.
+const goroutineStackSize = 16
+
+type goroutine struct {
+	// machine state, saved while the goroutine is not running.
+	ops             []Op
+	numOps          int
+	values          []TypedValue
+	numValues       int
+	exprs           []Expr
+	stmts           []Stmt
+	blocks          []*Block
+	frames          []*Frame
+	pkg             *PackageValue
+	realm           *Realm
+	exceptions      []Exception
+	numResults      int
+	panicScope      uint
+	deferPanicScope uint
+
+	park   *parking // set while blocked on channel operations.
+	exited bool
+}
+
+func (g *goroutine) blocked() bool {
+	return g.park != nil && g.park.fired == nil
+}
+
+type scheduler struct {
+	rand     *rand.Rand
+	main     *goroutine
+	current  *goroutine
+	runnable []*goroutine
+	quantum  int // ops left before current may be preempted.
+	depth    int // nesting depth of Machine.Run.
+}
+
+func newScheduler(seed int64) *scheduler {
+	main := &goroutine{}
+	s := &scheduler{
+		rand:    rand.New(rand.NewSource(seed)),
+		main:    main,
+		current: main,
+	}
+	s.quantum = 1 + s.rand.Intn(maxQuantum)
+	return s
+}
+
+// parking is the state of a goroutine blocked on one channel operation,
+// or on all the cases of a select.
+type parking struct {
+	g       *goroutine
+	waiters []*chanWaiter
+	fired   *chanWaiter // the waiter that woke the goroutine up.
+}
+
+type chanWaiter struct {
+	park  *parking
+	ch    *ChanValue // nil if blocked forever on a nil channel.
+	send  bool
+	index int        // select case index.
+	value TypedValue // value to send, or value received.
+	ok    bool       // false if woken up by close.
+}
+
+func (m *Machine) saveGoroutine(g *goroutine) {
+	g.ops = m.Ops
+	g.numOps = m.NumOps
+	g.values = m.Values
+	g.numValues = m.NumValues
+	g.exprs = m.Exprs
+	g.stmts = m.Stmts
+	g.blocks = m.Blocks
+	g.frames = m.Frames
+	g.pkg = m.Package
+	g.realm = m.Realm
+	g.exceptions = m.Exceptions
+	g.numResults = m.NumResults
+	g.panicScope = m.PanicScope
+	g.deferPanicScope = m.DeferPanicScope
+}
+
+func (m *Machine) loadGoroutine(g *goroutine) {
+	m.Ops = g.ops
+	m.NumOps = g.numOps
+	m.Values = g.values
+	m.NumValues = g.numValues
+	m.Exprs = g.exprs
+	m.Stmts = g.stmts
+	m.Blocks = g.blocks
+	m.Frames = g.frames
+	m.Package = g.pkg
+	m.Realm = g.realm
+	m.Exceptions = g.exceptions
+	m.NumResults = g.numResults
+	m.PanicScope = g.panicScope
+	m.DeferPanicScope = g.deferPanicScope
+}
+
+// enterRun is called when entering Machine.Run.
+func (m *Machine) enterRun() {
+	s := m.sched
+	if s.depth == 0 && s.current != s.main {
+		// the previous run panicked out of a goroutine;
+		// resume from the main goroutine.
+		m.resetToMain()
+	}
+	s.depth++
+}
+
+func (m *Machine) leaveRun() {
+	m.sched.depth--
+}
+
+// resetToMain abandons the current goroutine and loads the state of the
+// main goroutine back into the machine.
+func (m *Machine) resetToMain() {
+	s := m.sched
+	s.current.exited = true
+	m.loadGoroutine(s.main)
+	s.current = s.main
+}
+
+// schedule is called before each op of the outermost Run. It switches to
+// another runnable goroutine if the current one exited, is blocked, or
+// has used up its quantum.
+func (m *Machine) schedule() {
+	s := m.sched
+	if s.depth > 1 {
+		return
+	}
+	g := s.current
+	if !g.exited && !g.blocked() {
+		if s.quantum--; s.quantum > 0 || len(s.runnable) == 0 {
+			return
+		}
+		// preempt.
+		s.runnable = append(s.runnable, g)
+	}
+	if len(s.runnable) == 0 {
+		panic("all goroutines are asleep - deadlock!")
+	}
+	i := s.rand.Intn(len(s.runnable))
+	next := s.runnable[i]
+	s.runnable = append(s.runnable[:i], s.runnable[i+1:]...)
+	s.quantum = 1 + s.rand.Intn(maxQuantum)
+	if next != g {
+		m.saveGoroutine(g)
+		m.loadGoroutine(next)
+		s.current = next
+	}
+}
+
+// randIntn returns a pseudo-random number in [0,n) drawn from the
+// scheduler's seeded source, or 0 if goroutines are not enabled.
+func (m *Machine) randIntn(n int) int {
+	if m.sched == nil {
+		return 0
+	}
+	return m.sched.rand.Intn(n)
+}
+
+// block parks the current goroutine on the given waiters, until one of
+// them is fired. The blocking op must be on the op stack, so that it is
+// executed again when the goroutine resumes.
+func (m *Machine) block(ws ...*chanWaiter) {
+	s := m.sched
+	if s == nil {
+		panic("all goroutines are asleep - deadlock!")
+	}
+	if s.depth > 1 {
+		panic("channel operation would block in a nested call")
+	}
+	p := &parking{
+		g:       s.current,
+		waiters: ws,
+	}
+	for _, w := range ws {
+		w.park = p
+		if w.ch == nil {
+			continue
+		}
+		if w.send {
+			w.ch.sendq = append(w.ch.sendq, w)
+		} else {
+			w.ch.recvq = append(w.ch.recvq, w)
+		}
+	}
+	s.current.park = p
+}
+
+// unpark returns the waiter that woke up the current goroutine, or nil if
+// the current op is not resuming a blocked operation.
+func (m *Machine) unpark() *chanWaiter {
+	if m.sched == nil {
+		return nil
+	}
+	g := m.sched.current
+	if g.park == nil {
+		return nil
+	}
+	fired := g.park.fired
+	// remove the other waiters of a select from their queues.
+	for _, w := range g.park.waiters {
+		if w != fired && w.ch != nil {
+			w.ch.remove(w)
+		}
+	}
+	g.park = nil
+	return fired
+}
+
+// fire completes the operation of w and makes its goroutine runnable.
+func (m *Machine) fire(w *chanWaiter) {
+	w.park.fired = w
+	m.sched.runnable = append(m.sched.runnable, w.park.g)
+}
+
+// doOpGo starts a goroutine for the call of a go statement, with its
+// func and arguments already evaluated.
+func (m *Machine) doOpGo() {
+	s := m.sched
+	if s == nil {
+		panic("goroutines are not enabled")
+	}
+	gs := m.PopStmt().(*GoStmt)
+	args := m.PopCopyValues(gs.Call.NumArgs)
+	ftv := m.PopValue()
+	numValues := 1 + len(args)
+	values := make([]TypedValue, goroutineStackSize+numValues)
+	values[0] = *ftv
+	copy(values[1:], args)
+	ops := make([]Op, goroutineStackSize)
+	ops[0] = OpGoexit
+	ops[1] = OpPrecall
+	g := &goroutine{
+		ops:       ops,
+		numOps:    2,
+		values:    values,
+		numValues: numValues,
+		exprs:     []Expr{&gs.Call},
+		blocks:    []*Block{m.LastBlock()},
+		pkg:       m.Package,
+		realm:     m.Realm,
+	}
+	s.runnable = append(s.runnable, g)
+}
+
+func (m *Machine) doOpGoexit() {
+	m.sched.current.exited = true
+}
+
+// ----------------------------------------
+// channel operations
+
+func (cv *ChanValue) remove(w *chanWaiter) {
+	q := &cv.recvq
+	if w.send {
+		q = &cv.sendq
+	}
+	for i, qw := range *q {
+		if qw == w {
+			*q = append((*q)[:i], (*q)[i+1:]...)
+			return
+		}
+	}
+}
+
+// dequeue pops the first waiter of q that has not been fired yet;
+// waiters of a select that already proceeded are dropped.
+func dequeue(q *[]*chanWaiter) *chanWaiter {
+	for len(*q) > 0 {
+		w := (*q)[0]
+		(*q)[0] = nil
+		*q = (*q)[1:]
+		if w.park.fired == nil {
+			return w
+		}
+	}
+	return nil
+}
+
+func hasWaiter(q []*chanWaiter) bool {
+	for _, w := range q {
+		if w.park.fired == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (cv *ChanValue) canSend() bool {
+	return cv.Closed || len(cv.Buffer) < cv.Cap || hasWaiter(cv.recvq)
+}
+
+func (cv *ChanValue) canRecv() bool {
+	return cv.Closed || len(cv.Buffer) > 0 || hasWaiter(cv.sendq)
+}
+
+// trySend sends v if it can be done without blocking. The caller must
+// check that the channel is not closed.
+func (cv *ChanValue) trySend(m *Machine, v TypedValue) bool {
+	if w := dequeue(&cv.recvq); w != nil {
+		w.value, w.ok = v, true
+		m.fire(w)
+		return true
+	}
+	if len(cv.Buffer) < cv.Cap {
+		cv.Buffer = append(cv.Buffer, v)
+		return true
+	}
+	return false
+}
+
+// tryRecv receives a value if it can be done without blocking. ok is
+// false if the channel is closed and drained, in which case the caller
+// must use the zero value of the element type.
+func (cv *ChanValue) tryRecv(m *Machine) (v TypedValue, ok, done bool) {
+	if len(cv.Buffer) > 0 {
+		v = cv.Buffer[0]
+		cv.Buffer[0] = TypedValue{}
+		cv.Buffer = cv.Buffer[1:]
+		// make room for a blocked sender.
+		if w := dequeue(&cv.sendq); w != nil {
+			cv.Buffer = append(cv.Buffer, w.value)
+			w.ok = true
+			m.fire(w)
+		}
+		return v, true, true
+	}
+	if w := dequeue(&cv.sendq); w != nil {
+		w.ok = true
+		m.fire(w)
+		return w.value, true, true
+	}
+	if cv.Closed {
+		return TypedValue{}, false, true
+	}
+	return TypedValue{}, false, false
+}
+
+// close closes the channel, waking up all blocked receivers (with the
+// zero value) and senders (which then panic).
+func (cv *ChanValue) close(m *Machine) {
+	cv.Closed = true
+	for w := dequeue(&cv.recvq); w != nil; w = dequeue(&cv.recvq) {
+		w.ok = false
+		m.fire(w)
+	}
+	for w := dequeue(&cv.sendq); w != nil; w = dequeue(&cv.sendq) {
+		w.ok = false
+		m.fire(w)
+	}
+}
+
+// recvResult returns the value received from a channel of type ct.
+func (m *Machine) recvResult(ct Type, v TypedValue, ok bool) TypedValue {
+	if ok {
+		return v
+	}
+	return defaultTypedValue(m.Alloc, baseOf(ct).(*ChanType).Elt)
+}
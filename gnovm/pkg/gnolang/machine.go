@@ -14,6 +14,8 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/overflow"
 )
 
 // Exception represents a panic that originates from a gno program.
@@ -53,6 +55,7 @@ type Machine struct {
 	CheckTypes bool // not yet used
 	ReadOnly   bool
 	MaxCycles  int64
+	GasMeter   store.GasMeter
 
 	Output  io.Writer
 	Store   Store
@@ -65,6 +68,19 @@ type Machine struct {
 	// it is executed. It is reset to zero after the defer functions in the current
 	// scope have finished executing.
 	DeferPanicScope uint
+
+	// Coverage, if set, records the statements executed by the machine.
+	Coverage *Coverage
+
+	// Debugger, if set, pauses the machine at breakpoints and steps.
+	Debugger *Debugger
+
+	// sched is the goroutine scheduler; nil unless goroutines are enabled.
+	sched *scheduler
+
+	// isolatedFrames is the number of frames below the expression evaluated
+	// by EvalIsolated, if any. Panics don't unwind them.
+	isolatedFrames int
 }
 
 // NewMachine initializes a new gno virtual machine, acting as a shorthand
@@ -96,6 +112,12 @@ type MachineOptions struct {
 	Alloc         *Allocator // or see MaxAllocBytes.
 	MaxAllocBytes int64      // or 0 for no limit.
 	MaxCycles     int64      // or 0 for no limit.
+	GasMeter      store.GasMeter
+	// Goroutines enables go statements and blocking channel operations.
+	// Goroutines are interleaved deterministically, according to Seed.
+	// Off-chain use only.
+	Goroutines bool
+	Seed       int64
 }
 
 // the machine constructor gets spammed
@@ -128,6 +150,9 @@ func NewMachineWithOptions(opts MachineOptions) *Machine {
 	if alloc == nil {
 		alloc = NewAllocator(opts.MaxAllocBytes)
 	}
+	if opts.GasMeter != nil {
+		alloc.SetGasMeter(opts.GasMeter)
+	}
 	store := opts.Store
 	if store == nil {
 		// bare store, no stdlibs.
@@ -151,9 +176,13 @@ func NewMachineWithOptions(opts MachineOptions) *Machine {
 	mm.CheckTypes = checkTypes
 	mm.ReadOnly = readOnly
 	mm.MaxCycles = maxCycles
+	mm.GasMeter = opts.GasMeter
 	mm.Output = output
 	mm.Store = store
 	mm.Context = context
+	if opts.Goroutines {
+		mm.sched = newScheduler(opts.Seed)
+	}
 
 	if pv != nil {
 		mm.SetActivePackage(pv)
@@ -175,6 +204,10 @@ var (
 // and m should not be used after this call. Only Machines initialized with this
 // package's constructors should be released.
 func (m *Machine) Release() {
+	if m.sched != nil && m.sched.current != m.sched.main {
+		// get back the pooled stacks of the main goroutine.
+		m.resetToMain()
+	}
 	// here we zero in the values for the next user
 	m.NumOps = 0
 	m.NumValues = 0
@@ -497,6 +530,7 @@ func (m *Machine) runFiles(fns ...*FileNode) {
 	pv := m.Package
 	pb := pv.GetBlock(m.Store)
 	pn := pb.GetSource(m.Store).(*PackageNode)
+	pn.goroutines = m.sched != nil
 	fs := &FileSet{Files: fns}
 	fdeclared := map[Name]struct{}{}
 	if pn.FileSet == nil {
@@ -564,6 +598,10 @@ func (m *Machine) runFiles(fns ...*FileNode) {
 			if _, ok := fdeclared[dep]; ok {
 				continue
 			}
+			// if dep is generic, skip.
+			if _, ok := pn.generics.decls[dep]; ok {
+				continue
+			}
 			fn, depdecl, exists := pn.FileSet.GetDeclForSafe(dep)
 			// special case: if doesn't exist:
 			if !exists {
@@ -724,6 +762,38 @@ func (m *Machine) Eval(x Expr) []TypedValue {
 	return res
 }
 
+// isolatedPanic is panicked when a panic of an expression evaluated by
+// EvalIsolated reaches the frames below it.
+type isolatedPanic struct{}
+
+// EvalIsolated is like Eval, for native functions which call Gno code, such
+// as the methods of their arguments. A panic of x which isn't recovered
+// within x doesn't unwind the frames of the caller: the stacks of the machine
+// are restored as they were before the evaluation, and the exceptions are
+// returned. The native function may then re-raise the last one with Panic.
+func (m *Machine) EvalIsolated(x Expr) (res []TypedValue, exceptions []Exception) {
+	numOps, numValues := m.NumOps, m.NumValues
+	numExprs, numStmts, numBlocks := len(m.Exprs), len(m.Stmts), len(m.Blocks)
+	isolatedFrames := m.isolatedFrames
+	prevExceptions, panicScope, deferPanicScope := m.Exceptions, m.PanicScope, m.DeferPanicScope
+
+	m.isolatedFrames = len(m.Frames)
+	m.Exceptions = nil
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(isolatedPanic); !ok {
+				panic(r)
+			}
+			res, exceptions = nil, m.Exceptions
+			m.NumOps, m.NumValues = numOps, numValues
+			m.Exprs, m.Stmts, m.Blocks = m.Exprs[:numExprs], m.Stmts[:numStmts], m.Blocks[:numBlocks]
+		}
+		m.isolatedFrames = isolatedFrames
+		m.Exceptions, m.PanicScope, m.DeferPanicScope = prevExceptions, panicScope, deferPanicScope
+	}()
+	return m.Eval(x), nil
+}
+
 // Evaluate any preprocessed expression statically.
 // This is primiarily used by the preprocessor to evaluate
 // static types and values.
@@ -869,6 +939,7 @@ const (
 	OpPopFrameAndReset    Op = 0x15 // pop frame and reset.
 	OpPanic1              Op = 0x16 // pop exception and pop call frames.
 	OpPanic2              Op = 0x17 // pop call frames.
+	OpGoexit              Op = 0x18 // end of goroutine
 
 	/* Unary & binary operators */
 	OpUpos  Op = 0x20 // + (unary)
@@ -951,6 +1022,7 @@ const (
 	OpDefine      Op = 0x8C // X... := Y...
 	OpInc         Op = 0x8D // X++
 	OpDec         Op = 0x8E // X--
+	OpSend        Op = 0x8F // X <- Y
 
 	/* Decl operators */
 	OpValueDecl Op = 0x90 // var/const ...
@@ -965,12 +1037,25 @@ const (
 	OpRangeIterMap      Op = 0xD5
 	OpRangeIterArrayPtr Op = 0xD6
 	OpReturnCallDefers  Op = 0xD7 // TODO rename?
+	OpRangeIterChan     Op = 0xD8
 )
 
 //----------------------------------------
 // "CPU" steps.
 
+// GasFactorCPU is the amount of gas consumed per "CPU" cycle,
+// when the machine has a gas meter.
+const GasFactorCPU int64 = 1
+
+// GasCPUCyclesDesc is the descriptor of the gas consumed by "CPU" cycles.
+const GasCPUCyclesDesc = "CPUCycles"
+
 func (m *Machine) incrCPU(cycles int64) {
+	if m.GasMeter != nil {
+		gasCPU := overflow.Mul64p(cycles, GasFactorCPU)
+		m.GasMeter.ConsumeGas(gasCPU, GasCPUCyclesDesc)
+	}
+
 	m.Cycles += cycles
 	if m.MaxCycles != 0 && m.Cycles > m.MaxCycles {
 		panic("CPU cycle overrun")
@@ -1003,6 +1088,7 @@ const (
 	OpCPUPopFrameAndReset    = 1
 	OpCPUPanic1              = 1
 	OpCPUPanic2              = 1
+	OpCPUGoexit              = 1
 
 	/* Unary & binary operators */
 	OpCPUUpos  = 1
@@ -1085,6 +1171,7 @@ const (
 	OpCPUDefine      = 1
 	OpCPUInc         = 1
 	OpCPUDec         = 1
+	OpCPUSend        = 1
 
 	/* Decl operators */
 	OpCPUValueDecl = 1
@@ -1099,13 +1186,21 @@ const (
 	OpCPURangeIterMap      = 1
 	OpCPURangeIterArrayPtr = 1
 	OpCPUReturnCallDefers  = 1
+	OpCPURangeIterChan     = 1
 )
 
 //----------------------------------------
 // main run loop.
 
 func (m *Machine) Run() {
+	if m.sched != nil {
+		m.enterRun()
+		defer m.leaveRun()
+	}
 	for {
+		if m.sched != nil {
+			m.schedule()
+		}
 		op := m.PopOp()
 		// TODO: this can be optimized manually, even into tiers.
 		switch op {
@@ -1151,10 +1246,13 @@ func (m *Machine) Run() {
 			m.doOpCallDeferNativeBody()
 		case OpGo:
 			m.incrCPU(OpCPUGo)
-			panic("not yet implemented")
+			m.doOpGo()
+		case OpGoexit:
+			m.incrCPU(OpCPUGoexit)
+			m.doOpGoexit()
 		case OpSelect:
 			m.incrCPU(OpCPUSelect)
-			panic("not yet implemented")
+			m.doOpSelect()
 		case OpSwitchClause:
 			m.incrCPU(OpCPUSwitchClause)
 			m.doOpSwitchClause()
@@ -1398,6 +1496,9 @@ func (m *Machine) Run() {
 		case OpDec:
 			m.incrCPU(OpCPUDec)
 			m.doOpDec()
+		case OpSend:
+			m.incrCPU(OpCPUSend)
+			m.doOpSend()
 		/* Decl operators */
 		case OpValueDecl:
 			m.incrCPU(OpCPUValueDecl)
@@ -1424,6 +1525,9 @@ func (m *Machine) Run() {
 		case OpRangeIterMap:
 			m.incrCPU(OpCPURangeIterMap)
 			m.doOpExec(op)
+		case OpRangeIterChan:
+			m.incrCPU(OpCPURangeIterChan)
+			m.doOpExec(op)
 		case OpReturnCallDefers:
 			m.incrCPU(OpCPUReturnCallDefers)
 			m.doOpReturnCallDefers()
@@ -229,6 +229,7 @@ func (x *BasicLitExpr) assertNode()        {}
 func (x *BinaryExpr) assertNode()          {}
 func (x *CallExpr) assertNode()            {}
 func (x *IndexExpr) assertNode()           {}
+func (x *IndexListExpr) assertNode()       {}
 func (x *SelectorExpr) assertNode()        {}
 func (x *SliceExpr) assertNode()           {}
 func (x *StarExpr) assertNode()            {}
@@ -283,6 +284,7 @@ var (
 	_ Node = &BinaryExpr{}
 	_ Node = &CallExpr{}
 	_ Node = &IndexExpr{}
+	_ Node = &IndexListExpr{}
 	_ Node = &SelectorExpr{}
 	_ Node = &SliceExpr{}
 	_ Node = &StarExpr{}
@@ -351,6 +353,7 @@ func (*BasicLitExpr) assertExpr()     {}
 func (*BinaryExpr) assertExpr()       {}
 func (*CallExpr) assertExpr()         {}
 func (*IndexExpr) assertExpr()        {}
+func (*IndexListExpr) assertExpr()    {}
 func (*SelectorExpr) assertExpr()     {}
 func (*SliceExpr) assertExpr()        {}
 func (*StarExpr) assertExpr()         {}
@@ -368,6 +371,7 @@ var (
 	_ Expr = &BinaryExpr{}
 	_ Expr = &CallExpr{}
 	_ Expr = &IndexExpr{}
+	_ Expr = &IndexListExpr{}
 	_ Expr = &SelectorExpr{}
 	_ Expr = &SliceExpr{}
 	_ Expr = &StarExpr{}
@@ -420,6 +424,12 @@ type IndexExpr struct { // X[Index]
 	HasOK bool // if true, is form: `value, ok := <X>[<Key>]
 }
 
+type IndexListExpr struct { // X[Indices...]
+	Attributes
+	X       Expr  // generic function or type
+	Indices Exprs // type arguments
+}
+
 type SelectorExpr struct { // X.Sel
 	Attributes
 	X    Expr      // expression
@@ -461,8 +471,9 @@ type TypeAssertExpr struct { // X.(Type)
 // (referencing) are represented with RefExpr nodes.
 type UnaryExpr struct { // (Op X)
 	Attributes
-	X  Expr // operand
-	Op Word // operator
+	X     Expr // operand
+	Op    Word // operator
+	HasOK bool // if true, is form: `value, ok := <-<X>`.
 }
 
 // MyType{<key>:<value>} struct, array, slice, and map
@@ -619,8 +630,21 @@ type SliceTypeExpr struct {
 
 type InterfaceTypeExpr struct {
 	Attributes
-	Methods FieldTypeExprs // list of methods
-	Generic Name           // for uverse generics
+	Methods FieldTypeExprs  // list of methods
+	Generic Name            // for uverse generics
+	Unions  []TypeUnionExpr // type set of constraint interfaces
+}
+
+// TypeUnionExpr is a type set element of a constraint interface,
+// e.g. ~int | ~string.
+type TypeUnionExpr struct {
+	Terms []TypeTermExpr
+}
+
+// TypeTermExpr is a term of a TypeUnionExpr, e.g. ~int.
+type TypeTermExpr struct {
+	Tilde bool // if true, all types with the underlying type Type.
+	Type  Expr
 }
 
 type ChanDir int
@@ -834,6 +858,7 @@ type RangeStmt struct {
 	IsMap      bool // if X is map type
 	IsString   bool // if X is string type
 	IsArrayPtr bool // if X is array-pointer type
+	IsChan     bool // if X is chan type
 }
 
 type ReturnStmt struct {
@@ -996,6 +1021,8 @@ type FuncDecl struct {
 	Recv     FieldTypeExpr // receiver (if method); or empty (if function)
 	Type     FuncTypeExpr  // function signature: parameters and results
 	Body                   // function body; or empty for external (non-Go) function
+
+	TypeParams FieldTypeExprs // type parameters, if generic
 }
 
 func (x *FuncDecl) GetDeclNames() []Name {
@@ -1045,6 +1072,8 @@ type TypeDecl struct {
 	NameExpr
 	Type    Expr // Name, SelectorExpr, StarExpr, or XxxTypes
 	IsAlias bool // type alias since Go 1.9
+
+	TypeParams FieldTypeExprs // type parameters, if generic
 }
 
 func (x *TypeDecl) GetDeclNames() []Name {
@@ -1317,6 +1346,9 @@ type PackageNode struct {
 	PkgPath string
 	PkgName Name
 	*FileSet
+
+	generics   genericDecls // not persisted
+	goroutines bool         // not persisted; see assertConcurrencyAllowed
 }
 
 func PackageNodeLocation(path string) Location {
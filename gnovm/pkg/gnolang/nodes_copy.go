@@ -53,6 +53,13 @@ func (x *IndexExpr) Copy() Node {
 	}
 }
 
+func (x *IndexListExpr) Copy() Node {
+	return &IndexListExpr{
+		X:       x.X.Copy().(Expr),
+		Indices: copyExprs(x.Indices),
+	}
+}
+
 func (x *SelectorExpr) Copy() Node {
 	return &SelectorExpr{
 		X:   x.X.Copy().(Expr),
@@ -141,6 +148,7 @@ func (x *SliceTypeExpr) Copy() Node {
 func (x *InterfaceTypeExpr) Copy() Node {
 	return &InterfaceTypeExpr{
 		Methods: copyFTs(x.Methods),
+		Unions:  copyUnions(x.Unions),
 	}
 }
 
@@ -287,7 +295,7 @@ func (x *SelectStmt) Copy() Node {
 
 func (x *SelectCaseStmt) Copy() Node {
 	return &SelectCaseStmt{
-		Comm: x.Comm.Copy().(Stmt),
+		Comm: copyStmt(x.Comm),
 		Body: copyStmts(x.Body),
 	}
 }
@@ -317,10 +325,11 @@ func (x *SwitchClauseStmt) Copy() Node {
 
 func (x *FuncDecl) Copy() Node {
 	funcDecl := &FuncDecl{
-		NameExpr: *(x.NameExpr.Copy().(*NameExpr)),
-		IsMethod: x.IsMethod,
-		Type:     *(x.Type.Copy().(*FuncTypeExpr)),
-		Body:     copyStmts(x.Body),
+		NameExpr:   *(x.NameExpr.Copy().(*NameExpr)),
+		IsMethod:   x.IsMethod,
+		Type:       *(x.Type.Copy().(*FuncTypeExpr)),
+		Body:       copyStmts(x.Body),
+		TypeParams: copyFTs(x.TypeParams),
 	}
 	if x.IsMethod {
 		funcDecl.Recv = *(x.Recv.Copy().(*FieldTypeExpr))
@@ -346,9 +355,10 @@ func (x *ValueDecl) Copy() Node {
 
 func (x *TypeDecl) Copy() Node {
 	return &TypeDecl{
-		NameExpr: *(x.NameExpr.Copy().(*NameExpr)),
-		Type:     x.Type.Copy().(Expr),
-		IsAlias:  x.IsAlias,
+		NameExpr:   *(x.NameExpr.Copy().(*NameExpr)),
+		Type:       x.Type.Copy().(Expr),
+		IsAlias:    x.IsAlias,
+		TypeParams: copyFTs(x.TypeParams),
 	}
 }
 
@@ -386,6 +396,10 @@ func copyExpr(x Expr) Expr {
 }
 
 func copyExprs(xs []Expr) []Expr {
+	if xs == nil {
+		// e.g. naked returns.
+		return nil
+	}
 	res := make([]Expr, len(xs))
 	for i, x := range xs {
 		res[i] = x.Copy().(Expr)
@@ -432,6 +446,24 @@ func copyFTs(fts []FieldTypeExpr) []FieldTypeExpr {
 	return res
 }
 
+func copyUnions(uxs []TypeUnionExpr) []TypeUnionExpr {
+	if uxs == nil {
+		return nil
+	}
+	res := make([]TypeUnionExpr, len(uxs))
+	for i, ux := range uxs {
+		terms := make([]TypeTermExpr, len(ux.Terms))
+		for j, term := range ux.Terms {
+			terms[j] = TypeTermExpr{
+				Tilde: term.Tilde,
+				Type:  term.Type.Copy().(Expr),
+			}
+		}
+		res[i] = TypeUnionExpr{Terms: terms}
+	}
+	return res
+}
+
 func copyDecls(ds []Decl) []Decl {
 	res := make([]Decl, len(ds))
 	for i, d := range ds {
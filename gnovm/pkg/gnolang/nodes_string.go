@@ -2,6 +2,7 @@ package gnolang
 
 import (
 	"fmt"
+	"strings"
 )
 
 // ----------------------------------------
@@ -122,6 +123,10 @@ func (x IndexExpr) String() string {
 	return fmt.Sprintf("%s[%s]", x.X, x.Index)
 }
 
+func (x IndexListExpr) String() string {
+	return fmt.Sprintf("%s[%s]", x.X, x.Indices.String())
+}
+
 func (x SelectorExpr) String() string {
 	// NOTE: for debugging selector issues:
 	// return fmt.Sprintf("%s.(%v).%s", n.X, n.Path.Type, n.Sel)
@@ -204,15 +209,40 @@ func (x SliceTypeExpr) String() string {
 }
 
 func (x InterfaceTypeExpr) String() string {
-	return fmt.Sprintf("interface { %v }", x.Methods)
+	if len(x.Unions) == 0 {
+		return fmt.Sprintf("interface { %v }", x.Methods)
+	}
+	elems := make([]string, 0, len(x.Unions)+1)
+	if len(x.Methods) > 0 {
+		elems = append(elems, x.Methods.String())
+	}
+	for _, ux := range x.Unions {
+		elems = append(elems, ux.String())
+	}
+	return fmt.Sprintf("interface { %s }", strings.Join(elems, "; "))
+}
+
+func (x TypeUnionExpr) String() string {
+	terms := make([]string, len(x.Terms))
+	for i, term := range x.Terms {
+		terms[i] = term.String()
+	}
+	return strings.Join(terms, " | ")
+}
+
+func (x TypeTermExpr) String() string {
+	if x.Tilde {
+		return "~" + x.Type.String()
+	}
+	return x.Type.String()
 }
 
 func (x ChanTypeExpr) String() string {
 	switch x.Dir {
 	case SEND:
-		return fmt.Sprintf("<-chan %s", x.Value)
-	case RECV:
 		return fmt.Sprintf("chan<- %s", x.Value)
+	case RECV:
+		return fmt.Sprintf("<-chan %s", x.Value)
 	case SEND | RECV:
 		return fmt.Sprintf("chan %s", x.Value)
 	default:
@@ -368,6 +398,9 @@ func (x SelectStmt) String() string {
 }
 
 func (x SelectCaseStmt) String() string {
+	if x.Comm == nil {
+		return fmt.Sprintf("default: %s", x.Body.String())
+	}
 	return fmt.Sprintf("case %v: %s", x.Comm.String(), x.Body.String())
 }
 
@@ -408,8 +441,12 @@ func (x FuncDecl) String() string {
 	if x.IsMethod {
 		recv = "(" + x.Recv.String() + ") "
 	}
-	return fmt.Sprintf("func %s%s%s { %s }",
-		recv, x.Name, x.Type.String()[4:], x.Body.String())
+	tparams := ""
+	if len(x.TypeParams) > 0 {
+		tparams = "[" + x.TypeParams.String() + "]"
+	}
+	return fmt.Sprintf("func %s%s%s%s { %s }",
+		recv, x.Name, tparams, x.Type.String()[4:], x.Body.String())
 }
 
 func (x ImportDecl) String() string {
@@ -437,10 +474,14 @@ func (x ValueDecl) String() string {
 }
 
 func (x TypeDecl) String() string {
+	tparams := ""
+	if len(x.TypeParams) > 0 {
+		tparams = "[" + x.TypeParams.String() + "]"
+	}
 	if x.IsAlias {
-		return fmt.Sprintf("type %s = %s", x.Name, x.Type.String())
+		return fmt.Sprintf("type %s%s = %s", x.Name, tparams, x.Type.String())
 	}
-	return fmt.Sprintf("type %s %s", x.Name, x.Type.String())
+	return fmt.Sprintf("type %s%s %s", x.Name, tparams, x.Type.String())
 }
 
 func (x FileNode) String() string {
@@ -474,6 +474,8 @@ func isEql(store Store, lv, rv *TypedValue) bool {
 			return lfv.GetClosure(store) ==
 				rfv.GetClosure(store)
 		}
+	case ChanKind:
+		return lv.V == rv.V
 	case PointerKind:
 		if lv.V != nil && rv.V != nil {
 			lpv := lv.V.(PointerValue)
@@ -420,6 +420,10 @@ func (m *Machine) doOpPanic2() {
 		m.PanicScope = 0
 	} else {
 		// Keep panicking
+		if m.isolatedFrames > 0 && len(m.Frames) <= m.isolatedFrames {
+			// The panic reached the caller of EvalIsolated.
+			panic(isolatedPanic{})
+		}
 		last := m.PopUntilLastCallFrame()
 		if last == nil {
 			// Build exception string just as go, separated by \n\t.
@@ -29,6 +29,7 @@ RangeStmt ->
   OpRangeIterList +block
   OpRangeIterMap +block
   OpRangeIterString +block
+  OpRangeIterChan +block
 
 IfStmt ->
   OpIfCond -> +block
@@ -39,7 +40,7 @@ SwitchStmt -> +block
   OpTypeSwitch
 
 SelectStmt ->
-  OpSelectCase +block
+  OpSelect +block
 
 */
 
@@ -425,12 +426,97 @@ func (m *Machine) doOpExec(op Op) {
 				panic("should not happen")
 			}
 		}
+	case OpRangeIterChan:
+		bs := s.(*bodyStmt)
+		xv := m.PeekValue(1)
+		switch bs.NextBodyIndex {
+		case -2: // init.
+			bs.NumOps = m.NumOps
+			bs.NumValues = m.NumValues
+			bs.NumExprs = len(m.Exprs)
+			bs.NumStmts = len(m.Stmts)
+			bs.NextBodyIndex++
+			fallthrough
+		case -1: // receive and assign element.
+			var ev TypedValue
+			var ok bool
+			if w := m.unpark(); w != nil {
+				ev, ok = w.value, w.ok
+			} else {
+				var done bool
+				cv, _ := xv.V.(*ChanValue)
+				if cv != nil {
+					ev, ok, done = cv.tryRecv(m)
+				}
+				if !done {
+					// NOTE: sticky, will receive again once woken up.
+					m.block(&chanWaiter{ch: cv})
+					return
+				}
+			}
+			if !ok {
+				// closed, done with range.
+				m.PopFrameAndReset()
+				return
+			}
+			if bs.Key != nil {
+				switch bs.Op {
+				case ASSIGN:
+					m.PopAsPointer(bs.Key).Assign2(m.Alloc, m.Store, m.Realm, ev, false)
+				case DEFINE:
+					knxp := bs.Key.(*NameExpr).Path
+					ptr := m.LastBlock().GetPointerTo(m.Store, knxp)
+					ptr.TV.Assign(m.Alloc, ev, false)
+				default:
+					panic("should not happen")
+				}
+			}
+			bs.NextBodyIndex++
+			fallthrough
+		default:
+			// NOTE: duplicated for OpRangeIter,
+			// but the end is only known on receive.
+			if bs.NextBodyIndex < bs.BodyLen {
+				next := bs.Body[bs.NextBodyIndex]
+				bs.NextBodyIndex++
+				// continue onto exec stmt.
+				bs.Active = next
+				s = next // switch on bs.Active
+				goto EXEC_SWITCH
+			} else if bs.NextBodyIndex == bs.BodyLen {
+				// set up next assign if needed.
+				switch bs.Op {
+				case ASSIGN:
+					if bs.Key != nil {
+						m.PushForPointer(bs.Key)
+					}
+				case DEFINE:
+					// do nothing
+				case ILLEGAL:
+					// do nothing, no assignment
+				default:
+					panic("should not happen")
+				}
+				bs.ListIndex++
+				bs.NextBodyIndex = -1
+				bs.Active = nil
+				return // redo doOpExec:*bodyStmt
+			} else {
+				panic("should not happen")
+			}
+		}
 	}
 
 EXEC_SWITCH:
 	if debug {
 		debug.Printf("EXEC: %v\n", s)
 	}
+	if m.Coverage != nil {
+		m.Coverage.hit(s)
+	}
+	if m.Debugger != nil {
+		m.Debugger.hit(m, s)
+	}
 	switch cs := s.(type) {
 	case *AssignStmt:
 		switch cs.Op {
@@ -596,6 +682,8 @@ EXEC_SWITCH:
 			m.PushOp(OpRangeIterString)
 		} else if cs.IsArrayPtr {
 			m.PushOp(OpRangeIterArrayPtr)
+		} else if cs.IsChan {
+			m.PushOp(OpRangeIterChan)
 		} else {
 			m.PushOp(OpRangeIter)
 		}
@@ -628,7 +716,7 @@ EXEC_SWITCH:
 			for {
 				fr := m.LastFrame()
 				switch fr.Source.(type) {
-				case *ForStmt, *RangeStmt, *SwitchStmt:
+				case *ForStmt, *RangeStmt, *SwitchStmt, *SelectStmt:
 					if cs.Label != "" && cs.Label != fr.Label {
 						m.PopFrame()
 					} else {
@@ -742,6 +830,53 @@ EXEC_SWITCH:
 		// evaluate func
 		m.PushExpr(cs.Call.Func)
 		m.PushOp(OpEval)
+	case *GoStmt:
+		m.PushOp(OpGo)
+		// evaluate args
+		args := cs.Call.Args
+		for i := len(args) - 1; 0 <= i; i-- {
+			m.PushExpr(args[i])
+			m.PushOp(OpEval)
+		}
+		// evaluate func
+		m.PushExpr(cs.Call.Func)
+		m.PushOp(OpEval)
+	case *SendStmt:
+		m.PushOp(OpSend)
+		// evaluate value
+		m.PushExpr(cs.Value)
+		m.PushOp(OpEval)
+		// evaluate chan
+		m.PushExpr(cs.Chan)
+		m.PushOp(OpEval)
+	case *SelectStmt:
+		m.PushFrameBasic(cs)
+		m.PushOp(OpPopFrameAndReset)
+		m.PushOp(OpSelect)
+		if len(cs.Cases) == 0 {
+			return
+		}
+		// evaluate the channels, and the values to send,
+		// of all cases in source order. names in the
+		// operands are resolved from the case blocks, so
+		// evaluate them in a block like the case blocks.
+		b := m.Alloc.NewBlock(&cs.Cases[0], m.LastBlock())
+		m.PushBlock(b)
+		m.PushOp(OpPopBlock)
+		for i := len(cs.Cases) - 1; 0 <= i; i-- {
+			switch comm := cs.Cases[i].Comm.(type) {
+			case nil:
+				// default case.
+			case *SendStmt:
+				m.PushExpr(comm.Value)
+				m.PushOp(OpEval)
+				m.PushExpr(comm.Chan)
+				m.PushOp(OpEval)
+			default:
+				m.PushExpr(selectRecvExpr(comm).X)
+				m.PushOp(OpEval)
+			}
+		}
 	case *SwitchStmt:
 		m.PushFrameBasic(cs)
 		m.PushOp(OpPopFrameAndReset)
@@ -1001,3 +1136,168 @@ func (m *Machine) doOpSwitchClauseCase() {
 		}
 	}
 }
+
+func (m *Machine) doOpSend() {
+	if w := m.unpark(); w != nil {
+		// resumed after a receiver took the value, or close.
+		if !w.ok {
+			m.Panic(typedString("send on closed channel"))
+			return
+		}
+	} else {
+		xv := m.PeekValue(2)
+		cv, _ := xv.V.(*ChanValue)
+		if cv != nil && cv.Closed {
+			m.Panic(typedString("send on closed channel"))
+			return
+		}
+		v := m.PeekValue(1).Copy(m.Alloc)
+		if cv == nil || !cv.trySend(m, v) {
+			m.PushOp(OpSend)
+			m.block(&chanWaiter{ch: cv, send: true, value: v})
+			return
+		}
+	}
+	m.PopStmt()
+	m.PopValue() // value
+	m.PopValue() // chan
+}
+
+// selectRecvExpr returns the receive expression of the communication of
+// a select case, of the form `<-ch`, `v = <-ch` or `v, ok := <-ch`.
+func selectRecvExpr(comm Stmt) *UnaryExpr {
+	switch comm := comm.(type) {
+	case *ExprStmt:
+		return comm.X.(*UnaryExpr)
+	case *AssignStmt:
+		return comm.Rhs[0].(*UnaryExpr)
+	default:
+		panic("should not happen")
+	}
+}
+
+func (m *Machine) doOpSelect() {
+	ss := m.PeekStmt1().(*SelectStmt)
+	// the operands are, in case order, the channel of each
+	// case followed by the value to send for send cases.
+	numOperands := 0
+	for _, cs := range ss.Cases {
+		switch cs.Comm.(type) {
+		case nil:
+		case *SendStmt:
+			numOperands += 2
+		default:
+			numOperands++
+		}
+	}
+	chans := make([]*TypedValue, len(ss.Cases)) // nil for default.
+	sends := make([]*TypedValue, len(ss.Cases)) // nil for receives.
+	dflt := -1
+	j := m.NumValues - numOperands
+	for i, cs := range ss.Cases {
+		switch cs.Comm.(type) {
+		case nil:
+			dflt = i
+		case *SendStmt:
+			chans[i], sends[i] = &m.Values[j], &m.Values[j+1]
+			j += 2
+		default:
+			chans[i] = &m.Values[j]
+			j++
+		}
+	}
+	// choose the case to run.
+	chosen := -1
+	var rv TypedValue
+	var ok bool
+	if w := m.unpark(); w != nil {
+		// resumed after another goroutine completed a case, or close.
+		chosen, rv, ok = w.index, w.value, w.ok
+		if w.send && !ok {
+			m.Panic(typedString("send on closed channel"))
+			return
+		}
+	} else {
+		var ready []int
+		for i, ctv := range chans {
+			if ctv == nil {
+				continue
+			}
+			if cv, _ := ctv.V.(*ChanValue); cv != nil {
+				if sends[i] != nil && cv.canSend() ||
+					sends[i] == nil && cv.canRecv() {
+					ready = append(ready, i)
+				}
+			}
+		}
+		if len(ready) > 0 {
+			chosen = ready[m.randIntn(len(ready))]
+			cv := chans[chosen].V.(*ChanValue)
+			if sends[chosen] != nil {
+				if cv.Closed {
+					m.Panic(typedString("send on closed channel"))
+					return
+				}
+				cv.trySend(m, sends[chosen].Copy(m.Alloc))
+			} else {
+				rv, ok, _ = cv.tryRecv(m)
+			}
+		} else if dflt >= 0 {
+			chosen = dflt
+		} else {
+			// block on all cases; nil channels are never ready.
+			ws := make([]*chanWaiter, 0, len(ss.Cases))
+			for i, ctv := range chans {
+				if cv, _ := ctv.V.(*ChanValue); cv != nil {
+					w := &chanWaiter{ch: cv, index: i}
+					if sends[i] != nil {
+						w.send = true
+						w.value = sends[i].Copy(m.Alloc)
+					}
+					ws = append(ws, w)
+				}
+			}
+			m.PushOp(OpSelect)
+			m.block(ws...)
+			return
+		}
+	}
+	cs := &ss.Cases[chosen]
+	var ct Type
+	if chans[chosen] != nil {
+		ct = chans[chosen].T
+	}
+	m.PopStmt()
+	m.PopValues(numOperands)
+	// exec case body in a new block.
+	b := m.Alloc.NewBlock(cs, m.LastBlock())
+	m.PushBlock(b)
+	m.PushOp(OpPopBlock)
+	b.bodyStmt = bodyStmt{
+		Body:          cs.Body,
+		BodyLen:       len(cs.Body),
+		NextBodyIndex: -2,
+	}
+	m.PushOp(OpBody)
+	m.PushStmt(b.GetBodyStmt())
+	// assign the received value first, if any.
+	if as, isAssign := cs.Comm.(*AssignStmt); isAssign {
+		rx := as.Rhs[0]
+		rhs := []Expr{&ConstExpr{
+			Source:     rx,
+			TypedValue: m.recvResult(ct, rv, ok),
+		}}
+		if len(as.Lhs) == 2 {
+			rhs = append(rhs, &ConstExpr{
+				Source:     rx,
+				TypedValue: untypedBool(ok),
+			})
+		}
+		m.PushStmt(&AssignStmt{
+			Lhs: as.Lhs,
+			Op:  as.Op,
+			Rhs: rhs,
+		})
+		m.PushOp(OpExec)
+	}
+}
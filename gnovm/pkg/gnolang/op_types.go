@@ -508,6 +508,16 @@ func (m *Machine) doOpStaticTypeOf() {
 			m.PushOp(OpEval)
 		}
 	case *UnaryExpr:
+		if x.Op == ARROW {
+			start := m.NumValues
+			m.PushOp(OpHalt)
+			m.PushExpr(x.X)
+			m.PushOp(OpStaticTypeOf)
+			m.Run() // XXX replace
+			xt := m.ReapValues(start)[0].GetType()
+			m.PushValue(asValue(baseOf(xt).(*ChanType).Elt))
+			break
+		}
 		m.PushExpr(x.X)
 		m.PushOp(OpStaticTypeOf)
 	case *CompositeLitExpr:
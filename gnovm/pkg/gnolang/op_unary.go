@@ -121,5 +121,30 @@ func (m *Machine) doOpUxor() {
 }
 
 func (m *Machine) doOpUrecv() {
-	panic("not yet implemented")
+	xv := m.PeekValue(1)
+	var rv TypedValue
+	var ok bool
+	if w := m.unpark(); w != nil {
+		// resumed after a sender handed over a value, or close.
+		rv, ok = w.value, w.ok
+	} else {
+		var done bool
+		cv, _ := xv.V.(*ChanValue)
+		if cv != nil {
+			rv, ok, done = cv.tryRecv(m)
+		}
+		if !done {
+			m.PushOp(OpUrecv)
+			m.block(&chanWaiter{ch: cv})
+			return
+		}
+	}
+	ux := m.PopExpr().(*UnaryExpr)
+	if debug {
+		debug.Printf("doOpUrecv(%v)\n", ux)
+	}
+	*xv = m.recvResult(xv.T, rv, ok) // reuse as result
+	if ux.HasOK {
+		m.PushValue(untypedBool(ok))
+	}
 }
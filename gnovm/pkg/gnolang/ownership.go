@@ -117,6 +117,8 @@ type Object interface {
 	GetIsNewDeleted() bool
 	SetIsNewDeleted(bool)
 	GetIsTransient() bool
+	GetLastObjectSize() int64
+	SetLastObjectSize(int64)
 
 	// Saves to realm along the way if owned, and also (dirty
 	// or new).
@@ -145,6 +147,9 @@ type ObjectInfo struct {
 	isNewEscaped bool
 	isNewDeleted bool
 
+	// size of the object as last persisted, for storage accounting.
+	lastObjectSize int64
+
 	// XXX huh?
 	owner Object // mem reference to owner.
 }
@@ -324,6 +329,14 @@ func (oi *ObjectInfo) GetIsTransient() bool {
 	return false
 }
 
+func (oi *ObjectInfo) GetLastObjectSize() int64 {
+	return oi.lastObjectSize
+}
+
+func (oi *ObjectInfo) SetLastObjectSize(size int64) {
+	oi.lastObjectSize = size
+}
+
 func (tv *TypedValue) GetFirstObject(store Store) Object {
 	switch cv := tv.V.(type) {
 	case PointerValue:
@@ -53,6 +53,7 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	BinaryExpr{},
 	CallExpr{},
 	IndexExpr{},
+	IndexListExpr{},
 	SelectorExpr{},
 	SliceExpr{},
 	StarExpr{},
@@ -67,6 +68,8 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	ArrayTypeExpr{},
 	SliceTypeExpr{},
 	InterfaceTypeExpr{},
+	TypeUnionExpr{},
+	TypeTermExpr{},
 	ChanTypeExpr{},
 	FuncTypeExpr{},
 	MapTypeExpr{},
@@ -26,7 +26,10 @@ func PredefineFileSet(store Store, pn *PackageNode, fset *FileSet) {
 	for _, fn := range fset.Files {
 		SetNodeLocations(pn.PkgPath, string(fn.Name), fn)
 		fn.InitStaticBlock(fn, pn)
+		pn.extractGenerics(fn)
 	}
+	// Generic instances are preprocessed once all is predefined.
+	pn.beginPredefine()
 
 	// NOTE: much of what follows is duplicated for a single *FileNode
 	// in the main Preprocess translation function.  Keep synced.
@@ -89,6 +92,7 @@ func PredefineFileSet(store Store, pn *PackageNode, fset *FileSet) {
 			}
 		}
 	}
+	pn.endPredefine()
 }
 
 // This counter ensures (during testing) that certain functions
@@ -208,8 +212,61 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 					// nothing defined.
 				}
 
+			// TRANS_ENTER -----------------------
+			case *IndexExpr:
+				if gd := lookupGeneric(store, last, n.X); gd != nil {
+					// instantiate generic func or type.
+					return gd.instantiateExpr(store, last, n.X, []Expr{n.Index}), TRANS_SKIP
+				}
+
+			// TRANS_ENTER -----------------------
+			case *IndexListExpr:
+				gd := lookupGeneric(store, last, n.X)
+				if gd == nil {
+					panic(fmt.Sprintf("%s is not a generic function or type", n.X.String()))
+				}
+				return gd.instantiateExpr(store, last, n.X, n.Indices), TRANS_SKIP
+
+			// TRANS_ENTER -----------------------
+			case *CallExpr:
+				// infer the type arguments of generic funcs.
+				var fx Expr
+				var ixs []Expr
+				switch fn := n.Func.(type) {
+				case *IndexExpr:
+					fx, ixs = fn.X, []Expr{fn.Index}
+				case *IndexListExpr:
+					fx, ixs = fn.X, fn.Indices
+				default:
+					fx = fn
+				}
+				gd := lookupGeneric(store, last, fx)
+				if gd == nil || !gd.isFunc() || len(ixs) >= len(gd.typeParams()) {
+					break
+				}
+				explicit := make([]Type, len(ixs))
+				for i, ix := range ixs {
+					ix = Preprocess(store, last, ix).(Expr)
+					explicit[i] = evalStaticType(store, last, ix)
+				}
+				targs := gd.inferTypeArgs(store, last, n, explicit)
+				n.Func = gd.instanceExpr(n.Func, gd.instantiate(store, targs))
+
+			// TRANS_ENTER -----------------------
+			case *GoStmt, *SendStmt, *SelectStmt, *ChanTypeExpr:
+				assertConcurrencyAllowed(lastpn, n)
+
+			// TRANS_ENTER -----------------------
+			case *UnaryExpr:
+				if n.Op == ARROW {
+					assertConcurrencyAllowed(lastpn, n)
+				}
+
 			// TRANS_ENTER -----------------------
 			case *ImportDecl, *ValueDecl, *TypeDecl, *FuncDecl:
+				if td, ok := n.(*TypeDecl); ok && len(td.TypeParams) > 0 {
+					panic(fmt.Sprintf("generic type %s cannot be declared inside a function", td.Name))
+				}
 				// NOTE func decl usually must happen with a
 				// file, and so last is usually a *FileNode,
 				// but for testing convenience we allow
@@ -303,6 +360,18 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 					}
 					xt = xt.Elem()
 					n.IsArrayPtr = true
+				case ChanKind:
+					if n.Value != nil {
+						panic(fmt.Sprintf(
+							"range over %s permits only one iteration variable",
+							n.X.String()))
+					}
+					if baseOf(xt).(*ChanType).Dir == SEND {
+						panic(fmt.Sprintf(
+							"invalid operation: range %s receive from send-only channel %s",
+							n.X.String(), xt.String()))
+					}
+					n.IsChan = true
 				}
 				// key value if define.
 				if n.Op == DEFINE {
@@ -317,6 +386,12 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 							vn := n.Value.(*NameExpr).Name
 							last.Define(vn, anyValue(vt))
 						}
+					} else if xt.Kind() == ChanKind {
+						if n.Key != nil {
+							et := baseOf(xt).(*ChanType).Elt
+							kn := n.Key.(*NameExpr).Name
+							last.Define(kn, anyValue(et))
+						}
 					} else if xt.Kind() == StringKind {
 						if n.Key != nil {
 							it := IntType
@@ -501,6 +576,9 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 			case *FileNode:
 				// only for imports.
 				pushInitBlock(n, &last, &stack)
+				pn := packageOf(last)
+				pn.extractGenerics(n)
+				pn.beginPredefine()
 				{
 					// This logic supports out-of-order
 					// declarations.  (this must happen
@@ -574,6 +652,7 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 						}
 					}
 				}
+				pn.endPredefine()
 
 			// TRANS_BLOCK -----------------------
 			default:
@@ -1261,6 +1340,20 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 					// NOTE: like binary operations, unary operations are
 					// always computed in gno, never with reflect.
 				}
+				if n.Op == ARROW {
+					ct, ok := baseOf(xt).(*ChanType)
+					if !ok {
+						panic(fmt.Sprintf(
+							"invalid operation: cannot receive from non-channel %s",
+							n.X.String()))
+					}
+					if ct.Dir == SEND {
+						panic(fmt.Sprintf(
+							"invalid operation: cannot receive from send-only channel %s",
+							n.X.String()))
+					}
+					break
+				}
 				// Replace with *ConstExpr if const X.
 				if isConst(n.X) {
 					cx := evalConst(store, last, n)
@@ -1591,6 +1684,20 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 							// re-definitions
 							last.Define(lhs0, anyValue(mt.Value))
 							last.Define(lhs1, anyValue(BoolType))
+						case *UnaryExpr:
+							// Receive case: v, ok := <-x
+							if len(n.Lhs) != 2 || cx.Op != ARROW {
+								panic("should not happen")
+							}
+							cx.HasOK = true
+							lhs0 := n.Lhs[0].(*NameExpr).Name
+							lhs1 := n.Lhs[1].(*NameExpr).Name
+
+							dt := evalStaticTypeOf(store, last, cx.X)
+							ct := baseOf(dt).(*ChanType)
+							// re-definitions
+							last.Define(lhs0, anyValue(ct.Elt))
+							last.Define(lhs1, anyValue(BoolType))
 						default:
 							panic("should not happen")
 						}
@@ -1640,6 +1747,12 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 								panic("should not happen")
 							}
 							cx.HasOK = true
+						case *UnaryExpr:
+							// Receive case: v, ok = <-x
+							if len(n.Lhs) != 2 || cx.Op != ARROW {
+								panic("should not happen")
+							}
+							cx.HasOK = true
 						default:
 							panic("should not happen")
 						}
@@ -1763,8 +1876,20 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 
 			// TRANS_LEAVE -----------------------
 			case *SendStmt:
+				xt := evalStaticTypeOf(store, last, n.Chan)
+				ct, ok := baseOf(xt).(*ChanType)
+				if !ok {
+					panic(fmt.Sprintf(
+						"invalid operation: cannot send to non-channel %s",
+						n.Chan.String()))
+				}
+				if ct.Dir == RECV {
+					panic(fmt.Sprintf(
+						"invalid operation: cannot send to receive-only channel %s",
+						n.Chan.String()))
+				}
 				// Value consts become default *ConstExprs.
-				checkOrConvertType(store, last, &n.Value, nil, false)
+				checkOrConvertType(store, last, &n.Value, ct.Elt, false)
 
 			// TRANS_LEAVE -----------------------
 			case *SelectCaseStmt:
@@ -1937,6 +2062,10 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 				case *StructType:
 					*dst = *(tmp.(*StructType))
 				case *DeclaredType:
+					if n.IsAlias {
+						// dst is the aliased type.
+						break
+					}
 					// if store has this type, use that.
 					tid := DeclaredTypeID(lastpn.PkgPath, n.Name)
 					exists := false
@@ -2671,7 +2800,16 @@ func checkType(xt Type, dt Type, autoNative bool) {
 		panic("should not happen")
 	case *DeclaredType:
 		panic("should not happen")
-	case *StructType, *PackageType, *ChanType:
+	case *ChanType:
+		if ct, ok := xt.(*ChanType); ok {
+			// a bidirectional channel can be used as a
+			// directional one.
+			if (ct.Dir == cdt.Dir || ct.Dir == BOTH) &&
+				ct.Elt.TypeID() == cdt.Elt.TypeID() {
+				return // ok
+			}
+		}
+	case *StructType, *PackageType:
 		if xt.TypeID() == cdt.TypeID() {
 			return // ok
 		}
@@ -2725,6 +2863,10 @@ func findUndefined2(store Store, last BlockNode, x Expr, t Type) (un Name) {
 		if tv := last.GetValueRef(store, cx.Name); tv != nil {
 			return
 		}
+		if _, ok := packageOf(last).generics.decls[cx.Name]; ok {
+			// generic func or type, instantiated as needed.
+			return
+		}
 		if _, ok := UverseNode().GetLocalIndex(cx.Name); ok {
 			// XXX NOTE even if the name is shadowed by a file
 			// level declaration, it is fine to return here as it
@@ -2904,6 +3046,17 @@ func findUndefined2(store Store, last BlockNode, x Expr, t Type) (un Name) {
 		if un != "" {
 			return
 		}
+	case *IndexListExpr:
+		un = findUndefined(store, last, cx.X)
+		if un != "" {
+			return
+		}
+		for i := range cx.Indices {
+			un = findUndefined(store, last, cx.Indices[i])
+			if un != "" {
+				return
+			}
+		}
 	case *constTypeExpr:
 		return
 	case *ConstExpr:
@@ -3214,6 +3367,14 @@ func tryPredefine(store Store, last BlockNode, d Decl) (un Name) {
 					un = tx.Name
 					return
 				}
+			case *IndexExpr, *IndexListExpr:
+				// instance of generic type.
+				un = findUndefined(store, last, tx)
+				if un != "" {
+					return
+				}
+				d.Type = Preprocess(store, last, tx).(Expr)
+				t = evalStaticType(store, last, d.Type)
 			case *SelectorExpr:
 				// get package value.
 				un = findUndefined(store, last, tx.X)
@@ -3624,6 +3785,11 @@ func findDependentNames(n Node, dst map[Name]struct{}) {
 	case *IndexExpr:
 		findDependentNames(cn.X, dst)
 		findDependentNames(cn.Index, dst)
+	case *IndexListExpr:
+		findDependentNames(cn.X, dst)
+		for i := range cn.Indices {
+			findDependentNames(cn.Indices[i], dst)
+		}
 	case *FuncLitExpr:
 		findDependentNames(&cn.Type, dst)
 		for _, n := range cn.GetExternNames() {
@@ -3631,6 +3797,14 @@ func findDependentNames(n Node, dst map[Name]struct{}) {
 		}
 	case *constTypeExpr:
 	case *ConstExpr:
+		if fv, ok := cn.V.(*FuncValue); ok {
+			if fd, ok := fv.Source.(*FuncDecl); ok {
+				// instantiated generic function.
+				for _, n := range fd.GetExternNames() {
+					dst[n] = struct{}{}
+				}
+			}
+		}
 	case *ImportDecl:
 	case *ValueDecl:
 		if cn.Type != nil {
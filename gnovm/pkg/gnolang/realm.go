@@ -96,6 +96,8 @@ type Realm struct {
 	updated []Object // real objects that were modified.
 	deleted []Object // real objects that became deleted.
 	escaped []Object // real objects with refcount > 1.
+
+	sumDiff int64 // persisted size difference of the transaction, in bytes.
 }
 
 // Creates a blank new realm with counter 0.
@@ -333,6 +335,11 @@ func (rlm *Realm) FinalizeRealmTransaction(readonly bool, store Store) {
 	rlm.saveUnsavedObjects(store)
 	// delete all deleted objects.
 	rlm.removeDeletedObjects(store)
+	// account for the storage used by the realm.
+	if rlm.sumDiff != 0 {
+		store.AddRealmStorageDiff(rlm.Path, rlm.sumDiff)
+		rlm.sumDiff = 0
+	}
 	// reset realm state for new transaction.
 	rlm.clearMarks()
 }
@@ -743,7 +750,7 @@ func (rlm *Realm) saveObject(store Store, oo Object) {
 	}
 	// set object to store.
 	// NOTE: also sets the hash to object.
-	store.SetObject(oo)
+	rlm.sumDiff += store.SetObject(oo)
 	// set index.
 	if oo.GetIsEscaped() {
 		// XXX save oid->hash to iavl.
@@ -755,7 +762,7 @@ func (rlm *Realm) saveObject(store Store, oo Object) {
 
 func (rlm *Realm) removeDeletedObjects(store Store) {
 	for _, do := range rlm.deleted {
-		store.DelObject(do)
+		rlm.sumDiff += store.DelObject(do)
 	}
 }
 
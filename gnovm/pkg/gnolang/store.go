@@ -1,11 +1,14 @@
 package gnolang
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
 
+	"github.com/gnolang/gno/telemetry"
+	"github.com/gnolang/gno/telemetry/metrics"
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
@@ -29,8 +32,8 @@ type Store interface {
 	SetPackageRealm(*Realm)
 	GetObject(oid ObjectID) Object
 	GetObjectSafe(oid ObjectID) Object
-	SetObject(Object)
-	DelObject(Object)
+	SetObject(Object) int64 // returns the size difference, in bytes.
+	DelObject(Object) int64 // returns the size difference, in bytes.
 	GetType(tid TypeID) Type
 	GetTypeSafe(tid TypeID) Type
 	SetCacheType(Type)
@@ -59,6 +62,8 @@ type Store interface {
 	SetLogStoreOps(enabled bool)
 	SprintStoreOps() string
 	LogSwitchRealm(rlmpath string) // to mark change of realm boundaries
+	AddRealmStorageDiff(rlmpath string, diff int64)
+	RealmStorageDiffs() map[string]int64 // since the last ClearObjectCache.
 	ClearCache()
 	Print()
 }
@@ -78,8 +83,9 @@ type defaultStore struct {
 	go2gnoStrict     bool                  // if true, native->gno type conversion must be registered.
 
 	// transient
-	opslog  []StoreOp           // for debugging and testing.
-	current map[string]struct{} // for detecting import cycles.
+	opslog            []StoreOp           // for debugging and testing.
+	current           map[string]struct{} // for detecting import cycles.
+	realmStorageDiffs map[string]int64    // for storage deposits.
 }
 
 func NewStore(alloc *Allocator, baseStore, iavlStore store.Store) *defaultStore {
@@ -94,6 +100,8 @@ func NewStore(alloc *Allocator, baseStore, iavlStore store.Store) *defaultStore
 		iavlStore:        iavlStore,
 		go2gnoStrict:     true,
 		current:          make(map[string]struct{}),
+
+		realmStorageDiffs: make(map[string]int64),
 	}
 	InitStoreCaches(ds)
 	return ds
@@ -252,10 +260,16 @@ func (ds *defaultStore) GetObject(oid ObjectID) Object {
 func (ds *defaultStore) GetObjectSafe(oid ObjectID) Object {
 	// check cache.
 	if oo, exists := ds.cacheObjects[oid]; exists {
+		if telemetry.MetricsEnabled() {
+			metrics.VMStoreCacheHits.Add(context.Background(), 1)
+		}
 		return oo
 	}
 	// check baseStore.
 	if ds.baseStore != nil {
+		if telemetry.MetricsEnabled() {
+			metrics.VMStoreCacheMisses.Add(context.Background(), 1)
+		}
 		if oo := ds.loadObjectSafe(oid); oo != nil {
 			if debug {
 				if _, ok := oo.(*PackageValue); ok {
@@ -286,6 +300,7 @@ func (ds *defaultStore) loadObjectSafe(oid ObjectID) Object {
 			}
 		}
 		oo.SetHash(ValueHash{NewHashlet(hash)})
+		oo.SetLastObjectSize(int64(len(hashbz)))
 		ds.cacheObjects[oid] = oo
 		_ = fillTypesOfValue(ds, oo)
 		return oo
@@ -295,7 +310,9 @@ func (ds *defaultStore) loadObjectSafe(oid ObjectID) Object {
 
 // NOTE: unlike GetObject(), SetObject() is also used to persist updated
 // package values.
-func (ds *defaultStore) SetObject(oo Object) {
+// Returns the difference between the size of the object and its last
+// persisted size, in bytes.
+func (ds *defaultStore) SetObject(oo Object) int64 {
 	oid := oo.GetObjectID()
 	// replace children/fields with Ref.
 	o2 := copyValueWithRefs(nil, oo)
@@ -307,6 +324,10 @@ func (ds *defaultStore) SetObject(oo Object) {
 		panic("should not happen")
 	}
 	oo.SetHash(ValueHash{hash})
+	// compute size difference.
+	size := int64(len(hash) + len(bz))
+	diff := size - oo.GetLastObjectSize()
+	oo.SetLastObjectSize(size)
 	// save bytes to backend.
 	if ds.baseStore != nil {
 		key := backendObjectKey(oid)
@@ -347,10 +368,14 @@ func (ds *defaultStore) SetObject(oo Object) {
 		value = hash.Bytes()
 		ds.iavlStore.Set(key, value)
 	}
+	return diff
 }
 
-func (ds *defaultStore) DelObject(oo Object) {
+// Returns the negated last persisted size of the object, in bytes.
+func (ds *defaultStore) DelObject(oo Object) int64 {
 	oid := oo.GetObjectID()
+	diff := -oo.GetLastObjectSize()
+	oo.SetLastObjectSize(0)
 	// delete from cache.
 	delete(ds.cacheObjects, oid)
 	// delete from backend.
@@ -363,6 +388,7 @@ func (ds *defaultStore) DelObject(oo Object) {
 		ds.opslog = append(ds.opslog,
 			StoreOp{Type: StoreOpDel, Object: oo})
 	}
+	return diff
 }
 
 // NOTE: not used quite yet.
@@ -422,7 +448,8 @@ func (ds *defaultStore) SetCacheType(tt Type) {
 func (ds *defaultStore) SetType(tt Type) {
 	tid := tt.TypeID()
 	// return if tid already known.
-	if tt2, exists := ds.cacheTypes[tid]; exists {
+	tt2, exists := ds.cacheTypes[tid]
+	if exists {
 		if tt != tt2 {
 			// this can happen for a variety of reasons.
 			// TODO classify them and optimize.
@@ -435,6 +462,10 @@ func (ds *defaultStore) SetType(tt Type) {
 		tcopy := copyTypeWithRefs(tt)
 		bz := amino.MustMarshalAny(tcopy)
 		ds.baseStore.Set([]byte(key), bz)
+		// declared types are stored by the package declaring them.
+		if dt, ok := tt.(*DeclaredType); ok && !exists {
+			ds.AddRealmStorageDiff(dt.PkgPath, int64(len(bz)))
+		}
 	}
 	// save type to cache.
 	ds.cacheTypes[tid] = tt
@@ -530,6 +561,7 @@ func (ds *defaultStore) AddMemPackage(memPkg *std.MemPackage) {
 	ds.baseStore.Set(idxkey, []byte(memPkg.Path))
 	pathkey := []byte(backendPackagePathKey(memPkg.Path))
 	ds.iavlStore.Set(pathkey, bz)
+	ds.AddRealmStorageDiff(memPkg.Path, int64(len(bz)))
 }
 
 func (ds *defaultStore) GetMemPackage(path string) *std.MemPackage {
@@ -585,6 +617,7 @@ func (ds *defaultStore) ClearObjectCache() {
 	ds.alloc.Reset()
 	ds.cacheObjects = make(map[ObjectID]Object) // new cache.
 	ds.opslog = nil                             // new ops log.
+	ds.realmStorageDiffs = make(map[string]int64)
 	if len(ds.current) > 0 {
 		ds.current = make(map[string]struct{})
 	}
@@ -608,6 +641,8 @@ func (ds *defaultStore) Fork() Store {
 		go2gnoStrict:     ds.go2gnoStrict,
 		opslog:           nil, // new ops log.
 		current:          make(map[string]struct{}),
+
+		realmStorageDiffs: make(map[string]int64),
 	}
 	ds2.SetCachePackage(Uverse())
 	return ds2
@@ -706,6 +741,20 @@ func (ds *defaultStore) LogSwitchRealm(rlmpath string) {
 		StoreOp{Type: StoreOpSwitchRealm, RlmPath: rlmpath})
 }
 
+// AddRealmStorageDiff adds diff to the storage difference of the realm,
+// accumulated since the last ClearObjectCache. It is called with the size of
+// the objects finalized by the realm, and of the files and declared types of
+// the packages added to the store.
+func (ds *defaultStore) AddRealmStorageDiff(rlmpath string, diff int64) {
+	ds.realmStorageDiffs[rlmpath] += diff
+}
+
+// RealmStorageDiffs returns the storage differences of the realms finalized
+// since the last ClearObjectCache, in bytes.
+func (ds *defaultStore) RealmStorageDiffs() map[string]int64 {
+	return ds.realmStorageDiffs
+}
+
 func (ds *defaultStore) ClearCache() {
 	ds.cacheObjects = make(map[ObjectID]Object)
 	ds.cacheTypes = make(map[TypeID]Type)
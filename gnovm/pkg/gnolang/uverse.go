@@ -55,6 +55,17 @@ var gStringerType = &DeclaredType{
 	sealed: true,
 }
 
+// gComparableType is the constraint satisfied by all comparable types.
+// Used as a regular type, it is the empty interface.
+var gComparableType = &DeclaredType{
+	PkgPath: uversePkgPath,
+	Name:    "comparable",
+	Base: &InterfaceType{
+		PkgPath: uversePkgPath,
+	},
+	sealed: true,
+}
+
 // ----------------------------------------
 // Uverse package
 
@@ -605,7 +616,31 @@ func UverseNode() *PackageNode {
 			return
 		},
 	)
-	def("close", undefined)
+	defNative("close",
+		Flds( // params
+			"c", AnyT(),
+		),
+		nil, // results
+		func(m *Machine) {
+			arg0 := m.LastBlock().GetParams1()
+			if arg0.TV.T == nil || arg0.TV.T.Kind() != ChanKind {
+				panic("close() of non-channel type")
+			}
+			if baseOf(arg0.TV.T).(*ChanType).Dir == RECV {
+				panic("invalid operation: cannot close receive-only channel")
+			}
+			cv, _ := arg0.TV.V.(*ChanValue)
+			if cv == nil {
+				m.Panic(typedString("close of nil channel"))
+				return
+			}
+			if cv.Closed {
+				m.Panic(typedString("close of closed channel"))
+				return
+			}
+			cv.close(m)
+		},
+	)
 	def("complex", undefined)
 	defNative("copy",
 		Flds( // params
@@ -841,9 +876,22 @@ func UverseNode() *PackageNode {
 				}
 			case *ChanType:
 				if vargsl == 0 {
-					panic("not yet implemented")
+					m.PushValue(TypedValue{
+						T: tt,
+						V: m.Alloc.NewChan(0),
+					})
+					return
 				} else if vargsl == 1 {
-					panic("not yet implemented")
+					sv := vargs.TV.GetPointerAtIndexInt(m.Store, 0).Deref()
+					si := sv.ConvertGetInt()
+					if si < 0 {
+						panic("make() of chan type with negative size")
+					}
+					m.PushValue(TypedValue{
+						T: tt,
+						V: m.Alloc.NewChan(si),
+					})
+					return
 				} else {
 					panic("make() of chan type takes 1 or 2 arguments")
 				}
@@ -998,6 +1046,11 @@ func UverseNode() *PackageNode {
 			m.Exceptions = nil
 		},
 	)
+
+	// Generics
+	// NOTE: defined last to keep the value paths of other names.
+	def("any", asValue(&InterfaceType{PkgPath: uversePkgPath}))
+	def("comparable", asValue(gComparableType))
 	return uverseNode
 }
 
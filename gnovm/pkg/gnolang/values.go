@@ -35,6 +35,7 @@ func (*SliceValue) assertValue()       {}
 func (*StructValue) assertValue()      {}
 func (*FuncValue) assertValue()        {}
 func (*MapValue) assertValue()         {}
+func (*ChanValue) assertValue()        {}
 func (*BoundMethodValue) assertValue() {}
 func (TypeValue) assertValue()         {}
 func (*PackageValue) assertValue()     {}
@@ -58,6 +59,7 @@ var (
 	_ Value = &StructValue{}
 	_ Value = &FuncValue{}
 	_ Value = &MapValue{}
+	_ Value = &ChanValue{}
 	_ Value = &BoundMethodValue{}
 	_ Value = TypeValue{}
 	_ Value = &PackageValue{}
@@ -793,6 +795,30 @@ func (mv *MapValue) DeleteForKey(store Store, key *TypedValue) {
 	}
 }
 
+// ----------------------------------------
+// ChanValue
+
+// ChanValue is not an Object, and cannot be persisted: channel types are
+// rejected in realm code, and in any package run without goroutines, like
+// every package on-chain.
+// See goroutine.go for the channel operations.
+type ChanValue struct {
+	Buffer []TypedValue // buffered elements, oldest first.
+	Cap    int          // buffer capacity, or 0 if unbuffered.
+	Closed bool
+
+	recvq []*chanWaiter // goroutines blocked receiving.
+	sendq []*chanWaiter // goroutines blocked sending.
+}
+
+func (cv *ChanValue) GetLength() int {
+	return len(cv.Buffer)
+}
+
+func (cv *ChanValue) GetCapacity() int {
+	return cv.Cap
+}
+
 // ----------------------------------------
 // TypeValue
 
@@ -1544,7 +1570,7 @@ func (tv *TypedValue) ComputeMapKey(store Store, omitType bool) MapKey {
 		for i := 0; i < sl; i++ {
 			fv := fillValueTV(store, &sv.Fields[i])
 			ft := bt.Fields[i]
-			omitTypes := ft.Elem().Kind() != InterfaceKind
+			omitTypes := ft.Type.Kind() != InterfaceKind
 			bz = append(bz, fv.ComputeMapKey(store, omitTypes)...)
 			if i != sl-1 {
 				bz = append(bz, ',')
@@ -2032,7 +2058,7 @@ func (tv *TypedValue) GetLength() int {
 			return 0
 		case *ArrayType:
 			return bt.Len
-		case *SliceType:
+		case *SliceType, *MapType, *ChanType:
 			return 0
 		default:
 			panic(fmt.Sprintf(
@@ -2049,6 +2075,8 @@ func (tv *TypedValue) GetLength() int {
 		return cv.GetLength()
 	case *MapValue:
 		return cv.GetLength()
+	case *ChanValue:
+		return cv.GetLength()
 	case *NativeValue:
 		return cv.Value.Len()
 	default:
@@ -2065,6 +2093,7 @@ func (tv *TypedValue) GetCapacity() int {
 			// strings have no capacity.
 			case *ArrayType:
 			case *SliceType:
+			case *ChanType:
 			default:
 				panic("should not happen")
 			}
@@ -2078,6 +2107,8 @@ func (tv *TypedValue) GetCapacity() int {
 		return cv.GetCapacity()
 	case *SliceValue:
 		return cv.GetCapacity()
+	case *ChanValue:
+		return cv.GetCapacity()
 	case *NativeValue:
 		return cv.Value.Cap()
 	default:
@@ -2478,6 +2509,11 @@ func defaultValue(alloc *Allocator, t Type) Value {
 	}
 }
 
+// DefaultTypedValue returns the zero value of type t.
+func DefaultTypedValue(alloc *Allocator, t Type) TypedValue {
+	return defaultTypedValue(alloc, t)
+}
+
 func defaultTypedValue(alloc *Allocator, t Type) TypedValue {
 	if t.Kind() == InterfaceKind {
 		return TypedValue{}
@@ -2513,6 +2549,12 @@ func typedString(s string) TypedValue {
 	return tv
 }
 
+// FillValueTV loads the value of tv from the store, if it is a reference to
+// an object or a package which isn't loaded yet, and returns tv.
+func FillValueTV(store Store, tv *TypedValue) *TypedValue {
+	return fillValueTV(store, tv)
+}
+
 func fillValueTV(store Store, tv *TypedValue) *TypedValue {
 	switch cv := tv.V.(type) {
 	case RefValue:
@@ -219,6 +219,10 @@ func (mv *MapValue) ProtectedString(seen *seenValues) string {
 	return "map{" + strings.Join(ss, ",") + "}"
 }
 
+func (cv *ChanValue) String() string {
+	return fmt.Sprintf("chan{%d/%d}", len(cv.Buffer), cv.Cap)
+}
+
 func (v TypeValue) String() string {
 	ptr := ""
 	if reflect.TypeOf(v.Type).Kind() == reflect.Ptr {
@@ -369,8 +373,6 @@ func (tv *TypedValue) ProtectedSprint(seen *seenValues, considerDeclaredType boo
 		panic("should not happen")
 	case *PackageType:
 		return tv.V.(*PackageValue).String()
-	case *ChanType:
-		panic("not yet implemented")
 	case *TypeType:
 		return tv.V.(TypeValue).String()
 	default:
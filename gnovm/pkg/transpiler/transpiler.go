@@ -41,7 +41,9 @@ var stdlibWhitelist = []string{
 	"crypto/sha1",
 	"crypto/chacha20",
 	"crypto/cipher",
+	"crypto/ed25519",
 	"crypto/sha256",
+	"crypto/sha512",
 	"encoding/base64",
 	"encoding/binary",
 	"encoding/hex",
@@ -50,6 +52,7 @@ var stdlibWhitelist = []string{
 	"errors",
 	"hash",
 	"hash/adler32",
+	"hash/crc32",
 	"internal/bytealg",
 	"internal/os",
 	"flag",
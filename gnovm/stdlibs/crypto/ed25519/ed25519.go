@@ -0,0 +1,41 @@
+package ed25519
+
+import (
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+)
+
+// GasVerifyPerByte is the gas charged for each byte of the verified message,
+// which is hashed as part of the verification.
+const GasVerifyPerByte = 1
+
+// paramsContext is implemented by the execution context of the chain, see
+// std.ExecContext.
+type paramsContext interface {
+	GetParamInt64(key string, ptr *int64) bool
+}
+
+// GasVerify returns the gas charged for the verification of a signature of a
+// message of msgLen bytes. The cost of a signature is the one of the
+// verification of a transaction signature, the auth param
+// sig_verify_cost_ed25519, or its default without params.
+func GasVerify(m *gno.Machine, msgLen int) int64 {
+	cost := auth.DefaultSigVerifyCostED25519
+	if ctx, ok := m.Context.(paramsContext); ok {
+		ctx.GetParamInt64(auth.ParamSigVerifyCostED25519, &cost)
+	}
+	return cost + int64(msgLen)*GasVerifyPerByte
+}
+
+func X_verify(m *gno.Machine, publicKey []byte, message, sig []byte) bool {
+	if m.GasMeter != nil {
+		m.GasMeter.ConsumeGas(GasVerify(m, len(message)), "ed25519.Verify")
+	}
+	if len(publicKey) != ed25519.PubKeyEd25519Size {
+		return false
+	}
+	var pubKey ed25519.PubKeyEd25519
+	copy(pubKey[:], publicKey)
+	return pubKey.VerifyBytes(message, sig)
+}
@@ -0,0 +1,10 @@
+package ripemd160
+
+import "golang.org/x/crypto/ripemd160"
+
+func X_sum(data []byte) (sum [20]byte) {
+	h := ripemd160.New()
+	h.Write(data)
+	h.Sum(sum[:0])
+	return sum
+}
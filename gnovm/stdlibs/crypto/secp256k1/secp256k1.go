@@ -0,0 +1,41 @@
+package secp256k1
+
+import (
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+)
+
+// GasVerifyPerByte is the gas charged for each byte of the verified message,
+// which is hashed with SHA-256 before the verification.
+const GasVerifyPerByte = 1
+
+// paramsContext is implemented by the execution context of the chain, see
+// std.ExecContext.
+type paramsContext interface {
+	GetParamInt64(key string, ptr *int64) bool
+}
+
+// GasVerify returns the gas charged for the verification of a signature of a
+// message of msgLen bytes. The cost of a signature is the one of the
+// verification of a transaction signature, the auth param
+// sig_verify_cost_secp256k1, or its default without params.
+func GasVerify(m *gno.Machine, msgLen int) int64 {
+	cost := auth.DefaultSigVerifyCostSecp256k1
+	if ctx, ok := m.Context.(paramsContext); ok {
+		ctx.GetParamInt64(auth.ParamSigVerifyCostSecp256k1, &cost)
+	}
+	return cost + int64(msgLen)*GasVerifyPerByte
+}
+
+func X_verify(m *gno.Machine, publicKey []byte, message, sig []byte) bool {
+	if m.GasMeter != nil {
+		m.GasMeter.ConsumeGas(GasVerify(m, len(message)), "secp256k1.Verify")
+	}
+	if len(publicKey) != secp256k1.PubKeySecp256k1Size {
+		return false
+	}
+	var pubKey secp256k1.PubKeySecp256k1
+	copy(pubKey[:], publicKey)
+	return pubKey.VerifyBytes(message, sig)
+}
@@ -0,0 +1,7 @@
+package sha1
+
+import "crypto/sha1"
+
+func X_sum(data []byte) [20]byte {
+	return sha1.Sum(data)
+}
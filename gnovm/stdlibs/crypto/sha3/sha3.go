@@ -0,0 +1,62 @@
+package sha3
+
+import (
+	"encoding/binary"
+
+	"golang.org/x/crypto/sha3"
+)
+
+func X_sum224(data []byte) [28]byte {
+	return sha3.Sum224(data)
+}
+
+func X_sum256(data []byte) [32]byte {
+	return sha3.Sum256(data)
+}
+
+func X_sum384(data []byte) [48]byte {
+	return sha3.Sum384(data)
+}
+
+func X_sum512(data []byte) [64]byte {
+	return sha3.Sum512(data)
+}
+
+func X_keccak256(data []byte) (sum [32]byte) {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	h.Sum(sum[:0])
+	return sum
+}
+
+func X_keccak512(data []byte) (sum [64]byte) {
+	h := sha3.NewLegacyKeccak512()
+	h.Write(data)
+	h.Sum(sum[:0])
+	return sum
+}
+
+// X_absorb absorbs the full blocks of data into the sponge state a, and
+// returns the new state.
+func X_absorb(a [25]uint64, rate int, data []byte) [25]uint64 {
+	absorbBlocks(&a, rate, data[:len(data)-len(data)%rate])
+	return a
+}
+
+// X_squeeze pads the pending input tail with dsbyte, absorbs it into a copy
+// of the sponge state a, and returns the first size bytes of the output.
+func X_squeeze(a [25]uint64, rate int, dsbyte byte, tail []byte, size int) []byte {
+	block := make([]byte, rate)
+	copy(block, tail)
+	block[len(tail)] ^= dsbyte
+	block[rate-1] ^= 0x80
+	absorbBlocks(&a, rate, block)
+
+	out := make([]byte, size)
+	for i := 0; i < size; i += 8 {
+		var lane [8]byte
+		binary.LittleEndian.PutUint64(lane[:], a[i/8])
+		copy(out[i:], lane[:])
+	}
+	return out
+}
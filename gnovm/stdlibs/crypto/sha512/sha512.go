@@ -0,0 +1,19 @@
+package sha512
+
+import "crypto/sha512"
+
+func X_sum512(data []byte) [64]byte {
+	return sha512.Sum512(data)
+}
+
+func X_sum384(data []byte) [48]byte {
+	return sha512.Sum384(data)
+}
+
+func X_sum512_224(data []byte) [28]byte {
+	return sha512.Sum512_224(data)
+}
+
+func X_sum512_256(data []byte) [32]byte {
+	return sha512.Sum512_256(data)
+}
@@ -0,0 +1,746 @@
+package json
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	libsfmt "github.com/gnolang/gno/gnovm/stdlibs/fmt"
+)
+
+func X_unmarshal(m *gno.Machine, data []byte, v gno.TypedValue) (err gno.TypedValue) {
+	defer catchError(m, &err)
+	if isNil(v) {
+		return newError(m, "newInvalidUnmarshalError", typedString(""))
+	}
+	if v.T.Kind() != gno.PointerKind || v.V == nil {
+		return newError(m, "newInvalidUnmarshalError", typedString(libsfmt.TypeString(v.T)))
+	}
+	consumeGas(m, int64(len(data))*GasPerByte)
+	d := &decodeState{m: m, data: data, fields: fieldCache{}}
+	n := parse(data, 0)
+	d.value(&n, v.T.Elem(), v.V.(gno.PointerValue))
+	return d.savedError
+}
+
+// node is a JSON value of the data being decoded.
+type node struct {
+	start, end int    // offsets of the value in the data
+	keys       []node // keys of an object
+	elems      []node // elements of an array, or values of an object
+}
+
+// parse returns the node of the value starting at data[i:]. The data must
+// have been checked to be valid.
+func parse(data []byte, i int) node {
+	i = skipSpace(data, i)
+	n := node{start: i}
+	switch data[i] {
+	case '{':
+		for i = skipSpace(data, i+1); data[i] != '}'; {
+			k := parse(data, i)
+			v := parse(data, skipSpace(data, k.end)+1) // skip ':'
+			n.keys = append(n.keys, k)
+			n.elems = append(n.elems, v)
+			if i = skipSpace(data, v.end); data[i] == ',' {
+				i = skipSpace(data, i+1)
+			}
+		}
+		n.end = i + 1
+	case '[':
+		for i = skipSpace(data, i+1); data[i] != ']'; {
+			v := parse(data, i)
+			n.elems = append(n.elems, v)
+			if i = skipSpace(data, v.end); data[i] == ',' {
+				i = skipSpace(data, i+1)
+			}
+		}
+		n.end = i + 1
+	case '"':
+		for i++; data[i] != '"'; i++ {
+			if data[i] == '\\' {
+				i++
+			}
+		}
+		n.end = i + 1
+	default:
+		for i < len(data) && !isDelim(data[i]) {
+			i++
+		}
+		n.end = i
+	}
+	return n
+}
+
+func skipSpace(data []byte, i int) int {
+	for i < len(data) && isSpace(data[i]) {
+		i++
+	}
+	return i
+}
+
+func isSpace(c byte) bool {
+	return c <= ' ' && (c == ' ' || c == '\t' || c == '\r' || c == '\n')
+}
+
+func isDelim(c byte) bool {
+	return isSpace(c) || c == ',' || c == ']' || c == '}'
+}
+
+// decodeState decodes JSON into a Gno value.
+type decodeState struct {
+	m          *gno.Machine
+	data       []byte
+	savedError gno.TypedValue
+	// errorContext is the location of the value being decoded, which is
+	// added to the UnmarshalTypeErrors.
+	errorContext struct {
+		Struct     gno.Type
+		FieldStack []string
+	}
+	fields fieldCache
+}
+
+// error aborts the decoding with the Gno error err.
+func (d *decodeState) error(err gno.TypedValue) {
+	panic(jsonError{err})
+}
+
+// saveError saves the first error created by the function fn of the Gno
+// package, and is used to report it at the end of the decoding.
+func (d *decodeState) saveError(fn gno.Name, args ...gno.TypedValue) {
+	if isNil(d.savedError) {
+		d.savedError = newError(d.m, fn, args...)
+	}
+}
+
+// saveTypeError saves an UnmarshalTypeError, with the struct field being
+// decoded.
+func (d *decodeState) saveTypeError(value string, t gno.Type, offset int64) {
+	var strct, field string
+	if d.errorContext.Struct != nil || len(d.errorContext.FieldStack) > 0 {
+		if dt, ok := d.errorContext.Struct.(*gno.DeclaredType); ok {
+			strct = string(dt.Name)
+		}
+		field = strings.Join(d.errorContext.FieldStack, ".")
+	}
+	d.saveError("newUnmarshalTypeError",
+		typedString(value), typedString(libsfmt.TypeString(t)), typedInt64(offset),
+		typedString(strct), typedString(field))
+}
+
+// invalidStringTag returns the error message of the "string" option of a
+// struct field used with an invalid value.
+func invalidStringTag(item []byte, t gno.Type) string {
+	return fmt.Sprintf("json: invalid use of ,string struct tag, trying to unmarshal %q into %s",
+		item, libsfmt.TypeString(t))
+}
+
+// set assigns tv to the value pointed to by pv.
+func (d *decodeState) set(pv gno.PointerValue, tv gno.TypedValue) {
+	pv.Assign2(d.m.Alloc, d.m.Store, d.m.Realm, tv, false)
+}
+
+// bytes returns a new byte slice holding a copy of b.
+func (d *decodeState) bytes(b []byte) gno.TypedValue {
+	return gno.TypedValue{
+		T: byteSliceType,
+		V: d.m.Alloc.NewSliceFromData(append([]byte(nil), b...)),
+	}
+}
+
+// value decodes n into the value of type t pointed to by pv.
+func (d *decodeState) value(n *node, t gno.Type, pv gno.PointerValue) {
+	consumeGas(d.m, GasPerValue)
+	gno.FillValueTV(d.m.Store, pv.TV)
+	switch d.data[n.start] {
+	case '[':
+		d.array(n, t, pv)
+	case '{':
+		d.object(n, t, pv)
+	default:
+		d.literalStore(d.data[n.start:n.end], int64(n.end), t, pv, false)
+	}
+}
+
+// indirect walks down the pointers of the value of type t pointed to by pv,
+// allocating them as necessary, until it gets to a non-pointer.
+// If it encounters an Unmarshaler, indirect stops and returns that.
+// If decodingNull is true, indirect stops at the first settable pointer so it
+// can be set to nil.
+func (d *decodeState) indirect(t gno.Type, pv gno.PointerValue, decodingNull bool) (u, ut gno.TypedValue, _ gno.Type, _ gno.PointerValue) {
+	// If t is a named type, start with its address,
+	// so that if the type has pointer methods, we find them.
+	if _, ok := t.(*gno.DeclaredType); ok && t.Kind() != gno.PointerKind && pv.TV.T != gno.DataByteType {
+		ptv := gno.TypedValue{T: &gno.PointerType{Elt: t}, V: pv}
+		if u, ut, ok := unmarshalers(ptv, decodingNull); ok {
+			return u, ut, nil, gno.PointerValue{}
+		}
+	}
+	settable := true
+	for {
+		gno.FillValueTV(d.m.Store, pv.TV)
+		tv := pv.Deref()
+		// Load value from interface, but only if the result will be
+		// usefully addressable.
+		if t.Kind() == gno.InterfaceKind && !isNil(tv) &&
+			tv.T.Kind() == gno.PointerKind && tv.V != nil &&
+			(!decodingNull || tv.T.Elem().Kind() == gno.PointerKind) {
+			t, pv, settable = tv.T, gno.PointerValue{TV: &tv}, false
+		}
+		if t.Kind() != gno.PointerKind {
+			break
+		}
+		if decodingNull && settable {
+			break
+		}
+		if tv.V == nil {
+			d.m.Alloc.AllocatePointer()
+			elem := gno.DefaultTypedValue(d.m.Alloc, t.Elem())
+			tv = gno.TypedValue{T: t, V: gno.PointerValue{TV: &elem}}
+			d.set(pv, tv)
+		}
+		if u, ut, ok := unmarshalers(tv, decodingNull); ok {
+			return u, ut, nil, gno.PointerValue{}
+		}
+		t, pv, settable = t.Elem(), tv.V.(gno.PointerValue), true
+	}
+	return gno.TypedValue{}, gno.TypedValue{}, t, pv
+}
+
+// unmarshalers returns tv as an Unmarshaler or a TextUnmarshaler, if it
+// implements one of them.
+func unmarshalers(tv gno.TypedValue, decodingNull bool) (u, ut gno.TypedValue, ok bool) {
+	if gno.IsImplementedBy(unmarshalerType, tv.T) {
+		return tv, gno.TypedValue{}, true
+	}
+	if !decodingNull && gno.IsImplementedBy(textUnmarshalerType, tv.T) {
+		return gno.TypedValue{}, tv, true
+	}
+	return gno.TypedValue{}, gno.TypedValue{}, false
+}
+
+// unmarshalJSON calls the UnmarshalJSON method of u with item.
+func (d *decodeState) unmarshalJSON(u gno.TypedValue, item []byte) {
+	if err := callMethod(d.m, u, "UnmarshalJSON", d.bytes(item))[0]; !isNil(err) {
+		d.error(err)
+	}
+}
+
+// array decodes the array n into the value of type t pointed to by pv.
+func (d *decodeState) array(n *node, t gno.Type, pv gno.PointerValue) {
+	u, ut, it, ipv := d.indirect(t, pv, false)
+	if u.T != nil {
+		d.unmarshalJSON(u, d.data[n.start:n.end])
+		return
+	}
+	if ut.T != nil {
+		d.saveTypeError("array", t, int64(n.start+1))
+		return
+	}
+	t, pv = it, ipv
+
+	switch ct := gno.BaseOf(t).(type) {
+	case *gno.InterfaceType:
+		if len(ct.Methods) == 0 {
+			d.set(pv, d.arrayInterface(n))
+			return
+		}
+		d.saveTypeError("array", t, int64(n.start+1))
+	case *gno.SliceType:
+		var av *gno.ArrayValue
+		if ct.Elt.Kind() == gno.Uint8Kind {
+			av = d.m.Alloc.NewDataArray(len(n.elems))
+		} else {
+			av = d.m.Alloc.NewListArray(len(n.elems))
+			for i := range av.List {
+				av.List[i] = gno.DefaultTypedValue(d.m.Alloc, ct.Elt)
+			}
+		}
+		for i := range n.elems {
+			d.value(&n.elems[i], ct.Elt, av.GetPointerAtIndexInt2(d.m.Store, i, ct.Elt))
+		}
+		sv := d.m.Alloc.NewSlice(av, 0, len(n.elems), len(n.elems))
+		d.set(pv, gno.TypedValue{T: t, V: sv})
+	case *gno.ArrayType:
+		av := pv.TV.V.(*gno.ArrayValue)
+		for i := 0; i < ct.Len; i++ {
+			epv := av.GetPointerAtIndexInt2(d.m.Store, i, ct.Elt)
+			if i < len(n.elems) {
+				d.value(&n.elems[i], ct.Elt, epv)
+			} else {
+				// zero the remaining elements.
+				d.set(epv, gno.DefaultTypedValue(d.m.Alloc, ct.Elt))
+			}
+		}
+	default:
+		d.saveTypeError("array", t, int64(n.start+1))
+	}
+}
+
+// object decodes the object n into the value of type t pointed to by pv.
+func (d *decodeState) object(n *node, t gno.Type, pv gno.PointerValue) {
+	u, ut, it, ipv := d.indirect(t, pv, false)
+	if u.T != nil {
+		d.unmarshalJSON(u, d.data[n.start:n.end])
+		return
+	}
+	if ut.T != nil {
+		d.saveTypeError("object", t, int64(n.start+1))
+		return
+	}
+	t, pv = it, ipv
+
+	var fields *structFields
+	switch ct := gno.BaseOf(t).(type) {
+	case *gno.InterfaceType:
+		if len(ct.Methods) == 0 {
+			d.set(pv, d.objectInterface(n))
+			return
+		}
+		d.saveTypeError("object", t, int64(n.start+1))
+		return
+	case *gno.MapType:
+		switch ct.Key.Kind() {
+		case gno.StringKind,
+			gno.IntKind, gno.Int8Kind, gno.Int16Kind, gno.Int32Kind, gno.Int64Kind,
+			gno.UintKind, gno.Uint8Kind, gno.Uint16Kind, gno.Uint32Kind, gno.Uint64Kind:
+		default:
+			if !gno.IsImplementedBy(textUnmarshalerType, &gno.PointerType{Elt: ct.Key}) {
+				d.saveTypeError("object", t, int64(n.start+1))
+				return
+			}
+		}
+		if pv.TV.V == nil {
+			d.set(pv, gno.TypedValue{T: t, V: d.m.Alloc.NewMap(len(n.keys))})
+		}
+	case *gno.StructType:
+		fields = d.fields.get(t)
+	default:
+		d.saveTypeError("object", t, int64(n.start+1))
+		return
+	}
+
+	origErrorContext := d.errorContext
+	for i := range n.keys {
+		key := &n.keys[i]
+		item := d.data[key.start:key.end]
+		name := unquote(item)
+
+		if mt, ok := gno.BaseOf(t).(*gno.MapType); ok {
+			elem := gno.DefaultTypedValue(d.m.Alloc, mt.Value)
+			d.value(&n.elems[i], mt.Value, gno.PointerValue{TV: &elem})
+
+			// Write value back to map.
+			if k, ok := d.mapKey(mt.Key, item, name, int64(key.start+1)); ok {
+				mv := pv.TV.V.(*gno.MapValue)
+				d.set(mv.GetPointerForKey(d.m.Alloc, d.m.Store, &k), elem)
+			}
+			continue
+		}
+
+		f := fields.byExactName[name]
+		if f == nil {
+			f = fields.byFoldedName[foldName(name)]
+		}
+		if f == nil {
+			// ignore the keys without a field.
+			continue
+		}
+		if ft, fpv, ok := d.field(t, pv, f); ok {
+			if f.quoted {
+				v := &n.elems[i]
+				switch d.data[v.start] {
+				case 'n':
+					d.literalStore([]byte("null"), int64(v.end), ft, fpv, false)
+				case '"':
+					d.literalStore([]byte(unquote(d.data[v.start:v.end])), int64(v.end), ft, fpv, true)
+				default:
+					d.saveError("newError", typedString(
+						"json: invalid use of ,string struct tag, trying to unmarshal unquoted value into "+
+							libsfmt.TypeString(ft)))
+				}
+			} else {
+				d.value(&n.elems[i], ft, fpv)
+			}
+		}
+
+		// Reset errorContext to its original state.
+		d.errorContext.FieldStack = d.errorContext.FieldStack[:len(origErrorContext.FieldStack)]
+		d.errorContext.Struct = origErrorContext.Struct
+	}
+}
+
+// field returns the type of the field f of the struct of type t pointed to by
+// pv, and the pointer to it, allocating the embedded pointers as necessary.
+// It returns false if an embedded pointer cannot be set.
+func (d *decodeState) field(t gno.Type, pv gno.PointerValue, f *field) (gno.Type, gno.PointerValue, bool) {
+	d.errorContext.Struct = t
+	var name gno.Name // name of the embedded field being walked
+	for i, ind := range f.index {
+		if t.Kind() == gno.PointerKind {
+			tv := *gno.FillValueTV(d.m.Store, pv.TV)
+			if tv.V == nil {
+				if !isExported(name) {
+					d.saveError("newError", typedString(
+						"json: cannot set embedded pointer to unexported struct: "+libsfmt.TypeString(t.Elem())))
+					return nil, gno.PointerValue{}, false
+				}
+				d.m.Alloc.AllocatePointer()
+				elem := gno.DefaultTypedValue(d.m.Alloc, t.Elem())
+				tv = gno.TypedValue{T: t, V: gno.PointerValue{TV: &elem}}
+				d.set(pv, tv)
+			}
+			t, pv = t.Elem(), tv.V.(gno.PointerValue)
+		}
+		st := gno.BaseOf(t).(*gno.StructType)
+		name = st.Fields[ind].Name
+		if i < len(f.index)-1 {
+			d.errorContext.FieldStack = append(d.errorContext.FieldStack, string(name))
+		}
+		sv := gno.FillValueTV(d.m.Store, pv.TV).V.(*gno.StructValue)
+		t, pv = st.Fields[ind].Type, sv.GetPointerToInt(d.m.Store, ind)
+	}
+	d.errorContext.FieldStack = append(d.errorContext.FieldStack, f.name)
+	return t, pv, true
+}
+
+// mapKey returns the key of type kt of a map, decoded from the object key
+// item. name is the unquoted key.
+func (d *decodeState) mapKey(kt gno.Type, item []byte, name string, offset int64) (gno.TypedValue, bool) {
+	k := gno.DefaultTypedValue(d.m.Alloc, kt)
+	if gno.IsImplementedBy(textUnmarshalerType, &gno.PointerType{Elt: kt}) {
+		d.literalStore(item, offset, kt, gno.PointerValue{TV: &k}, true)
+		return k, true
+	}
+	switch kt.Kind() {
+	case gno.StringKind:
+		k.SetString(d.m.Alloc.NewString(name))
+	case gno.IntKind, gno.Int8Kind, gno.Int16Kind, gno.Int32Kind, gno.Int64Kind:
+		n, err := strconv.ParseInt(name, 10, 64)
+		if err != nil || overflowInt(kt, n) {
+			d.saveTypeError("number "+name, kt, offset)
+			return k, false
+		}
+		setInt(&k, n)
+	case gno.UintKind, gno.Uint8Kind, gno.Uint16Kind, gno.Uint32Kind, gno.Uint64Kind:
+		n, err := strconv.ParseUint(name, 10, 64)
+		if err != nil || overflowUint(kt, n) {
+			d.saveTypeError("number "+name, kt, offset)
+			return k, false
+		}
+		setUint(&k, n)
+	default:
+		panic("json: Unexpected key type") // should never occur
+	}
+	return k, true
+}
+
+// literalStore decodes a literal stored in item into the value of type t
+// pointed to by pv. offset is the end of the literal in the data. The
+// literal is the quoted value of a field with the "string" option if
+// fromQuoted is true.
+func (d *decodeState) literalStore(item []byte, offset int64, t gno.Type, pv gno.PointerValue, fromQuoted bool) {
+	// Check for unmarshaler.
+	if len(item) == 0 {
+		// Empty string given.
+		d.saveError("newError", typedString(invalidStringTag(item, t)))
+		return
+	}
+	isNull := item[0] == 'n' // null
+	u, ut, it, ipv := d.indirect(t, pv, isNull)
+	if u.T != nil {
+		d.unmarshalJSON(u, item)
+		return
+	}
+	if ut.T != nil {
+		if item[0] != '"' {
+			if fromQuoted {
+				d.saveError("newError", typedString(invalidStringTag(item, t)))
+				return
+			}
+			val := "number"
+			switch item[0] {
+			case 'n':
+				val = "null"
+			case 't', 'f':
+				val = "bool"
+			}
+			d.saveTypeError(val, t, offset)
+			return
+		}
+		s := unquote(item)
+		if err := callMethod(d.m, ut, "UnmarshalText", d.bytes([]byte(s)))[0]; !isNil(err) {
+			d.error(err)
+		}
+		return
+	}
+	t, pv = it, ipv
+
+	switch c := item[0]; c {
+	case 'n': // null
+		// The main parser checks that only true and false can reach here,
+		// but if this was a quoted string input, it could be anything.
+		if fromQuoted && string(item) != "null" {
+			d.saveError("newError", typedString(invalidStringTag(item, t)))
+			break
+		}
+		switch t.Kind() {
+		case gno.InterfaceKind, gno.PointerKind, gno.MapKind, gno.SliceKind:
+			d.set(pv, gno.DefaultTypedValue(d.m.Alloc, t))
+			// otherwise, ignore null for primitives/string
+		}
+	case 't', 'f': // true, false
+		value := item[0] == 't'
+		// The main parser checks that only true and false can reach here,
+		// but if this was a quoted string input, it could be anything.
+		if fromQuoted && string(item) != "true" && string(item) != "false" {
+			d.saveError("newError", typedString(invalidStringTag(item, t)))
+			break
+		}
+		switch {
+		case t.Kind() == gno.BoolKind:
+			tv := gno.TypedValue{T: t}
+			tv.SetBool(value)
+			d.set(pv, tv)
+		case isEmptyInterface(t):
+			tv := gno.TypedValue{T: gno.BoolType}
+			tv.SetBool(value)
+			d.set(pv, tv)
+		case fromQuoted:
+			d.saveError("newError", typedString(invalidStringTag(item, t)))
+		default:
+			d.saveTypeError("bool", t, offset)
+		}
+
+	case '"': // string
+		s := unquote(item)
+		switch ct := gno.BaseOf(t).(type) {
+		case *gno.SliceType:
+			if ct.Elt.Kind() != gno.Uint8Kind {
+				d.saveTypeError("string", t, offset)
+				break
+			}
+			b, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				d.saveError("newError", typedString(err.Error()))
+				break
+			}
+			d.set(pv, gno.TypedValue{T: t, V: d.m.Alloc.NewSliceFromData(b)})
+		case *gno.InterfaceType:
+			if len(ct.Methods) != 0 {
+				d.saveTypeError("string", t, offset)
+				break
+			}
+			d.set(pv, typedString(s))
+		default:
+			if t.Kind() != gno.StringKind {
+				d.saveTypeError("string", t, offset)
+				break
+			}
+			if isNumberType(t) && !isValidNumber(s) {
+				d.error(newError(d.m, "newError", typedString(fmt.Sprintf(
+					"json: invalid number literal, trying to unmarshal %q into Number", item))))
+			}
+			tv := gno.TypedValue{T: t}
+			tv.SetString(d.m.Alloc.NewString(s))
+			d.set(pv, tv)
+		}
+
+	default: // number
+		if c != '-' && (c < '0' || c > '9') {
+			d.error(newError(d.m, "newError", typedString(invalidStringTag(item, t))))
+		}
+		s := string(item)
+		tv := gno.TypedValue{T: t}
+		switch k := t.Kind(); k {
+		case gno.InterfaceKind:
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				d.saveTypeError("number "+s, gno.Float64Type, offset)
+				break
+			}
+			if !isEmptyInterface(t) {
+				d.saveTypeError("number", t, offset)
+				break
+			}
+			tv = gno.TypedValue{T: gno.Float64Type}
+			tv.SetFloat64(f)
+			d.set(pv, tv)
+		case gno.IntKind, gno.Int8Kind, gno.Int16Kind, gno.Int32Kind, gno.Int64Kind:
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil || overflowInt(t, n) {
+				d.saveTypeError("number "+s, t, offset)
+				break
+			}
+			setInt(&tv, n)
+			d.set(pv, tv)
+		case gno.UintKind, gno.Uint8Kind, gno.Uint16Kind, gno.Uint32Kind, gno.Uint64Kind:
+			n, err := strconv.ParseUint(s, 10, 64)
+			if err != nil || overflowUint(t, n) {
+				d.saveTypeError("number "+s, t, offset)
+				break
+			}
+			setUint(&tv, n)
+			d.set(pv, tv)
+		case gno.Float32Kind, gno.Float64Kind:
+			bits := 64
+			if k == gno.Float32Kind {
+				bits = 32
+			}
+			n, err := strconv.ParseFloat(s, bits)
+			if err != nil {
+				d.saveTypeError("number "+s, t, offset)
+				break
+			}
+			if bits == 32 {
+				tv.SetFloat32(float32(n))
+			} else {
+				tv.SetFloat64(n)
+			}
+			d.set(pv, tv)
+		default:
+			if isNumberType(t) {
+				// s must be a valid number, because it's
+				// already been tokenized.
+				tv.SetString(d.m.Alloc.NewString(s))
+				d.set(pv, tv)
+				break
+			}
+			if fromQuoted {
+				d.error(newError(d.m, "newError", typedString(invalidStringTag(item, t))))
+			}
+			d.saveTypeError("number", t, offset)
+		}
+	}
+}
+
+// arrayInterface returns the value of the array n as a []any.
+func (d *decodeState) arrayInterface(n *node) gno.TypedValue {
+	list := make([]gno.TypedValue, len(n.elems))
+	for i := range n.elems {
+		list[i] = d.valueInterface(&n.elems[i])
+	}
+	return gno.TypedValue{
+		T: &gno.SliceType{Elt: anyType},
+		V: d.m.Alloc.NewSliceFromList(list),
+	}
+}
+
+// objectInterface returns the value of the object n as a map[string]any.
+func (d *decodeState) objectInterface(n *node) gno.TypedValue {
+	mv := d.m.Alloc.NewMap(len(n.keys))
+	for i := range n.keys {
+		k := typedString(unquote(d.data[n.keys[i].start:n.keys[i].end]))
+		d.set(mv.GetPointerForKey(d.m.Alloc, d.m.Store, &k), d.valueInterface(&n.elems[i]))
+	}
+	return gno.TypedValue{
+		T: &gno.MapType{Key: gno.StringType, Value: anyType},
+		V: mv,
+	}
+}
+
+// valueInterface returns the value of n as stored in an empty interface.
+func (d *decodeState) valueInterface(n *node) gno.TypedValue {
+	consumeGas(d.m, GasPerValue)
+	switch item := d.data[n.start:n.end]; item[0] {
+	case '[':
+		return d.arrayInterface(n)
+	case '{':
+		return d.objectInterface(n)
+	case 'n': // null
+		return gno.TypedValue{}
+	case 't', 'f': // true, false
+		tv := gno.TypedValue{T: gno.BoolType}
+		tv.SetBool(item[0] == 't')
+		return tv
+	case '"': // string
+		return typedString(unquote(item))
+	default: // number
+		f, err := strconv.ParseFloat(string(item), 64)
+		if err != nil {
+			d.saveTypeError("number "+string(item), gno.Float64Type, int64(n.end))
+			return gno.TypedValue{}
+		}
+		tv := gno.TypedValue{T: gno.Float64Type}
+		tv.SetFloat64(f)
+		return tv
+	}
+}
+
+// unquote returns the string of the JSON string item.
+func unquote(item []byte) string {
+	var s string
+	if err := json.Unmarshal(item, &s); err != nil {
+		panic("json: invalid string " + string(item)) // already checked
+	}
+	return s
+}
+
+// isEmptyInterface reports whether t is an interface without methods.
+func isEmptyInterface(t gno.Type) bool {
+	it, ok := gno.BaseOf(t).(*gno.InterfaceType)
+	return ok && len(it.Methods) == 0
+}
+
+func bitSize(k gno.Kind) int {
+	switch k {
+	case gno.Int8Kind, gno.Uint8Kind:
+		return 8
+	case gno.Int16Kind, gno.Uint16Kind:
+		return 16
+	case gno.Int32Kind, gno.Uint32Kind:
+		return 32
+	case gno.IntKind, gno.UintKind:
+		return strconv.IntSize
+	default:
+		return 64
+	}
+}
+
+// overflowInt reports whether n cannot be represented by the type t.
+func overflowInt(t gno.Type, n int64) bool {
+	bits := bitSize(t.Kind())
+	trunc := (n << (64 - bits)) >> (64 - bits)
+	return n != trunc
+}
+
+// overflowUint reports whether n cannot be represented by the type t.
+func overflowUint(t gno.Type, n uint64) bool {
+	bits := bitSize(t.Kind())
+	trunc := (n << (64 - bits)) >> (64 - bits)
+	return n != trunc
+}
+
+func setInt(tv *gno.TypedValue, n int64) {
+	switch tv.T.Kind() {
+	case gno.IntKind:
+		tv.SetInt(int(n))
+	case gno.Int8Kind:
+		tv.SetInt8(int8(n))
+	case gno.Int16Kind:
+		tv.SetInt16(int16(n))
+	case gno.Int32Kind:
+		tv.SetInt32(int32(n))
+	default:
+		tv.SetInt64(n)
+	}
+}
+
+func setUint(tv *gno.TypedValue, n uint64) {
+	switch tv.T.Kind() {
+	case gno.UintKind:
+		tv.SetUint(uint(n))
+	case gno.Uint8Kind:
+		tv.SetUint8(uint8(n))
+	case gno.Uint16Kind:
+		tv.SetUint16(uint16(n))
+	case gno.Uint32Kind:
+		tv.SetUint32(uint32(n))
+	default:
+		tv.SetUint64(n)
+	}
+}
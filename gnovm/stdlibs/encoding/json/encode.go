@@ -0,0 +1,936 @@
+package json
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	libsfmt "github.com/gnolang/gno/gnovm/stdlibs/fmt"
+)
+
+const (
+	// Path of the Gno package.
+	pkgPath = "encoding/json"
+	// Package path of the predeclared types, such as error.
+	uversePkgPath = ".uverse"
+)
+
+// Gas charged by the natives, which walk the encoded and decoded values
+// without running Gno code.
+const (
+	// GasPerValue is charged for every value encoded or decoded, including
+	// the elements of composite values.
+	GasPerValue = 10
+	// GasPerByte is charged per byte of JSON read or written.
+	GasPerByte = 1
+)
+
+var (
+	byteSliceType = &gno.SliceType{Elt: gno.Uint8Type}
+	anyType       = &gno.InterfaceType{}
+	errorType     = &gno.DeclaredType{
+		PkgPath: uversePkgPath,
+		Name:    "error",
+		Base:    methodInterface("Error", nil, []gno.Type{gno.StringType}),
+	}
+)
+
+// Interfaces whose methods are called to encode and decode the values.
+var (
+	marshalerType       = methodInterface("MarshalJSON", nil, []gno.Type{byteSliceType, errorType})
+	unmarshalerType     = methodInterface("UnmarshalJSON", []gno.Type{byteSliceType}, []gno.Type{errorType})
+	textMarshalerType   = methodInterface("MarshalText", nil, []gno.Type{byteSliceType, errorType})
+	textUnmarshalerType = methodInterface("UnmarshalText", []gno.Type{byteSliceType}, []gno.Type{errorType})
+)
+
+// methodInterface returns the type of an interface with a single method.
+func methodInterface(name gno.Name, params, results []gno.Type) *gno.InterfaceType {
+	ft := &gno.FuncType{}
+	for _, t := range params {
+		ft.Params = append(ft.Params, gno.FieldType{Type: t})
+	}
+	for _, t := range results {
+		ft.Results = append(ft.Results, gno.FieldType{Type: t})
+	}
+	return &gno.InterfaceType{
+		Methods: []gno.FieldType{{Name: name, Type: ft}},
+	}
+}
+
+// jsonError is an error wrapper type for internal use only.
+// Panics with errors are wrapped in jsonError so that the top-level recover
+// can distinguish intentional panics from this package.
+type jsonError struct{ err gno.TypedValue }
+
+// methodPanic aborts the encoding or decoding when a method of a value
+// panics, so that the panic is raised again in the machine.
+type methodPanic struct{ ex gno.TypedValue }
+
+// catchError recovers the error of a jsonError panic into err, and raises the
+// panic of a methodPanic in the machine.
+func catchError(m *gno.Machine, err *gno.TypedValue) {
+	if r := recover(); r != nil {
+		switch r := r.(type) {
+		case jsonError:
+			*err = r.err
+		case methodPanic:
+			m.Panic(r.ex)
+		default:
+			panic(r)
+		}
+	}
+}
+
+func X_marshal(m *gno.Machine, v gno.TypedValue) (b []byte, err gno.TypedValue) {
+	e := &encodeState{m: m, fields: fieldCache{}}
+	defer catchError(m, &err)
+	e.value(v, nil, false)
+	e.consumeGas(0)
+	return e.buf.Bytes(), err
+}
+
+// encodeState encodes a Gno value into JSON.
+type encodeState struct {
+	m   *gno.Machine
+	buf bytes.Buffer
+
+	// pointers and maps being encoded, to detect the cycles.
+	visiting []interface{}
+	fields   fieldCache
+	// charged is the length of buf already charged for.
+	charged int
+}
+
+// error aborts the encoding with the Gno error err.
+func (e *encodeState) error(err gno.TypedValue) {
+	panic(jsonError{err})
+}
+
+// consumeGas charges the given number of values, and the bytes written since
+// the last charge.
+func (e *encodeState) consumeGas(values int64) {
+	consumeGas(e.m, values*GasPerValue+int64(e.buf.Len()-e.charged)*GasPerByte)
+	e.charged = e.buf.Len()
+}
+
+// value encodes tv. addr is the pointer to tv if it is addressable, so that
+// its pointer methods can be called. quoted is set by the "string" option of
+// the struct fields.
+func (e *encodeState) value(tv gno.TypedValue, addr *gno.PointerValue, quoted bool) {
+	e.consumeGas(1)
+	gno.FillValueTV(e.m.Store, &tv)
+	t := tv.T
+	if isNil(tv) {
+		e.buf.WriteString("null")
+		return
+	}
+	if _, ok := t.(*gno.NativeType); ok {
+		e.error(newError(e.m, "newUnsupportedTypeError", typedString(libsfmt.TypeString(t))))
+	}
+
+	isPtr := t.Kind() == gno.PointerKind
+	switch {
+	case !isPtr && addr != nil && gno.IsImplementedBy(marshalerType, &gno.PointerType{Elt: t}):
+		e.marshalJSON(gno.TypedValue{T: &gno.PointerType{Elt: t}, V: *addr})
+		return
+	case gno.IsImplementedBy(marshalerType, t):
+		if isPtr && tv.V == nil {
+			e.buf.WriteString("null")
+		} else {
+			e.marshalJSON(tv)
+		}
+		return
+	case !isPtr && addr != nil && gno.IsImplementedBy(textMarshalerType, &gno.PointerType{Elt: t}):
+		e.marshalText(gno.TypedValue{T: &gno.PointerType{Elt: t}, V: *addr})
+		return
+	case gno.IsImplementedBy(textMarshalerType, t):
+		if isPtr && tv.V == nil {
+			e.buf.WriteString("null")
+		} else {
+			e.marshalText(tv)
+		}
+		return
+	}
+
+	switch ct := gno.BaseOf(t).(type) {
+	case gno.PrimitiveType:
+		e.primitive(tv, quoted)
+	case *gno.StructType:
+		e.structValue(tv, addr)
+	case *gno.MapType:
+		e.mapValue(tv, ct)
+	case *gno.SliceType:
+		if tv.V == nil {
+			e.buf.WriteString("null")
+			return
+		}
+		// Byte slices are encoded as base64 strings, unless their elements
+		// have methods which encode them.
+		if et := ct.Elt; et.Kind() == gno.Uint8Kind &&
+			!gno.IsImplementedBy(marshalerType, &gno.PointerType{Elt: et}) &&
+			!gno.IsImplementedBy(textMarshalerType, &gno.PointerType{Elt: et}) {
+			e.buf.WriteByte('"')
+			e.buf.WriteString(base64.StdEncoding.EncodeToString(bytesOf(e.m.Store, tv)))
+			e.buf.WriteByte('"')
+			return
+		}
+		e.array(tv, ct.Elt, true)
+	case *gno.ArrayType:
+		e.array(tv, ct.Elt, addr != nil)
+	case *gno.PointerType:
+		if tv.V == nil {
+			e.buf.WriteString("null")
+			return
+		}
+		pv := tv.V.(gno.PointerValue)
+		e.enter(pointerKey(pv), t)
+		gno.FillValueTV(e.m.Store, pv.TV)
+		e.value(pv.Deref(), &pv, quoted)
+		e.leave()
+	default:
+		e.error(newError(e.m, "newUnsupportedTypeError", typedString(libsfmt.TypeString(t))))
+	}
+}
+
+// enter records that the pointer or map k of type t is being encoded, and
+// fails if it already is.
+func (e *encodeState) enter(k interface{}, t gno.Type) {
+	for _, v := range e.visiting {
+		if v == k {
+			e.error(newError(e.m, "newUnsupportedValueError",
+				typedString("encountered a cycle via "+libsfmt.TypeString(t))))
+		}
+	}
+	e.visiting = append(e.visiting, k)
+}
+
+func (e *encodeState) leave() {
+	e.visiting = e.visiting[:len(e.visiting)-1]
+}
+
+// pointerKey returns the value identifying the target of pv.
+func pointerKey(pv gno.PointerValue) interface{} {
+	if o, ok := pv.TV.V.(gno.Object); ok {
+		return o
+	}
+	return pv.TV
+}
+
+// marshalJSON encodes tv with its MarshalJSON method.
+func (e *encodeState) marshalJSON(tv gno.TypedValue) {
+	res := callMethod(e.m, tv, "MarshalJSON")
+	if !isNil(res[1]) {
+		e.error(newError(e.m, "newMarshalerError",
+			typedString(libsfmt.TypeString(tv.T)), res[1], typedString("MarshalJSON")))
+	}
+	// copy JSON into buffer, checking validity.
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, bytesOf(e.m.Store, res[0])); err != nil {
+		msg, offset := syntaxError(err)
+		e.error(newError(e.m, "newMarshalerError",
+			typedString(libsfmt.TypeString(tv.T)),
+			newError(e.m, "syntaxError", typedString(msg), typedInt64(offset)),
+			typedString("MarshalJSON")))
+	}
+	json.HTMLEscape(&e.buf, buf.Bytes())
+}
+
+// marshalText encodes tv as the JSON string returned by its MarshalText method.
+func (e *encodeState) marshalText(tv gno.TypedValue) {
+	res := callMethod(e.m, tv, "MarshalText")
+	if !isNil(res[1]) {
+		e.error(newError(e.m, "newMarshalerError",
+			typedString(libsfmt.TypeString(tv.T)), res[1], typedString("MarshalText")))
+	}
+	appendString(&e.buf, string(bytesOf(e.m.Store, res[0])), true)
+}
+
+// primitive encodes a boolean, a number or a string.
+func (e *encodeState) primitive(tv gno.TypedValue, quoted bool) {
+	var s string
+	switch k := tv.T.Kind(); k {
+	case gno.BoolKind:
+		s = strconv.FormatBool(tv.GetBool())
+	case gno.IntKind, gno.Int8Kind, gno.Int16Kind, gno.Int32Kind, gno.Int64Kind:
+		s = strconv.FormatInt(getInt(tv), 10)
+	case gno.UintKind, gno.Uint8Kind, gno.Uint16Kind, gno.Uint32Kind, gno.Uint64Kind:
+		s = strconv.FormatUint(getUint(tv), 10)
+	case gno.Float32Kind, gno.Float64Kind:
+		s = e.float(tv)
+	case gno.StringKind:
+		s = tv.GetString()
+		if isNumberType(tv.T) {
+			if s == "" {
+				s = "0" // Number's zero-val
+			}
+			if !isValidNumber(s) {
+				e.error(newError(e.m, "newError", typedString(fmt.Sprintf("json: invalid number literal %q", s))))
+			}
+			break
+		}
+		if quoted {
+			var b bytes.Buffer
+			appendString(&b, s, true)
+			appendString(&e.buf, b.String(), false)
+		} else {
+			appendString(&e.buf, s, true)
+		}
+		return
+	default:
+		e.error(newError(e.m, "newUnsupportedTypeError", typedString(libsfmt.TypeString(tv.T))))
+	}
+	if quoted {
+		e.buf.WriteByte('"')
+	}
+	e.buf.WriteString(s)
+	if quoted {
+		e.buf.WriteByte('"')
+	}
+}
+
+// float returns the JSON number of a float value.
+func (e *encodeState) float(tv gno.TypedValue) string {
+	var (
+		f    float64
+		bits = 64
+	)
+	if tv.T.Kind() == gno.Float32Kind {
+		f, bits = float64(tv.GetFloat32()), 32
+	} else {
+		f = tv.GetFloat64()
+	}
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		e.error(newError(e.m, "newUnsupportedValueError", typedString(strconv.FormatFloat(f, 'g', -1, bits))))
+	}
+
+	// Convert as if by ES6 number to string conversion.
+	// This matches most other JSON generators.
+	// See golang.org/issue/6384 and golang.org/issue/14135.
+	// Like fmt %g, but the exponent cutoffs are different
+	// and exponents themselves are not padded to two digits.
+	abs := math.Abs(f)
+	format := byte('f')
+	if abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b := strconv.AppendFloat(nil, f, format, -1, bits)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	return string(b)
+}
+
+// structValue encodes the fields of a struct as a JSON object.
+func (e *encodeState) structValue(tv gno.TypedValue, addr *gno.PointerValue) {
+	next := byte('{')
+FieldLoop:
+	for i := range e.fields.get(tv.T).list {
+		f := &e.fields.get(tv.T).list[i]
+
+		// Find the nested struct field by following f.index.
+		fv, faddr := tv, addr
+		for _, ind := range f.index {
+			if fv.T.Kind() == gno.PointerKind {
+				if fv.V == nil {
+					continue FieldLoop
+				}
+				pv := fv.V.(gno.PointerValue)
+				fv, faddr = *gno.FillValueTV(e.m.Store, pv.TV), &pv
+			}
+			sv := gno.FillValueTV(e.m.Store, &fv).V.(*gno.StructValue)
+			fpv := sv.GetPointerToInt(e.m.Store, ind)
+			fv = *fpv.TV
+			if faddr != nil {
+				faddr = &fpv
+			}
+		}
+
+		if f.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		e.buf.WriteByte(next)
+		next = ','
+		appendString(&e.buf, f.name, true)
+		e.buf.WriteByte(':')
+		e.value(fv, faddr, f.quoted)
+	}
+	if next == '{' {
+		e.buf.WriteString("{}")
+	} else {
+		e.buf.WriteByte('}')
+	}
+}
+
+// mapValue encodes a map as a JSON object, sorted by key.
+func (e *encodeState) mapValue(tv gno.TypedValue, mt *gno.MapType) {
+	switch mt.Key.Kind() {
+	case gno.StringKind,
+		gno.IntKind, gno.Int8Kind, gno.Int16Kind, gno.Int32Kind, gno.Int64Kind,
+		gno.UintKind, gno.Uint8Kind, gno.Uint16Kind, gno.Uint32Kind, gno.Uint64Kind:
+	default:
+		if !gno.IsImplementedBy(textMarshalerType, mt.Key) {
+			e.error(newError(e.m, "newUnsupportedTypeError", typedString(libsfmt.TypeString(tv.T))))
+		}
+	}
+	if tv.V == nil {
+		e.buf.WriteString("null")
+		return
+	}
+	mv := tv.V.(*gno.MapValue)
+	e.enter(mv, tv.T)
+
+	// Extract and sort the keys.
+	type kv struct {
+		ks string
+		v  gno.TypedValue
+	}
+	var items []kv
+	for item := mv.List.Head; item != nil; item = item.Next {
+		k := *gno.FillValueTV(e.m.Store, &item.Key)
+		items = append(items, kv{
+			ks: e.resolveKeyName(k, tv.T),
+			v:  *gno.FillValueTV(e.m.Store, &item.Value),
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ks < items[j].ks
+	})
+
+	e.buf.WriteByte('{')
+	for i, item := range items {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		appendString(&e.buf, item.ks, true)
+		e.buf.WriteByte(':')
+		e.value(item.v, nil, false)
+	}
+	e.buf.WriteByte('}')
+	e.leave()
+}
+
+// resolveKeyName returns the string of a key of a map of type mt.
+func (e *encodeState) resolveKeyName(k gno.TypedValue, mt gno.Type) string {
+	switch k.T.Kind() {
+	case gno.StringKind:
+		return k.GetString()
+	}
+	if gno.IsImplementedBy(textMarshalerType, k.T) {
+		if k.T.Kind() == gno.PointerKind && k.V == nil {
+			return ""
+		}
+		res := callMethod(e.m, k, "MarshalText")
+		if !isNil(res[1]) {
+			msg := callMethod(e.m, res[1], "Error")[0].GetString()
+			e.error(newError(e.m, "newError", typedString(
+				fmt.Sprintf("json: encoding error for type %q: %q", libsfmt.TypeString(mt), msg))))
+		}
+		return string(bytesOf(e.m.Store, res[0]))
+	}
+	switch k.T.Kind() {
+	case gno.IntKind, gno.Int8Kind, gno.Int16Kind, gno.Int32Kind, gno.Int64Kind:
+		return strconv.FormatInt(getInt(k), 10)
+	case gno.UintKind, gno.Uint8Kind, gno.Uint16Kind, gno.Uint32Kind, gno.Uint64Kind:
+		return strconv.FormatUint(getUint(k), 10)
+	}
+	panic("unexpected map key type")
+}
+
+// array encodes the elements of an array or a slice as a JSON array.
+func (e *encodeState) array(tv gno.TypedValue, et gno.Type, addressable bool) {
+	e.buf.WriteByte('[')
+	n := tv.GetLength()
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		pv := tv.GetPointerAtIndexInt(e.m.Store, i)
+		var addr *gno.PointerValue
+		// the bytes of the data arrays have no address.
+		if addressable && et.Kind() != gno.Uint8Kind {
+			addr = &pv
+		}
+		e.value(pv.Deref(), addr, false)
+	}
+	e.buf.WriteByte(']')
+}
+
+// isEmptyValue reports whether the value of a field with the "omitempty"
+// option is empty.
+func isEmptyValue(tv gno.TypedValue) bool {
+	if isNil(tv) {
+		return true
+	}
+	switch tv.T.Kind() {
+	case gno.ArrayKind, gno.MapKind, gno.SliceKind, gno.StringKind:
+		return tv.GetLength() == 0
+	case gno.BoolKind:
+		return !tv.GetBool()
+	case gno.IntKind, gno.Int8Kind, gno.Int16Kind, gno.Int32Kind, gno.Int64Kind:
+		return getInt(tv) == 0
+	case gno.UintKind, gno.Uint8Kind, gno.Uint16Kind, gno.Uint32Kind, gno.Uint64Kind:
+		return getUint(tv) == 0
+	case gno.Float32Kind:
+		return tv.GetFloat32() == 0
+	case gno.Float64Kind:
+		return tv.GetFloat64() == 0
+	case gno.PointerKind:
+		return tv.V == nil
+	}
+	return false
+}
+
+// appendString appends the JSON string of s to buf.
+func appendString(buf *bytes.Buffer, s string, escapeHTML bool) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(escapeHTML)
+	enc.Encode(s)
+	// remove the newline written by Encode.
+	buf.Truncate(buf.Len() - 1)
+}
+
+// isNumberType reports whether t is the Number type of the package.
+func isNumberType(t gno.Type) bool {
+	dt, ok := t.(*gno.DeclaredType)
+	return ok && dt.PkgPath == pkgPath && dt.Name == "Number"
+}
+
+// isValidNumber reports whether s is a valid JSON number literal.
+func isValidNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	_, err := json.Marshal(json.Number(s))
+	return err == nil
+}
+
+// isNil reports whether tv is a nil interface value.
+func isNil(tv gno.TypedValue) bool {
+	// a nil interface value may keep its static type.
+	return tv.T == nil || tv.T.Kind() == gno.InterfaceKind
+}
+
+func getInt(tv gno.TypedValue) int64 {
+	switch tv.T.Kind() {
+	case gno.IntKind:
+		return int64(tv.GetInt())
+	case gno.Int8Kind:
+		return int64(tv.GetInt8())
+	case gno.Int16Kind:
+		return int64(tv.GetInt16())
+	case gno.Int32Kind:
+		return int64(tv.GetInt32())
+	default:
+		return tv.GetInt64()
+	}
+}
+
+func getUint(tv gno.TypedValue) uint64 {
+	switch tv.T.Kind() {
+	case gno.UintKind:
+		return uint64(tv.GetUint())
+	case gno.Uint8Kind:
+		return uint64(tv.GetUint8())
+	case gno.Uint16Kind:
+		return uint64(tv.GetUint16())
+	case gno.Uint32Kind:
+		return uint64(tv.GetUint32())
+	default:
+		return tv.GetUint64()
+	}
+}
+
+// bytesOf returns the elements of a byte slice.
+func bytesOf(store gno.Store, tv gno.TypedValue) []byte {
+	if tv.V == nil {
+		return nil
+	}
+	b := make([]byte, tv.GetLength())
+	for i := range b {
+		ev := tv.GetPointerAtIndexInt(store, i).Deref()
+		b[i] = ev.GetUint8()
+	}
+	return b
+}
+
+func typedString(s string) gno.TypedValue {
+	tv := gno.TypedValue{T: gno.StringType}
+	tv.SetString(gno.StringValue(s))
+	return tv
+}
+
+func typedInt64(n int64) gno.TypedValue {
+	tv := gno.TypedValue{T: gno.Int64Type}
+	tv.SetInt64(n)
+	return tv
+}
+
+// consumeGas charges gas to the gas meter of the machine, if any.
+func consumeGas(m *gno.Machine, gas int64) {
+	if m.GasMeter != nil {
+		m.GasMeter.ConsumeGas(gas, "json")
+	}
+}
+
+// callMethod calls the method of tv with the given arguments, and returns its
+// results.
+func callMethod(m *gno.Machine, tv gno.TypedValue, name gno.Name, args ...gno.TypedValue) []gno.TypedValue {
+	return eval(m, gno.Call(gno.Sel(&gno.ConstExpr{TypedValue: tv}, name), constExprs(args)...))
+}
+
+// newError returns the error created by the function fn of the Gno package.
+func newError(m *gno.Machine, fn gno.Name, args ...gno.TypedValue) gno.TypedValue {
+	return eval(m, gno.Call(gno.Nx(fn), constExprs(args)...))[0]
+}
+
+// eval evaluates x in isolation from the stacks of the machine. If it panics,
+// the encoding or decoding is aborted with a methodPanic.
+func eval(m *gno.Machine, x gno.Expr) []gno.TypedValue {
+	res, exs := m.EvalIsolated(x)
+	if exs != nil {
+		panic(methodPanic{exs[len(exs)-1].Value})
+	}
+	return res
+}
+
+func constExprs(args []gno.TypedValue) []interface{} {
+	xs := make([]interface{}, len(args))
+	for i, arg := range args {
+		xs[i] = &gno.ConstExpr{TypedValue: arg}
+	}
+	return xs
+}
+
+// A field represents a single field found in a struct.
+type field struct {
+	name      string
+	tag       bool
+	index     []int
+	typ       gno.Type
+	omitEmpty bool
+	quoted    bool
+}
+
+// structFields are the fields of a struct type which are encoded and
+// decoded.
+type structFields struct {
+	list         []field
+	byExactName  map[string]*field
+	byFoldedName map[string]*field
+}
+
+// fieldCache caches the fields of the struct types during an encoding or a
+// decoding.
+type fieldCache map[gno.TypeID]*structFields
+
+func (c fieldCache) get(t gno.Type) *structFields {
+	id := t.TypeID()
+	if f, ok := c[id]; ok {
+		return f
+	}
+	f := typeFields(t)
+	c[id] = f
+	return f
+}
+
+// typeFields returns a list of fields that JSON should recognize for the given type.
+// The algorithm is breadth-first search over the set of structs to include - the top struct
+// and then any reachable anonymous structs.
+func typeFields(t gno.Type) *structFields {
+	// Anonymous fields to explore at the current level and the next.
+	current := []field{}
+	next := []field{{typ: t}}
+
+	// Count of queued names for current level and the next.
+	var count, nextCount map[gno.TypeID]int
+
+	// Types already visited at an earlier level.
+	visited := map[gno.TypeID]bool{}
+
+	// Fields found.
+	var fields []field
+
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[gno.TypeID]int{}
+
+		for _, f := range current {
+			if visited[f.typ.TypeID()] {
+				continue
+			}
+			visited[f.typ.TypeID()] = true
+
+			// Scan f.typ for fields to include.
+			for i, sf := range gno.BaseOf(f.typ).(*gno.StructType).Fields {
+				if sf.Embedded {
+					t := sf.Type
+					if t.Kind() == gno.PointerKind {
+						t = t.Elem()
+					}
+					if !isExported(sf.Name) && t.Kind() != gno.StructKind {
+						// Ignore embedded fields of unexported non-struct types.
+						continue
+					}
+					// Do not ignore embedded fields of unexported struct types
+					// since they may have exported fields.
+				} else if !isExported(sf.Name) {
+					// Ignore unexported non-embedded fields.
+					continue
+				}
+				tag := reflect.StructTag(sf.Tag).Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts := parseTag(tag)
+				if !isValidTag(name) {
+					name = ""
+				}
+				index := make([]int, len(f.index)+1)
+				copy(index, f.index)
+				index[len(f.index)] = i
+
+				ft := sf.Type
+				if pt, ok := ft.(*gno.PointerType); ok {
+					// Follow pointer.
+					ft = pt.Elt
+				}
+
+				// Only strings, floats, integers, and booleans can be quoted.
+				quoted := false
+				if opts.Contains("string") {
+					switch ft.Kind() {
+					case gno.BoolKind,
+						gno.IntKind, gno.Int8Kind, gno.Int16Kind, gno.Int32Kind, gno.Int64Kind,
+						gno.UintKind, gno.Uint8Kind, gno.Uint16Kind, gno.Uint32Kind, gno.Uint64Kind,
+						gno.Float32Kind, gno.Float64Kind,
+						gno.StringKind:
+						quoted = true
+					}
+				}
+
+				// Record found field and index sequence.
+				if name != "" || !sf.Embedded || ft.Kind() != gno.StructKind {
+					tagged := name != ""
+					if name == "" {
+						name = string(sf.Name)
+					}
+					field := field{
+						name:      name,
+						tag:       tagged,
+						index:     index,
+						typ:       ft,
+						omitEmpty: opts.Contains("omitempty"),
+						quoted:    quoted,
+					}
+
+					fields = append(fields, field)
+					if count[f.typ.TypeID()] > 1 {
+						// If there were multiple instances, add a second,
+						// so that the annihilation code will see a duplicate.
+						// It only cares about the distinction between 1 and 2,
+						// so don't bother generating any more copies.
+						fields = append(fields, fields[len(fields)-1])
+					}
+					continue
+				}
+
+				// Record new anonymous struct to explore in next round.
+				nextCount[ft.TypeID()]++
+				if nextCount[ft.TypeID()] == 1 {
+					next = append(next, field{name: string(sf.Name), index: index, typ: ft})
+				}
+			}
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		x := fields
+		// sort field by name, breaking ties with depth, then
+		// breaking ties with "name came from json tag", then
+		// breaking ties with index sequence.
+		if x[i].name != x[j].name {
+			return x[i].name < x[j].name
+		}
+		if len(x[i].index) != len(x[j].index) {
+			return len(x[i].index) < len(x[j].index)
+		}
+		if x[i].tag != x[j].tag {
+			return x[i].tag
+		}
+		return compareIndex(x[i].index, x[j].index) < 0
+	})
+
+	// Delete all fields that are hidden by the Go rules for embedded fields,
+	// except that fields with JSON tags are promoted.
+
+	// The fields are sorted in primary order of name, secondary order
+	// of field index length. Loop over names; for each name, delete
+	// hidden fields by choosing the one dominant field that survives.
+	out := fields[:0]
+	for advance, i := 0, 0; i < len(fields); i += advance {
+		// One iteration per name.
+		// Find the sequence of fields with the name of this first field.
+		fi := fields[i]
+		name := fi.name
+		for advance = 1; i+advance < len(fields); advance++ {
+			fj := fields[i+advance]
+			if fj.name != name {
+				break
+			}
+		}
+		if advance == 1 { // Only one field with this name
+			out = append(out, fi)
+			continue
+		}
+		dominant, ok := dominantField(fields[i : i+advance])
+		if ok {
+			out = append(out, dominant)
+		}
+	}
+
+	fields = out
+	sort.Slice(fields, func(i, j int) bool {
+		return compareIndex(fields[i].index, fields[j].index) < 0
+	})
+
+	exactNameIndex := make(map[string]*field, len(fields))
+	foldedNameIndex := make(map[string]*field, len(fields))
+	for i, field := range fields {
+		exactNameIndex[field.name] = &fields[i]
+		// For historical reasons, first folded match takes precedence.
+		if _, ok := foldedNameIndex[foldName(field.name)]; !ok {
+			foldedNameIndex[foldName(field.name)] = &fields[i]
+		}
+	}
+	return &structFields{fields, exactNameIndex, foldedNameIndex}
+}
+
+// dominantField looks through the fields, all of which are known to
+// have the same name, to find the single field that dominates the
+// others using Go's embedding rules, modified by the presence of
+// JSON tags. If there are multiple top-level fields, the boolean
+// will be false: This condition is an error in Go and we skip all
+// the fields.
+func dominantField(fields []field) (field, bool) {
+	// The fields are sorted in increasing index-length order, then by presence of tag.
+	// That means that the first field is the dominant one. We need only check
+	// for error cases: two fields at top level, either both tagged or neither tagged.
+	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].tag == fields[1].tag {
+		return field{}, false
+	}
+	return fields[0], true
+}
+
+func compareIndex(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return len(a) - len(b)
+}
+
+func isExported(name gno.Name) bool {
+	r, _ := utf8.DecodeRuneInString(string(name))
+	return unicode.IsUpper(r)
+}
+
+func isValidTag(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+			// Backslash and quote chars are reserved, but
+			// otherwise any punctuation chars are allowed
+			// in a tag name.
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
+
+// tagOptions is the string following a comma in a struct field's "json"
+// tag, or the empty string. It does not include the leading comma.
+type tagOptions string
+
+// parseTag splits a struct field's json tag into its name and
+// comma-separated options.
+func parseTag(tag string) (string, tagOptions) {
+	if idx := strings.Index(tag, ","); idx != -1 {
+		return tag[:idx], tagOptions(tag[idx+1:])
+	}
+	return tag, tagOptions("")
+}
+
+// Contains reports whether a comma-separated list of options
+// contains a particular substr flag. substr must be surrounded by a
+// string boundary or commas.
+func (o tagOptions) Contains(optionName string) bool {
+	if len(o) == 0 {
+		return false
+	}
+	s := string(o)
+	for s != "" {
+		var next string
+		i := strings.Index(s, ",")
+		if i >= 0 {
+			s, next = s[:i], s[i+1:]
+		}
+		if s == optionName {
+			return true
+		}
+		s = next
+	}
+	return false
+}
+
+// foldName returns a folded string such that foldName(x) == foldName(y)
+// is identical to bytes.EqualFold(x, y).
+func foldName(in string) string {
+	var out []byte
+	for i := 0; i < len(in); {
+		// Handle single-byte ASCII.
+		if c := in[i]; c < utf8.RuneSelf {
+			if 'a' <= c && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			out = append(out, c)
+			i++
+			continue
+		}
+		// Handle multi-byte Unicode.
+		r, n := utf8.DecodeRuneInString(in[i:])
+		out = utf8.AppendRune(out, foldRune(r))
+		i += n
+	}
+	return string(out)
+}
+
+func foldRune(r rune) rune {
+	for {
+		r2 := unicode.SimpleFold(r)
+		if r2 <= r {
+			return r2
+		}
+		r = r2
+	}
+}
@@ -0,0 +1,76 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+func X_checkValid(m *gno.Machine, data []byte) (string, int64) {
+	consumeGas(m, int64(len(data))*GasPerByte)
+	var raw json.RawMessage
+	return syntaxError(json.Unmarshal(data, &raw))
+}
+
+func X_compact(m *gno.Machine, src []byte) ([]byte, string, int64) {
+	consumeGas(m, int64(len(src))*GasPerByte)
+	var buf bytes.Buffer
+	msg, offset := syntaxError(json.Compact(&buf, src))
+	return buf.Bytes(), msg, offset
+}
+
+func X_indentJSON(m *gno.Machine, src []byte, prefix, indent string) ([]byte, string, int64) {
+	consumeGas(m, indentedSize(src, prefix, indent)*GasPerByte)
+	var buf bytes.Buffer
+	msg, offset := syntaxError(json.Indent(&buf, src, prefix, indent))
+	return buf.Bytes(), msg, offset
+}
+
+// indentedSize returns an upper bound of the size of src once indented, so
+// that its gas is charged before it is written: the brackets and the commas
+// of src may start a new line, with the prefix and an indent per level of
+// nesting, and the colons are followed by a space.
+func indentedSize(src []byte, prefix, indent string) int64 {
+	size := int64(len(src))
+	line := func(depth int) int64 {
+		return int64(1 + len(prefix) + depth*len(indent))
+	}
+	depth, inString, escaped := 0, false, false
+	for _, c := range src {
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			escaped = c == '\\'
+			inString = c != '"'
+		case c == '"':
+			inString = true
+		case c == '[' || c == '{':
+			depth++
+			size += line(depth)
+		case c == ']' || c == '}':
+			depth--
+			size += line(max(depth, 0))
+		case c == ',':
+			size += line(depth)
+		case c == ':':
+			size++ // the space after the colon
+		}
+	}
+	return size
+}
+
+// syntaxError returns the message and the offset of a syntax error, which
+// are turned into a SyntaxError by the Gno package.
+func syntaxError(err error) (string, int64) {
+	if err == nil {
+		return "", 0
+	}
+	var serr *json.SyntaxError
+	if errors.As(err, &serr) {
+		return serr.Error(), serr.Offset
+	}
+	return err.Error(), 0
+}
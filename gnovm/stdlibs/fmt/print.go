@@ -0,0 +1,1292 @@
+package fmt
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// Strings for use with buf.WriteString.
+const (
+	commaSpaceString  = ", "
+	nilAngleString    = "<nil>"
+	nilParenString    = "(nil)"
+	nilString         = "nil"
+	cycleString       = "<cycle>"
+	percentBangString = "%!"
+	missingString     = "(MISSING)"
+	badIndexString    = "(BADINDEX)"
+	extraString       = "%!(EXTRA "
+	badWidthString    = "%!(BADWIDTH)"
+	badPrecString     = "%!(BADPREC)"
+	noVerbString      = "%!(NOVERB)"
+	panicString       = "(PANIC="
+)
+
+// Package path of the predeclared types, such as error.
+const uversePkgPath = ".uverse"
+
+// Gas charged by the natives of the printer, which walk the printed values
+// without running Gno code.
+const (
+	// GasPerValue is charged for every value printed, including the
+	// elements of composite values.
+	GasPerValue = 10
+	// GasPerByte is charged per byte formatted from a value, and per byte
+	// of the width and precision of its verb.
+	GasPerByte = 1
+)
+
+// Interfaces whose methods are called to print the operands.
+var (
+	errorType      = methodInterface("Error")
+	stringerType   = methodInterface("String")
+	goStringerType = methodInterface("GoString")
+)
+
+// formatterType is the Formatter interface. Only the TypeID of the State
+// type matters, to check the signature of the Format methods.
+var formatterType = &gno.InterfaceType{
+	Methods: []gno.FieldType{
+		{
+			Name: "Format",
+			Type: &gno.FuncType{
+				Params: []gno.FieldType{
+					{Type: &gno.DeclaredType{PkgPath: "fmt", Name: "State", Base: &gno.InterfaceType{PkgPath: "fmt"}}},
+					{Type: gno.Int32Type},
+				},
+			},
+		},
+	},
+}
+
+// methodInterface returns the type of interface { <name>() string }.
+func methodInterface(name gno.Name) *gno.InterfaceType {
+	return &gno.InterfaceType{
+		Methods: []gno.FieldType{
+			{
+				Name: name,
+				Type: &gno.FuncType{
+					Results: []gno.FieldType{{Type: gno.StringType}},
+				},
+			},
+		},
+	}
+}
+
+func X_sprint(m *gno.Machine, a gno.TypedValue) string {
+	p := &printer{m: m}
+	defer p.catchRepanic()
+	p.doPrint(p.operands(a))
+	return p.buf.String()
+}
+
+func X_sprintln(m *gno.Machine, a gno.TypedValue) string {
+	p := &printer{m: m}
+	defer p.catchRepanic()
+	p.doPrintln(p.operands(a))
+	return p.buf.String()
+}
+
+func X_sprintf(m *gno.Machine, format string, a gno.TypedValue, wrapErrs bool) (string, []int) {
+	p := &printer{m: m, wrapErrs: wrapErrs}
+	defer p.catchRepanic()
+	p.doPrintf(format, p.operands(a))
+	if p.reordered {
+		sort.Ints(p.wrappedErrs)
+	}
+	// remove the duplicated operands.
+	wrapped := []int{}
+	for i, argNum := range p.wrappedErrs {
+		if i > 0 && p.wrappedErrs[i-1] == argNum {
+			continue
+		}
+		wrapped = append(wrapped, argNum)
+	}
+	return p.buf.String(), wrapped
+}
+
+func X_output(m *gno.Machine, s string) int {
+	n, _ := m.Output.Write([]byte(s))
+	return n
+}
+
+// flags of the verb being printed.
+type flags struct {
+	widPresent  bool
+	precPresent bool
+	minus       bool
+	plus        bool
+	sharp       bool
+	space       bool
+	zero        bool
+
+	// For the formats %+v %#v, we set the plusV/sharpV flags
+	// and clear the plus/sharp flags since %+v and %#v are in effect
+	// different, flagless formats set at the top level.
+	plusV  bool
+	sharpV bool
+
+	wid  int // width
+	prec int // precision
+}
+
+// printer formats the operands of a Gno print function, which are walked
+// as typed values. The Error, String and GoString methods are called with
+// the machine. The primitive values are formatted with Go's fmt, so that the
+// flags of the verbs behave the same.
+type printer struct {
+	m   *gno.Machine
+	buf strings.Builder
+	flags
+
+	// reordered records whether the format string used argument reordering.
+	reordered bool
+	// goodArgNum records whether the most recent reordering directive was valid.
+	goodArgNum bool
+	// erroring is set when printing an error string to guard against calling handleMethods.
+	erroring bool
+	// panicking is set when printing the value of a panic of a method, which
+	// is raised again if the method of the value also panics.
+	panicking bool
+	// wrapErrs is set when the format string may contain a %w verb.
+	wrapErrs bool
+	// wrappedErrs records the targets of the %w verb.
+	wrappedErrs []int
+	// value is the value being printed.
+	value gno.TypedValue
+	// pointers being printed, to break the cycles.
+	pointers []*gno.TypedValue
+}
+
+// operands returns the elements of the []any slice a.
+func (p *printer) operands(a gno.TypedValue) []gno.TypedValue {
+	args := make([]gno.TypedValue, a.GetLength())
+	for i := range args {
+		args[i] = p.index(a, i)
+	}
+	return args
+}
+
+// spec returns the Go format specifier of the current flags and verb.
+func (p *printer) spec(verb rune) string {
+	var sb strings.Builder
+	sb.WriteByte('%')
+	if p.sharp || p.sharpV {
+		sb.WriteByte('#')
+	}
+	if p.zero {
+		sb.WriteByte('0')
+	}
+	if p.plus || p.plusV {
+		sb.WriteByte('+')
+	}
+	if p.minus {
+		sb.WriteByte('-')
+	}
+	if p.space {
+		sb.WriteByte(' ')
+	}
+	if p.widPresent {
+		sb.WriteString(strconv.Itoa(p.wid))
+	}
+	if p.precPresent {
+		sb.WriteByte('.')
+		sb.WriteString(strconv.Itoa(p.prec))
+	}
+	sb.WriteRune(verb)
+	return sb.String()
+}
+
+// fmtGo formats a Go value with the current flags.
+func (p *printer) fmtGo(v interface{}, verb rune) {
+	// the width and precision are charged before they are formatted.
+	var pad int
+	if p.widPresent {
+		pad += p.wid
+	}
+	if p.precPresent {
+		pad += p.prec
+	}
+	p.consumeGas(int64(pad) * GasPerByte)
+	n := p.buf.Len()
+	fmt.Fprintf(&p.buf, p.spec(verb), v)
+	p.consumeGas(int64(p.buf.Len()-n) * GasPerByte)
+}
+
+// consumeGas charges gas to the gas meter of the machine, if any.
+func (p *printer) consumeGas(gas int64) {
+	if p.m.GasMeter != nil {
+		p.m.GasMeter.ConsumeGas(gas, "fmt")
+	}
+}
+
+// fmtS formats a string with the width and precision of the current flags.
+func (p *printer) fmtS(s string) {
+	f := p.flags
+	p.flags = flags{
+		widPresent:  f.widPresent,
+		wid:         f.wid,
+		precPresent: f.precPresent,
+		prec:        f.prec,
+		minus:       f.minus,
+	}
+	p.fmtGo(s, 's')
+	p.flags = f
+}
+
+func (p *printer) fmtBool(v bool, verb rune) {
+	switch verb {
+	case 't', 'v':
+		p.fmtGo(v, verb)
+	default:
+		p.badVerb(verb)
+	}
+}
+
+func (p *printer) fmtInteger(v interface{}, verb rune) {
+	switch verb {
+	case 'v', 'd', 'b', 'o', 'O', 'x', 'X', 'c', 'q', 'U':
+		p.fmtGo(v, verb)
+	default:
+		p.badVerb(verb)
+	}
+}
+
+func (p *printer) fmtFloat(v interface{}, verb rune) {
+	switch verb {
+	case 'v', 'b', 'g', 'G', 'x', 'X', 'f', 'F', 'e', 'E':
+		p.fmtGo(v, verb)
+	default:
+		p.badVerb(verb)
+	}
+}
+
+func (p *printer) fmtString(v string, verb rune) {
+	switch verb {
+	case 'v', 's', 'x', 'X', 'q':
+		p.fmtGo(v, verb)
+	default:
+		p.badVerb(verb)
+	}
+}
+
+// badVerb prints an error for a verb which isn't valid for the value being
+// printed.
+func (p *printer) badVerb(verb rune) {
+	p.erroring = true
+	p.buf.WriteString(percentBangString)
+	p.buf.WriteRune(verb)
+	p.buf.WriteByte('(')
+	if tv := p.value; tv.T == nil {
+		p.buf.WriteString(nilAngleString)
+	} else {
+		p.buf.WriteString(TypeString(tv.T))
+		p.buf.WriteByte('=')
+		p.printValue(tv, 'v', 0, false)
+		p.value = tv
+	}
+	p.buf.WriteByte(')')
+	p.erroring = false
+}
+
+// printArg prints an operand of a print function.
+func (p *printer) printArg(arg gno.TypedValue, verb rune) {
+	p.consumeGas(GasPerValue)
+	p.value = arg
+
+	if arg.T == nil {
+		switch verb {
+		case 'T', 'v':
+			p.fmtS(nilAngleString)
+		default:
+			p.badVerb(verb)
+		}
+		return
+	}
+
+	// Special processing considerations.
+	// %T (the value's type) and %p (its address) are special; we always do them first.
+	switch verb {
+	case 'T':
+		p.fmtS(TypeString(arg.T))
+		return
+	case 'p':
+		// addresses are not available in Gno.
+		p.badVerb(verb)
+		return
+	}
+
+	// Unnamed byte slices are formatted as in Go.
+	if st, ok := arg.T.(*gno.SliceType); ok && st.Elt.Kind() == gno.Uint8Kind {
+		p.fmtGo(p.bytes(arg), verb)
+		return
+	}
+
+	if _, ok := arg.T.(gno.PrimitiveType); ok || !p.handleMethods(arg, verb) {
+		p.printValue(arg, verb, 0, true)
+	}
+}
+
+// handleMethods prints tv with its Error, String or GoString method, and
+// returns whether it did.
+func (p *printer) handleMethods(tv gno.TypedValue, verb rune) bool {
+	if p.erroring {
+		return false
+	}
+	if verb == 'w' {
+		// It is invalid to use %w other than with Errorf or with a non-error arg.
+		if !p.wrapErrs || !gno.IsImplementedBy(errorType, tv.T) {
+			p.badVerb(verb)
+			return true
+		}
+		verb = 'v'
+	}
+
+	// Is it a Formatter?
+	if gno.IsImplementedBy(formatterType, tv.T) {
+		if s, ok := p.callFormat(tv, verb); ok {
+			p.buf.WriteString(s)
+		}
+		return true
+	}
+
+	// Is it a Go syntax value?
+	if p.sharpV {
+		if gno.IsImplementedBy(goStringerType, tv.T) {
+			if s, ok := p.callMethod(tv, "GoString", verb); ok {
+				p.fmtS(s)
+			}
+			return true
+		}
+		return false
+	}
+
+	// If a string is acceptable according to the format, see if
+	// the value satisfies one of the string-valued interfaces.
+	switch verb {
+	case 'v', 's', 'x', 'X', 'q':
+		switch {
+		case gno.IsImplementedBy(errorType, tv.T):
+			if s, ok := p.callMethod(tv, "Error", verb); ok {
+				p.fmtString(s, verb)
+			}
+			return true
+		case gno.IsImplementedBy(stringerType, tv.T):
+			if s, ok := p.callMethod(tv, "String", verb); ok {
+				p.fmtString(s, verb)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// callMethod calls the method of tv which returns a string. If the method
+// panics, the panic is printed instead and ok is false.
+func (p *printer) callMethod(tv gno.TypedValue, name gno.Name, verb rune) (s string, ok bool) {
+	res, ok := p.call(gno.Call(gno.Sel(&gno.ConstExpr{TypedValue: tv}, name)), tv, name, verb)
+	if !ok {
+		return "", false
+	}
+	return res[0].GetString(), true
+}
+
+// callFormat calls the Format method of tv, through the formatOperand function of
+// the Gno package which provides the State, and returns its output. If the
+// method panics, the panic is printed instead and ok is false.
+func (p *printer) callFormat(tv gno.TypedValue, verb rune) (s string, ok bool) {
+	var flags strings.Builder
+	for _, f := range []struct {
+		c   byte
+		set bool
+	}{
+		{'-', p.minus},
+		{'+', p.plus || p.plusV},
+		{'#', p.sharp || p.sharpV},
+		{' ', p.space},
+		{'0', p.zero},
+	} {
+		if f.set {
+			flags.WriteByte(f.c)
+		}
+	}
+
+	verbTV := gno.TypedValue{T: gno.Int32Type}
+	verbTV.SetInt32(verb)
+	flagsTV := gno.TypedValue{T: gno.StringType}
+	flagsTV.SetString(gno.StringValue(flags.String()))
+	widTV := gno.TypedValue{T: gno.IntType}
+	widTV.SetInt(p.wid)
+	precTV := gno.TypedValue{T: gno.IntType}
+	precTV.SetInt(p.prec)
+	widPresentTV := gno.TypedValue{T: gno.BoolType}
+	widPresentTV.SetBool(p.widPresent)
+	precPresentTV := gno.TypedValue{T: gno.BoolType}
+	precPresentTV.SetBool(p.precPresent)
+
+	res, ok := p.call(gno.Call(gno.Nx("formatOperand"),
+		&gno.ConstExpr{TypedValue: tv},
+		&gno.ConstExpr{TypedValue: verbTV},
+		&gno.ConstExpr{TypedValue: flagsTV},
+		&gno.ConstExpr{TypedValue: widTV},
+		&gno.ConstExpr{TypedValue: precTV},
+		&gno.ConstExpr{TypedValue: widPresentTV},
+		&gno.ConstExpr{TypedValue: precPresentTV},
+	), tv, "Format", verb)
+	if !ok {
+		return "", false
+	}
+	return res[0].GetString(), true
+}
+
+// hasPointerReceiver returns whether the method of the pointer type t with
+// the given name is declared with a pointer receiver, and can then be called
+// on a nil pointer.
+func (p *printer) hasPointerReceiver(t gno.Type, name gno.Name) bool {
+	dt, ok := gno.BaseOf(t).(*gno.PointerType).Elt.(*gno.DeclaredType)
+	if !ok {
+		return false
+	}
+	for _, mv := range dt.Methods {
+		if fv := mv.V.(*gno.FuncValue); fv.Name == name {
+			return fv.GetType(p.m.Store).HasPointerReceiver()
+		}
+	}
+	return false
+}
+
+// repanic is panicked by the printer to abort printing, when the panic of a
+// method must be raised again in the machine.
+type repanic struct {
+	ex gno.TypedValue
+}
+
+// catchRepanic is deferred by the natives of the printer, to raise the
+// panics of the methods which Go's fmt doesn't recover from.
+func (p *printer) catchRepanic() {
+	if r := recover(); r != nil {
+		rp, ok := r.(repanic)
+		if !ok {
+			panic(r)
+		}
+		p.m.Panic(rp.ex)
+	}
+}
+
+// call evaluates x, a call of the method of tv with the given name, in
+// isolation from the stacks of the machine. If it panics, the panic is printed
+// as Go's fmt does, and ok is false.
+func (p *printer) call(x gno.Expr, tv gno.TypedValue, name gno.Name, verb rune) (res []gno.TypedValue, ok bool) {
+	isNilPointer := tv.T.Kind() == gno.PointerKind && tv.V == nil
+	var exs []gno.Exception
+	// The method of a nil pointer for a value receiver would panic, but
+	// dereferencing it can't be recovered from in the VM, so it isn't called.
+	if !isNilPointer || p.hasPointerReceiver(tv.T, name) {
+		res, exs = p.m.EvalIsolated(x)
+		if exs == nil {
+			return res, true
+		}
+	}
+
+	// If it's a nil pointer, just say "<nil>". The likeliest causes are a
+	// Stringer that fails to guard against nil or a nil pointer for a
+	// value receiver, and in either case, "<nil>" is a nice result.
+	if isNilPointer {
+		p.fmtS(nilAngleString)
+		return nil, false
+	}
+	ex := exs[len(exs)-1].Value
+	// Nested panics; the recursion in printArg cannot succeed.
+	if p.panicking {
+		panic(repanic{ex})
+	}
+
+	oldFlags := p.flags
+	// For this output we want default behavior.
+	p.flags = flags{}
+
+	p.buf.WriteString(percentBangString)
+	p.buf.WriteRune(verb)
+	p.buf.WriteString(panicString)
+	p.buf.WriteString(string(name))
+	p.buf.WriteString(" method: ")
+	p.panicking = true
+	p.printArg(ex, 'v')
+	p.panicking = false
+	p.buf.WriteByte(')')
+
+	p.flags = oldFlags
+	return nil, false
+}
+
+// printValue prints the value of tv, by walking it if it is a composite
+// value. canInterface is false for the values of unexported fields, whose
+// methods are not called.
+func (p *printer) printValue(tv gno.TypedValue, verb rune, depth int, canInterface bool) {
+	// Handle values with special methods if not already handled by printArg (depth == 0).
+	if depth > 0 {
+		p.consumeGas(GasPerValue)
+		if tv.T != nil && canInterface && p.handleMethods(tv, verb) {
+			return
+		}
+	}
+	p.value = tv
+
+	if tv.T == nil {
+		// nil interface in a composite value.
+		if verb == 'v' {
+			p.fmtS(nilAngleString)
+		} else {
+			p.badVerb(verb)
+		}
+		return
+	}
+
+	switch bt := gno.BaseOf(tv.T).(type) {
+	case gno.PrimitiveType:
+		p.printPrimitive(tv, bt, verb)
+	case *gno.StructType:
+		if p.sharpV {
+			p.buf.WriteString(TypeString(tv.T))
+		}
+		p.buf.WriteByte('{')
+		sv, _ := tv.V.(*gno.StructValue)
+		for i, f := range bt.Fields {
+			if i > 0 {
+				if p.sharpV {
+					p.buf.WriteString(commaSpaceString)
+				} else {
+					p.buf.WriteByte(' ')
+				}
+			}
+			if p.plusV || p.sharpV {
+				p.buf.WriteString(string(f.Name))
+				p.buf.WriteByte(':')
+			}
+			var field gno.TypedValue
+			if sv != nil {
+				field = sv.GetPointerToInt(p.m.Store, i).Deref()
+			}
+			p.printElem(field, f.Type, verb, depth+1, canInterface && isExported(f.Name))
+		}
+		p.buf.WriteByte('}')
+	case *gno.MapType:
+		if p.sharpV {
+			p.buf.WriteString(TypeString(tv.T))
+			if tv.V == nil {
+				p.buf.WriteString(nilParenString)
+				return
+			}
+			p.buf.WriteByte('{')
+		} else {
+			p.buf.WriteString("map[")
+		}
+		for i, item := range p.mapItems(tv) {
+			if i > 0 {
+				if p.sharpV {
+					p.buf.WriteString(commaSpaceString)
+				} else {
+					p.buf.WriteByte(' ')
+				}
+			}
+			p.printElem(item.Key, bt.Key, verb, depth+1, canInterface)
+			p.buf.WriteByte(':')
+			p.printElem(item.Value, bt.Value, verb, depth+1, canInterface)
+		}
+		if p.sharpV {
+			p.buf.WriteByte('}')
+		} else {
+			p.buf.WriteByte(']')
+		}
+	case *gno.ArrayType:
+		p.printList(tv, bt.Elt, verb, depth, canInterface)
+	case *gno.SliceType:
+		p.printList(tv, bt.Elt, verb, depth, canInterface)
+	case *gno.PointerType:
+		if tv.V == nil {
+			p.printNilPointer(tv, verb)
+			return
+		}
+		// pointer to array or slice or struct? ok at top level
+		// but not embedded (avoid loops)
+		ptv := tv.V.(gno.PointerValue).TV
+		for _, seen := range p.pointers {
+			if seen == ptv {
+				p.buf.WriteByte('&')
+				p.buf.WriteString(cycleString)
+				return
+			}
+		}
+		p.pointers = append(p.pointers, ptv)
+		p.buf.WriteByte('&')
+		p.printValue(*gno.FillValueTV(p.m.Store, ptv), verb, depth+1, canInterface)
+		p.pointers = p.pointers[:len(p.pointers)-1]
+	case *gno.FuncType:
+		if tv.V == nil {
+			p.printNilPointer(tv, verb)
+			return
+		}
+		if verb != 'v' {
+			p.badVerb(verb)
+			return
+		}
+		p.fmtS(TypeString(tv.T))
+	case *gno.NativeType:
+		nv, _ := tv.V.(*gno.NativeValue)
+		if nv == nil {
+			p.fmtS(nilAngleString)
+			return
+		}
+		p.fmtGo(nv.Value.Interface(), verb)
+	default:
+		// types and packages.
+		p.fmtS(tv.String())
+	}
+}
+
+// printElem prints an element of a composite value, of the static type typ.
+func (p *printer) printElem(tv gno.TypedValue, typ gno.Type, verb rune, depth int, canInterface bool) {
+	if tv.T == nil && p.sharpV {
+		// nil interface.
+		p.buf.WriteString(TypeString(typ))
+		p.buf.WriteString(nilParenString)
+		return
+	}
+	p.printValue(tv, verb, depth, canInterface)
+}
+
+func (p *printer) printNilPointer(tv gno.TypedValue, verb rune) {
+	switch verb {
+	case 'v':
+		if p.sharpV {
+			p.buf.WriteByte('(')
+			p.buf.WriteString(TypeString(tv.T))
+			p.buf.WriteString(")(")
+			p.buf.WriteString(nilString)
+			p.buf.WriteByte(')')
+		} else {
+			p.fmtS(nilAngleString)
+		}
+	default:
+		p.badVerb(verb)
+	}
+}
+
+func (p *printer) printPrimitive(tv gno.TypedValue, bt gno.PrimitiveType, verb rune) {
+	switch bt {
+	case gno.BoolType, gno.UntypedBoolType:
+		p.fmtBool(tv.GetBool(), verb)
+	case gno.StringType, gno.UntypedStringType:
+		p.fmtString(tv.GetString(), verb)
+	case gno.IntType:
+		p.fmtInteger(tv.GetInt(), verb)
+	case gno.Int8Type:
+		p.fmtInteger(tv.GetInt8(), verb)
+	case gno.Int16Type:
+		p.fmtInteger(tv.GetInt16(), verb)
+	case gno.Int32Type, gno.UntypedRuneType:
+		p.fmtInteger(tv.GetInt32(), verb)
+	case gno.Int64Type:
+		p.fmtInteger(tv.GetInt64(), verb)
+	case gno.UintType:
+		p.fmtInteger(tv.GetUint(), verb)
+	case gno.Uint8Type:
+		p.fmtInteger(tv.GetUint8(), verb)
+	case gno.Uint16Type:
+		p.fmtInteger(tv.GetUint16(), verb)
+	case gno.Uint32Type:
+		p.fmtInteger(tv.GetUint32(), verb)
+	case gno.Uint64Type:
+		p.fmtInteger(tv.GetUint64(), verb)
+	case gno.Float32Type:
+		p.fmtFloat(tv.GetFloat32(), verb)
+	case gno.Float64Type:
+		p.fmtFloat(tv.GetFloat64(), verb)
+	default:
+		// untyped big numbers.
+		p.fmtS(tv.String())
+	}
+}
+
+// printList prints an array or a slice.
+func (p *printer) printList(tv gno.TypedValue, elt gno.Type, verb rune, depth int, canInterface bool) {
+	if elt.Kind() == gno.Uint8Kind {
+		switch verb {
+		case 's', 'q', 'x', 'X':
+			p.fmtGo(p.bytes(tv), verb)
+			return
+		}
+	}
+	_, isSlice := gno.BaseOf(tv.T).(*gno.SliceType)
+	if p.sharpV {
+		p.buf.WriteString(TypeString(tv.T))
+		if isSlice && tv.V == nil {
+			p.buf.WriteString(nilParenString)
+			return
+		}
+		p.buf.WriteByte('{')
+		for i := 0; i < tv.GetLength(); i++ {
+			if i > 0 {
+				p.buf.WriteString(commaSpaceString)
+			}
+			p.printElem(p.index(tv, i), elt, verb, depth+1, canInterface)
+		}
+		p.buf.WriteByte('}')
+		return
+	}
+	p.buf.WriteByte('[')
+	for i := 0; i < tv.GetLength(); i++ {
+		if i > 0 {
+			p.buf.WriteByte(' ')
+		}
+		p.printElem(p.index(tv, i), elt, verb, depth+1, canInterface)
+	}
+	p.buf.WriteByte(']')
+}
+
+// index returns the element i of an array or a slice.
+func (p *printer) index(tv gno.TypedValue, i int) gno.TypedValue {
+	ev := tv.GetPointerAtIndexInt(p.m.Store, i).Deref()
+	if ev.T != nil && ev.T.Kind() == gno.InterfaceKind {
+		// a nil interface value may keep its static type.
+		return gno.TypedValue{}
+	}
+	return ev
+}
+
+// bytes returns the elements of an array or a slice of bytes.
+func (p *printer) bytes(tv gno.TypedValue) []byte {
+	if tv.V == nil {
+		if _, ok := gno.BaseOf(tv.T).(*gno.SliceType); ok {
+			return nil
+		}
+	}
+	p.consumeGas(int64(tv.GetLength()) * GasPerByte)
+	b := make([]byte, tv.GetLength())
+	for i := range b {
+		ev := p.index(tv, i)
+		b[i] = ev.GetUint8()
+	}
+	return b
+}
+
+// mapItems returns the items of a map, sorted by key as in Go.
+func (p *printer) mapItems(tv gno.TypedValue) []gno.MapListItem {
+	mv, _ := tv.V.(*gno.MapValue)
+	if mv == nil {
+		return nil
+	}
+	items := make([]gno.MapListItem, 0, mv.GetLength())
+	for item := mv.List.Head; item != nil; item = item.Next {
+		items = append(items, gno.MapListItem{
+			Key:   *gno.FillValueTV(p.m.Store, &item.Key),
+			Value: *gno.FillValueTV(p.m.Store, &item.Value),
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return p.compare(items[i].Key, items[j].Key) < 0
+	})
+	return items
+}
+
+// compare compares two map keys, as Go's internal/fmtsort. The keys which
+// can't be ordered, such as pointers, keep the insertion order of the map.
+func (p *printer) compare(a, b gno.TypedValue) int {
+	if a.T == nil || b.T == nil {
+		switch {
+		case a.T == nil && b.T == nil:
+			return 0
+		case a.T == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
+	if a.T.TypeID() != b.T.TypeID() {
+		// interface keys of different types.
+		return strings.Compare(string(a.T.TypeID()), string(b.T.TypeID()))
+	}
+
+	switch bt := gno.BaseOf(a.T).(type) {
+	case gno.PrimitiveType:
+		switch bt.Kind() {
+		case gno.BoolKind:
+			x, y := a.GetBool(), b.GetBool()
+			switch {
+			case x == y:
+				return 0
+			case x:
+				return 1
+			default:
+				return -1
+			}
+		case gno.StringKind:
+			return strings.Compare(a.GetString(), b.GetString())
+		case gno.IntKind, gno.Int8Kind, gno.Int16Kind, gno.Int32Kind, gno.Int64Kind:
+			return compareOrdered(a.ConvertGetInt(), b.ConvertGetInt())
+		case gno.UintKind, gno.Uint8Kind, gno.Uint16Kind, gno.Uint32Kind, gno.Uint64Kind:
+			return compareOrdered(p.uint64(a), p.uint64(b))
+		case gno.Float32Kind:
+			return compareFloat(float64(a.GetFloat32()), float64(b.GetFloat32()))
+		case gno.Float64Kind:
+			return compareFloat(a.GetFloat64(), b.GetFloat64())
+		}
+	case *gno.ArrayType:
+		for i := 0; i < bt.Len; i++ {
+			if c := p.compare(p.index(a, i), p.index(b, i)); c != 0 {
+				return c
+			}
+		}
+	case *gno.StructType:
+		sa, sb := a.V.(*gno.StructValue), b.V.(*gno.StructValue)
+		for i := range bt.Fields {
+			fa := sa.GetPointerToInt(p.m.Store, i).Deref()
+			fb := sb.GetPointerToInt(p.m.Store, i).Deref()
+			if c := p.compare(fa, fb); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+func (p *printer) uint64(tv gno.TypedValue) uint64 {
+	switch tv.T.Kind() {
+	case gno.UintKind:
+		return uint64(tv.GetUint())
+	case gno.Uint8Kind:
+		return uint64(tv.GetUint8())
+	case gno.Uint16Kind:
+		return uint64(tv.GetUint16())
+	case gno.Uint32Kind:
+		return uint64(tv.GetUint32())
+	default:
+		return tv.GetUint64()
+	}
+}
+
+func compareOrdered[T int | int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareFloat compares two floats, with NaN < everything.
+func compareFloat(a, b float64) int {
+	switch {
+	case a != a && b != b:
+		return 0
+	case a != a:
+		return -1
+	case b != b:
+		return 1
+	default:
+		return compareOrdered(a, b)
+	}
+}
+
+// doPrint is the implementation of Sprint.
+func (p *printer) doPrint(a []gno.TypedValue) {
+	prevString := false
+	for argNum, arg := range a {
+		isString := arg.T != nil && arg.T.Kind() == gno.StringKind
+		// Add a space between two non-string arguments.
+		if argNum > 0 && !isString && !prevString {
+			p.buf.WriteByte(' ')
+		}
+		p.printArg(arg, 'v')
+		prevString = isString
+	}
+}
+
+// doPrintln is like doPrint but always adds a space between arguments
+// and a newline after the last argument.
+func (p *printer) doPrintln(a []gno.TypedValue) {
+	for argNum, arg := range a {
+		if argNum > 0 {
+			p.buf.WriteByte(' ')
+		}
+		p.printArg(arg, 'v')
+	}
+	p.buf.WriteByte('\n')
+}
+
+// doPrintf is the implementation of Sprintf, ported from Go's fmt.
+func (p *printer) doPrintf(format string, a []gno.TypedValue) {
+	end := len(format)
+	argNum := 0         // we process one argument per non-trivial format
+	afterIndex := false // previous item in format was an index like [3].
+	p.reordered = false
+formatLoop:
+	for i := 0; i < end; {
+		p.goodArgNum = true
+		lasti := i
+		for i < end && format[i] != '%' {
+			i++
+		}
+		if i > lasti {
+			p.buf.WriteString(format[lasti:i])
+		}
+		if i >= end {
+			// done processing format string
+			break
+		}
+
+		// Process one verb
+		i++
+
+		// Do we have flags?
+		p.flags = flags{}
+	simpleFormat:
+		for ; i < end; i++ {
+			c := format[i]
+			switch c {
+			case '#':
+				p.sharp = true
+			case '0':
+				p.zero = true
+			case '+':
+				p.plus = true
+			case '-':
+				p.minus = true
+			case ' ':
+				p.space = true
+			default:
+				// Fast path for common case of ascii lower case simple verbs
+				// without precision or width or argument indices.
+				if 'a' <= c && c <= 'z' && argNum < len(a) {
+					switch c {
+					case 'w':
+						p.wrappedErrs = append(p.wrappedErrs, argNum)
+						fallthrough
+					case 'v':
+						// Go syntax
+						p.sharpV = p.sharp
+						p.sharp = false
+						// Struct-field syntax
+						p.plusV = p.plus
+						p.plus = false
+					}
+					p.printArg(a[argNum], rune(c))
+					argNum++
+					i++
+					continue formatLoop
+				}
+				// Format is more complex than simple flags and a verb or is malformed.
+				break simpleFormat
+			}
+		}
+
+		// Do we have an explicit argument index?
+		argNum, i, afterIndex = p.argNumber(argNum, format, i, len(a))
+
+		// Do we have width?
+		if i < end && format[i] == '*' {
+			i++
+			p.wid, p.widPresent, argNum = intFromArg(a, argNum)
+
+			if !p.widPresent {
+				p.buf.WriteString(badWidthString)
+			}
+
+			// We have a negative width, so take its value and ensure
+			// that the minus flag is set
+			if p.wid < 0 {
+				p.wid = -p.wid
+				p.minus = true
+				p.zero = false // Do not pad with zeros to the right.
+			}
+			afterIndex = false
+		} else {
+			p.wid, p.widPresent, i = parsenum(format, i, end)
+			if afterIndex && p.widPresent { // "%[3]2d"
+				p.goodArgNum = false
+			}
+		}
+
+		// Do we have precision?
+		if i+1 < end && format[i] == '.' {
+			i++
+			if afterIndex { // "%[3].2d"
+				p.goodArgNum = false
+			}
+			argNum, i, afterIndex = p.argNumber(argNum, format, i, len(a))
+			if i < end && format[i] == '*' {
+				i++
+				p.prec, p.precPresent, argNum = intFromArg(a, argNum)
+				// Negative precision arguments don't make sense
+				if p.prec < 0 {
+					p.prec = 0
+					p.precPresent = false
+				}
+				if !p.precPresent {
+					p.buf.WriteString(badPrecString)
+				}
+				afterIndex = false
+			} else {
+				p.prec, p.precPresent, i = parsenum(format, i, end)
+				if !p.precPresent {
+					p.prec = 0
+					p.precPresent = true
+				}
+			}
+		}
+
+		if !afterIndex {
+			argNum, i, afterIndex = p.argNumber(argNum, format, i, len(a))
+		}
+
+		if i >= end {
+			p.buf.WriteString(noVerbString)
+			break
+		}
+
+		verb, size := rune(format[i]), 1
+		if verb >= utf8.RuneSelf {
+			verb, size = utf8.DecodeRuneInString(format[i:])
+		}
+		i += size
+
+		switch {
+		case verb == '%': // Percent does not absorb operands and ignores f.wid and f.prec.
+			p.buf.WriteByte('%')
+		case !p.goodArgNum:
+			p.badArgNum(verb)
+		case argNum >= len(a): // No argument left over to print for the current verb.
+			p.missingArg(verb)
+		case verb == 'w':
+			p.wrappedErrs = append(p.wrappedErrs, argNum)
+			fallthrough
+		case verb == 'v':
+			// Go syntax
+			p.sharpV = p.sharp
+			p.sharp = false
+			// Struct-field syntax
+			p.plusV = p.plus
+			p.plus = false
+			fallthrough
+		default:
+			p.printArg(a[argNum], verb)
+			argNum++
+		}
+	}
+
+	// Check for extra arguments unless the call accessed the arguments
+	// out of order, in which case it's too expensive to detect if they've all
+	// been used and arguably OK if they're not.
+	if !p.reordered && argNum < len(a) {
+		p.flags = flags{}
+		p.buf.WriteString(extraString)
+		for i, arg := range a[argNum:] {
+			if i > 0 {
+				p.buf.WriteString(commaSpaceString)
+			}
+			if arg.T == nil {
+				p.buf.WriteString(nilAngleString)
+			} else {
+				p.buf.WriteString(TypeString(arg.T))
+				p.buf.WriteByte('=')
+				p.printArg(arg, 'v')
+			}
+		}
+		p.buf.WriteByte(')')
+	}
+}
+
+func (p *printer) badArgNum(verb rune) {
+	p.buf.WriteString(percentBangString)
+	p.buf.WriteRune(verb)
+	p.buf.WriteString(badIndexString)
+}
+
+func (p *printer) missingArg(verb rune) {
+	p.buf.WriteString(percentBangString)
+	p.buf.WriteRune(verb)
+	p.buf.WriteString(missingString)
+}
+
+// argNumber returns the next argument to evaluate, which is either the value of the passed-in
+// argNum or the value of the bracketed integer that begins format[i:]. It also returns
+// the new value of i, that is, the index of the next byte of the format to process.
+func (p *printer) argNumber(argNum int, format string, i int, numArgs int) (newArgNum, newi int, found bool) {
+	if len(format) <= i || format[i] != '[' {
+		return argNum, i, false
+	}
+	p.reordered = true
+	index, wid, ok := parseArgNumber(format[i:])
+	if ok && 0 <= index && index < numArgs {
+		return index, i + wid, true
+	}
+	p.goodArgNum = false
+	return argNum, i + wid, ok
+}
+
+// parseArgNumber returns the value of the bracketed number, minus 1
+// (explicit argument numbers are one-indexed but we want zero-indexed).
+// The opening bracket is known to be present at format[0].
+// The returned values are the index, the number of bytes to consume
+// up to the closing paren, if present, and whether the number parsed
+// ok. The bytes to consume will be 1 if no closing paren is present.
+func parseArgNumber(format string) (index int, wid int, ok bool) {
+	// There must be at least 3 bytes: [n].
+	if len(format) < 3 {
+		return 0, 1, false
+	}
+
+	// Find closing bracket.
+	for i := 1; i < len(format); i++ {
+		if format[i] == ']' {
+			width, ok, newi := parsenum(format, 1, i)
+			if !ok || newi != i {
+				return 0, i + 1, false
+			}
+			return width - 1, i + 1, true // arg numbers are one-indexed and skip paren.
+		}
+	}
+	return 0, 1, false
+}
+
+// intFromArg gets the argNumth element of a. On return, isInt reports whether the argument has integer type.
+func intFromArg(a []gno.TypedValue, argNum int) (num int, isInt bool, newArgNum int) {
+	newArgNum = argNum
+	if argNum < len(a) {
+		arg := a[argNum]
+		if arg.T != nil {
+			switch arg.T.Kind() {
+			case gno.IntKind, gno.Int8Kind, gno.Int16Kind, gno.Int32Kind, gno.Int64Kind,
+				gno.UintKind, gno.Uint8Kind, gno.Uint16Kind, gno.Uint32Kind:
+				num, isInt = arg.ConvertGetInt(), true
+			case gno.Uint64Kind:
+				n := arg.GetUint64()
+				if int64(n) >= 0 && uint64(int(n)) == n {
+					num, isInt = int(n), true
+				}
+			}
+		}
+		newArgNum = argNum + 1
+		if tooLarge(num) {
+			num = 0
+			isInt = false // Argument too large.
+		}
+	}
+	return
+}
+
+// tooLarge reports whether the magnitude of the integer is
+// too large to be used as a formatting width or precision.
+func tooLarge(x int) bool {
+	const max int = 1e6
+	return x > max || x < -max
+}
+
+// parsenum converts ASCII to integer.  num is 0 (and isnum is false) if no number present.
+func parsenum(s string, start, end int) (num int, isnum bool, newi int) {
+	if start >= end {
+		return 0, false, end
+	}
+	for newi = start; newi < end && '0' <= s[newi] && s[newi] <= '9'; newi++ {
+		if tooLarge(num) {
+			return 0, false, end // Overflow; crazy long number most likely.
+		}
+		num = num*10 + int(s[newi]-'0')
+		isnum = true
+	}
+	return
+}
+
+// TypeString returns the string of a type, as printed by Go with the %T verb.
+func TypeString(t gno.Type) string {
+	switch ct := t.(type) {
+	case nil:
+		return nilAngleString
+	case *gno.DeclaredType:
+		if ct.PkgPath == uversePkgPath {
+			return string(ct.Name)
+		}
+		return path.Base(ct.PkgPath) + "." + string(ct.Name)
+	case *gno.PointerType:
+		return "*" + TypeString(ct.Elt)
+	case *gno.SliceType:
+		return "[]" + TypeString(ct.Elt)
+	case *gno.ArrayType:
+		return "[" + strconv.Itoa(ct.Len) + "]" + TypeString(ct.Elt)
+	case *gno.MapType:
+		return "map[" + TypeString(ct.Key) + "]" + TypeString(ct.Value)
+	case *gno.ChanType:
+		return "chan " + TypeString(ct.Elt)
+	case *gno.StructType:
+		if len(ct.Fields) == 0 {
+			return "struct {}"
+		}
+		fields := make([]string, len(ct.Fields))
+		for i, f := range ct.Fields {
+			if f.Embedded {
+				fields[i] = TypeString(f.Type)
+			} else {
+				fields[i] = string(f.Name) + " " + TypeString(f.Type)
+			}
+		}
+		return "struct { " + strings.Join(fields, "; ") + " }"
+	case *gno.InterfaceType:
+		if len(ct.Methods) == 0 {
+			return "interface {}"
+		}
+		methods := make([]string, len(ct.Methods))
+		for i, m := range ct.Methods {
+			if ft, ok := m.Type.(*gno.FuncType); ok {
+				methods[i] = string(m.Name) + signatureString(ft)
+			} else {
+				methods[i] = TypeString(m.Type)
+			}
+		}
+		return "interface { " + strings.Join(methods, "; ") + " }"
+	case *gno.FuncType:
+		return "func" + signatureString(ct)
+	case *gno.NativeType:
+		return ct.Type.String()
+	default:
+		return t.String()
+	}
+}
+
+// signatureString returns the parameters and results of a function type.
+func signatureString(ft *gno.FuncType) string {
+	params := make([]string, len(ft.Params))
+	for i, f := range ft.Params {
+		if st, ok := f.Type.(*gno.SliceType); ok && st.Vrd {
+			params[i] = "..." + TypeString(st.Elt)
+		} else {
+			params[i] = TypeString(f.Type)
+		}
+	}
+	s := "(" + strings.Join(params, ", ") + ")"
+	switch len(ft.Results) {
+	case 0:
+		return s
+	case 1:
+		return s + " " + TypeString(ft.Results[0].Type)
+	default:
+		results := make([]string, len(ft.Results))
+		for i, f := range ft.Results {
+			results[i] = TypeString(f.Type)
+		}
+		return s + " (" + strings.Join(results, ", ") + ")"
+	}
+}
+
+func isExported(name gno.Name) bool {
+	r, _ := utf8.DecodeRuneInString(string(name))
+	return unicode.IsUpper(r)
+}
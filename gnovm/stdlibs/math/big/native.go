@@ -0,0 +1,400 @@
+package big
+
+import (
+	"math"
+	"math/big"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// Gas charged by the natives. Costs grow with the size of the operands,
+// counted in 64-bit words.
+const (
+	// GasBase is charged for every call to a native.
+	GasBase = 10
+	// GasPerWord is charged per word of the operands and of the result of
+	// linear-time operations, such as additions, shifts and bitwise operations.
+	GasPerWord = 1
+	// GasPerWordMul is charged per product of the number of words of the
+	// operands of quadratic operations, such as multiplications, divisions
+	// and conversions to and from decimal strings.
+	GasPerWordMul = 1
+)
+
+// consumeGas charges GasBase plus the given gas; gas is a float64 so that
+// the estimation of the cost of huge operations saturates instead of
+// overflowing, and runs out of gas before any work is done.
+func consumeGas(m *gno.Machine, gas float64, descriptor string) {
+	if m.GasMeter == nil {
+		return
+	}
+	gas += GasBase
+	if remaining := m.GasMeter.Remaining(); gas > float64(remaining) && remaining < math.MaxInt64 {
+		// charge just past the limit, so that the meter runs out of gas
+		// rather than overflowing.
+		m.GasMeter.ConsumeGas(remaining+1, descriptor)
+		return
+	}
+	if gas >= math.MaxInt64 {
+		m.GasMeter.ConsumeGas(math.MaxInt64, descriptor)
+		return
+	}
+	m.GasMeter.ConsumeGas(int64(gas), descriptor)
+}
+
+// allocResult charges GasPerWord per word of a result of w words, which is
+// estimated before it is computed, and counts its bytes against the
+// allocation limit of the machine; so that operations whose result is far
+// larger than their operands fail before it is allocated.
+func allocResult(m *gno.Machine, w float64, descriptor string) {
+	consumeGas(m, w*GasPerWord, descriptor)
+	if m.Alloc == nil {
+		return
+	}
+	size := w * 8
+	if maxBytes, bytes := m.Alloc.Status(); size > float64(maxBytes-bytes) {
+		panic("allocation limit exceeded")
+	}
+	m.Alloc.Allocate(int64(size))
+}
+
+// words returns the number of 64-bit words of the magnitude x.
+func words(x []byte) float64 {
+	return float64((len(x) + 7) / 8)
+}
+
+func linearGas(xs ...[]byte) float64 {
+	var n float64
+	for _, x := range xs {
+		n += words(x)
+	}
+	return n * GasPerWord
+}
+
+func mulGas(x, y float64) float64 {
+	return x*y*GasPerWordMul + (x+y)*GasPerWord
+}
+
+// toInt returns the big.Int with the given sign and big-endian magnitude.
+func toInt(neg bool, abs []byte) *big.Int {
+	x := new(big.Int).SetBytes(abs)
+	if neg {
+		x.Neg(x)
+	}
+	return x
+}
+
+// fromInt returns the sign and the big-endian magnitude of x, which is nil
+// if x is zero.
+func fromInt(x *big.Int) (bool, []byte) {
+	if x.Sign() == 0 {
+		return false, nil
+	}
+	return x.Sign() < 0, x.Bytes()
+}
+
+func X_add(m *gno.Machine, xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	consumeGas(m, 2*linearGas(x, y), "big.Add")
+	return fromInt(new(big.Int).Add(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_mul(m *gno.Machine, x, y []byte) []byte {
+	consumeGas(m, mulGas(words(x), words(y)), "big.Mul")
+	return new(big.Int).Mul(toInt(false, x), toInt(false, y)).Bytes()
+}
+
+func X_quoRem(m *gno.Machine, xneg bool, x []byte, yneg bool, y []byte) (bool, []byte, bool, []byte) {
+	consumeGas(m, mulGas(words(x), words(y)), "big.QuoRem")
+	q, r := new(big.Int).QuoRem(toInt(xneg, x), toInt(yneg, y), new(big.Int))
+	qneg, qabs := fromInt(q)
+	rneg, rabs := fromInt(r)
+	return qneg, qabs, rneg, rabs
+}
+
+func X_divMod(m *gno.Machine, xneg bool, x []byte, yneg bool, y []byte) (bool, []byte, bool, []byte) {
+	consumeGas(m, mulGas(words(x), words(y)), "big.DivMod")
+	q, r := new(big.Int).DivMod(toInt(xneg, x), toInt(yneg, y), new(big.Int))
+	qneg, qabs := fromInt(q)
+	rneg, rabs := fromInt(r)
+	return qneg, qabs, rneg, rabs
+}
+
+func X_exp(m *gno.Machine, xneg bool, x []byte, yneg bool, y []byte, mneg bool, mod []byte) (bool, []byte, bool) {
+	xi, yi := toInt(xneg, x), toInt(yneg, y)
+	var mi *big.Int
+	if len(mod) > 0 {
+		mi = toInt(mneg, mod)
+		// One squaring, one multiplication and their reductions per bit of
+		// the exponent.
+		wm := words(mod)
+		consumeGas(m, float64(yi.BitLen())*2*mulGas(wm, wm)+mulGas(words(x), wm), "big.Exp")
+	} else if yi.Sign() > 0 && xi.CmpAbs(big.NewInt(1)) > 0 {
+		// The result has about y*bitlen(x) bits, and the last squaring
+		// dominates the cost.
+		yf, _ := new(big.Float).SetInt(yi).Float64()
+		wz := yf * float64(xi.BitLen()) / 64
+		allocResult(m, wz, "big.Exp")
+		consumeGas(m, 2*mulGas(wz, wz), "big.Exp")
+	} else {
+		consumeGas(m, linearGas(x), "big.Exp")
+	}
+	z := new(big.Int).Exp(xi, yi, mi)
+	if z == nil {
+		return false, nil, false
+	}
+	zneg, zabs := fromInt(z)
+	return zneg, zabs, true
+}
+
+func X_gcd(m *gno.Machine, aneg bool, a []byte, bneg bool, b []byte, cofactors bool) ([]byte, bool, []byte, bool, []byte) {
+	w := math.Max(words(a), words(b))
+	consumeGas(m, 2*mulGas(w, w), "big.GCD")
+	var x, y *big.Int
+	if cofactors {
+		x, y = new(big.Int), new(big.Int)
+	}
+	d := new(big.Int).GCD(x, y, toInt(aneg, a), toInt(bneg, b))
+	var (
+		xneg, yneg bool
+		xabs, yabs []byte
+	)
+	if cofactors {
+		xneg, xabs = fromInt(x)
+		yneg, yabs = fromInt(y)
+	}
+	return d.Bytes(), xneg, xabs, yneg, yabs
+}
+
+func X_modInverse(m *gno.Machine, gneg bool, g []byte, nneg bool, n []byte) (bool, []byte, bool) {
+	w := math.Max(words(g), words(n))
+	consumeGas(m, 2*mulGas(w, w), "big.ModInverse")
+	z := new(big.Int).ModInverse(toInt(gneg, g), toInt(nneg, n))
+	if z == nil {
+		return false, nil, false
+	}
+	zneg, zabs := fromInt(z)
+	return zneg, zabs, true
+}
+
+func X_jacobi(m *gno.Machine, xneg bool, x []byte, yneg bool, y []byte) int {
+	w := math.Max(words(x), words(y))
+	consumeGas(m, 2*mulGas(w, w), "big.Jacobi")
+	return big.Jacobi(toInt(xneg, x), toInt(yneg, y))
+}
+
+func X_modSqrt(m *gno.Machine, xneg bool, x []byte, p []byte) (bool, []byte, bool) {
+	// Tonelli-Shanks performs a modular exponentiation, and at most s^2
+	// modular multiplications, where p-1 = q*2^s.
+	pi := toInt(false, p)
+	s := new(big.Int).Sub(pi, big.NewInt(1)).TrailingZeroBits()
+	wp := words(p)
+	consumeGas(m, (2*float64(pi.BitLen())+float64(s)*float64(s))*mulGas(wp, wp)+mulGas(words(x), wp), "big.ModSqrt")
+	z := new(big.Int).ModSqrt(toInt(xneg, x), pi)
+	if z == nil {
+		return false, nil, false
+	}
+	zneg, zabs := fromInt(z)
+	return zneg, zabs, true
+}
+
+func X_sqrt(m *gno.Machine, x []byte) []byte {
+	// Newton's method converges in a few divisions of the size of x.
+	w := words(x)
+	consumeGas(m, 4*mulGas(w, w), "big.Sqrt")
+	return new(big.Int).Sqrt(toInt(false, x)).Bytes()
+}
+
+func X_lsh(m *gno.Machine, x []byte, n uint) []byte {
+	if len(x) > 0 {
+		allocResult(m, words(x)+float64(n)/64, "big.Lsh")
+	}
+	consumeGas(m, (2*words(x)+float64(n)/64)*GasPerWord, "big.Lsh")
+	return new(big.Int).Lsh(toInt(false, x), n).Bytes()
+}
+
+func X_rsh(m *gno.Machine, xneg bool, x []byte, n uint) (bool, []byte) {
+	consumeGas(m, 2*linearGas(x), "big.Rsh")
+	return fromInt(new(big.Int).Rsh(toInt(xneg, x), n))
+}
+
+func X_and(m *gno.Machine, xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	consumeGas(m, 2*linearGas(x, y), "big.And")
+	return fromInt(new(big.Int).And(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_andNot(m *gno.Machine, xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	consumeGas(m, 2*linearGas(x, y), "big.AndNot")
+	return fromInt(new(big.Int).AndNot(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_or(m *gno.Machine, xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	consumeGas(m, 2*linearGas(x, y), "big.Or")
+	return fromInt(new(big.Int).Or(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_xor(m *gno.Machine, xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	consumeGas(m, 2*linearGas(x, y), "big.Xor")
+	return fromInt(new(big.Int).Xor(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_not(m *gno.Machine, xneg bool, x []byte) (bool, []byte) {
+	consumeGas(m, 2*linearGas(x), "big.Not")
+	return fromInt(new(big.Int).Not(toInt(xneg, x)))
+}
+
+func X_bit(m *gno.Machine, xneg bool, x []byte, i int) uint {
+	consumeGas(m, linearGas(x), "big.Bit")
+	return toInt(xneg, x).Bit(i)
+}
+
+func X_setBit(m *gno.Machine, xneg bool, x []byte, i int, b uint) (bool, []byte) {
+	if b != 0 {
+		allocResult(m, math.Max(words(x), float64(i)/64+1), "big.SetBit")
+	}
+	consumeGas(m, (2*words(x)+float64(i)/64)*GasPerWord, "big.SetBit")
+	x0 := toInt(xneg, x)
+	return fromInt(x0.SetBit(x0, i, b))
+}
+
+func X_mulRange(m *gno.Machine, a, b int64) (bool, []byte) {
+	if a <= b && (a > 0 || b < 0) {
+		// Each of the b-a+1 factors is multiplied with a product of at most
+		// (b-a+1)*bitlen(max(|a|, |b|)) bits.
+		n := float64(b) - float64(a) + 1
+		bl := math.Max(math.Abs(float64(a)), math.Abs(float64(b)))
+		wz := n * math.Ceil(math.Log2(bl+1)) / 64
+		allocResult(m, wz, "big.MulRange")
+		consumeGas(m, n*mulGas(wz, 1), "big.MulRange")
+	} else {
+		consumeGas(m, 0, "big.MulRange")
+	}
+	return fromInt(new(big.Int).MulRange(a, b))
+}
+
+func X_probablyPrime(m *gno.Machine, x []byte, n int) bool {
+	// Each Miller-Rabin round, and the Baillie-PSW test, perform a modular
+	// exponentiation.
+	w := words(x)
+	consumeGas(m, float64(n+1)*float64(len(x)*8)*2*mulGas(w, w), "big.ProbablyPrime")
+	return toInt(false, x).ProbablyPrime(n)
+}
+
+func X_intFloat64(m *gno.Machine, xneg bool, x []byte) (float64, int) {
+	consumeGas(m, linearGas(x), "big.Float64")
+	f, acc := toInt(xneg, x).Float64()
+	return f, int(acc)
+}
+
+func X_text(m *gno.Machine, xneg bool, x []byte, base int) string {
+	if base >= 2 {
+		// A digit holds at least log2(base) bits.
+		allocResult(m, words(x)*64/math.Floor(math.Log2(float64(base)))/8, "big.Text")
+	}
+	consumeGas(m, convGas(words(x), base), "big.Text")
+	return toInt(xneg, x).Text(base)
+}
+
+func X_setString(m *gno.Machine, s string, base int) (bool, []byte, bool) {
+	// A digit holds at most 6 bits, in base 62.
+	consumeGas(m, convGas(float64(len(s))*6/64, base), "big.SetString")
+	x, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		return false, nil, false
+	}
+	xneg, xabs := fromInt(x)
+	return xneg, xabs, true
+}
+
+// convGas returns the gas charged for the conversion of a number of w words
+// from or to a string in the given base, which is linear for powers of two
+// and quadratic otherwise.
+func convGas(w float64, base int) float64 {
+	if base&(base-1) == 0 {
+		return 2 * w * GasPerWord
+	}
+	return mulGas(w, w)
+}
+
+func X_ratFloat64(m *gno.Machine, aneg bool, a []byte, b []byte) (float64, bool) {
+	consumeGas(m, mulGas(words(a), words(b)), "big.Rat.Float64")
+	return ratOf(aneg, a, b).Float64()
+}
+
+func X_ratFloat32(m *gno.Machine, aneg bool, a []byte, b []byte) (float32, bool) {
+	consumeGas(m, mulGas(words(a), words(b)), "big.Rat.Float32")
+	return ratOf(aneg, a, b).Float32()
+}
+
+func X_ratSetFloat64(m *gno.Machine, f float64) (bool, []byte, []byte, bool) {
+	// A float64 is at most 2^1023 or 2^-1074, so that its numerator or
+	// denominator fit in 17 words.
+	consumeGas(m, mulGas(17, 17), "big.Rat.SetFloat64")
+	r := new(big.Rat).SetFloat64(f)
+	if r == nil {
+		return false, nil, nil, false
+	}
+	aneg, a, b := fromRat(r)
+	return aneg, a, b, true
+}
+
+func X_ratSetString(m *gno.Machine, s string) (bool, []byte, []byte, bool) {
+	// Exponents make the size of the result independent of len(s); big.Rat
+	// rejects exponents which do not fit in an int64, and the decimal digits
+	// of the exponent are accounted for below.
+	w := float64(len(s)) * 6 / 64
+	if i := exponentIndex(s); i >= 0 {
+		if e, ok := new(big.Int).SetString(s[i+1:], 10); ok && e.IsInt64() {
+			ef, _ := new(big.Float).SetInt(e).Float64()
+			w += math.Abs(ef) * math.Log2(10) / 64
+		}
+	}
+	allocResult(m, 2*w, "big.Rat.SetString")
+	consumeGas(m, 2*mulGas(w, w), "big.Rat.SetString")
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return false, nil, nil, false
+	}
+	aneg, a, b := fromRat(r)
+	return aneg, a, b, true
+}
+
+// exponentIndex returns the index of the exponent character of s, or -1.
+func exponentIndex(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case 'e', 'E', 'p', 'P':
+			// Hexadecimal mantissas don't have an 'e' exponent.
+			if (s[i] == 'e' || s[i] == 'E') && len(s) > 2 && (s[1] == 'x' || s[1] == 'X' || s[2] == 'x' || s[2] == 'X') {
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+func X_ratFloatString(m *gno.Machine, aneg bool, a []byte, b []byte, prec int) string {
+	w := math.Max(words(a), words(b)) + float64(prec)*math.Log2(10)/64
+	// The integer part has at most 20 decimal digits per word of a.
+	allocResult(m, (words(a)*20+float64(prec))/8, "big.Rat.FloatString")
+	consumeGas(m, 2*mulGas(w, w), "big.Rat.FloatString")
+	return ratOf(aneg, a, b).FloatString(prec)
+}
+
+// ratOf returns the big.Rat a/b; b is one if empty.
+func ratOf(aneg bool, a []byte, b []byte) *big.Rat {
+	if len(b) == 0 {
+		return new(big.Rat).SetInt(toInt(aneg, a))
+	}
+	return new(big.Rat).SetFrac(toInt(aneg, a), toInt(false, b))
+}
+
+// fromRat returns the sign and magnitude of the numerator of r, and its
+// denominator, which is nil if it is one.
+func fromRat(r *big.Rat) (bool, []byte, []byte) {
+	aneg, a := fromInt(r.Num())
+	if r.IsInt() {
+		return aneg, a, nil
+	}
+	return aneg, a, r.Denom().Bytes()
+}
@@ -17,4 +17,16 @@ type ExecContext struct {
 	OrigSend      std.Coins
 	OrigSendSpent *std.Coins // mutable
 	Banker        BankerInterface
+	Params        ParamsInterface
+	Validators    ValidatorsInterface
+	EventLogger   *sdk.EventLogger
+}
+
+// GetParamInt64 reads the param key into ptr, and returns whether it is set.
+// It lets the natives which can't import this package read the params.
+func (ctx ExecContext) GetParamInt64(key string, ptr *int64) bool {
+	if ctx.Params == nil {
+		return false
+	}
+	return ctx.Params.GetInt64(key, ptr)
 }
@@ -0,0 +1,49 @@
+package std
+
+import (
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	libsed25519 "github.com/gnolang/gno/gnovm/stdlibs/crypto/ed25519"
+	libssecp256k1 "github.com/gnolang/gno/gnovm/stdlibs/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+)
+
+func VerifySignature(m *gno.Machine, pubKey string, msg []byte, sig []byte) bool {
+	pk, err := crypto.PubKeyFromBech32(pubKey)
+	if err != nil {
+		return false
+	}
+	gas, ok := verifyGas(m, pk, len(msg))
+	if !ok {
+		return false
+	}
+	if m.GasMeter != nil {
+		m.GasMeter.ConsumeGas(gas, "std.VerifySignature")
+	}
+	return pk.VerifyBytes(msg, sig)
+}
+
+// verifyGas returns the gas charged for the verification of a signature by
+// pk of a message of msgLen bytes, or false if its type is not supported.
+func verifyGas(m *gno.Machine, pk crypto.PubKey, msgLen int) (int64, bool) {
+	switch pk := pk.(type) {
+	case ed25519.PubKeyEd25519:
+		return libsed25519.GasVerify(m, msgLen), true
+	case secp256k1.PubKeySecp256k1:
+		return libssecp256k1.GasVerify(m, msgLen), true
+	case multisig.PubKeyMultisigThreshold:
+		var total int64
+		for _, sub := range pk.PubKeys {
+			gas, ok := verifyGas(m, sub, msgLen)
+			if !ok {
+				return 0, false
+			}
+			total += gas
+		}
+		return total, true
+	default:
+		return 0, false
+	}
+}
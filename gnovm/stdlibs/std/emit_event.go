@@ -0,0 +1,85 @@
+package std
+
+import (
+	"errors"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+const (
+	// GasEmitEvent is the gas charged for each event emitted with std.Emit.
+	GasEmitEvent = 100
+	// GasEmitEventPerByte is the gas charged for each byte of the type,
+	// keys and values of an emitted event.
+	GasEmitEventPerByte = 10
+)
+
+var errInvalidGnoEventAttrs = errors.New("cannot pair attributes due to odd count")
+
+// GnoEvent is an event emitted by Gno code, using std.Emit.
+type GnoEvent struct {
+	Type       string              `json:"type"`
+	PkgPath    string              `json:"pkg_path"`
+	Func       string              `json:"func"`
+	Attributes []GnoEventAttribute `json:"attrs"`
+}
+
+func (e GnoEvent) AssertABCIEvent() {}
+
+// GnoEventAttribute is a key-value pair of a GnoEvent.
+type GnoEventAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func X_emit(m *gno.Machine, typ string, attrs []string) {
+	if m.GasMeter != nil {
+		size := len(typ)
+		for _, attr := range attrs {
+			size += len(attr)
+		}
+		m.GasMeter.ConsumeGas(GasEmitEvent+int64(size)*GasEmitEventPerByte, "std.Emit")
+	}
+
+	eventAttrs, err := attrKeysAndValues(attrs)
+	if err != nil {
+		m.Panic(typedString(err.Error()))
+		return
+	}
+
+	// The last call frames are the ones of emit and Emit,
+	// the caller of Emit is the one emitting the event.
+	pkgPath := ""
+	if fr := m.LastCallFrame(2); fr != nil && fr.LastPackage != nil {
+		pkgPath = fr.LastPackage.PkgPath
+	}
+	fnName := ""
+	if fr := m.LastCallFrame(3); fr != nil && fr.Func != nil {
+		fnName = string(fr.Func.Name)
+	}
+
+	ctx := m.Context.(ExecContext)
+	if ctx.EventLogger == nil {
+		return
+	}
+	ctx.EventLogger.EmitEvent(GnoEvent{
+		Type:       typ,
+		PkgPath:    pkgPath,
+		Func:       fnName,
+		Attributes: eventAttrs,
+	})
+}
+
+func attrKeysAndValues(attrs []string) ([]GnoEventAttribute, error) {
+	if len(attrs)%2 != 0 {
+		return nil, errInvalidGnoEventAttrs
+	}
+	eventAttrs := make([]GnoEventAttribute, len(attrs)/2)
+	for i := 0; i < len(attrs)-1; i += 2 {
+		eventAttrs[i/2] = GnoEventAttribute{
+			Key:   attrs[i],
+			Value: attrs[i+1],
+		}
+	}
+	return eventAttrs, nil
+}
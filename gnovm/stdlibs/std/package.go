@@ -0,0 +1,17 @@
+package std
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+)
+
+var Package = amino.RegisterPackage(amino.NewPackage(
+	"github.com/gnolang/gno/gnovm/stdlibs/std",
+	"gno",
+	amino.GetCallersDirname(),
+).WithDependencies(
+	abci.Package,
+).WithTypes(
+	GnoEvent{}, "GnoEvent",
+	GnoEventAttribute{}, "GnoEventAttribute",
+))
@@ -0,0 +1,80 @@
+package std
+
+import (
+	"strings"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// ParamsInterface is the interface through which Gno is capable of accessing
+// the blockchain's params.
+//
+// The name is what it is to avoid a collision with Gno's Params, when
+// transpiling.
+type ParamsInterface interface {
+	SetString(key, val string)
+	SetBool(key string, val bool)
+	SetInt64(key string, val int64)
+	SetUint64(key string, val uint64)
+	SetBytes(key string, val []byte)
+
+	// GetInt64 reads the param key, like "auth.sig_verify_cost_ed25519.int64",
+	// into ptr, and returns whether it is set. It is only used by natives.
+	GetInt64(key string, ptr *int64) bool
+}
+
+func X_setParamString(m *gno.Machine, key, val string) {
+	pk, ok := pkey(m, key, "string")
+	if !ok {
+		return
+	}
+	m.Context.(ExecContext).Params.SetString(pk, val)
+}
+
+func X_setParamBool(m *gno.Machine, key string, val bool) {
+	pk, ok := pkey(m, key, "bool")
+	if !ok {
+		return
+	}
+	m.Context.(ExecContext).Params.SetBool(pk, val)
+}
+
+func X_setParamInt64(m *gno.Machine, key string, val int64) {
+	pk, ok := pkey(m, key, "int64")
+	if !ok {
+		return
+	}
+	m.Context.(ExecContext).Params.SetInt64(pk, val)
+}
+
+func X_setParamUint64(m *gno.Machine, key string, val uint64) {
+	pk, ok := pkey(m, key, "uint64")
+	if !ok {
+		return
+	}
+	m.Context.(ExecContext).Params.SetUint64(pk, val)
+}
+
+func X_setParamBytes(m *gno.Machine, key string, val []byte) {
+	pk, ok := pkey(m, key, "bytes")
+	if !ok {
+		return
+	}
+	m.Context.(ExecContext).Params.SetBytes(pk, val)
+}
+
+// pkey validates the key, and prefixes it with the path of the current realm,
+// ie. "gno.land/r/demo/foo:max_items.int64". If the key is invalid, the
+// machine panics and ok is false.
+func pkey(m *gno.Machine, key string, kind string) (pk string, ok bool) {
+	name := strings.TrimSuffix(key, "."+kind)
+	if name == key || name == "" {
+		m.Panic(typedString("invalid param key " + key + ", expected suffix ." + kind))
+		return "", false
+	}
+	if m.Realm == nil {
+		m.Panic(typedString("params can only be set by realms"))
+		return "", false
+	}
+	return m.Realm.Path + ":" + key, true
+}
@@ -0,0 +1,34 @@
+package std
+
+import (
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+// ValidatorsInterface is the interface through which Gno is capable of
+// updating the blockchain's validator set.
+type ValidatorsInterface interface {
+	// UpdateValidator records a change of the validator set, requested by the
+	// realm at realmPath. It returns an error if the change is not allowed.
+	UpdateValidator(realmPath string, pubKey crypto.PubKey, power int64) error
+}
+
+func X_updateValidator(m *gno.Machine, pubKey string, power int64) {
+	if m.Realm == nil {
+		m.Panic(typedString("validators can only be updated by realms"))
+		return
+	}
+	if power < 0 {
+		m.Panic(typedString("validator power cannot be negative"))
+		return
+	}
+	pk, err := crypto.PubKeyFromBech32(pubKey)
+	if err != nil {
+		m.Panic(typedString("invalid validator pubkey: " + err.Error()))
+		return
+	}
+	ctx := m.Context.(ExecContext)
+	if err := ctx.Validators.UpdateValidator(m.Realm.Path, pk, power); err != nil {
+		m.Panic(typedString(err.Error()))
+	}
+}
@@ -50,6 +50,8 @@ func testMachineCustom(store gno.Store, pkgPath string, stdout io.Writer, maxAll
 		OrigSend:      send,
 		OrigSendSpent: new(std.Coins),
 		Banker:        banker,
+		Params:        newTestParams(),
+		Validators:    newTestValidators(),
 	}
 	m := gno.NewMachineWithOptions(gno.MachineOptions{
 		PkgPath:       "", // set later.
@@ -57,6 +59,9 @@ func testMachineCustom(store gno.Store, pkgPath string, stdout io.Writer, maxAll
 		Store:         store,
 		Context:       ctx,
 		MaxAllocBytes: maxAlloc,
+		// Packages meant for gno.land are tested as they run on-chain,
+		// without goroutines.
+		Goroutines: !strings.HasPrefix(pkgPath, "gno.land/"),
 	})
 	return m
 }
@@ -545,3 +550,48 @@ func (tb *testBanker) RemoveCoin(addr crypto.Bech32Address, denom string, amt in
 	rest := coins.Sub(std.Coins{{denom, amt}})
 	tb.coinTable[addr] = rest
 }
+
+// ----------------------------------------
+// testParams
+
+type testParams struct {
+	kvstore map[string]interface{}
+}
+
+func newTestParams() *testParams {
+	return &testParams{kvstore: make(map[string]interface{})}
+}
+
+func (tp *testParams) SetBool(key string, val bool)     { tp.kvstore[key] = val }
+func (tp *testParams) SetBytes(key string, val []byte)  { tp.kvstore[key] = val }
+func (tp *testParams) SetInt64(key string, val int64)   { tp.kvstore[key] = val }
+func (tp *testParams) SetUint64(key string, val uint64) { tp.kvstore[key] = val }
+func (tp *testParams) SetString(key string, val string) { tp.kvstore[key] = val }
+
+func (tp *testParams) GetInt64(key string, ptr *int64) bool {
+	val, ok := tp.kvstore[key].(int64)
+	if ok {
+		*ptr = val
+	}
+	return ok
+}
+
+// ----------------------------------------
+// testValidators
+
+type testValidators struct {
+	powers map[crypto.Address]int64
+}
+
+func newTestValidators() *testValidators {
+	return &testValidators{powers: make(map[crypto.Address]int64)}
+}
+
+func (tv *testValidators) UpdateValidator(realmPath string, pubKey crypto.PubKey, power int64) error {
+	if power == 0 {
+		delete(tv.powers, pubKey.Address())
+	} else {
+		tv.powers[pubKey.Address()] = power
+	}
+	return nil
+}
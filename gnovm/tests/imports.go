@@ -82,6 +82,8 @@ func TestStore(rootDir, filesPath string, stdin io.Reader, stdout, stderr io.Wri
 					PkgPath: "test",
 					Output:  stdout,
 					Store:   store,
+					// Test packages are never used on-chain.
+					Goroutines: true,
 				})
 				// pkg := gno.NewPackageNode(gno.Name(memPkg.Name), memPkg.Path, nil)
 				// pv := pkg.NewPackage()
@@ -449,10 +451,9 @@ func testPackageInjector(store gno.Store, pn *gno.PackageNode) {
 	// Test specific injections:
 	switch pn.PkgPath {
 	case "strconv":
-		// NOTE: Itoa and Atoi are already injected
+		// NOTE: Itoa, Atoi and ParseInt are already injected
 		// from stdlibs.InjectNatives.
 		pn.DefineGoNativeType(reflect.TypeOf(strconv.NumError{}))
-		pn.DefineGoNativeValue("ParseInt", strconv.ParseInt)
 	}
 }
 
@@ -75,6 +75,9 @@ func runPackageTest(t *testing.T, dir string, path string) {
 		Output:  stdout,
 		Store:   store,
 		Context: nil,
+		// Packages meant for gno.land are tested as they run on-chain,
+		// without goroutines.
+		Goroutines: !strings.HasPrefix(path, "gno.land/"),
 	})
 	m.TestMemPackage(t, memPkg)
 
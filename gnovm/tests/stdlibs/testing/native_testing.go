@@ -1,7 +1,18 @@
 package testing
 
-import "time"
+import (
+	"time"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
 
 func X_unixNano() int64 {
 	return time.Now().UnixNano()
 }
+
+func X_benchStats(m *gno.Machine) (cycles, bytes, allocs int64) {
+	if m.Alloc != nil {
+		_, bytes = m.Alloc.Status()
+	}
+	return m.Cycles, bytes, m.Alloc.NumAllocs()
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/bft/consensus"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/consensus/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/mempool"
+	"github.com/gnolang/gno/tm2/pkg/bft/statesync"
 	btypes "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/bitarray"
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
@@ -51,6 +52,7 @@ func execGen(_ context.Context, _ []string) error {
 		mempool.Package,
 		ed25519.Package,
 		blockchain.Package,
+		statesync.Package,
 		hd.Package,
 		multisig.Package,
 		std.Package,
@@ -2,4 +2,7 @@ package exporter
 
 import "errors"
 
-var ErrEndpointNotSet = errors.New("telemetry exporter endpoint not set")
+var (
+	ErrEndpointNotSet          = errors.New("telemetry exporter endpoint or prometheus address not set")
+	ErrServiceInstanceIDNotSet = errors.New("telemetry service instance ID not set")
+)
@@ -0,0 +1,105 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelPrometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// PrometheusPath is the path the Prometheus metrics are served at.
+const PrometheusPath = "/metrics"
+
+// Prometheus is a pull exporter, serving the collected metrics in the
+// Prometheus text exposition format.
+type Prometheus struct {
+	exporter *otelPrometheus.Exporter
+	handler  http.Handler
+
+	srv      *http.Server
+	serveErr chan error
+}
+
+// NewPrometheus creates a new Prometheus exporter. Its reader must be
+// registered with the meter provider.
+func NewPrometheus() (*Prometheus, error) {
+	// Use a dedicated registry, so only the metrics of the meter provider
+	// are exported.
+	registry := prometheus.NewRegistry()
+
+	exporter, err := otelPrometheus.New(
+		otelPrometheus.WithRegisterer(registry),
+		otelPrometheus.WithoutScopeInfo(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create prometheus exporter: %w", err)
+	}
+
+	return &Prometheus{
+		exporter: exporter,
+		handler:  promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
+	}, nil
+}
+
+// Reader returns the metric reader of the exporter.
+func (p *Prometheus) Reader() sdkMetric.Reader {
+	return p.exporter
+}
+
+// ListenAndServe serves the metrics on addr, at PrometheusPath, in the background,
+// until Shutdown is called.
+func (p *Prometheus) ListenAndServe(addr string) error {
+	if p.srv != nil {
+		return errors.New("prometheus exporter already serving")
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(PrometheusPath, p)
+
+	p.srv = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	p.serveErr = make(chan error, 1)
+	go func() {
+		p.serveErr <- p.srv.Serve(ln)
+	}()
+
+	return nil
+}
+
+// ServeHTTP collects the metrics, and writes them in the text exposition format.
+func (p *Prometheus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.handler.ServeHTTP(w, r)
+}
+
+// Shutdown stops the metrics server, if any, and returns the error
+// it stopped serving with.
+// The reader is shut down with the meter provider it is registered with.
+func (p *Prometheus) Shutdown(ctx context.Context) error {
+	if p.srv == nil {
+		return nil
+	}
+
+	if err := p.srv.Shutdown(ctx); err != nil {
+		return fmt.Errorf("unable to shutdown prometheus server: %w", err)
+	}
+
+	if err := <-p.serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("prometheus server failed: %w", err)
+	}
+
+	return nil
+}
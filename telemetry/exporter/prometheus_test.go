@@ -0,0 +1,94 @@
+package exporter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+func TestPrometheus_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	promExporter, err := NewPrometheus()
+	require.NoError(t, err)
+	provider := sdkMetric.NewMeterProvider(
+		sdkMetric.WithReader(promExporter.Reader()),
+		sdkMetric.WithResource(
+			resource.NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.ServiceInstanceIDKey.String("node-1"),
+			),
+		),
+	)
+	meter := provider.Meter("test")
+
+	// Record a counter, a histogram and a gauge.
+	counter, err := meter.Int64Counter("exec_msgs", metric.WithDescription("executed msgs"))
+	require.NoError(t, err)
+	counter.Add(context.Background(), 2, metric.WithAttributes(attribute.String("pkg_path", `gno.land/r/"quoted"`)))
+
+	hist, err := meter.Int64Histogram(
+		"cycles_hist",
+		metric.WithExplicitBucketBoundaries(10, 100),
+	)
+	require.NoError(t, err)
+	hist.Record(context.Background(), 5)
+	hist.Record(context.Background(), 50)
+	hist.Record(context.Background(), 500)
+
+	_, err = meter.Int64ObservableGauge(
+		"block.height",
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(42)
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	promExporter.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, PrometheusPath, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+
+	for _, line := range []string{
+		`target_info{service_instance_id="node-1"} 1`,
+		"# HELP exec_msgs_total executed msgs",
+		"# TYPE exec_msgs_total counter",
+		`exec_msgs_total{pkg_path="gno.land/r/\"quoted\""} 2`,
+		"# TYPE cycles_hist histogram",
+		`cycles_hist_bucket{le="10"} 1`,
+		`cycles_hist_bucket{le="100"} 2`,
+		`cycles_hist_bucket{le="+Inf"} 3`,
+		"cycles_hist_sum 555",
+		"cycles_hist_count 3",
+		"# TYPE block_height gauge",
+		"block_height 42",
+	} {
+		assert.Contains(t, string(body), line+"\n")
+	}
+}
+
+func TestPrometheus_ListenAndServe(t *testing.T) {
+	t.Parallel()
+
+	promExporter, err := NewPrometheus()
+	require.NoError(t, err)
+	assert.Error(t, promExporter.ListenAndServe("invalid address"))
+
+	require.NoError(t, promExporter.ListenAndServe("127.0.0.1:0"))
+	assert.Error(t, promExporter.ListenAndServe("127.0.0.1:0"))
+
+	require.NoError(t, promExporter.Shutdown(context.Background()))
+}
@@ -4,6 +4,8 @@ package telemetry
 // https://github.com/open-telemetry/opentelemetry-go/blob/main/example/prometheus/main.go
 
 import (
+	"context"
+
 	"github.com/gnolang/gno/telemetry/metrics"
 	"github.com/gnolang/gno/telemetry/options"
 )
@@ -38,3 +40,12 @@ func Init(options ...Option) error {
 
 	return nil
 }
+
+// Shutdown flushes the collected metrics, and stops the metrics exporters.
+func Shutdown(ctx context.Context) error {
+	if config.MetricsEnabled {
+		return metrics.Shutdown(ctx)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Int64Gauge is a gauge whose last set value is reported when the metrics
+// are collected. Values are only reported once set.
+type Int64Gauge struct {
+	value atomic.Int64
+	isSet atomic.Bool
+}
+
+// Set sets the current value of the gauge.
+func (g *Int64Gauge) Set(value int64) {
+	g.value.Store(value)
+	g.isSet.Store(true)
+}
+
+// register registers the gauge as an observable gauge of the meter.
+func (g *Int64Gauge) register(meter metric.Meter, name, description string) error {
+	_, err := meter.Int64ObservableGauge(
+		name,
+		metric.WithDescription(description),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			if g.isSet.Load() {
+				o.Observe(g.value.Load())
+			}
+			return nil
+		}),
+	)
+
+	return err
+}
@@ -2,68 +2,86 @@ package metrics
 
 import (
 	"context"
+	"errors"
 
 	"github.com/gnolang/gno/telemetry/exporter"
 	"github.com/gnolang/gno/telemetry/options"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
-	"go.opentelemetry.io/otel/metric"
 	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 )
 
 var (
-	// Metrics.
-	BroadcastTxTimer metric.Int64Histogram
-	BuildBlockTimer  metric.Int64Histogram
+	provider     *sdkMetric.MeterProvider
+	promExporter *exporter.Prometheus
 )
 
 func Init(config options.Config) error {
-	if config.ExporterEndpoint == "" {
+	if config.ExporterEndpoint == "" && config.PrometheusAddr == "" {
 		return exporter.ErrEndpointNotSet
 	}
-
-	// Use oltp metric exporter.
-	exporter, err := otlpmetricgrpc.New(
-		context.Background(),
-		otlpmetricgrpc.WithEndpoint(config.ExporterEndpoint),
-		otlpmetricgrpc.WithInsecure(), // TODO: enable security
-	)
-	if err != nil {
-		return err
+	// The instance ID tells the nodes apart, so it can't have a default.
+	if config.ServiceInstanceID == "" {
+		return exporter.ErrServiceInstanceIDNotSet
 	}
 
-	provider := sdkMetric.NewMeterProvider(
-		// Default period is 1m.
-		sdkMetric.WithReader(sdkMetric.NewPeriodicReader(exporter)),
+	providerOptions := []sdkMetric.Option{
 		sdkMetric.WithResource(
 			resource.NewWithAttributes(
 				semconv.SchemaURL,
 				semconv.ServiceNameKey.String(config.ServiceName),
 				semconv.ServiceVersionKey.String("1.0.0"),
-				semconv.ServiceInstanceIDKey.String("gno-node-1"),
+				semconv.ServiceInstanceIDKey.String(config.ServiceInstanceID),
 			),
 		),
-	)
-	otel.SetMeterProvider(provider)
-	meter := provider.Meter(config.MeterName)
+	}
+
+	// Use oltp metric exporter, to push the metrics.
+	if config.ExporterEndpoint != "" {
+		otlpExporter, err := otlpmetricgrpc.New(
+			context.Background(),
+			otlpmetricgrpc.WithEndpoint(config.ExporterEndpoint),
+			otlpmetricgrpc.WithInsecure(), // TODO: enable security
+		)
+		if err != nil {
+			return err
+		}
 
-	if BroadcastTxTimer, err = meter.Int64Histogram(
-		"broadcast_tx_hist",
-		metric.WithDescription("broadcast tx duration"),
-		metric.WithUnit("ms"),
-	); err != nil {
-		return err
+		// Default period is 1m.
+		providerOptions = append(providerOptions, sdkMetric.WithReader(sdkMetric.NewPeriodicReader(otlpExporter)))
 	}
 
-	if BuildBlockTimer, err = meter.Int64Histogram(
-		"build_block_hist",
-		metric.WithDescription("block build duration"),
-		metric.WithUnit("ms"),
-	); err != nil {
-		return err
+	// Use prometheus exporter, to let the metrics be pulled.
+	if config.PrometheusAddr != "" {
+		var err error
+		promExporter, err = exporter.NewPrometheus()
+		if err != nil {
+			return err
+		}
+		if err := promExporter.ListenAndServe(config.PrometheusAddr); err != nil {
+			return err
+		}
+
+		providerOptions = append(providerOptions, sdkMetric.WithReader(promExporter.Reader()))
 	}
 
-	return nil
+	provider = sdkMetric.NewMeterProvider(providerOptions...)
+	otel.SetMeterProvider(provider)
+	meter := provider.Meter(config.MeterName)
+
+	return initInstruments(meter)
+}
+
+// Shutdown flushes the metrics, and stops the exporters.
+func Shutdown(ctx context.Context) error {
+	var errs []error
+	if provider != nil {
+		errs = append(errs, provider.Shutdown(ctx))
+	}
+	if promExporter != nil {
+		errs = append(errs, promExporter.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
 }
@@ -0,0 +1,194 @@
+package metrics
+
+import (
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	// Consensus.
+	BuildBlockTimer      metric.Int64Histogram
+	BlockIntervalTimer   metric.Int64Histogram
+	BlockRounds          metric.Int64Histogram
+	BlockTxs             metric.Int64Histogram
+	BlockSizeBytes       metric.Int64Histogram
+	BlockHeight          Int64Gauge
+	ValidatorCount       Int64Gauge
+	ValidatorVotingPower Int64Gauge
+
+	// Mempool.
+	MempoolTxSizeBytes metric.Int64Histogram
+	MempoolFailedTxs   metric.Int64Counter
+	MempoolEvictedTxs  metric.Int64Counter
+	MempoolSize        Int64Gauge
+	MempoolSizeBytes   Int64Gauge
+
+	// Networking.
+	BroadcastTxTimer metric.Int64Histogram
+	InboundPeers     Int64Gauge
+	OutboundPeers    Int64Gauge
+	DialingPeers     Int64Gauge
+
+	// VM.
+	VMExecMsgs         metric.Int64Counter
+	VMCPUCycles        metric.Int64Histogram
+	VMGasUsed          metric.Int64Histogram
+	VMStoreCacheHits   metric.Int64Counter
+	VMStoreCacheMisses metric.Int64Counter
+)
+
+// Bucket boundaries of the histograms whose values span several orders
+// of magnitude.
+var (
+	countBuckets = []float64{0, 1, 5, 10, 50, 100, 500, 1000, 5000, 10000}
+	bytesBuckets = []float64{1 << 8, 1 << 10, 1 << 12, 1 << 14, 1 << 16, 1 << 18, 1 << 20, 1 << 22, 1 << 24}
+	vmBuckets    = []float64{1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9}
+)
+
+func initInstruments(meter metric.Meter) (err error) {
+	// Consensus.
+	if BuildBlockTimer, err = meter.Int64Histogram(
+		"build_block_hist",
+		metric.WithDescription("block build duration"),
+		metric.WithUnit("ms"),
+	); err != nil {
+		return err
+	}
+
+	if BlockIntervalTimer, err = meter.Int64Histogram(
+		"block_interval_hist",
+		metric.WithDescription("duration between the last two committed blocks"),
+		metric.WithUnit("ms"),
+	); err != nil {
+		return err
+	}
+
+	if BlockRounds, err = meter.Int64Histogram(
+		"block_rounds_hist",
+		metric.WithDescription("number of rounds to commit a block"),
+		metric.WithExplicitBucketBoundaries(0, 1, 2, 3, 5, 10),
+	); err != nil {
+		return err
+	}
+
+	if BlockTxs, err = meter.Int64Histogram(
+		"block_txs_hist",
+		metric.WithDescription("number of transactions in a committed block"),
+		metric.WithExplicitBucketBoundaries(countBuckets...),
+	); err != nil {
+		return err
+	}
+
+	if BlockSizeBytes, err = meter.Int64Histogram(
+		"block_size_hist",
+		metric.WithDescription("size of a committed block"),
+		metric.WithUnit("B"),
+		metric.WithExplicitBucketBoundaries(bytesBuckets...),
+	); err != nil {
+		return err
+	}
+
+	if err = BlockHeight.register(meter, "block_height", "height of the last committed block"); err != nil {
+		return err
+	}
+
+	if err = ValidatorCount.register(meter, "validator_count", "number of validators"); err != nil {
+		return err
+	}
+
+	if err = ValidatorVotingPower.register(meter, "validator_voting_power", "total voting power of the validators"); err != nil {
+		return err
+	}
+
+	// Mempool.
+	if MempoolTxSizeBytes, err = meter.Int64Histogram(
+		"mempool_tx_size_hist",
+		metric.WithDescription("size of a transaction added to the mempool"),
+		metric.WithUnit("B"),
+		metric.WithExplicitBucketBoundaries(bytesBuckets...),
+	); err != nil {
+		return err
+	}
+
+	if MempoolFailedTxs, err = meter.Int64Counter(
+		"mempool_failed_txs",
+		metric.WithDescription("number of transactions rejected by the mempool"),
+	); err != nil {
+		return err
+	}
+
+	if MempoolEvictedTxs, err = meter.Int64Counter(
+		"mempool_evicted_txs",
+		metric.WithDescription("number of transactions evicted from the mempool when rechecked"),
+	); err != nil {
+		return err
+	}
+
+	if err = MempoolSize.register(meter, "mempool_size", "number of transactions in the mempool"); err != nil {
+		return err
+	}
+
+	if err = MempoolSizeBytes.register(meter, "mempool_size_bytes", "total size of the transactions in the mempool"); err != nil {
+		return err
+	}
+
+	// Networking.
+	if BroadcastTxTimer, err = meter.Int64Histogram(
+		"broadcast_tx_hist",
+		metric.WithDescription("broadcast tx duration"),
+		metric.WithUnit("ms"),
+	); err != nil {
+		return err
+	}
+
+	if err = InboundPeers.register(meter, "inbound_peers", "number of inbound peers"); err != nil {
+		return err
+	}
+
+	if err = OutboundPeers.register(meter, "outbound_peers", "number of outbound peers"); err != nil {
+		return err
+	}
+
+	if err = DialingPeers.register(meter, "dialing_peers", "number of peers being dialed"); err != nil {
+		return err
+	}
+
+	// VM.
+	if VMExecMsgs, err = meter.Int64Counter(
+		"vm_exec_msgs",
+		metric.WithDescription("number of executed VM messages"),
+	); err != nil {
+		return err
+	}
+
+	if VMCPUCycles, err = meter.Int64Histogram(
+		"vm_cpu_cycles_hist",
+		metric.WithDescription("CPU cycles of a VM message execution"),
+		metric.WithExplicitBucketBoundaries(vmBuckets...),
+	); err != nil {
+		return err
+	}
+
+	if VMGasUsed, err = meter.Int64Histogram(
+		"vm_gas_used_hist",
+		metric.WithDescription("gas used by a VM message execution"),
+		metric.WithExplicitBucketBoundaries(vmBuckets...),
+	); err != nil {
+		return err
+	}
+
+	if VMStoreCacheHits, err = meter.Int64Counter(
+		"vm_store_cache_hits",
+		metric.WithDescription("number of objects found in the VM store cache"),
+	); err != nil {
+		return err
+	}
+
+	if VMStoreCacheMisses, err = meter.Int64Counter(
+		"vm_store_cache_misses",
+		metric.WithDescription("number of objects loaded from the VM backend store"),
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
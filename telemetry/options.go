@@ -33,3 +33,19 @@ func WithOptionServiceName(serviceName string) Option {
 		}
 	}
 }
+
+func WithOptionServiceInstanceID(instanceID string) Option {
+	return func(c *options.Config) {
+		if instanceID != "" {
+			c.ServiceInstanceID = instanceID
+		}
+	}
+}
+
+func WithOptionPrometheusAddr(prometheusAddr string) Option {
+	return func(c *options.Config) {
+		if prometheusAddr != "" {
+			c.PrometheusAddr = prometheusAddr
+		}
+	}
+}
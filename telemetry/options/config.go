@@ -1,8 +1,10 @@
 package options
 
 type Config struct {
-	MetricsEnabled   bool
-	MeterName        string
-	ServiceName      string
-	ExporterEndpoint string
+	MetricsEnabled    bool
+	MeterName         string
+	ServiceName       string
+	ServiceInstanceID string
+	ExporterEndpoint  string
+	PrometheusAddr    string
 }
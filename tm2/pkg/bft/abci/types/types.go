@@ -90,6 +90,32 @@ type RequestCommit struct {
 	RequestBase
 }
 
+type RequestListSnapshots struct {
+	RequestBase
+}
+
+// Offers a snapshot to restore, whose restored state must have the app hash.
+type RequestOfferSnapshot struct {
+	RequestBase
+	Snapshot Snapshot
+	AppHash  []byte
+}
+
+type RequestLoadSnapshotChunk struct {
+	RequestBase
+	Height int64
+	Format uint32
+	Chunk  uint32
+}
+
+// Applies a chunk of the offered snapshot.
+type RequestApplySnapshotChunk struct {
+	RequestBase
+	Index  uint32
+	Chunk  []byte
+	Sender string // nondeterministic
+}
+
 // ----------------------------------------
 // Response types
 
@@ -165,6 +191,11 @@ type ResponseCheckTx struct {
 	ResponseBase
 	GasWanted int64 // nondeterministic
 	GasUsed   int64
+
+	// Optional, used by the priority mempool to order txs.
+	Sender   crypto.Address // account whose sequence orders the tx.
+	Sequence uint64         // sequence of the tx for Sender.
+	GasFee   int64          // fee paid; the gas price is GasFee/GasWanted.
 }
 
 type ResponseDeliverTx struct {
@@ -184,6 +215,28 @@ type ResponseCommit struct {
 	ResponseBase
 }
 
+type ResponseListSnapshots struct {
+	ResponseBase
+	Snapshots []Snapshot
+}
+
+// The snapshot is rejected if Error is set.
+type ResponseOfferSnapshot struct {
+	ResponseBase
+}
+
+type ResponseLoadSnapshotChunk struct {
+	ResponseBase
+	Chunk []byte
+}
+
+// The chunk is rejected if Error is set.
+// Done is set once the last chunk was applied and the state restored.
+type ResponseApplySnapshotChunk struct {
+	ResponseBase
+	Done bool
+}
+
 // ----------------------------------------
 // Interface types
 
@@ -257,6 +310,16 @@ type LastCommitInfo struct {
 	Votes []VoteInfo
 }
 
+// Snapshot is a state sync snapshot of the application state,
+// split into chunks.
+type Snapshot struct {
+	Height   int64
+	Format   uint32
+	Chunks   uint32
+	Hash     []byte
+	Metadata []byte
+}
+
 // unstable
 type VoteInfo struct {
 	Address         crypto.Address
@@ -41,6 +41,15 @@ type Query interface {
 	//	SetOptionSync(key string, value string) (res abci.Result)
 }
 
+type StateSync interface {
+	Error() error
+
+	ListSnapshotsSync(abci.RequestListSnapshots) (abci.ResponseListSnapshots, error)
+	OfferSnapshotSync(abci.RequestOfferSnapshot) (abci.ResponseOfferSnapshot, error)
+	LoadSnapshotChunkSync(abci.RequestLoadSnapshotChunk) (abci.ResponseLoadSnapshotChunk, error)
+	ApplySnapshotChunkSync(abci.RequestApplySnapshotChunk) (abci.ResponseApplySnapshotChunk, error)
+}
+
 //-----------------------------------------------------------------------------------------
 // Implements Consensus (subset of abcicli.Client)
 
@@ -143,3 +152,36 @@ func (app *query) InfoSync(req abci.RequestInfo) (abci.ResponseInfo, error) {
 func (app *query) QuerySync(reqQuery abci.RequestQuery) (abci.ResponseQuery, error) {
 	return app.appConn.QuerySync(reqQuery)
 }
+
+//------------------------------------------------
+// Implements StateSync (subset of abcicli.Client)
+
+type stateSync struct {
+	appConn abcicli.Client
+}
+
+func NewStateSync(appConn abcicli.Client) *stateSync {
+	return &stateSync{
+		appConn: appConn,
+	}
+}
+
+func (app *stateSync) Error() error {
+	return app.appConn.Error()
+}
+
+func (app *stateSync) ListSnapshotsSync(req abci.RequestListSnapshots) (abci.ResponseListSnapshots, error) {
+	return app.appConn.ListSnapshotsSync(req)
+}
+
+func (app *stateSync) OfferSnapshotSync(req abci.RequestOfferSnapshot) (abci.ResponseOfferSnapshot, error) {
+	return app.appConn.OfferSnapshotSync(req)
+}
+
+func (app *stateSync) LoadSnapshotChunkSync(req abci.RequestLoadSnapshotChunk) (abci.ResponseLoadSnapshotChunk, error) {
+	return app.appConn.LoadSnapshotChunkSync(req)
+}
+
+func (app *stateSync) ApplySnapshotChunkSync(req abci.RequestApplySnapshotChunk) (abci.ResponseApplySnapshotChunk, error) {
+	return app.appConn.ApplySnapshotChunkSync(req)
+}
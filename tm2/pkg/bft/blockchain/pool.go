@@ -104,6 +104,15 @@ func (pool *BlockPool) OnStart() error {
 	return nil
 }
 
+// setHeight sets the height of the next block to sync, before the pool is
+// started.
+func (pool *BlockPool) setHeight(height int64) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	pool.height = height
+}
+
 // spawns requesters as needed
 func (pool *BlockPool) makeRequestersRoutine() {
 	for {
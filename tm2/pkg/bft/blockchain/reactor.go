@@ -119,6 +119,28 @@ func (bcR *BlockchainReactor) OnStart() error {
 	return nil
 }
 
+// SwitchToFastSync starts fast syncing from the given state, for a node
+// bootstrapped by state sync, whose reactor was created without fast sync.
+func (bcR *BlockchainReactor) SwitchToFastSync(state sm.State) error {
+	if bcR.fastSync {
+		return errors.New("already fast syncing")
+	}
+	if state.LastBlockHeight != bcR.store.Height() {
+		return fmt.Errorf("state (%v) and store (%v) height mismatch", state.LastBlockHeight,
+			bcR.store.Height())
+	}
+
+	bcR.Logger.Info("SwitchToFastSync", "height", state.LastBlockHeight)
+	bcR.fastSync = true
+	bcR.initialState = state
+	bcR.pool.setHeight(state.LastBlockHeight + 1)
+	if err := bcR.pool.Start(); err != nil {
+		return err
+	}
+	go bcR.poolRoutine()
+	return nil
+}
+
 // OnStop implements cmn.Service.
 func (bcR *BlockchainReactor) OnStop() {
 	bcR.pool.Stop()
@@ -1350,6 +1350,10 @@ func (cs *ConsensusState) finalizeCommit(height int64) {
 
 	fail.Fail() // XXX
 
+	if telemetry.MetricsEnabled() {
+		cs.recordMetrics(block, blockParts)
+	}
+
 	// Create a copy of the state for staging and an event cache for txs.
 	stateCopy := cs.state.Copy()
 
@@ -1383,6 +1387,31 @@ func (cs *ConsensusState) finalizeCommit(height int64) {
 	// * cs.StartTime is set to when we will start round0.
 }
 
+// recordMetrics records the metrics of the block being committed.
+func (cs *ConsensusState) recordMetrics(block *types.Block, blockParts *types.PartSet) {
+	ctx := context.Background()
+
+	metrics.BlockHeight.Set(block.Height)
+	metrics.BlockRounds.Record(ctx, int64(cs.CommitRound))
+	metrics.BlockTxs.Record(ctx, block.NumTxs)
+
+	// The size of a block is the size of its parts.
+	var size int
+	for i := 0; i < blockParts.Total(); i++ {
+		if part := blockParts.GetPart(i); part != nil {
+			size += len(part.Bytes)
+		}
+	}
+	metrics.BlockSizeBytes.Record(ctx, int64(size))
+
+	if block.Height > 1 {
+		metrics.BlockIntervalTimer.Record(ctx, block.Time.Sub(cs.state.LastBlockTime).Milliseconds())
+	}
+
+	metrics.ValidatorCount.Set(int64(cs.Validators.Size()))
+	metrics.ValidatorVotingPower.Set(cs.Validators.TotalVotingPower())
+}
+
 // -----------------------------------------------------------------------------
 
 func (cs *ConsensusState) defaultSetProposal(proposal *types.Proposal) error {
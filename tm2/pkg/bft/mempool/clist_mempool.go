@@ -3,6 +3,7 @@ package mempool
 import (
 	"bytes"
 	"container/list"
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"log/slog"
@@ -10,12 +11,15 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/gnolang/gno/telemetry"
+	"github.com/gnolang/gno/telemetry/metrics"
 	auto "github.com/gnolang/gno/tm2/pkg/autofile"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
 	cfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/clist"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/log"
 	osm "github.com/gnolang/gno/tm2/pkg/os"
@@ -63,6 +67,10 @@ type CListMempool struct {
 	// A log of mempool txs
 	wal *auto.AutoFile
 
+	// Indexes the txs of a priority mempool, see NewPriorityMempool.
+	// nil for a FIFO mempool.
+	priority *priorityIndex
+
 	logger *slog.Logger
 }
 
@@ -185,6 +193,10 @@ func (mem *CListMempool) Flush() {
 
 	mem.txsMap = sync.Map{}
 	_ = atomic.SwapInt64(&mem.txsBytes, 0)
+
+	if mem.priority != nil {
+		mem.priority.reset()
+	}
 }
 
 // TxsFront returns the first transaction in the ordered list for peer
@@ -222,9 +234,13 @@ func (mem *CListMempool) CheckTxWithInfo(tx types.Tx, cb func(abci.Response), tx
 		txSize   = len(tx)
 	)
 
-	// Check max pending txs bytes
-	if memSize >= mem.config.Size ||
-		int64(txSize)+txsBytes > mem.config.MaxPendingTxsBytes {
+	// Check max pending txs bytes.
+	// A priority mempool may evict txs to make room for the tx, once it is
+	// checked.
+	if mem.priority == nil && (memSize >= mem.config.Size ||
+		int64(txSize)+txsBytes > mem.config.MaxPendingTxsBytes) ||
+		mem.priority != nil && (mem.config.Size == 0 ||
+			int64(txSize) > mem.config.MaxPendingTxsBytes) {
 		return MempoolIsFullError{
 			memSize, mem.config.Size,
 			txsBytes, mem.config.MaxPendingTxsBytes,
@@ -317,7 +333,7 @@ func (mem *CListMempool) reqResCb(tx []byte, peerID uint16, externalCb func(abci
 			panic("recheck cursor is not nil in reqResCb")
 		}
 
-		mem.resCbFirstTime(tx, peerID, res)
+		res = mem.resCbFirstTime(tx, peerID, res)
 
 		// Passed in by the caller of CheckTx, eg. the RPC.
 		// The external callback cannot modify the result.
@@ -330,15 +346,26 @@ func (mem *CListMempool) reqResCb(tx []byte, peerID uint16, externalCb func(abci
 // Called from:
 //   - resCbFirstTime (lock not held) if tx is valid
 func (mem *CListMempool) addTx(memTx *mempoolTx) {
+	if mem.priority != nil {
+		mem.priority.add(memTx)
+	}
 	e := mem.txs.PushBack(memTx)
 	mem.txsMap.Store(txKey(memTx.tx), e)
 	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
+
+	if telemetry.MetricsEnabled() {
+		metrics.MempoolTxSizeBytes.Record(context.Background(), int64(len(memTx.tx)))
+		mem.recordSizeMetrics()
+	}
 }
 
 // Called from:
 //   - Update (lock held) if tx was committed
 //   - resCbRecheck (lock not held) if tx was invalidated
 func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromCache bool) {
+	if mem.priority != nil {
+		mem.priority.remove(elem.Value.(*mempoolTx))
+	}
 	mem.txs.Remove(elem)
 	elem.DetachPrev()
 	mem.txsMap.Delete(txKey(tx))
@@ -347,13 +374,25 @@ func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromC
 	if removeFromCache {
 		mem.cache.Remove(tx)
 	}
+
+	if telemetry.MetricsEnabled() {
+		mem.recordSizeMetrics()
+	}
+}
+
+// recordSizeMetrics records the current size of the mempool.
+func (mem *CListMempool) recordSizeMetrics() {
+	metrics.MempoolSize.Set(int64(mem.Size()))
+	metrics.MempoolSizeBytes.Set(mem.TxsBytes())
 }
 
 // callback, which is called after the app checked the tx for the first time.
+// It returns the response, with an error if the tx passed CheckTx but was
+// rejected by a priority mempool.
 //
 // The case where the app checks the tx for the second and subsequent times is
 // handled by the resCbRecheck callback.
-func (mem *CListMempool) resCbFirstTime(tx []byte, peerID uint16, res abci.Response) {
+func (mem *CListMempool) resCbFirstTime(tx []byte, peerID uint16, res abci.Response) abci.Response {
 	switch res := res.(type) {
 	case abci.ResponseCheckTx:
 		if res.Error == nil {
@@ -361,9 +400,20 @@ func (mem *CListMempool) resCbFirstTime(tx []byte, peerID uint16, res abci.Respo
 				height:    mem.height,
 				gasWanted: res.GasWanted,
 				tx:        tx,
+				sender:    res.Sender,
+				sequence:  res.Sequence,
+				gasFee:    res.GasFee,
 			}
 			memTx.senders.Store(peerID, true)
-			mem.addTx(memTx)
+			if mem.priority == nil {
+				mem.addTx(memTx)
+			} else if err := mem.addPriorityTx(memTx); err != nil {
+				mem.logger.Info("Rejected transaction", "tx", txID(tx), "err", err)
+				// remove from cache (it might be good later)
+				mem.cache.Remove(tx)
+				res.Error = abci.StringError(err.Error())
+				return res
+			}
 			mem.logger.Info("Added good transaction",
 				"tx", txID(tx),
 				"res", res,
@@ -374,11 +424,16 @@ func (mem *CListMempool) resCbFirstTime(tx []byte, peerID uint16, res abci.Respo
 		} else {
 			// ignore bad transaction
 			mem.logger.Info("Rejected bad transaction", "tx", txID(tx), "res", res, "err", res.Error)
+			if telemetry.MetricsEnabled() {
+				metrics.MempoolFailedTxs.Add(context.Background(), 1)
+			}
 			// remove from cache (it might be good later)
 			mem.cache.Remove(tx)
 		}
+		return res
 	default:
 		// ignore other messages
+		return res
 	}
 }
 
@@ -402,6 +457,9 @@ func (mem *CListMempool) resCbRecheck(req abci.Request, res abci.Response) {
 		} else {
 			// Tx became invalidated due to newly committed block.
 			mem.logger.Info("Tx is no longer valid", "tx", txID(tx), "res", res, "err", res.Error)
+			if telemetry.MetricsEnabled() {
+				metrics.MempoolEvictedTxs.Add(context.Background(), 1)
+			}
 			// NOTE: we remove tx from the cache because it might be good later
 			mem.removeTx(tx, mem.recheckCursor, true)
 		}
@@ -462,8 +520,7 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxDataBytes, maxGas int64) types.Tx
 	// size per tx, and set the initial capacity based off of that.
 	// txs := make([]types.Tx, 0, min(mem.txs.Len(), max/mem.avgTxSize))
 	txs := make([]types.Tx, 0, mem.txs.Len())
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
+	for _, memTx := range mem.orderedTxs() {
 		// Check total size requirement
 		if maxDataBytes > -1 && totalBytes+int64(len(memTx.tx)) > maxDataBytes {
 			return txs
@@ -497,13 +554,28 @@ func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
 	}
 
 	txs := make([]types.Tx, 0, min(mem.txs.Len(), max))
-	for e := mem.txs.Front(); e != nil && len(txs) <= max; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
+	for _, memTx := range mem.orderedTxs() {
+		if len(txs) > max {
+			break
+		}
 		txs = append(txs, memTx.tx)
 	}
 	return txs
 }
 
+// orderedTxs returns the txs of the mempool in the order they are reaped:
+// by arrival, or by priority for a priority mempool.
+func (mem *CListMempool) orderedTxs() []*mempoolTx {
+	if mem.priority != nil {
+		return mem.priorityOrder()
+	}
+	txs := make([]*mempoolTx, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		txs = append(txs, e.Value.(*mempoolTx))
+	}
+	return txs
+}
+
 func (mem *CListMempool) Update(
 	height int64,
 	txs types.Txs,
@@ -514,6 +586,9 @@ func (mem *CListMempool) Update(
 	// Set height
 	mem.height = height
 	mem.notifiedTxsAvailable = false
+	if mem.priority != nil {
+		mem.priority.resetDropped()
+	}
 
 	if preCheck != nil {
 		mem.preCheck = preCheck
@@ -606,6 +681,12 @@ type mempoolTx struct {
 	gasWanted int64    // amount of gas this tx states it will require
 	tx        types.Tx //
 
+	// set by the app, for the priority mempool.
+	sender   crypto.Address
+	sequence uint64
+	gasFee   int64
+	arrival  uint64 // order of arrival in the priority mempool.
+
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
 	senders sync.Map
@@ -5,9 +5,21 @@ import "github.com/gnolang/gno/tm2/pkg/errors"
 // -----------------------------------------------------------------------------
 // MempoolConfig
 
+// Mempool types.
+const (
+	// TypeFIFO orders txs by arrival.
+	TypeFIFO = "fifo"
+
+	// TypePriority orders txs by gas price, keeping each sender's sequence
+	// order, evicts the lowest-priced txs when full, and allows a tx to be
+	// replaced by one with the same sequence and a higher fee.
+	TypePriority = "priority"
+)
+
 // MempoolConfig defines the configuration options for the Tendermint mempool
 type MempoolConfig struct {
 	RootDir            string `toml:"home"`
+	Type               string `toml:"type" comment:"Mempool type: \"fifo\" orders txs by arrival, \"priority\" orders txs by gas price,\n keeping each sender's sequence order"`
 	Recheck            bool   `toml:"recheck"`
 	Broadcast          bool   `toml:"broadcast"`
 	WalPath            string `toml:"wal_dir"`
@@ -19,6 +31,7 @@ type MempoolConfig struct {
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool
 func DefaultMempoolConfig() *MempoolConfig {
 	return &MempoolConfig{
+		Type:      TypeFIFO,
 		Recheck:   true,
 		Broadcast: true,
 		WalPath:   "",
@@ -50,6 +63,9 @@ func (cfg *MempoolConfig) WalEnabled() bool {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *MempoolConfig) ValidateBasic() error {
+	if cfg.Type != TypeFIFO && cfg.Type != TypePriority {
+		return errors.New("invalid type %q", cfg.Type)
+	}
 	if cfg.Size < 0 {
 		return errors.New("size can't be negative")
 	}
@@ -9,6 +9,16 @@ import (
 // ErrTxInCache is returned to the client if we saw tx earlier
 var ErrTxInCache = errors.New("Tx already exists in cache")
 
+// ErrReplacementUnderpriced is returned by the priority mempool for a tx with
+// the same sender and sequence as a tx of the mempool, but which doesn't pay
+// a higher fee.
+var ErrReplacementUnderpriced = errors.New("replacement tx must pay a higher fee than the tx it replaces")
+
+// ErrSenderTxDropped is returned by the priority mempool for a tx which
+// follows a tx of the same sender that was evicted or rejected since the last
+// block.
+var ErrSenderTxDropped = errors.New("a previous tx of the sender was dropped from the mempool")
+
 // TxTooLargeError means the tx is too big to be sent in a message to other peers
 type TxTooLargeError struct {
 	max    int64
@@ -0,0 +1,328 @@
+package mempool
+
+import (
+	"container/heap"
+	"math/bits"
+	"sort"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
+	"github.com/gnolang/gno/tm2/pkg/clist"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+// NewPriorityMempool returns a mempool which, unlike the FIFO mempool
+// returned by NewCListMempool, orders txs by gas price.
+//
+// The sender, sequence and fee of txs are reported by the application in
+// ResponseCheckTx. Txs of the same sender are kept in sequence order; txs
+// which have no sender are ordered only by gas price. When the mempool is
+// full, the lowest-priced txs are evicted to make room for a higher-priced
+// tx, and a tx with the same sender and sequence as a tx in the mempool
+// replaces it if it pays a higher fee (the application must then accept
+// such a tx in CheckTx, as the auth ante handler does). Fees are compared by
+// amount, so the application must only accept fees in a single denom.
+//
+// Txs are still gossiped in arrival order.
+func NewPriorityMempool(
+	config *cfg.MempoolConfig,
+	proxyAppConn appconn.Mempool,
+	height int64,
+	maxTxBytes int64,
+	options ...CListMempoolOption,
+) *CListMempool {
+	mem := NewCListMempool(config, proxyAppConn, height, maxTxBytes, options...)
+	mem.priority = newPriorityIndex()
+	return mem
+}
+
+// priorityIndex indexes the txs of a priority mempool by sender.
+type priorityIndex struct {
+	senders map[crypto.Address]map[uint64]*mempoolTx // sender -> sequence -> tx
+	arrival uint64                                   // arrival counter.
+
+	// the lowest sequence of the txs of each sender that were evicted or
+	// rejected since the last block. The checkTx state of the application
+	// has already accounted for them, so the later txs of the sender can't
+	// be included in a block before they are resubmitted.
+	dropped map[crypto.Address]uint64
+}
+
+func newPriorityIndex() *priorityIndex {
+	return &priorityIndex{
+		senders: make(map[crypto.Address]map[uint64]*mempoolTx),
+		dropped: make(map[crypto.Address]uint64),
+	}
+}
+
+func (idx *priorityIndex) get(sender crypto.Address, sequence uint64) *mempoolTx {
+	return idx.senders[sender][sequence]
+}
+
+func (idx *priorityIndex) add(memTx *mempoolTx) {
+	idx.arrival++
+	memTx.arrival = idx.arrival
+	if memTx.sender.IsZero() {
+		return
+	}
+	txs := idx.senders[memTx.sender]
+	if txs == nil {
+		txs = make(map[uint64]*mempoolTx)
+		idx.senders[memTx.sender] = txs
+	}
+	txs[memTx.sequence] = memTx
+}
+
+func (idx *priorityIndex) remove(memTx *mempoolTx) {
+	if memTx.sender.IsZero() {
+		return
+	}
+	txs := idx.senders[memTx.sender]
+	if txs[memTx.sequence] != memTx {
+		return
+	}
+	delete(txs, memTx.sequence)
+	if len(txs) == 0 {
+		delete(idx.senders, memTx.sender)
+	}
+}
+
+// drop records that memTx was evicted or rejected.
+func (idx *priorityIndex) drop(memTx *mempoolTx) {
+	if memTx.sender.IsZero() {
+		return
+	}
+	if seq, ok := idx.dropped[memTx.sender]; !ok || memTx.sequence < seq {
+		idx.dropped[memTx.sender] = memTx.sequence
+	}
+}
+
+// blocked returns whether memTx follows a dropped tx of its sender.
+func (idx *priorityIndex) blocked(memTx *mempoolTx) bool {
+	if memTx.sender.IsZero() {
+		return false
+	}
+	seq, ok := idx.dropped[memTx.sender]
+	return ok && memTx.sequence > seq
+}
+
+func (idx *priorityIndex) reset() {
+	idx.senders = make(map[crypto.Address]map[uint64]*mempoolTx)
+	idx.dropped = make(map[crypto.Address]uint64)
+}
+
+// resetDropped is called on Update: once the application has rechecked the
+// txs of the mempool, dropped txs can be resubmitted.
+func (idx *priorityIndex) resetDropped() {
+	idx.dropped = make(map[crypto.Address]uint64)
+}
+
+// addPriorityTx adds memTx, which passed CheckTx, to a priority mempool. It
+// replaces the tx with the same sender and sequence, if any, and evicts
+// lower-priced txs if the mempool is full. An error is returned if memTx is
+// rejected.
+func (mem *CListMempool) addPriorityTx(memTx *mempoolTx) error {
+	idx := mem.priority
+	if idx.blocked(memTx) {
+		idx.drop(memTx)
+		return ErrSenderTxDropped
+	}
+
+	var replaced *mempoolTx
+	if !memTx.sender.IsZero() {
+		replaced = idx.get(memTx.sender, memTx.sequence)
+	}
+	if replaced != nil && memTx.gasFee <= replaced.gasFee {
+		return ErrReplacementUnderpriced
+	}
+
+	// make room for memTx.
+	evicted, err := mem.evictionsFor(memTx, replaced)
+	if err != nil {
+		idx.drop(memTx)
+		return err
+	}
+
+	if replaced != nil {
+		mem.removeMemTx(replaced, false)
+		mem.logger.Info("Replaced transaction", "tx", txID(replaced.tx), "by", txID(memTx.tx))
+	}
+	for _, e := range evicted {
+		idx.drop(e)
+		mem.removeMemTx(e, true)
+		mem.logger.Info("Evicted transaction", "tx", txID(e.tx), "for", txID(memTx.tx))
+	}
+	mem.addTx(memTx)
+	return nil
+}
+
+// evictionsFor returns the txs to evict to make room for memTx, if the
+// mempool is full, or a MempoolIsFullError if memTx doesn't pay more than the
+// txs which could be evicted.
+//
+// Only the last tx of a sender can be evicted, as evicting any other would
+// make the following ones invalid, and the txs of the sender of memTx are
+// never evicted.
+func (mem *CListMempool) evictionsFor(memTx *mempoolTx, replaced *mempoolTx) ([]*mempoolTx, error) {
+	size, txsBytes := mem.Size(), mem.TxsBytes()
+	if replaced != nil {
+		size--
+		txsBytes -= int64(len(replaced.tx))
+	}
+	full := func() bool {
+		return size >= mem.config.Size ||
+			int64(len(memTx.tx))+txsBytes > mem.config.MaxPendingTxsBytes
+	}
+	if !full() {
+		return nil, nil
+	}
+	fullErr := MempoolIsFullError{
+		size, mem.config.Size,
+		txsBytes, mem.config.MaxPendingTxsBytes,
+	}
+
+	// the txs of each sender, in sequence order, and the last tx of each
+	// sender and the txs without sender, lowest-priced first.
+	bySender := make(map[crypto.Address][]*mempoolTx)
+	var last txHeap
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		tx := e.Value.(*mempoolTx)
+		switch {
+		case tx == replaced:
+		case tx.sender.IsZero():
+			last.txs = append(last.txs, tx)
+		case tx.sender != memTx.sender:
+			bySender[tx.sender] = append(bySender[tx.sender], tx)
+		}
+	}
+	for _, txs := range bySender {
+		sortBySequence(txs)
+		last.txs = append(last.txs, txs[len(txs)-1])
+	}
+	last.less = func(a, b *mempoolTx) bool {
+		return comparePrices(a, b) < 0 || (comparePrices(a, b) == 0 && a.arrival > b.arrival)
+	}
+	heap.Init(&last)
+
+	var evicted []*mempoolTx
+	for full() {
+		if last.Len() == 0 {
+			return nil, fullErr
+		}
+		tx := heap.Pop(&last).(*mempoolTx)
+		if comparePrices(tx, memTx) >= 0 {
+			return nil, fullErr
+		}
+		evicted = append(evicted, tx)
+		size--
+		txsBytes -= int64(len(tx.tx))
+		if !tx.sender.IsZero() {
+			// the previous tx of the sender is now its last one.
+			txs := bySender[tx.sender]
+			txs = txs[:len(txs)-1]
+			bySender[tx.sender] = txs
+			if len(txs) > 0 {
+				heap.Push(&last, txs[len(txs)-1])
+			}
+		}
+	}
+	return evicted, nil
+}
+
+// removeMemTx removes memTx from the mempool.
+func (mem *CListMempool) removeMemTx(memTx *mempoolTx, removeFromCache bool) {
+	if e, ok := mem.txsMap.Load(txKey(memTx.tx)); ok {
+		mem.removeTx(memTx.tx, e.(*clist.CElement), removeFromCache)
+	}
+}
+
+// priorityOrder returns the txs of a priority mempool, highest-priced first,
+// with the txs of each sender in sequence order: a tx comes after all the
+// txs of its sender with lower sequences, even if they have a lower price.
+func (mem *CListMempool) priorityOrder() []*mempoolTx {
+	// the txs of each sender, in sequence order, and the first tx of each
+	// sender and the txs without sender, highest-priced first.
+	bySender := make(map[crypto.Address][]*mempoolTx)
+	var next txHeap
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		tx := e.Value.(*mempoolTx)
+		if tx.sender.IsZero() {
+			next.txs = append(next.txs, tx)
+		} else {
+			bySender[tx.sender] = append(bySender[tx.sender], tx)
+		}
+	}
+	for sender, txs := range bySender {
+		sortBySequence(txs)
+		next.txs = append(next.txs, txs[0])
+		bySender[sender] = txs[1:]
+	}
+	next.less = func(a, b *mempoolTx) bool {
+		return comparePrices(a, b) > 0 || (comparePrices(a, b) == 0 && a.arrival < b.arrival)
+	}
+	heap.Init(&next)
+
+	ordered := make([]*mempoolTx, 0, mem.txs.Len())
+	for next.Len() > 0 {
+		tx := heap.Pop(&next).(*mempoolTx)
+		ordered = append(ordered, tx)
+		if txs := bySender[tx.sender]; !tx.sender.IsZero() && len(txs) > 0 {
+			heap.Push(&next, txs[0])
+			bySender[tx.sender] = txs[1:]
+		}
+	}
+	return ordered
+}
+
+// comparePrices compares the gas prices, gasFee/gasWanted, of a and b.
+func comparePrices(a, b *mempoolTx) int {
+	// compare a.gasFee*b.gasWanted and b.gasFee*a.gasWanted, which can't
+	// overflow as 128 bits integers.
+	ahi, alo := bits.Mul64(nonNegative(a.gasFee), nonNegative(b.gasWanted))
+	bhi, blo := bits.Mul64(nonNegative(b.gasFee), nonNegative(a.gasWanted))
+	if ahi != bhi {
+		return compareUint64(ahi, bhi)
+	}
+	return compareUint64(alo, blo)
+}
+
+func compareUint64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func nonNegative(n int64) uint64 {
+	if n < 0 {
+		return 0
+	}
+	return uint64(n)
+}
+
+func sortBySequence(txs []*mempoolTx) {
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].sequence < txs[j].sequence
+	})
+}
+
+// txHeap implements heap.Interface.
+type txHeap struct {
+	txs  []*mempoolTx
+	less func(a, b *mempoolTx) bool
+}
+
+func (h *txHeap) Len() int           { return len(h.txs) }
+func (h *txHeap) Less(i, j int) bool { return h.less(h.txs[i], h.txs[j]) }
+func (h *txHeap) Swap(i, j int)      { h.txs[i], h.txs[j] = h.txs[j], h.txs[i] }
+func (h *txHeap) Push(x interface{}) { h.txs = append(h.txs, x.(*mempoolTx)) }
+
+func (h *txHeap) Pop() interface{} {
+	tx := h.txs[len(h.txs)-1]
+	h.txs = h.txs[:len(h.txs)-1]
+	return tx
+}
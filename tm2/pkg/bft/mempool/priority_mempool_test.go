@@ -0,0 +1,181 @@
+package mempool
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/log"
+)
+
+// priorityApp is an application whose txs are "sender/sequence/fee/gas",
+// "sender" being empty for txs without sender.
+type priorityApp struct {
+	abci.BaseApplication
+}
+
+func (priorityApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	parts := strings.Split(string(req.Tx), "/")
+	seq, _ := strconv.ParseUint(parts[1], 10, 64)
+	fee, _ := strconv.ParseInt(parts[2], 10, 64)
+	gas, _ := strconv.ParseInt(parts[3], 10, 64)
+	var sender crypto.Address
+	copy(sender[:], parts[0])
+	return abci.ResponseCheckTx{
+		GasWanted: gas,
+		Sender:    sender,
+		Sequence:  seq,
+		GasFee:    fee,
+	}
+}
+
+func priorityTx(sender string, seq uint64, fee, gas int64) types.Tx {
+	return types.Tx(fmt.Sprintf("%s/%d/%d/%d", sender, seq, fee, gas))
+}
+
+func newPriorityMempool(t *testing.T, size int) *CListMempool {
+	t.Helper()
+
+	appConnMem, _ := proxy.NewLocalClientCreator(priorityApp{}).NewABCIClient()
+	appConnMem.SetLogger(log.NewNoopLogger())
+	require.NoError(t, appConnMem.Start())
+	t.Cleanup(func() { appConnMem.Stop() })
+
+	config := cfg.TestMempoolConfig()
+	config.Type = cfg.TypePriority
+	config.Size = size
+	mempool := NewPriorityMempool(config, appConnMem, 0, testMaxTxBytes)
+	mempool.SetLogger(log.NewNoopLogger())
+	return mempool
+}
+
+// checkPriorityTx runs CheckTx for tx, and returns the error of the
+// response, if any.
+func checkPriorityTx(t *testing.T, mempool Mempool, tx types.Tx) error {
+	t.Helper()
+
+	var resErr error
+	err := mempool.CheckTx(tx, func(res abci.Response) {
+		if e := res.(abci.ResponseCheckTx).Error; e != nil {
+			resErr = e
+		}
+	})
+	if err != nil {
+		return err
+	}
+	require.NoError(t, mempool.FlushAppConn())
+	return resErr
+}
+
+func TestPriorityMempoolOrder(t *testing.T) {
+	t.Parallel()
+
+	mempool := newPriorityMempool(t, 100)
+	txs := types.Txs{
+		priorityTx("a", 0, 1, 1),
+		priorityTx("a", 1, 100, 1),
+		priorityTx("b", 0, 50, 1),
+		priorityTx("", 0, 10, 1),
+		priorityTx("c", 0, 60, 2), // gas price 30
+		priorityTx("", 0, 30, 1),  // same price, arrived later
+	}
+	for _, tx := range txs {
+		require.NoError(t, checkPriorityTx(t, mempool, tx))
+	}
+
+	expected := types.Txs{txs[2], txs[4], txs[5], txs[3], txs[0], txs[1]}
+	assert.Equal(t, expected, mempool.ReapMaxTxs(-1))
+	assert.Equal(t, expected, mempool.ReapMaxBytesMaxGas(-1, -1))
+	assert.Equal(t, expected[:3], mempool.ReapMaxBytesMaxGas(-1, 4))
+}
+
+func TestPriorityMempoolEviction(t *testing.T) {
+	t.Parallel()
+
+	mempool := newPriorityMempool(t, 3)
+	a0, a1 := priorityTx("a", 0, 1, 1), priorityTx("a", 1, 20, 1)
+	b0 := priorityTx("b", 0, 5, 1)
+	for _, tx := range []types.Tx{a0, a1, b0} {
+		require.NoError(t, checkPriorityTx(t, mempool, tx))
+	}
+
+	// only the last tx of a sender can be evicted: b0, not a0.
+	c0 := priorityTx("c", 0, 10, 1)
+	require.NoError(t, checkPriorityTx(t, mempool, c0))
+	assert.Equal(t, types.Txs{c0, a0, a1}, mempool.ReapMaxTxs(-1))
+
+	// a lower-priced tx is rejected.
+	err := checkPriorityTx(t, mempool, priorityTx("d", 0, 2, 1))
+	assert.ErrorContains(t, err, "mempool is full")
+
+	// as well as the later txs of its sender, until the next block.
+	err = checkPriorityTx(t, mempool, priorityTx("d", 1, 100, 1))
+	assert.ErrorContains(t, err, ErrSenderTxDropped.Error())
+
+	require.NoError(t, mempool.Update(1, types.Txs{c0}, []abci.ResponseDeliverTx{{}}, nil, 0))
+	d0 := priorityTx("d", 0, 100, 1)
+	require.NoError(t, checkPriorityTx(t, mempool, d0))
+	assert.Equal(t, types.Txs{d0, a0, a1}, mempool.ReapMaxTxs(-1))
+
+	// several txs may be evicted, the txs of the sender excepted.
+	e0 := priorityTx("e", 0, 200, 1)
+	mempool.config.Size = 2
+	require.NoError(t, checkPriorityTx(t, mempool, e0))
+	assert.Equal(t, types.Txs{e0, d0}, mempool.ReapMaxTxs(-1))
+}
+
+func TestPriorityMempoolReplacement(t *testing.T) {
+	t.Parallel()
+
+	mempool := newPriorityMempool(t, 100)
+	a0, b0 := priorityTx("a", 0, 10, 1), priorityTx("b", 0, 5, 1)
+	for _, tx := range []types.Tx{a0, b0} {
+		require.NoError(t, checkPriorityTx(t, mempool, tx))
+	}
+
+	// the replacement must pay a higher fee.
+	err := checkPriorityTx(t, mempool, priorityTx("a", 0, 10, 2))
+	assert.ErrorContains(t, err, ErrReplacementUnderpriced.Error())
+
+	a0bis := priorityTx("a", 0, 11, 1)
+	require.NoError(t, checkPriorityTx(t, mempool, a0bis))
+	assert.Equal(t, 2, mempool.Size())
+	assert.Equal(t, types.Txs{a0bis, b0}, mempool.ReapMaxTxs(-1))
+
+	// txs without sender are never replaced.
+	require.NoError(t, checkPriorityTx(t, mempool, priorityTx("", 0, 1, 1)))
+	require.NoError(t, checkPriorityTx(t, mempool, priorityTx("", 0, 2, 1)))
+	assert.Equal(t, 4, mempool.Size())
+}
+
+func TestComparePrices(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		feeA, gasA, feeB, gasB int64
+		expected               int
+	}{
+		{1, 1, 1, 1, 0},
+		{2, 1, 1, 1, 1},
+		{1, 2, 1, 1, -1},
+		{10, 4, 5, 2, 0},
+		{1, 0, 1, 1, 1},
+		{0, 0, 0, 1, 0},
+		{1 << 62, 1, 1 << 62, 2, 1},
+	}
+	for _, tt := range tests {
+		a := &mempoolTx{gasFee: tt.feeA, gasWanted: tt.gasA}
+		b := &mempoolTx{gasFee: tt.feeB, gasWanted: tt.gasB}
+		assert.Equal(t, tt.expected, comparePrices(a, b), "%+v", tt)
+		assert.Equal(t, -tt.expected, comparePrices(b, a), "%+v", tt)
+	}
+}
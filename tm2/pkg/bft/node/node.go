@@ -4,6 +4,7 @@ package node
 // is enabled by the user by setting a profiling address
 
 import (
+	"bytes"
 	"fmt"
 	"log/slog"
 	"net"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/file"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
 	"github.com/rs/cors"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
@@ -22,6 +24,7 @@ import (
 	cfg "github.com/gnolang/gno/tm2/pkg/bft/config"
 	cs "github.com/gnolang/gno/tm2/pkg/bft/consensus"
 	mempl "github.com/gnolang/gno/tm2/pkg/bft/mempool"
+	memplcfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
 	"github.com/gnolang/gno/tm2/pkg/bft/privval"
 	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
 	rpccore "github.com/gnolang/gno/tm2/pkg/bft/rpc/core"
@@ -30,6 +33,7 @@ import (
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/null"
+	"github.com/gnolang/gno/tm2/pkg/bft/statesync"
 	"github.com/gnolang/gno/tm2/pkg/bft/store"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	tmtime "github.com/gnolang/gno/tm2/pkg/bft/types/time"
@@ -158,9 +162,12 @@ type Node struct {
 	// services
 	evsw              events.EventSwitch
 	stateDB           dbm.DB
-	blockStore        *store.BlockStore // store the blockchain to disk
-	bcReactor         p2p.Reactor       // for fast-syncing
-	mempoolReactor    *mempl.Reactor    // for gossipping transactions
+	blockStore        *store.BlockStore           // store the blockchain to disk
+	bcReactor         p2p.Reactor                 // for fast-syncing
+	stateSyncReactor  *statesync.StateSyncReactor // for state syncing, and serving snapshots
+	stateSync         bool                        // whether to state sync on start
+	stateSyncGenesis  sm.State                    // state to state sync from
+	mempoolReactor    *mempl.Reactor              // for gossipping transactions
 	mempool           mempl.Mempool
 	consensusState    *cs.ConsensusState   // latest consensus state
 	consensusReactor  *cs.ConsensusReactor // for participating in the consensus
@@ -214,6 +221,12 @@ func createAndStartEventStoreService(
 		if err != nil {
 			return nil, nil, fmt.Errorf("unable to create file tx event store, %w", err)
 		}
+	case kv.EventStoreType:
+		// Transaction events should be indexed in a queryable database
+		txEventStore, err = kv.NewTxEventStore(cfg.TxEventStore)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to create kv tx event store, %w", err)
+		}
 	default:
 		// Transaction event storing should be omitted
 		txEventStore = null.NewNullEventStore()
@@ -266,7 +279,11 @@ func onlyValidatorIsUs(state sm.State, privVal types.PrivValidator) bool {
 func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp appconn.AppConns,
 	state sm.State, logger *slog.Logger,
 ) (*mempl.Reactor, *mempl.CListMempool) {
-	mempool := mempl.NewCListMempool(
+	newMempool := mempl.NewCListMempool
+	if config.Mempool.Type == memplcfg.TypePriority {
+		newMempool = mempl.NewPriorityMempool
+	}
+	mempool := newMempool(
 		config.Mempool,
 		proxyApp.Mempool(),
 		state.LastBlockHeight,
@@ -296,6 +313,17 @@ func createBlockchainReactor(config *cfg.Config,
 	return bcReactor, nil
 }
 
+func createStateSyncReactor(config *cfg.Config,
+	proxyApp appconn.AppConns,
+	stateDB dbm.DB,
+	blockStore *store.BlockStore,
+	logger *slog.Logger,
+) *statesync.StateSyncReactor {
+	stateSyncReactor := statesync.NewStateSyncReactor(config.StateSync, proxyApp.StateSync(), stateDB, blockStore)
+	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
+	return stateSyncReactor
+}
+
 func createConsensusReactor(config *cfg.Config,
 	state sm.State,
 	blockExec *sm.BlockExecutor,
@@ -386,6 +414,7 @@ func createSwitch(config *cfg.Config,
 	peerFilters []p2p.PeerFilterFunc,
 	mempoolReactor *mempl.Reactor,
 	bcReactor p2p.Reactor,
+	stateSyncReactor *statesync.StateSyncReactor,
 	consensusReactor *cs.ConsensusReactor,
 	nodeInfo p2p.NodeInfo,
 	nodeKey *p2p.NodeKey,
@@ -399,6 +428,7 @@ func createSwitch(config *cfg.Config,
 	sw.SetLogger(p2pLogger)
 	sw.AddReactor("MEMPOOL", mempoolReactor)
 	sw.AddReactor("BLOCKCHAIN", bcReactor)
+	sw.AddReactor("STATESYNC", stateSyncReactor)
 	sw.AddReactor("CONSENSUS", consensusReactor)
 
 	sw.SetNodeInfo(nodeInfo)
@@ -494,6 +524,14 @@ func NewNode(config *cfg.Config,
 	// We don't fast-sync when the only validator is us.
 	fastSync := config.FastSyncMode && !onlyValidatorIsUs(state, privValidator)
 
+	// Decide whether to state sync or not
+	// We only state sync a fresh node: the blockchain and consensus reactors
+	// wait for the state to be restored.
+	stateSync := config.StateSync.Enable && state.LastBlockHeight == 0
+	if stateSync {
+		logger.Info("State sync enabled, fast sync and consensus wait for the restored state")
+	}
+
 	// Make MempoolReactor
 	mempoolReactor, mempool := createMempoolAndMempoolReactor(config, proxyApp, state, logger)
 
@@ -506,7 +544,7 @@ func NewNode(config *cfg.Config,
 	)
 
 	// Make BlockchainReactor
-	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, fastSync, logger)
+	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, fastSync && !stateSync, logger)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create blockchain reactor")
 	}
@@ -514,9 +552,12 @@ func NewNode(config *cfg.Config,
 	// Make ConsensusReactor
 	consensusReactor, consensusState := createConsensusReactor(
 		config, state, blockExec, blockStore, mempool,
-		privValidator, fastSync, evsw, consensusLogger,
+		privValidator, fastSync || stateSync, evsw, consensusLogger,
 	)
 
+	// Make StateSyncReactor
+	stateSyncReactor := createStateSyncReactor(config, proxyApp, stateDB, blockStore, logger)
+
 	nodeInfo, err := makeNodeInfo(config, nodeKey, txEventStore, genDoc, state)
 	if err != nil {
 		return nil, errors.Wrap(err, "error making NodeInfo")
@@ -529,7 +570,7 @@ func NewNode(config *cfg.Config,
 	p2pLogger := logger.With("module", "p2p")
 	sw := createSwitch(
 		config, transport, peerFilters, mempoolReactor, bcReactor,
-		consensusReactor, nodeInfo, nodeKey, p2pLogger,
+		stateSyncReactor, consensusReactor, nodeInfo, nodeKey, p2pLogger,
 	)
 
 	err = sw.AddPersistentPeers(splitAndTrimEmpty(config.P2P.PersistentPeers, ",", " "))
@@ -562,6 +603,9 @@ func NewNode(config *cfg.Config,
 		stateDB:           stateDB,
 		blockStore:        blockStore,
 		bcReactor:         bcReactor,
+		stateSyncReactor:  stateSyncReactor,
+		stateSync:         stateSync,
+		stateSyncGenesis:  state,
 		mempoolReactor:    mempoolReactor,
 		mempool:           mempool,
 		consensusState:    consensusState,
@@ -643,6 +687,50 @@ func (n *Node) OnStart() error {
 		return errors.Wrap(err, "could not dial peers from persistent_peers field")
 	}
 
+	// Run state sync, then switch to fast sync or consensus.
+	if n.stateSync {
+		go func() {
+			if err := n.startStateSync(); err != nil {
+				n.Logger.Error("State sync failed, the node must be reset before syncing again", "err", err)
+			}
+		}()
+	}
+
+	return nil
+}
+
+// startStateSync restores the application state from a snapshot, bootstraps
+// the state and block stores at its height, and starts fast syncing from
+// there, or participating in the consensus if fast sync is disabled.
+func (n *Node) startStateSync() error {
+	state, commit, err := n.stateSyncReactor.Sync(n.stateSyncGenesis)
+	if err != nil {
+		return err
+	}
+
+	// Don't take the application's word for it.
+	res, err := n.proxyApp.Query().InfoSync(abci.RequestInfo{})
+	if err != nil {
+		return err
+	}
+	if res.LastBlockHeight != state.LastBlockHeight || !bytes.Equal(res.LastBlockAppHash, state.AppHash) {
+		return fmt.Errorf("restored application at height %d with app hash %X, expected height %d and app hash %X",
+			res.LastBlockHeight, res.LastBlockAppHash, state.LastBlockHeight, state.AppHash)
+	}
+
+	if err := sm.BootstrapState(n.stateDB, state); err != nil {
+		return err
+	}
+	n.blockStore.Bootstrap(state.LastBlockHeight, commit)
+
+	if n.config.FastSyncMode && !onlyValidatorIsUs(state, n.privValidator) {
+		bcR, ok := n.bcReactor.(*bc.BlockchainReactor)
+		if !ok {
+			return errors.New("blockchain reactor doesn't support fast sync after state sync")
+		}
+		return bcR.SwitchToFastSync(state)
+	}
+	n.consensusReactor.SwitchToConsensus(state, 0)
 	return nil
 }
 
@@ -700,6 +788,7 @@ func (n *Node) configureRPC() {
 	rpccore.SetGetFastSync(n.consensusReactor.FastSync)
 	rpccore.SetLogger(n.Logger.With("module", "rpc"))
 	rpccore.SetEventSwitch(n.evsw)
+	rpccore.SetTxEventStore(n.txEventStore)
 	rpccore.SetConfig(*n.config.RPC)
 }
 
@@ -886,7 +975,7 @@ func makeNodeInfo(
 		Network:    genDoc.ChainID,
 		Version:    version.Version,
 		Channels: []byte{
-			bcChannel,
+			bcChannel, statesync.StateSyncChannel,
 			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
 			mempl.MempoolChannel,
 		},
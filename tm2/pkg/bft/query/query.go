@@ -0,0 +1,276 @@
+// Package query implements the query language used to search transactions
+// (tx_search) and to filter the events of RPC subscriptions.
+//
+// A query is a list of `tag op value` conditions joined by AND:
+//
+//	tm.event = 'Tx' AND tx.height >= 10 AND msg.path = 'gno.land/r/demo/boards'
+package query
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+// Query tags
+const (
+	TagEvent       = "tm.event"     // event type, ie. NewBlock or Tx
+	TagBlockHeight = "block.height" // height of NewBlock and NewBlockHeader events
+	TagHeight      = "tx.height"
+	TagHash        = "tx.hash"
+	TagSigner      = "tx.signer"
+	TagMsgType     = "msg.type"
+	TagRoute       = "msg.route"
+	TagPath        = "msg.path"
+)
+
+var (
+	ErrEmptyQuery         = errors.New("empty query")
+	ErrInvalidCondition   = errors.New("invalid query condition")
+	ErrUnknownTag         = errors.New("unknown query tag")
+	ErrInvalidOperator    = errors.New("invalid query operator")
+	ErrInvalidHeightValue = errors.New("invalid height value")
+)
+
+// Operator is a query condition comparison operator
+type Operator string
+
+const (
+	OpEqual        Operator = "="
+	OpLess         Operator = "<"
+	OpLessEqual    Operator = "<="
+	OpGreater      Operator = ">"
+	OpGreaterEqual Operator = ">="
+)
+
+// operators are ordered so that the longest operators are matched first
+var operators = []Operator{
+	OpLessEqual,
+	OpGreaterEqual,
+	OpEqual,
+	OpLess,
+	OpGreater,
+}
+
+// Condition is a single `tag op value` query condition
+type Condition struct {
+	Tag   string
+	Op    Operator
+	Value string
+
+	Height int64 // parsed value, for height conditions
+}
+
+// Query is a parsed query.
+// All conditions need to match for a transaction or an event to be selected
+type Query struct {
+	raw        string
+	Conditions []Condition
+}
+
+// Parse parses a query of the form:
+//
+//	tx.height >= 10 AND tx.signer = 'g1...' AND msg.path = 'gno.land/r/demo/boards'
+//
+// Only the height tags support the range operators (<, <=, >, >=),
+// every other tag can only be matched on equality
+func Parse(raw string) (*Query, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, ErrEmptyQuery
+	}
+
+	q := &Query{raw: raw}
+
+	for _, part := range splitConditions(raw) {
+		cond, err := parseCondition(part)
+		if err != nil {
+			return nil, err
+		}
+
+		q.Conditions = append(q.Conditions, cond)
+	}
+
+	return q, nil
+}
+
+// MustParse is like Parse, but panics if the query is invalid
+func MustParse(raw string) *Query {
+	q, err := Parse(raw)
+	if err != nil {
+		panic(err)
+	}
+
+	return q
+}
+
+// String returns the query, as it was parsed
+func (q *Query) String() string {
+	return q.raw
+}
+
+// Matches checks if the tags of a transaction or an event match all
+// the query conditions. A condition on a tag which is missing doesn't match
+func (q *Query) Matches(tags []Tag) bool {
+	for _, cond := range q.Conditions {
+		matched := false
+
+		for _, tag := range tags {
+			if tag.Name == cond.Tag && cond.matches(tag.Value) {
+				matched = true
+
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// splitConditions splits the raw query on AND keywords
+// that are outside of quoted values
+func splitConditions(raw string) []string {
+	var (
+		parts  []string
+		quoted bool
+		start  int
+	)
+
+	for i := 0; i < len(raw); i++ {
+		switch {
+		case raw[i] == '\'':
+			quoted = !quoted
+		case !quoted && hasAndAt(raw, i):
+			parts = append(parts, raw[start:i])
+			i += len(" AND ") - 1
+			start = i + 1
+		}
+	}
+
+	return append(parts, raw[start:])
+}
+
+// hasAndAt checks if there is an AND keyword
+// surrounded by whitespace at the given position
+func hasAndAt(raw string, i int) bool {
+	const and = " AND "
+
+	return len(raw)-i >= len(and) && strings.EqualFold(raw[i:i+len(and)], and)
+}
+
+// parseCondition parses a single `tag op value` condition
+func parseCondition(raw string) (Condition, error) {
+	raw = strings.TrimSpace(raw)
+
+	// The operator is the first one found after the tag
+	idx := strings.IndexAny(raw, "<>=")
+	if idx < 0 {
+		return Condition{}, fmt.Errorf("%w: %q", ErrInvalidCondition, raw)
+	}
+
+	for _, op := range operators {
+		if !strings.HasPrefix(raw[idx:], string(op)) {
+			continue
+		}
+
+		cond := Condition{
+			Tag:   strings.TrimSpace(raw[:idx]),
+			Op:    op,
+			Value: unquote(strings.TrimSpace(raw[idx+len(op):])),
+		}
+
+		if err := cond.validate(); err != nil {
+			return Condition{}, err
+		}
+
+		return cond, nil
+	}
+
+	return Condition{}, fmt.Errorf("%w: %q", ErrInvalidCondition, raw)
+}
+
+// unquote strips the single quotes surrounding a value, if any
+func unquote(value string) string {
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
+
+// validate verifies the condition tag and operator,
+// and parses the height value, if any
+func (c *Condition) validate() error {
+	switch c.Tag {
+	case TagHeight, TagBlockHeight:
+		height, err := strconv.ParseInt(c.Value, 10, 64)
+		if err != nil || height < 0 {
+			return fmt.Errorf("%w: %q", ErrInvalidHeightValue, c.Value)
+		}
+
+		c.Height = height
+
+		return nil
+	case TagEvent, TagHash, TagSigner, TagMsgType, TagRoute, TagPath:
+		if c.Op != OpEqual {
+			return fmt.Errorf("%w: %s only supports %s", ErrInvalidOperator, c.Tag, OpEqual)
+		}
+
+		if c.Value == "" {
+			return fmt.Errorf("%w: empty value for %s", ErrInvalidCondition, c.Tag)
+		}
+
+		if c.Tag == TagHash {
+			// Hashes are indexed in upper-case hex
+			c.Value = strings.ToUpper(c.Value)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownTag, c.Tag)
+	}
+}
+
+// IsHeight returns true if the condition is on a height tag
+func (c Condition) IsHeight() bool {
+	return c.Tag == TagHeight || c.Tag == TagBlockHeight
+}
+
+// HeightRange returns the inclusive height range matched by the condition
+func (c Condition) HeightRange() (int64, int64) {
+	switch c.Op {
+	case OpLess:
+		return 0, c.Height - 1
+	case OpLessEqual:
+		return 0, c.Height
+	case OpGreater:
+		return c.Height + 1, math.MaxInt64
+	case OpGreaterEqual:
+		return c.Height, math.MaxInt64
+	default:
+		return c.Height, c.Height
+	}
+}
+
+// matches checks if the value of a tag matches the condition
+func (c Condition) matches(value string) bool {
+	if !c.IsHeight() {
+		return value == c.Value
+	}
+
+	height, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	from, to := c.HeightRange()
+
+	return height >= from && height <= to
+}
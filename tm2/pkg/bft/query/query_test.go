@@ -0,0 +1,192 @@
+package query
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+func TestQuery_Parse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid queries", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			name     string
+			query    string
+			expected []Condition
+		}{
+			{
+				"single height condition",
+				"tx.height=10",
+				[]Condition{
+					{Tag: TagHeight, Op: OpEqual, Value: "10", Height: 10},
+				},
+			},
+			{
+				"range operators",
+				"tx.height >= 1 AND tx.height < 5",
+				[]Condition{
+					{Tag: TagHeight, Op: OpGreaterEqual, Value: "1", Height: 1},
+					{Tag: TagHeight, Op: OpLess, Value: "5", Height: 5},
+				},
+			},
+			{
+				"quoted values with keywords and operators",
+				"msg.path = 'gno.land/r/a AND b' and msg.type = 'x<=y'",
+				[]Condition{
+					{Tag: TagPath, Op: OpEqual, Value: "gno.land/r/a AND b"},
+					{Tag: TagMsgType, Op: OpEqual, Value: "x<=y"},
+				},
+			},
+			{
+				"hash is upper-cased",
+				"tx.hash = 'abcd'",
+				[]Condition{
+					{Tag: TagHash, Op: OpEqual, Value: "ABCD"},
+				},
+			},
+		}
+
+		for _, testCase := range testTable {
+			testCase := testCase
+
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				q, err := Parse(testCase.query)
+				require.NoError(t, err)
+
+				assert.Equal(t, testCase.expected, q.Conditions)
+			})
+		}
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			name        string
+			query       string
+			expectedErr error
+		}{
+			{
+				"empty query",
+				"  ",
+				ErrEmptyQuery,
+			},
+			{
+				"missing operator",
+				"tx.height 10",
+				ErrInvalidCondition,
+			},
+			{
+				"unknown tag",
+				"tx.unknown = 'value'",
+				ErrUnknownTag,
+			},
+			{
+				"range operator on string tag",
+				"tx.signer > 'g1'",
+				ErrInvalidOperator,
+			},
+			{
+				"invalid height",
+				"tx.height = 'abc'",
+				ErrInvalidHeightValue,
+			},
+			{
+				"empty value",
+				"msg.path = ''",
+				ErrInvalidCondition,
+			},
+		}
+
+		for _, testCase := range testTable {
+			testCase := testCase
+
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				q, err := Parse(testCase.query)
+
+				assert.Nil(t, q)
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			})
+		}
+	})
+}
+
+func TestQuery_Matches(t *testing.T) {
+	t.Parallel()
+
+	newBlock := types.EventNewBlock{Block: &types.Block{Header: types.Header{Height: 10}}}
+	tx := types.EventTx{Result: types.TxResult{Height: 5, Tx: types.Tx("tx")}}
+
+	testTable := []struct {
+		name     string
+		query    string
+		event    types.TMEvent
+		expected bool
+	}{
+		{
+			"event type",
+			"tm.event = 'NewBlock'",
+			newBlock,
+			true,
+		},
+		{
+			"other event type",
+			"tm.event = 'Tx'",
+			newBlock,
+			false,
+		},
+		{
+			"block height range",
+			"tm.event = 'NewBlock' AND block.height > 5 AND block.height <= 10",
+			newBlock,
+			true,
+		},
+		{
+			"block height out of range",
+			"block.height < 10",
+			newBlock,
+			false,
+		},
+		{
+			"tx height",
+			"tm.event = 'Tx' AND tx.height = 5",
+			tx,
+			true,
+		},
+		{
+			"tx hash",
+			fmt.Sprintf("tx.hash = '%x'", types.Tx("tx").Hash()),
+			tx,
+			true,
+		},
+		{
+			"missing tag",
+			"msg.path = 'gno.land/r/demo/boards'",
+			tx,
+			false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := MustParse(testCase.query)
+
+			assert.Equal(t, testCase.expected, q.Matches(EventTags(testCase.event)))
+		})
+	}
+}
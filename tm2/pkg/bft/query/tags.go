@@ -0,0 +1,102 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/events"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Tag is a single queryable attribute of a transaction or an event
+type Tag struct {
+	Name  string
+	Value string
+}
+
+// pkgPathMsg is implemented by messages that target a specific
+// package path (ie. VM messages), so they can be searched by path
+type pkgPathMsg interface {
+	GetPkgPath() string
+}
+
+// TxTags extracts the queryable tags from the transaction result.
+// Transactions that can't be decoded as std.Tx only have
+// their height and hash tags
+func TxTags(result types.TxResult) []Tag {
+	tags := []Tag{
+		{Name: TagHeight, Value: strconv.FormatInt(result.Height, 10)},
+		{Name: TagHash, Value: fmt.Sprintf("%X", result.Tx.Hash())},
+	}
+
+	var tx std.Tx
+	if err := amino.Unmarshal(result.Tx, &tx); err != nil {
+		return tags
+	}
+
+	seen := make(map[Tag]struct{})
+	add := func(name, value string) {
+		tag := Tag{Name: name, Value: value}
+		if _, ok := seen[tag]; ok || value == "" {
+			return
+		}
+
+		seen[tag] = struct{}{}
+		tags = append(tags, tag)
+	}
+
+	for _, signer := range tx.GetSigners() {
+		add(TagSigner, signer.String())
+	}
+
+	for _, msg := range tx.GetMsgs() {
+		add(TagMsgType, msg.Type())
+		add(TagRoute, msg.Route())
+
+		if pathMsg, ok := msg.(pkgPathMsg); ok {
+			add(TagPath, pathMsg.GetPkgPath())
+		}
+	}
+
+	return tags
+}
+
+// EventTags extracts the queryable tags from an event of the event switch:
+// its type, the tags of the transaction of Tx events, and the height of
+// NewBlock and NewBlockHeader events
+func EventTags(event events.Event) []Tag {
+	tags := []Tag{{Name: TagEvent, Value: EventType(event)}}
+
+	switch ev := event.(type) {
+	case types.EventTx:
+		tags = append(tags, TxTags(ev.Result)...)
+	case types.EventNewBlock:
+		if ev.Block != nil {
+			tags = append(tags, Tag{Name: TagBlockHeight, Value: strconv.FormatInt(ev.Block.Height, 10)})
+		}
+	case types.EventNewBlockHeader:
+		tags = append(tags, Tag{Name: TagBlockHeight, Value: strconv.FormatInt(ev.Header.Height, 10)})
+	}
+
+	return tags
+}
+
+// EventType returns the type of the event, matched by the tm.event tag:
+// the name of its Go type, without the Event prefix (ie. NewBlock for
+// types.EventNewBlock)
+func EventType(event events.Event) string {
+	rt := reflect.TypeOf(event)
+	if rt == nil {
+		return ""
+	}
+
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+
+	return strings.TrimPrefix(rt.Name(), "Event")
+}
@@ -0,0 +1,356 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/client"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+// Suffix of the ID of the events of a subscription, appended by the server
+// to the ID of the subscribe request.
+const eventsIDSuffix = "#event"
+
+// Number of events buffered for each subscription.
+const subscriptionBufferSize = 100
+
+var (
+	ErrAlreadySubscribed = errors.New("already subscribed")
+	ErrNotSubscribed     = errors.New("not subscribed")
+	ErrClientStopped     = errors.New("websocket client stopped")
+)
+
+/*
+WS is a client of the event subscriptions of a Tendermint node, over a
+websocket connection.
+
+When the connection is lost, the client reconnects and subscribes again to
+all its queries. The events fired while the client was disconnected are lost.
+*/
+type WS struct {
+	ws *rpcclient.WSClient
+
+	mtx     sync.Mutex
+	nextID  int
+	subs    map[string]*Subscription // by query
+	byID    map[string]*Subscription // by subscribe request ID
+	pending map[string]chan error    // by request ID
+	done    chan struct{}            // closed when the client is stopped
+}
+
+// NewWS takes a remote endpoint in the form <protocol>://<host>:<port> and
+// the websocket path (which always seems to be "/websocket").
+// The function panics if the provided remote is invalid.
+func NewWS(remote, wsEndpoint string) *WS {
+	c := &WS{
+		subs:    make(map[string]*Subscription),
+		byID:    make(map[string]*Subscription),
+		pending: make(map[string]chan error),
+		done:    make(chan struct{}),
+	}
+	c.ws = rpcclient.NewWSClient(remote, wsEndpoint, rpcclient.OnReconnect(c.resubscribe))
+
+	return c
+}
+
+// SetLogger sets the logger of the websocket connection.
+func (c *WS) SetLogger(l *slog.Logger) {
+	c.ws.SetLogger(l)
+}
+
+// Start dials the node.
+func (c *WS) Start() error {
+	if err := c.ws.Start(); err != nil {
+		return err
+	}
+	go c.routeResponses(c.ws.ResponsesCh)
+
+	return nil
+}
+
+// Stop closes the connection, and all the subscriptions with
+// ErrClientStopped.
+func (c *WS) Stop() error {
+	return c.ws.Stop()
+}
+
+// Subscribe subscribes to the events matching the query. See the subscribe
+// RPC route for the syntax of queries.
+//
+// The events must be read from Events() as they come: the node cancels the
+// subscriptions which are too slow.
+func (c *WS) Subscribe(ctx context.Context, query string) (*Subscription, error) {
+	sub := &Subscription{
+		Query:  query,
+		events: make(chan ctypes.ResultEvent, subscriptionBufferSize),
+		quit:   make(chan struct{}),
+	}
+
+	c.mtx.Lock()
+	if _, ok := c.subs[query]; ok {
+		c.mtx.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrAlreadySubscribed, query)
+	}
+	c.subs[query] = sub
+	c.mtx.Unlock()
+
+	// the subscription is registered before it is sent, since its first
+	// events may come before the response.
+	if err := c.subscribe(ctx, sub); err != nil {
+		c.mtx.Lock()
+		c.removeLocked(sub)
+		c.mtx.Unlock()
+		sub.close(err)
+
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+// Unsubscribe cancels the subscription to the query.
+func (c *WS) Unsubscribe(ctx context.Context, query string) error {
+	c.mtx.Lock()
+	sub, ok := c.subs[query]
+	if !ok {
+		c.mtx.Unlock()
+		return fmt.Errorf("%w: %s", ErrNotSubscribed, query)
+	}
+	c.removeLocked(sub)
+	c.mtx.Unlock()
+	sub.close(nil)
+
+	return c.call(ctx, "unsubscribe", map[string]interface{}{"query": query}, nil)
+}
+
+// UnsubscribeAll cancels all the subscriptions.
+func (c *WS) UnsubscribeAll(ctx context.Context) error {
+	c.mtx.Lock()
+	subs := make([]*Subscription, 0, len(c.subs))
+	for _, sub := range c.subs {
+		c.removeLocked(sub)
+		subs = append(subs, sub)
+	}
+	c.mtx.Unlock()
+	for _, sub := range subs {
+		sub.close(nil)
+	}
+
+	return c.call(ctx, "unsubscribe_all", map[string]interface{}{}, nil)
+}
+
+// subscribe sends the subscribe request of the subscription.
+func (c *WS) subscribe(ctx context.Context, sub *Subscription) error {
+	return c.call(ctx, "subscribe", map[string]interface{}{"query": sub.Query}, func(id string) {
+		if c.subs[sub.Query] != sub {
+			return // unsubscribed in the meantime.
+		}
+		delete(c.byID, sub.id)
+		sub.id = id
+		c.byID[id] = sub
+	})
+}
+
+// resubscribe sends again the subscribe requests of all the subscriptions,
+// after a reconnection.
+func (c *WS) resubscribe() {
+	c.mtx.Lock()
+	subs := make([]*Subscription, 0, len(c.subs))
+	for _, sub := range c.subs {
+		subs = append(subs, sub)
+	}
+	c.mtx.Unlock()
+
+	for _, sub := range subs {
+		if err := c.subscribe(context.Background(), sub); err != nil {
+			c.ws.Logger.Error("failed to resubscribe", "query", sub.Query, "err", err)
+			c.mtx.Lock()
+			c.removeLocked(sub)
+			c.mtx.Unlock()
+			sub.close(err)
+		}
+	}
+}
+
+// call sends a request and waits for its response. The register function,
+// if any, is called with the ID of the request (with c.mtx locked) before
+// it is sent.
+func (c *WS) call(ctx context.Context, method string, params map[string]interface{}, register func(id string)) error {
+	c.mtx.Lock()
+	c.nextID++
+	id := fmt.Sprintf("ws-%d", c.nextID)
+	request, err := rpctypes.MapToRequest(rpctypes.JSONRPCStringID(id), method, params)
+	if err != nil {
+		c.mtx.Unlock()
+		return err
+	}
+	resCh := make(chan error, 1)
+	c.pending[id] = resCh
+	if register != nil {
+		register(id)
+	}
+	c.mtx.Unlock()
+
+	defer func() {
+		c.mtx.Lock()
+		delete(c.pending, id)
+		c.mtx.Unlock()
+	}()
+
+	if err := c.ws.Send(ctx, request); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-resCh:
+		return err
+	case <-c.done:
+		return ErrClientStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// routeResponses routes the responses of the node to the pending requests
+// and to the subscriptions, until the connection is closed.
+func (c *WS) routeResponses(responses <-chan rpctypes.RPCResponse) {
+	for res := range responses {
+		id := fmt.Sprintf("%v", res.ID)
+
+		if subID, ok := strings.CutSuffix(id, eventsIDSuffix); ok {
+			c.routeEvent(subID, res)
+			continue
+		}
+
+		c.mtx.Lock()
+		resCh, ok := c.pending[id]
+		c.mtx.Unlock()
+		if !ok {
+			continue
+		}
+		if res.Error != nil {
+			resCh <- res.Error
+		} else {
+			resCh <- nil
+		}
+	}
+
+	// the client was stopped.
+	c.mtx.Lock()
+	close(c.done)
+	subs := make([]*Subscription, 0, len(c.subs))
+	for _, sub := range c.subs {
+		c.removeLocked(sub)
+		subs = append(subs, sub)
+	}
+	c.mtx.Unlock()
+	for _, sub := range subs {
+		sub.close(ErrClientStopped)
+	}
+}
+
+// routeEvent sends an event, or an error, to its subscription.
+func (c *WS) routeEvent(subID string, res rpctypes.RPCResponse) {
+	c.mtx.Lock()
+	sub, ok := c.byID[subID]
+	if ok && res.Error != nil {
+		// the node cancelled the subscription.
+		c.removeLocked(sub)
+	}
+	c.mtx.Unlock()
+	if !ok {
+		return
+	}
+
+	if res.Error != nil {
+		sub.close(res.Error)
+		return
+	}
+
+	var result ctypes.ResultEvent
+	if err := amino.UnmarshalJSON(res.Result, &result); err != nil {
+		c.ws.Logger.Error("failed to decode event", "query", sub.Query, "err", err)
+		return
+	}
+	sub.send(result)
+}
+
+func (c *WS) removeLocked(sub *Subscription) {
+	if c.subs[sub.Query] == sub {
+		delete(c.subs, sub.Query)
+	}
+	if c.byID[sub.id] == sub {
+		delete(c.byID, sub.id)
+	}
+}
+
+// ----------------------------------------
+// Subscription
+
+// Subscription receives the events matching its query.
+type Subscription struct {
+	Query string
+
+	id     string // ID of the last subscribe request
+	events chan ctypes.ResultEvent
+	quit   chan struct{}
+	closed bool
+	mtx    sync.Mutex // guards events and closed
+
+	err     error
+	errMtx  sync.Mutex
+	onceErr sync.Once
+}
+
+// Events returns the channel of the events of the subscription, which is
+// closed when the subscription is cancelled.
+func (s *Subscription) Events() <-chan ctypes.ResultEvent {
+	return s.events
+}
+
+// Err returns the reason why the subscription was cancelled, once the events
+// channel is closed: nil after Unsubscribe, ErrClientStopped when the client
+// is stopped, or the error of the node (ie. when the events were not read
+// fast enough).
+func (s *Subscription) Err() error {
+	s.errMtx.Lock()
+	defer s.errMtx.Unlock()
+
+	return s.err
+}
+
+func (s *Subscription) send(result ctypes.ResultEvent) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.closed {
+		return
+	}
+	select {
+	case s.events <- result:
+	case <-s.quit:
+	}
+}
+
+func (s *Subscription) close(err error) {
+	s.onceErr.Do(func() {
+		s.errMtx.Lock()
+		s.err = err
+		s.errMtx.Unlock()
+
+		// quit first, to release a pending send.
+		close(s.quit)
+
+		s.mtx.Lock()
+		s.closed = true
+		close(s.events)
+		s.mtx.Unlock()
+	})
+}
@@ -0,0 +1,133 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpctest "github.com/gnolang/gno/tm2/pkg/bft/rpc/test"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+func getWSClient(t *testing.T) *client.WS {
+	t.Helper()
+
+	c := client.NewWS(rpctest.GetConfig().RPC.ListenAddress, "/websocket")
+	require.NoError(t, c.Start())
+	t.Cleanup(func() { c.Stop() })
+
+	return c
+}
+
+func nextEvent(t *testing.T, sub *client.Subscription) ctypes.ResultEvent {
+	t.Helper()
+
+	select {
+	case result, ok := <-sub.Events():
+		require.True(t, ok, "subscription cancelled: %v", sub.Err())
+		return result
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "timed out waiting for an event")
+	}
+
+	return ctypes.ResultEvent{}
+}
+
+func TestWSSubscribe(t *testing.T) {
+	t.Parallel()
+
+	c := getWSClient(t)
+	ctx := context.Background()
+
+	t.Run("new blocks", func(t *testing.T) {
+		query := "tm.event = 'NewBlock'"
+		sub, err := c.Subscribe(ctx, query)
+		require.NoError(t, err)
+
+		result := nextEvent(t, sub)
+		assert.Equal(t, query, result.Query)
+		block, ok := result.Event.(types.EventNewBlock)
+		require.True(t, ok, "%T", result.Event)
+
+		// the next events are filtered by height.
+		next := fmt.Sprintf("tm.event = 'NewBlock' AND block.height > %d", block.Block.Height+1)
+		nextSub, err := c.Subscribe(ctx, next)
+		require.NoError(t, err)
+		result = nextEvent(t, nextSub)
+		assert.Greater(t, result.Event.(types.EventNewBlock).Block.Height, block.Block.Height+1)
+
+		require.NoError(t, c.Unsubscribe(ctx, query))
+		require.NoError(t, c.Unsubscribe(ctx, next))
+		for range sub.Events() {
+		}
+		assert.NoError(t, sub.Err())
+	})
+
+	t.Run("txs", func(t *testing.T) {
+		_, _, tx := MakeTxKV()
+		sub, err := c.Subscribe(ctx, fmt.Sprintf("tm.event = 'Tx' AND tx.hash = '%X'", types.Tx(tx).Hash()))
+		require.NoError(t, err)
+
+		bres, err := getHTTPClient().BroadcastTxCommit(tx)
+		require.NoError(t, err)
+
+		result := nextEvent(t, sub)
+		txEvent, ok := result.Event.(types.EventTx)
+		require.True(t, ok, "%T", result.Event)
+		assert.Equal(t, bres.Height, txEvent.Result.Height)
+		assert.EqualValues(t, tx, txEvent.Result.Tx)
+
+		require.NoError(t, c.UnsubscribeAll(ctx))
+		for range sub.Events() {
+		}
+		assert.NoError(t, sub.Err())
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		_, err := c.Subscribe(ctx, "tx.unknown = 'value'")
+		assert.ErrorContains(t, err, "unknown query tag")
+
+		err = c.Unsubscribe(ctx, "tm.event = 'Vote'")
+		assert.ErrorIs(t, err, client.ErrNotSubscribed)
+	})
+}
+
+func TestWSSubscribeLimit(t *testing.T) {
+	t.Parallel()
+
+	c := getWSClient(t)
+	ctx := context.Background()
+
+	limit := rpctest.GetConfig().RPC.MaxSubscriptionsPerClient
+	for i := 0; i < limit; i++ {
+		_, err := c.Subscribe(ctx, fmt.Sprintf("tx.height = %d", 1000000+i))
+		require.NoError(t, err)
+	}
+
+	_, err := c.Subscribe(ctx, "tx.height = 0")
+	assert.ErrorContains(t, err, "max_subscriptions_per_client reached")
+
+	_, err = c.Subscribe(ctx, "tx.height = 1000000")
+	assert.ErrorIs(t, err, client.ErrAlreadySubscribed)
+}
+
+func TestWSStop(t *testing.T) {
+	t.Parallel()
+
+	c := client.NewWS(rpctest.GetConfig().RPC.ListenAddress, "/websocket")
+	require.NoError(t, c.Start())
+
+	sub, err := c.Subscribe(context.Background(), "tm.event = 'NewBlockHeader'")
+	require.NoError(t, err)
+	require.NoError(t, c.Stop())
+
+	for range sub.Events() {
+	}
+	assert.ErrorIs(t, sub.Err(), client.ErrClientStopped)
+}
@@ -0,0 +1,301 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/query"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/events"
+	"github.com/gnolang/gno/tm2/pkg/random"
+)
+
+// Number of events buffered for each subscription. When a client doesn't
+// read its events fast enough and the buffer is full, the subscription is
+// cancelled.
+const subscriptionBufferSize = 100
+
+var (
+	errNotWebsocket        = errors.New("subscriptions are only available over a websocket connection")
+	errAlreadySubscribed   = errors.New("already subscribed")
+	errSubscriptionMissing = errors.New("subscription not found")
+	errTooManySubscribers  = errors.New("max_subscriptions_per_client reached")
+	errSubscriptionTooSlow = errors.New("subscription was cancelled: client is not pulling events fast enough")
+)
+
+// Subscribe for events via WebSocket.
+//
+// The query selects the events to be sent, with conditions on the following
+// tags, joined by AND:
+//
+//   - tm.event: the type of the event: NewBlock, NewBlockHeader, Tx, Vote,
+//     ValidatorSetUpdates or one of the consensus events (NewRoundStep...)
+//   - block.height: the height of NewBlock and NewBlockHeader events
+//   - tx.height, tx.hash, tx.signer: the height, hash and signers of Tx events
+//   - msg.type, msg.route, msg.path: the type, route and package path of the
+//     messages of Tx events
+//
+// Heights can be compared with =, <, <=, > and >=, the other tags only with
+// =. Values may be quoted with single quotes.
+//
+// ```go
+// import "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+//
+// client := client.NewWS("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+//
+//	if err != nil {
+//	  // handle error
+//	}
+//
+// defer client.Stop()
+// sub, err := client.Subscribe(ctx, "tm.event = 'Tx' AND msg.path = 'gno.land/r/demo/boards'")
+//
+//	if err != nil {
+//	  // handle error
+//	}
+//
+//	for result := range sub.Events() {
+//	  // handle result.Event
+//	}
+//
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+//
+//	{
+//		"error": "",
+//		"result": {},
+//		"id": "1",
+//		"jsonrpc": "2.0"
+//	}
+//
+// ```
+//
+// Each matching event is then sent as a ResultEvent, with the ID of the
+// subscribe request followed by "#event":
+//
+// ```json
+//
+//	{
+//		"jsonrpc": "2.0",
+//		"id": "1#event",
+//		"result": {
+//			"query": "tm.event = 'Tx' AND msg.path = 'gno.land/r/demo/boards'",
+//			"event": {...}
+//		}
+//	}
+//
+// ```
+//
+// A client can have at most max_subscriptions_per_client subscriptions. If
+// it doesn't read its events fast enough, the subscription is cancelled,
+// and an error is sent with the ID of the events of the subscription.
+//
+// ### Query Parameters
+//
+// | Parameter | Type   | Default | Required | Description |
+// |-----------+--------+---------+----------+-------------|
+// | query     | string | ""      | true     | Query       |
+func Subscribe(ctx *rpctypes.Context, rawQuery string) (*ctypes.ResultSubscribe, error) {
+	if ctx.WSConn == nil || ctx.JSONReq == nil {
+		return nil, errNotWebsocket
+	}
+
+	q, err := query.Parse(rawQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := gSubscriptions.add(ctx.WSConn, q, eventsID(ctx.JSONReq))
+	if err != nil {
+		return nil, err
+	}
+	go sub.run(ctx.Context())
+
+	logger.Info("Subscribe to query", "remote", ctx.RemoteAddr(), "query", q)
+	return &ctypes.ResultSubscribe{}, nil
+}
+
+// Unsubscribe from events via WebSocket.
+//
+// ### Query Parameters
+//
+// | Parameter | Type   | Default | Required | Description |
+// |-----------+--------+---------+----------+-------------|
+// | query     | string | ""      | true     | Query       |
+func Unsubscribe(ctx *rpctypes.Context, rawQuery string) (*ctypes.ResultUnsubscribe, error) {
+	if ctx.WSConn == nil {
+		return nil, errNotWebsocket
+	}
+
+	q, err := query.Parse(rawQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := gSubscriptions.get(ctx.WSConn, q.String())
+	if sub == nil {
+		return nil, errSubscriptionMissing
+	}
+	gSubscriptions.remove(sub)
+
+	logger.Info("Unsubscribe from query", "remote", ctx.RemoteAddr(), "query", q)
+	return &ctypes.ResultUnsubscribe{}, nil
+}
+
+// Unsubscribe from all events via WebSocket.
+func UnsubscribeAll(ctx *rpctypes.Context) (*ctypes.ResultUnsubscribe, error) {
+	if ctx.WSConn == nil {
+		return nil, errNotWebsocket
+	}
+
+	gSubscriptions.removeAll(ctx.WSConn)
+
+	logger.Info("Unsubscribe from all", "remote", ctx.RemoteAddr())
+	return &ctypes.ResultUnsubscribe{}, nil
+}
+
+// eventsID returns the ID of the events of a subscription: the ID of the
+// subscribe request, followed by "#event".
+func eventsID(req *rpctypes.RPCRequest) rpctypes.JSONRPCStringID {
+	return rpctypes.JSONRPCStringID(fmt.Sprintf("%v#event", req.ID))
+}
+
+// ----------------------------------------
+// subscriptions
+
+var gSubscriptions = &subscriptions{
+	byConn: make(map[rpctypes.WSRPCConnection]map[string]*subscription),
+}
+
+// subscriptions are the event subscriptions of the websocket connections,
+// by query.
+type subscriptions struct {
+	mtx    sync.Mutex
+	byConn map[rpctypes.WSRPCConnection]map[string]*subscription
+}
+
+// subscription sends the events of the event switch matching its query to
+// a websocket connection.
+type subscription struct {
+	conn       rpctypes.WSRPCConnection
+	query      *query.Query
+	id         rpctypes.JSONRPCStringID
+	listenerID string
+
+	events   chan events.Event
+	overflow chan struct{} // closed when the events buffer is full
+	quit     chan struct{} // closed when the subscription is removed
+
+	overflowOnce sync.Once
+	quitOnce     sync.Once
+}
+
+func (subs *subscriptions) add(conn rpctypes.WSRPCConnection, q *query.Query, id rpctypes.JSONRPCStringID) (*subscription, error) {
+	subs.mtx.Lock()
+	defer subs.mtx.Unlock()
+
+	connSubs := subs.byConn[conn]
+	if _, ok := connSubs[q.String()]; ok {
+		return nil, errAlreadySubscribed
+	}
+	if len(connSubs) >= config.MaxSubscriptionsPerClient {
+		return nil, fmt.Errorf("%w (%d)", errTooManySubscribers, config.MaxSubscriptionsPerClient)
+	}
+
+	sub := &subscription{
+		conn:       conn,
+		query:      q,
+		id:         id,
+		listenerID: fmt.Sprintf("rpc-subscription#%v", random.RandStr(8)),
+		events:     make(chan events.Event, subscriptionBufferSize),
+		overflow:   make(chan struct{}),
+		quit:       make(chan struct{}),
+	}
+	if connSubs == nil {
+		connSubs = make(map[string]*subscription)
+		subs.byConn[conn] = connSubs
+	}
+	connSubs[q.String()] = sub
+
+	// NOTE: the callback is called synchronously by the event emitters
+	// (ie. consensus), so it must not block.
+	evsw.AddListener(sub.listenerID, func(event events.Event) {
+		if !q.Matches(query.EventTags(event)) {
+			return
+		}
+		select {
+		case sub.events <- event:
+		default:
+			sub.overflowOnce.Do(func() { close(sub.overflow) })
+		}
+	})
+
+	return sub, nil
+}
+
+func (subs *subscriptions) get(conn rpctypes.WSRPCConnection, rawQuery string) *subscription {
+	subs.mtx.Lock()
+	defer subs.mtx.Unlock()
+
+	return subs.byConn[conn][rawQuery]
+}
+
+func (subs *subscriptions) remove(sub *subscription) {
+	subs.mtx.Lock()
+	defer subs.mtx.Unlock()
+
+	subs.removeLocked(sub)
+}
+
+func (subs *subscriptions) removeAll(conn rpctypes.WSRPCConnection) {
+	subs.mtx.Lock()
+	defer subs.mtx.Unlock()
+
+	for _, sub := range subs.byConn[conn] {
+		subs.removeLocked(sub)
+	}
+}
+
+func (subs *subscriptions) removeLocked(sub *subscription) {
+	connSubs := subs.byConn[sub.conn]
+	if connSubs[sub.query.String()] != sub {
+		return // already removed.
+	}
+	delete(connSubs, sub.query.String())
+	if len(connSubs) == 0 {
+		delete(subs.byConn, sub.conn)
+	}
+
+	evsw.RemoveListener(sub.listenerID)
+	sub.quitOnce.Do(func() { close(sub.quit) })
+}
+
+// run sends the events of the subscription to its connection, until it is
+// removed, the connection is closed (done), or the events buffer is full.
+func (sub *subscription) run(ctx context.Context) {
+	for {
+		select {
+		case event := <-sub.events:
+			sub.conn.WriteRPCResponse(rpctypes.NewRPCSuccessResponse(sub.id, ctypes.ResultEvent{
+				Query: sub.query.String(),
+				Event: event,
+			}))
+		case <-sub.overflow:
+			gSubscriptions.remove(sub)
+			sub.conn.WriteRPCResponse(rpctypes.RPCServerError(sub.id, errSubscriptionTooSlow))
+			return
+		case <-ctx.Done():
+			gSubscriptions.remove(sub)
+			return
+		case <-sub.quit:
+			return
+		}
+	}
+}
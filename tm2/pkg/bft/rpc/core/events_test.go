@@ -0,0 +1,126 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/rpc/config"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/events"
+	"github.com/gnolang/gno/tm2/pkg/log"
+)
+
+// mockWSConn is a websocket connection which sends its responses on a
+// channel.
+type mockWSConn struct {
+	ctx       context.Context
+	responses chan rpctypes.RPCResponse
+}
+
+func newMockWSConn(ctx context.Context) *mockWSConn {
+	return &mockWSConn{
+		ctx:       ctx,
+		responses: make(chan rpctypes.RPCResponse),
+	}
+}
+
+func (c *mockWSConn) GetRemoteAddr() string { return "mock" }
+
+func (c *mockWSConn) WriteRPCResponse(resp rpctypes.RPCResponse) { c.responses <- resp }
+
+func (c *mockWSConn) TryWriteRPCResponse(resp rpctypes.RPCResponse) bool {
+	select {
+	case c.responses <- resp:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *mockWSConn) Context() context.Context { return c.ctx }
+
+func (c *mockWSConn) next(t *testing.T) rpctypes.RPCResponse {
+	t.Helper()
+
+	select {
+	case resp := <-c.responses:
+		return resp
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for a response")
+	}
+
+	return rpctypes.RPCResponse{}
+}
+
+func TestSubscribe(t *testing.T) {
+	logger = log.NewNoopLogger()
+	evsw = events.NewEventSwitch()
+	config = *cfg.DefaultRPCConfig()
+	config.MaxSubscriptionsPerClient = 2
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn := newMockWSConn(ctx)
+	rpcCtx := func(id string) *rpctypes.Context {
+		return &rpctypes.Context{
+			JSONReq: &rpctypes.RPCRequest{ID: rpctypes.JSONRPCStringID(id)},
+			WSConn:  conn,
+		}
+	}
+
+	_, err := Subscribe(&rpctypes.Context{}, "tm.event = 'NewBlock'")
+	assert.ErrorIs(t, err, errNotWebsocket)
+	_, err = Subscribe(rpcCtx("1"), "tm.unknown = 'NewBlock'")
+	assert.Error(t, err)
+
+	// matching events are sent with the ID of the events.
+	_, err = Subscribe(rpcCtx("1"), "tm.event = 'NewBlockHeader' AND block.height >= 2")
+	require.NoError(t, err)
+	_, err = Subscribe(rpcCtx("2"), "tm.event = 'NewBlockHeader' AND block.height >= 2")
+	assert.ErrorIs(t, err, errAlreadySubscribed)
+
+	evsw.FireEvent(types.EventNewBlockHeader{Header: types.Header{Height: 1}})
+	evsw.FireEvent(types.EventNewBlockHeader{Header: types.Header{Height: 2}})
+	resp := conn.next(t)
+	assert.Equal(t, rpctypes.JSONRPCStringID("1#event"), resp.ID)
+	assert.Nil(t, resp.Error)
+	assert.Contains(t, string(resp.Result), `"height":"2"`)
+
+	// the subscriptions are limited.
+	_, err = Subscribe(rpcCtx("2"), "tm.event = 'Tx'")
+	require.NoError(t, err)
+	_, err = Subscribe(rpcCtx("3"), "tm.event = 'Vote'")
+	assert.ErrorIs(t, err, errTooManySubscribers)
+
+	_, err = Unsubscribe(rpcCtx("4"), "tm.event = 'Tx'")
+	require.NoError(t, err)
+	_, err = Unsubscribe(rpcCtx("4"), "tm.event = 'Tx'")
+	assert.ErrorIs(t, err, errSubscriptionMissing)
+
+	// a subscription which is not read fast enough is cancelled.
+	for i := 0; i < subscriptionBufferSize+2; i++ {
+		evsw.FireEvent(types.EventNewBlockHeader{Header: types.Header{Height: 10}})
+	}
+	for {
+		resp := conn.next(t)
+		if resp.Error != nil {
+			assert.Equal(t, rpctypes.JSONRPCStringID("1#event"), resp.ID)
+			assert.Contains(t, resp.Error.Data, errSubscriptionTooSlow.Error())
+			break
+		}
+	}
+	assert.Nil(t, gSubscriptions.get(conn, "tm.event = 'NewBlockHeader' AND block.height >= 2"))
+
+	// the subscriptions are removed when the connection is closed.
+	_, err = Subscribe(rpcCtx("5"), "tm.event = 'Tx'")
+	require.NoError(t, err)
+	cancel()
+	assert.Eventually(t, func() bool {
+		return gSubscriptions.get(conn, "tm.event = 'Tx'") == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
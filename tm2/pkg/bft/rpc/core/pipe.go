@@ -10,6 +10,7 @@ import (
 	mempl "github.com/gnolang/gno/tm2/pkg/bft/mempool"
 	cfg "github.com/gnolang/gno/tm2/pkg/bft/rpc/config"
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
@@ -70,6 +71,7 @@ var (
 	gTxDispatcher *txDispatcher
 	mempool       mempl.Mempool
 	getFastSync   func() bool // avoids dependency on consensus pkg
+	txEventStore  eventstore.TxEventStore
 
 	logger *slog.Logger
 
@@ -116,6 +118,10 @@ func SetGetFastSync(v func() bool) {
 	getFastSync = v
 }
 
+func SetTxEventStore(store eventstore.TxEventStore) {
+	txEventStore = store
+}
+
 func SetLogger(l *slog.Logger) {
 	logger = l
 }
@@ -162,3 +168,12 @@ func validatePerPage(perPage int) int {
 	}
 	return perPage
 }
+
+func validateSkipCount(page, perPage int) int {
+	skipCount := (page - 1) * perPage
+	if skipCount < 0 {
+		return 0
+	}
+
+	return skipCount
+}
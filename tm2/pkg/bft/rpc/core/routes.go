@@ -7,17 +7,22 @@ import (
 // TODO: better system than "unsafe" prefix
 // NOTE: Amino is registered in rpc/core/types/codec.go.
 var Routes = map[string]*rpc.RPCFunc{
+	// subscribe/unsubscribe are reserved for websocket events.
+	"subscribe":       rpc.NewWSRPCFunc(Subscribe, "query"),
+	"unsubscribe":     rpc.NewWSRPCFunc(Unsubscribe, "query"),
+	"unsubscribe_all": rpc.NewWSRPCFunc(UnsubscribeAll, ""),
+
 	// info API
-	"health":        rpc.NewRPCFunc(Health, ""),
-	"status":        rpc.NewRPCFunc(Status, ""),
-	"net_info":      rpc.NewRPCFunc(NetInfo, ""),
-	"blockchain":    rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight"),
-	"genesis":       rpc.NewRPCFunc(Genesis, ""),
-	"block":         rpc.NewRPCFunc(Block, "height"),
-	"block_results": rpc.NewRPCFunc(BlockResults, "height"),
-	"commit":        rpc.NewRPCFunc(Commit, "height"),
-	//"tx":                   rpc.NewRPCFunc(Tx, "hash,prove"),
-	//"tx_search":            rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page"),
+	"health":               rpc.NewRPCFunc(Health, ""),
+	"status":               rpc.NewRPCFunc(Status, ""),
+	"net_info":             rpc.NewRPCFunc(NetInfo, ""),
+	"blockchain":           rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight"),
+	"genesis":              rpc.NewRPCFunc(Genesis, ""),
+	"block":                rpc.NewRPCFunc(Block, "height"),
+	"block_results":        rpc.NewRPCFunc(BlockResults, "height"),
+	"commit":               rpc.NewRPCFunc(Commit, "height"),
+	"tx":                   rpc.NewRPCFunc(Tx, "hash,prove"),
+	"tx_search":            rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page"),
 	"validators":           rpc.NewRPCFunc(Validators, "height"),
 	"dump_consensus_state": rpc.NewRPCFunc(DumpConsensusState, ""),
 	"consensus_state":      rpc.NewRPCFunc(ConsensusState, ""),
@@ -1,19 +1,17 @@
 package core
 
-/*
-
 import (
+	"errors"
 	"fmt"
 
-	cmn "github.com/gnolang/gno/tm2/pkg/common"
-
-	tmquery "github.com/gnolang/gno/tm2/pkg/pubsub/query"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
-	"github.com/gnolang/gno/tm2/pkg/bft/state/txindex/null"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 )
 
+var errTxIndexingDisabled = errors.New("transaction indexing is disabled")
+
 // Tx allows you to query the transaction results. `nil` could mean the
 // transaction is in the mempool, invalidated, or was not sent in the first
 // place.
@@ -81,19 +79,19 @@ import (
 // - `height`: `int` - height of the block where this transaction was in
 // - `hash`: `[]byte` - hash of the transaction
 func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
-
 	// if index is disabled, return error
-	if _, ok := txIndexer.(*null.TxIndex); ok {
-		return nil, fmt.Errorf("Transaction indexing is disabled")
+	querier, ok := txEventStore.(eventstore.TxEventQuerier)
+	if !ok {
+		return nil, errTxIndexingDisabled
 	}
 
-	r, err := txIndexer.Get(hash)
+	r, err := querier.GetTx(hash)
 	if err != nil {
 		return nil, err
 	}
 
 	if r == nil {
-		return nil, fmt.Errorf("Tx (%X) not found", hash)
+		return nil, fmt.Errorf("tx (%X) not found", hash)
 	}
 
 	height := r.Height
@@ -102,6 +100,10 @@ func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error
 	var proof types.TxProof
 	if prove {
 		block := blockStore.LoadBlock(height)
+		if block == nil {
+			return nil, fmt.Errorf("block at height %d not found", height)
+		}
+
 		proof = block.Data.Txs.Proof(int(index)) // XXX: overflow on 32-bit machines
 	}
 
@@ -109,7 +111,7 @@ func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error
 		Hash:     hash,
 		Height:   height,
 		Index:    index,
-		TxResult: r.Result,
+		TxResult: r.Response,
 		Tx:       r.Tx,
 		Proof:    proof,
 	}, nil
@@ -118,8 +120,12 @@ func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error
 // TxSearch allows you to query for multiple transactions results. It returns a
 // list of transactions (maximum ?per_page entries) and the total count.
 //
+// The query is a list of `tag op value` conditions joined by AND. The
+// tx.height tag supports the =, <, <=, > and >= operators, while the tx.hash,
+// tx.signer, msg.type, msg.route and msg.path tags can only be matched with =.
+//
 // ```shell
-// curl "localhost:26657/tx_search?query=\"account.owner='Ivan'\"&prove=true"
+// curl "localhost:26657/tx_search?query=\"tx.signer='g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5'\"&prove=true"
 // ```
 //
 // ```go
@@ -129,8 +135,7 @@ func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error
 //   // handle error
 // }
 // defer client.Stop()
-// q, err := tmquery.New("account.owner='Ivan'")
-// tx, err := client.TxSearch(q, true)
+// tx, err := client.TxSearch("tx.signer='g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5'", true, 1, 30)
 // ```
 //
 // > The above command returns JSON structured like this:
@@ -188,38 +193,35 @@ func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error
 // - `hash`: `[]byte` - hash of the transaction
 func TxSearch(ctx *rpctypes.Context, query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error) {
 	// if index is disabled, return error
-	if _, ok := txIndexer.(*null.TxIndex); ok {
-		return nil, fmt.Errorf("Transaction indexing is disabled")
+	querier, ok := txEventStore.(eventstore.TxEventQuerier)
+	if !ok {
+		return nil, errTxIndexingDisabled
 	}
 
-	q, err := tmquery.New(query)
-	if err != nil {
-		return nil, err
-	}
+	perPage = validatePerPage(perPage)
+	skipCount := validateSkipCount(page, perPage)
 
-	results, err := txIndexer.Search(q)
+	results, totalCount, err := querier.SearchTxs(query, skipCount, perPage)
 	if err != nil {
 		return nil, err
 	}
 
-	totalCount := len(results)
-	perPage = validatePerPage(perPage)
-	page, err = validatePage(page, perPage, totalCount)
-	if err != nil {
+	if _, err = validatePage(page, perPage, totalCount); err != nil {
 		return nil, err
 	}
-	skipCount := validateSkipCount(page, perPage)
 
-	apiResults := make([]*ctypes.ResultTx, cmn.MinInt(perPage, totalCount-skipCount))
-	var proof types.TxProof
-	// if there's no tx in the results array, we don't need to loop through the apiResults array
-	for i := 0; i < len(apiResults); i++ {
-		r := results[skipCount+i]
+	apiResults := make([]*ctypes.ResultTx, len(results))
+	for i, r := range results {
 		height := r.Height
 		index := r.Index
 
+		var proof types.TxProof
 		if prove {
 			block := blockStore.LoadBlock(height)
+			if block == nil {
+				return nil, fmt.Errorf("block at height %d not found", height)
+			}
+
 			proof = block.Data.Txs.Proof(int(index)) // XXX: overflow on 32-bit machines
 		}
 
@@ -227,7 +229,7 @@ func TxSearch(ctx *rpctypes.Context, query string, prove bool, page, perPage int
 			Hash:     r.Tx.Hash(),
 			Height:   height,
 			Index:    index,
-			TxResult: r.Result,
+			TxResult: r.Response,
 			Tx:       r.Tx,
 			Proof:    proof,
 		}
@@ -235,4 +237,3 @@ func TxSearch(ctx *rpctypes.Context, query string, prove bool, page, perPage int
 
 	return &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
 }
-*/
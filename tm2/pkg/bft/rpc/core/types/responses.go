@@ -203,5 +203,12 @@ type (
 
 // Event data from a subscription
 type ResultEvent struct {
+	Query string        `json:"query"`
 	Event types.TMEvent `json:"event"`
 }
+
+// empty subscription results
+type (
+	ResultSubscribe   struct{}
+	ResultUnsubscribe struct{}
+)
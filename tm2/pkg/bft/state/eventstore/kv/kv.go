@@ -0,0 +1,375 @@
+package kv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/query"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	storetypes "github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+var (
+	_ eventstore.TxEventStore   = (*TxEventStore)(nil)
+	_ eventstore.TxEventQuerier = (*TxEventStore)(nil)
+)
+
+const (
+	EventStoreType = "kv"
+	Path           = "path"
+	Backend        = "backend"
+
+	dbName = "tx_events"
+)
+
+var (
+	errMissingPath = errors.New("missing path param")
+	errInvalidType = errors.New("invalid config for kv event store specified")
+	errNotStarted  = errors.New("kv event store not started")
+	errEventTag    = errors.New("event tags are not supported by the kv event store")
+)
+
+var (
+	txPrefix    = []byte("tx/")
+	indexPrefix = []byte("idx/")
+)
+
+// TxEventStore is the implementation of a transaction event store
+// that indexes transactions in a key-value database, so they can be
+// fetched by hash and searched by tags
+type TxEventStore struct {
+	path    string
+	backend dbm.BackendType
+
+	mux sync.RWMutex
+	db  dbm.DB
+}
+
+// NewTxEventStore creates a new db-backed tx event store
+func NewTxEventStore(cfg *storetypes.Config) (*TxEventStore, error) {
+	// Parse config params
+	if EventStoreType != cfg.EventStoreType {
+		return nil, errInvalidType
+	}
+
+	path, ok := cfg.GetParam(Path).(string)
+	if !ok {
+		return nil, errMissingPath
+	}
+
+	backend := dbm.GoLevelDBBackend
+	if rawBackend, ok := cfg.GetParam(Backend).(string); ok && rawBackend != "" {
+		backend = dbm.BackendType(rawBackend)
+	}
+
+	return &TxEventStore{
+		path:    path,
+		backend: backend,
+	}, nil
+}
+
+// Start starts the kv transaction event store, by opening the database
+func (t *TxEventStore) Start() error {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	db, err := dbm.NewDB(dbName, t.backend, t.path)
+	if err != nil {
+		return fmt.Errorf("unable to open event store database, %w", err)
+	}
+
+	t.db = db
+
+	return nil
+}
+
+// Stop stops the kv transaction event store, by closing the database
+func (t *TxEventStore) Stop() error {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if t.db != nil {
+		t.db.Close()
+		t.db = nil
+	}
+
+	return nil
+}
+
+// GetType returns the kv transaction event store type
+func (t *TxEventStore) GetType() string {
+	return EventStoreType
+}
+
+// Append stores the transaction result, along with
+// the index entries for each of its searchable tags
+func (t *TxEventStore) Append(result types.TxResult) error {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+
+	if t.db == nil {
+		return errNotStarted
+	}
+
+	resultRaw, err := amino.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("unable to marshal transaction, %w", err)
+	}
+
+	hash := result.Tx.Hash()
+
+	batch := t.db.NewBatch()
+	defer batch.Close()
+
+	batch.Set(txKey(hash), resultRaw)
+
+	for _, tag := range extractTags(result) {
+		batch.Set(indexKey(tag.name, tag.value, result.Height, result.Index), hash)
+	}
+
+	batch.WriteSync()
+
+	return nil
+}
+
+// GetTx returns the transaction result with the given hash, if any
+func (t *TxEventStore) GetTx(hash []byte) (*types.TxResult, error) {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+
+	if t.db == nil {
+		return nil, errNotStarted
+	}
+
+	return t.getTx(hash)
+}
+
+func (t *TxEventStore) getTx(hash []byte) (*types.TxResult, error) {
+	resultRaw := t.db.Get(txKey(hash))
+	if resultRaw == nil {
+		return nil, nil
+	}
+
+	var result types.TxResult
+	if err := amino.Unmarshal(resultRaw, &result); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal transaction, %w", err)
+	}
+
+	return &result, nil
+}
+
+// SearchTxs returns the transaction results matching the query, ordered by
+// height and index. The first offset matches are skipped and at most limit
+// results are returned, along with the total number of matches
+func (t *TxEventStore) SearchTxs(rawQuery string, offset, limit int) ([]*types.TxResult, int, error) {
+	q, err := query.Parse(rawQuery)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Only transactions are stored, which aren't tagged with
+	// the event type and block height
+	for _, cond := range q.Conditions {
+		if cond.Tag == query.TagEvent || cond.Tag == query.TagBlockHeight {
+			return nil, 0, fmt.Errorf("%w: %s", errEventTag, cond.Tag)
+		}
+	}
+
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+
+	if t.db == nil {
+		return nil, 0, errNotStarted
+	}
+
+	// The index entries of every condition are ordered by height and index,
+	// so the entries of the first one are iterated, and every other
+	// condition is checked on the transaction of each entry.
+	// Value conditions are preferred, as they match fewer entries
+	driver := 0
+
+	for i, cond := range q.Conditions {
+		if cond.Tag != query.TagHeight {
+			driver = i
+
+			break
+		}
+	}
+
+	start, end := conditionRange(q.Conditions[driver])
+	if start == nil {
+		return []*types.TxResult{}, 0, nil
+	}
+
+	var (
+		results = make([]*types.TxResult, 0, limit)
+		total   = 0
+	)
+
+	it := t.db.Iterator(start, end)
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		height, index := decodePosition(it.Key())
+
+		if !t.matchConditions(q.Conditions, driver, height, index) {
+			continue
+		}
+
+		total++
+
+		if total <= offset || len(results) >= limit {
+			continue
+		}
+
+		result, err := t.getTx(it.Value())
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+
+	return results, total, nil
+}
+
+// matchConditions checks if the transaction at the given height and index
+// matches every condition, except the one at the skipped position
+func (t *TxEventStore) matchConditions(conds []query.Condition, skip int, height int64, index uint32) bool {
+	for i, cond := range conds {
+		if i == skip {
+			continue
+		}
+
+		if cond.Tag == query.TagHeight {
+			from, to := cond.HeightRange()
+			if height < from || height > to {
+				return false
+			}
+
+			continue
+		}
+
+		if !t.db.Has(indexKey(cond.Tag, []byte(cond.Value), height, index)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// conditionRange returns the range of the index entries matching the
+// condition. The start key is nil if no entry can match
+func conditionRange(cond query.Condition) ([]byte, []byte) {
+	if cond.Tag != query.TagHeight {
+		prefix := valuePrefix(cond.Tag, []byte(cond.Value))
+
+		return prefix, cpIncr(prefix)
+	}
+
+	from, to := cond.HeightRange()
+	if from > to {
+		return nil, nil
+	}
+
+	prefix := tagPrefix(query.TagHeight)
+	start := append(bytes.Clone(prefix), encodeHeight(from)...)
+
+	if to == math.MaxInt64 {
+		return start, cpIncr(prefix)
+	}
+
+	return start, append(bytes.Clone(prefix), encodeHeight(to+1)...)
+}
+
+// tag is a single searchable transaction attribute
+type tag struct {
+	name  string
+	value []byte
+}
+
+// extractTags extracts the searchable tags from the transaction result.
+// Heights are encoded so they can be searched by range
+func extractTags(result types.TxResult) []tag {
+	txTags := query.TxTags(result)
+	tags := make([]tag, 0, len(txTags))
+
+	for _, txTag := range txTags {
+		value := []byte(txTag.Value)
+		if txTag.Name == query.TagHeight {
+			value = encodeHeight(result.Height)
+		}
+
+		tags = append(tags, tag{name: txTag.Name, value: value})
+	}
+
+	return tags
+}
+
+// txKey returns the key of the transaction result with the given hash
+func txKey(hash []byte) []byte {
+	return append(bytes.Clone(txPrefix), hash...)
+}
+
+// tagPrefix returns the key prefix of all index entries for the tag
+func tagPrefix(name string) []byte {
+	key := append(bytes.Clone(indexPrefix), name...)
+
+	return append(key, 0x00)
+}
+
+// valuePrefix returns the key prefix of the index entries
+// for the given tag value
+func valuePrefix(name string, value []byte) []byte {
+	key := append(tagPrefix(name), value...)
+
+	return append(key, 0x00)
+}
+
+// indexKey returns the key of the index entry for the given
+// tag value, for the transaction at the given height and index
+func indexKey(name string, value []byte, height int64, index uint32) []byte {
+	key := valuePrefix(name, value)
+	key = append(key, encodeHeight(height)...)
+
+	return binary.BigEndian.AppendUint32(key, index)
+}
+
+// decodePosition returns the height and index of the
+// transaction of an index entry, from the end of its key
+func decodePosition(key []byte) (int64, uint32) {
+	pos := key[len(key)-12:]
+
+	return int64(binary.BigEndian.Uint64(pos[:8])), binary.BigEndian.Uint32(pos[8:])
+}
+
+// encodeHeight encodes the height so that the
+// lexicographic ordering of keys matches the height ordering
+func encodeHeight(height int64) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(height))
+}
+
+// cpIncr returns the smallest key that is greater than all keys with
+// the given prefix (the prefix is never made up solely of 0xFF bytes)
+func cpIncr(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xFF {
+			end[i]++
+
+			return end[:i+1]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,353 @@
+package kv
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	storetypes "github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	_ "github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testMsg is a simple message that targets a package path
+type testMsg struct {
+	Signer crypto.Address
+	Path   string
+}
+
+func (m testMsg) Route() string                { return "test" }
+func (m testMsg) Type() string                 { return "call" }
+func (m testMsg) ValidateBasic() error         { return nil }
+func (m testMsg) GetSignBytes() []byte         { return nil }
+func (m testMsg) GetSigners() []crypto.Address { return []crypto.Address{m.Signer} }
+func (m testMsg) GetPkgPath() string           { return m.Path }
+
+var _ = amino.RegisterPackage(amino.NewPackage(
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv",
+	"kv",
+	amino.GetCallersDirname(),
+).WithDependencies(
+	std.Package,
+).WithTypes(
+	testMsg{}, "testMsg",
+))
+
+// newTestEventStore creates a new started in-memory event store
+func newTestEventStore(t *testing.T) *TxEventStore {
+	t.Helper()
+
+	eventStore, err := NewTxEventStore(&storetypes.Config{
+		EventStoreType: EventStoreType,
+		Params: map[string]any{
+			Path:    t.TempDir(),
+			Backend: dbm.MemDBBackend.String(),
+		},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, eventStore.Start())
+
+	t.Cleanup(func() {
+		require.NoError(t, eventStore.Stop())
+	})
+
+	return eventStore
+}
+
+// generateTxResult generates a transaction result for the given signer and path
+func generateTxResult(t *testing.T, height int64, index uint32, signer crypto.Address, path string) types.TxResult {
+	t.Helper()
+
+	tx := std.Tx{
+		Msgs: []std.Msg{
+			testMsg{
+				Signer: signer,
+				Path:   path,
+			},
+		},
+		Memo: fmt.Sprintf("%d-%d", height, index),
+	}
+
+	txRaw, err := amino.Marshal(tx)
+	require.NoError(t, err)
+
+	return types.TxResult{
+		Height: height,
+		Index:  index,
+		Tx:     txRaw,
+	}
+}
+
+func TestTxEventStore_New(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid type specified", func(t *testing.T) {
+		t.Parallel()
+
+		i, err := NewTxEventStore(&storetypes.Config{
+			EventStoreType: "invalid",
+		})
+
+		assert.Nil(t, i)
+		assert.ErrorIs(t, err, errInvalidType)
+	})
+
+	t.Run("missing path specified", func(t *testing.T) {
+		t.Parallel()
+
+		i, err := NewTxEventStore(&storetypes.Config{
+			EventStoreType: EventStoreType,
+		})
+
+		assert.Nil(t, i)
+		assert.ErrorIs(t, err, errMissingPath)
+	})
+
+	t.Run("valid config specified", func(t *testing.T) {
+		t.Parallel()
+
+		i, err := NewTxEventStore(&storetypes.Config{
+			EventStoreType: EventStoreType,
+			Params: map[string]any{
+				Path: ".",
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, ".", i.path)
+		assert.Equal(t, dbm.GoLevelDBBackend, i.backend)
+		assert.Equal(t, EventStoreType, i.GetType())
+	})
+}
+
+func TestTxEventStore_NotStarted(t *testing.T) {
+	t.Parallel()
+
+	eventStore, err := NewTxEventStore(&storetypes.Config{
+		EventStoreType: EventStoreType,
+		Params: map[string]any{
+			Path: ".",
+		},
+	})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, eventStore.Append(types.TxResult{}), errNotStarted)
+
+	_, err = eventStore.GetTx([]byte("hash"))
+	assert.ErrorIs(t, err, errNotStarted)
+
+	_, _, err = eventStore.SearchTxs("tx.height = 1", 0, 10)
+	assert.ErrorIs(t, err, errNotStarted)
+}
+
+func TestTxEventStore_GetTx(t *testing.T) {
+	t.Parallel()
+
+	eventStore := newTestEventStore(t)
+
+	result := generateTxResult(t, 10, 2, crypto.Address{1}, "gno.land/r/demo/foo")
+	require.NoError(t, eventStore.Append(result))
+
+	t.Run("existing tx", func(t *testing.T) {
+		t.Parallel()
+
+		fetched, err := eventStore.GetTx(result.Tx.Hash())
+		require.NoError(t, err)
+
+		assert.Equal(t, result, *fetched)
+	})
+
+	t.Run("missing tx", func(t *testing.T) {
+		t.Parallel()
+
+		fetched, err := eventStore.GetTx([]byte("missing"))
+		require.NoError(t, err)
+
+		assert.Nil(t, fetched)
+	})
+}
+
+func TestTxEventStore_SearchTxs(t *testing.T) {
+	t.Parallel()
+
+	var (
+		alice = crypto.Address{1}
+		bob   = crypto.Address{2}
+
+		fooPath = "gno.land/r/demo/foo"
+		barPath = "gno.land/r/demo/foo/bar"
+	)
+
+	eventStore := newTestEventStore(t)
+
+	results := []types.TxResult{
+		generateTxResult(t, 1, 0, alice, fooPath),
+		generateTxResult(t, 1, 1, bob, barPath),
+		generateTxResult(t, 2, 0, alice, barPath),
+		generateTxResult(t, 3, 0, bob, fooPath),
+		generateTxResult(t, 5, 0, alice, fooPath),
+		{Height: 5, Index: 1, Tx: []byte("not a std.Tx")},
+	}
+
+	// Append them in reverse, to make sure results are ordered
+	for i := len(results) - 1; i >= 0; i-- {
+		require.NoError(t, eventStore.Append(results[i]))
+	}
+
+	testTable := []struct {
+		name     string
+		query    string
+		expected []types.TxResult
+	}{
+		{
+			"height equal",
+			"tx.height = 1",
+			results[0:2],
+		},
+		{
+			"height range",
+			"tx.height > 1 AND tx.height <= 3",
+			results[2:4],
+		},
+		{
+			"height lower than",
+			"tx.height < 2",
+			results[0:2],
+		},
+		{
+			"height greater or equal",
+			"tx.height >= 5",
+			results[4:6],
+		},
+		{
+			"hash",
+			fmt.Sprintf("tx.hash = '%x'", results[3].Tx.Hash()),
+			results[3:4],
+		},
+		{
+			"signer",
+			fmt.Sprintf("tx.signer = '%s'", alice),
+			[]types.TxResult{results[0], results[2], results[4]},
+		},
+		{
+			"message type",
+			"msg.type = 'call'",
+			results[0:5],
+		},
+		{
+			"message route",
+			"msg.route = 'test'",
+			results[0:5],
+		},
+		{
+			"package path is not matched by prefix",
+			fmt.Sprintf("msg.path = '%s'", fooPath),
+			[]types.TxResult{results[0], results[3], results[4]},
+		},
+		{
+			"combined conditions",
+			fmt.Sprintf("tx.signer = '%s' AND msg.path = '%s' AND tx.height > 1", alice, fooPath),
+			results[4:5],
+		},
+		{
+			"no matches",
+			fmt.Sprintf("tx.signer = '%s' AND tx.height = 2", bob),
+			[]types.TxResult{},
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			found, total, err := eventStore.SearchTxs(testCase.query, 0, len(results))
+			require.NoError(t, err)
+
+			assert.Equal(t, len(testCase.expected), total)
+			require.Len(t, found, len(testCase.expected))
+
+			for index, result := range found {
+				assert.Equal(t, testCase.expected[index], *result)
+			}
+		})
+	}
+}
+
+func TestTxEventStore_SearchTxs_Pagination(t *testing.T) {
+	t.Parallel()
+
+	var (
+		alice = crypto.Address{1}
+		bob   = crypto.Address{2}
+	)
+
+	eventStore := newTestEventStore(t)
+
+	var aliceResults []types.TxResult
+
+	for height := int64(1); height <= 10; height++ {
+		aliceResult := generateTxResult(t, height, 0, alice, "gno.land/r/demo/foo")
+		aliceResults = append(aliceResults, aliceResult)
+
+		require.NoError(t, eventStore.Append(aliceResult))
+		require.NoError(t, eventStore.Append(generateTxResult(t, height, 1, bob, "gno.land/r/demo/foo")))
+	}
+
+	testTable := []struct {
+		name          string
+		query         string
+		offset, limit int
+		expected      []types.TxResult
+	}{
+		{
+			"first page",
+			fmt.Sprintf("tx.signer = '%s'", alice),
+			0, 3,
+			aliceResults[0:3],
+		},
+		{
+			"middle page",
+			fmt.Sprintf("tx.signer = '%s' AND msg.path = 'gno.land/r/demo/foo'", alice),
+			3, 3,
+			aliceResults[3:6],
+		},
+		{
+			"last page",
+			fmt.Sprintf("tx.height > 0 AND tx.signer = '%s'", alice),
+			9, 3,
+			aliceResults[9:10],
+		},
+		{
+			"past the last page",
+			fmt.Sprintf("tx.signer = '%s'", alice),
+			12, 3,
+			[]types.TxResult{},
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			found, total, err := eventStore.SearchTxs(testCase.query, testCase.offset, testCase.limit)
+			require.NoError(t, err)
+
+			assert.Equal(t, len(aliceResults), total)
+			require.Len(t, found, len(testCase.expected))
+
+			for index, result := range found {
+				assert.Equal(t, testCase.expected[index], *result)
+			}
+		})
+	}
+}
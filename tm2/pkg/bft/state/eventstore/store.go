@@ -22,3 +22,16 @@ type TxEventStore interface {
 	// to the event store
 	Append(result types.TxResult) error
 }
+
+// TxEventQuerier is implemented by transaction event stores
+// that support lookups of previously appended transactions
+type TxEventQuerier interface {
+	// GetTx returns the transaction result with the given hash, if any.
+	// Returns nil if the transaction is not found
+	GetTx(hash []byte) (*types.TxResult, error)
+
+	// SearchTxs returns the transaction results matching the given query,
+	// ordered by height and index, skipping the first offset matches and
+	// returning at most limit of them, along with the total number of matches
+	SearchTxs(query string, offset, limit int) ([]*types.TxResult, int, error)
+}
@@ -94,6 +94,30 @@ func SaveState(db dbm.DB, state State) {
 	saveState(db, state, stateKey)
 }
 
+// BootstrapState persists the State of a node bootstrapped at a height other
+// than genesis, e.g. by state sync, and the validator sets and consensus
+// params it relies on, which aren't stored for the previous heights.
+// The state must have changed its validators at LastBlockHeight+2, and its
+// consensus params at LastBlockHeight+1, so that they are persisted in full.
+func BootstrapState(db dbm.DB, state State) error {
+	height := state.LastBlockHeight
+	if height <= 0 {
+		return fmt.Errorf("cannot bootstrap the state at height %d", height)
+	}
+	if state.LastHeightValidatorsChanged != height+2 {
+		return fmt.Errorf("validators of the bootstrapped state must change at height %d, got %d",
+			height+2, state.LastHeightValidatorsChanged)
+	}
+	if state.LastHeightConsensusParamsChanged != height+1 {
+		return fmt.Errorf("consensus params of the bootstrapped state must change at height %d, got %d",
+			height+1, state.LastHeightConsensusParamsChanged)
+	}
+	saveValidatorsInfo(db, height, height, state.LastValidators)
+	saveValidatorsInfo(db, height+1, height+1, state.Validators)
+	saveState(db, state, stateKey)
+	return nil
+}
+
 func saveState(db dbm.DB, state State, key []byte) {
 	nextHeight := state.LastBlockHeight + 1
 	// If first block, save validators for block 1.
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// StateSyncConfig
+
+// StateSyncConfig defines the configuration for the Tendermint state sync
+// service, which bootstraps a new node from an application snapshot, and for
+// the snapshots the node takes and serves to its peers.
+type StateSyncConfig struct {
+	// Bootstrap a new node from a snapshot, instead of replaying all the blocks
+	Enable bool `toml:"enable" comment:"State sync bootstraps a new node from a snapshot of the application state,\n taken at the trusted height, instead of replaying all the blocks since genesis"`
+
+	// Trusted height and block hash, e.g. from a block explorer or another node
+	TrustHeight int64  `toml:"trust_height" comment:"Trusted height and block hash (hex-encoded), obtained from a trusted source.\n The snapshot restored is the one taken at the trusted height"`
+	TrustHash   string `toml:"trust_hash"`
+
+	// Time spent discovering the peers' snapshots, and timeout of the requests to peers
+	DiscoveryTime  time.Duration `toml:"discovery_time" comment:"Time spent discovering the snapshots of the peers, and timeout of the requests to peers"`
+	RequestTimeout time.Duration `toml:"request_timeout"`
+
+	// Snapshots taken by the node, and served to its peers
+	SnapshotInterval   int64 `toml:"snapshot_interval" comment:"Number of blocks between the snapshots taken by the node and served to its peers,\n or 0 to disable them, and number of snapshots to keep (0 keeps all of them)"`
+	SnapshotKeepRecent int   `toml:"snapshot_keep_recent"`
+}
+
+// DefaultStateSyncConfig returns a default configuration for the state sync service
+func DefaultStateSyncConfig() *StateSyncConfig {
+	return &StateSyncConfig{
+		Enable:             false,
+		DiscoveryTime:      15 * time.Second,
+		RequestTimeout:     30 * time.Second,
+		SnapshotInterval:   0,
+		SnapshotKeepRecent: 2,
+	}
+}
+
+// TestStateSyncConfig returns a configuration for testing the state sync service
+func TestStateSyncConfig() *StateSyncConfig {
+	cfg := DefaultStateSyncConfig()
+	cfg.DiscoveryTime = 1 * time.Second
+	cfg.RequestTimeout = 5 * time.Second
+	return cfg
+}
+
+// TrustHashBytes returns the decoded trusted block hash.
+func (cfg *StateSyncConfig) TrustHashBytes() ([]byte, error) {
+	return hex.DecodeString(cfg.TrustHash)
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *StateSyncConfig) ValidateBasic() error {
+	if cfg.Enable {
+		if cfg.TrustHeight <= 0 {
+			return errors.New("trust_height must be positive")
+		}
+		if hash, err := cfg.TrustHashBytes(); err != nil || len(hash) == 0 {
+			return errors.New("trust_hash must be a hex-encoded block hash")
+		}
+	}
+	if cfg.DiscoveryTime < 0 {
+		return errors.New("discovery_time can't be negative")
+	}
+	if cfg.RequestTimeout <= 0 {
+		return errors.New("request_timeout must be positive")
+	}
+	if cfg.SnapshotInterval < 0 {
+		return errors.New("snapshot_interval can't be negative")
+	}
+	if cfg.SnapshotKeepRecent < 0 {
+		return errors.New("snapshot_keep_recent can't be negative")
+	}
+	return nil
+}
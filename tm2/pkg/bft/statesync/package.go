@@ -0,0 +1,23 @@
+package statesync
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	btypes "github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+var Package = amino.RegisterPackage(amino.NewPackage(
+	"github.com/gnolang/gno/tm2/pkg/bft/statesync",
+	"tm",
+	amino.GetCallersDirname(),
+).WithDependencies(
+	abci.Package,
+	btypes.Package,
+).WithTypes(
+	&ssSnapshotsRequestMessage{}, "SnapshotsRequest",
+	&ssSnapshotsResponseMessage{}, "SnapshotsResponse",
+	&ssChunkRequestMessage{}, "ChunkRequest",
+	&ssChunkResponseMessage{}, "ChunkResponse",
+	&ssStateRequestMessage{}, "StateRequest",
+	&ssStateResponseMessage{}, "StateResponse",
+))
@@ -0,0 +1,423 @@
+package statesync
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/statesync/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/p2p"
+)
+
+const (
+	// StateSyncChannel is a channel for snapshots, snapshot chunks and the
+	// light blocks needed to verify them.
+	StateSyncChannel = byte(0x60)
+
+	// maxChunkSize is the maximum size of a snapshot chunk served or
+	// accepted by the reactor.
+	maxChunkSize = 16 << 20
+
+	// maxSnapshots is the maximum number of snapshots advertised to a peer.
+	maxSnapshots = 10
+
+	maxMsgSize = maxChunkSize + 1024
+)
+
+// StateSyncReactor serves the snapshots of the application, and the blocks
+// needed to verify them, to the peers. When syncing, it restores the
+// application state from the snapshots of its peers.
+type StateSyncReactor struct {
+	p2p.BaseReactor
+
+	// immutable
+	config     *config.StateSyncConfig
+	conn       appconn.StateSync
+	stateDB    dbm.DB
+	blockStore *store.BlockStore
+
+	mtx    sync.Mutex
+	syncer *syncer // set while syncing
+}
+
+// NewStateSyncReactor returns a new reactor instance.
+func NewStateSyncReactor(
+	config *config.StateSyncConfig,
+	conn appconn.StateSync,
+	stateDB dbm.DB,
+	blockStore *store.BlockStore,
+) *StateSyncReactor {
+	ssR := &StateSyncReactor{
+		config:     config,
+		conn:       conn,
+		stateDB:    stateDB,
+		blockStore: blockStore,
+	}
+	ssR.BaseReactor = *p2p.NewBaseReactor("StateSyncReactor", ssR)
+	return ssR
+}
+
+// GetChannels implements Reactor
+func (ssR *StateSyncReactor) GetChannels() []*p2p.ChannelDescriptor {
+	return []*p2p.ChannelDescriptor{
+		{
+			ID:                  StateSyncChannel,
+			Priority:            3,
+			SendQueueCapacity:   10,
+			RecvBufferCapacity:  4096,
+			RecvMessageCapacity: maxMsgSize,
+		},
+	}
+}
+
+// AddPeer implements Reactor by asking the peer for its snapshots, if syncing.
+func (ssR *StateSyncReactor) AddPeer(peer p2p.Peer) {
+	if ssR.getSyncer() != nil {
+		peer.TrySend(StateSyncChannel, amino.MustMarshalAny(&ssSnapshotsRequestMessage{}))
+	}
+}
+
+// RemovePeer implements Reactor by forgetting the snapshots of the peer.
+func (ssR *StateSyncReactor) RemovePeer(peer p2p.Peer, reason interface{}) {
+	if s := ssR.getSyncer(); s != nil {
+		s.removePeer(peer.ID())
+	}
+}
+
+// Receive implements Reactor.
+func (ssR *StateSyncReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
+	msg, err := decodeMsg(msgBytes)
+	if err != nil {
+		ssR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
+		ssR.Switch.StopPeerForError(src, err)
+		return
+	}
+
+	if err = msg.ValidateBasic(); err != nil {
+		ssR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
+		ssR.Switch.StopPeerForError(src, err)
+		return
+	}
+
+	ssR.Logger.Debug("Receive", "src", src, "chID", chID, "msg", msg)
+
+	switch msg := msg.(type) {
+	case *ssSnapshotsRequestMessage:
+		ssR.respondSnapshots(src)
+	case *ssChunkRequestMessage:
+		ssR.respondChunk(msg, src)
+	case *ssStateRequestMessage:
+		ssR.respondState(msg, src)
+	case *ssSnapshotsResponseMessage:
+		if s := ssR.getSyncer(); s != nil {
+			s.addSnapshots(src.ID(), msg.Snapshots)
+		}
+	case *ssChunkResponseMessage:
+		if s := ssR.getSyncer(); s != nil {
+			s.deliverChunk(src.ID(), msg)
+		}
+	case *ssStateResponseMessage:
+		if s := ssR.getSyncer(); s != nil {
+			s.deliverState(src.ID(), msg)
+		}
+	default:
+		ssR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
+	}
+}
+
+func (ssR *StateSyncReactor) respondSnapshots(src p2p.Peer) {
+	res, err := ssR.conn.ListSnapshotsSync(abci.RequestListSnapshots{})
+	if err == nil && res.Error != nil {
+		err = res.Error
+	}
+	if err != nil {
+		ssR.Logger.Error("Failed to list snapshots", "err", err)
+		return
+	}
+
+	snapshots := res.Snapshots
+	if len(snapshots) > maxSnapshots {
+		snapshots = snapshots[:maxSnapshots]
+	}
+	src.TrySend(StateSyncChannel, amino.MustMarshalAny(&ssSnapshotsResponseMessage{Snapshots: snapshots}))
+}
+
+func (ssR *StateSyncReactor) respondChunk(msg *ssChunkRequestMessage, src p2p.Peer) {
+	res, err := ssR.conn.LoadSnapshotChunkSync(abci.RequestLoadSnapshotChunk{
+		Height: msg.Height,
+		Format: msg.Format,
+		Chunk:  msg.Index,
+	})
+	if err == nil && res.Error != nil {
+		err = res.Error
+	}
+	if err != nil {
+		ssR.Logger.Info("Peer asking for a snapshot chunk we can't load",
+			"src", src, "height", msg.Height, "format", msg.Format, "index", msg.Index, "err", err)
+	}
+
+	src.TrySend(StateSyncChannel, amino.MustMarshalAny(&ssChunkResponseMessage{
+		Height:  msg.Height,
+		Format:  msg.Format,
+		Index:   msg.Index,
+		Chunk:   res.Chunk,
+		Missing: err != nil || len(res.Chunk) == 0,
+	}))
+}
+
+func (ssR *StateSyncReactor) respondState(msg *ssStateRequestMessage, src p2p.Peer) {
+	res := ssR.loadState(msg.Height)
+	if res.Missing {
+		ssR.Logger.Info("Peer asking for a state we don't have", "src", src, "height", msg.Height)
+	}
+	src.TrySend(StateSyncChannel, amino.MustMarshalAny(res))
+}
+
+// loadState loads what a peer needs to verify and bootstrap its state at
+// height, which requires the block at height+1 and its commit.
+func (ssR *StateSyncReactor) loadState(height int64) *ssStateResponseMessage {
+	missing := &ssStateResponseMessage{Height: height, Missing: true}
+
+	meta, nextMeta := ssR.blockStore.LoadBlockMeta(height), ssR.blockStore.LoadBlockMeta(height+1)
+	if meta == nil || nextMeta == nil {
+		return missing
+	}
+	commit := ssR.blockStore.LoadBlockCommit(height)
+	nextCommit := ssR.blockStore.LoadBlockCommit(height + 1)
+	if nextCommit == nil {
+		nextCommit = ssR.blockStore.LoadSeenCommit(height + 1)
+	}
+	if commit == nil || nextCommit == nil {
+		return missing
+	}
+
+	res := &ssStateResponseMessage{
+		Height:     height,
+		Header:     &meta.Header,
+		NextHeader: &nextMeta.Header,
+		Commit:     commit,
+		NextCommit: nextCommit,
+	}
+	var err error
+	if res.LastValidators, err = sm.LoadValidators(ssR.stateDB, height); err != nil {
+		return missing
+	}
+	if res.Validators, err = sm.LoadValidators(ssR.stateDB, height+1); err != nil {
+		return missing
+	}
+	if res.NextValidators, err = sm.LoadValidators(ssR.stateDB, height+2); err != nil {
+		return missing
+	}
+	if res.ConsensusParams, err = sm.LoadConsensusParams(ssR.stateDB, height+1); err != nil {
+		return missing
+	}
+	return res
+}
+
+func (ssR *StateSyncReactor) getSyncer() *syncer {
+	ssR.mtx.Lock()
+	defer ssR.mtx.Unlock()
+
+	return ssR.syncer
+}
+
+func (ssR *StateSyncReactor) setSyncer(s *syncer) {
+	ssR.mtx.Lock()
+	defer ssR.mtx.Unlock()
+
+	ssR.syncer = s
+}
+
+// Sync restores the application state from a snapshot of the peers, taken
+// at the trusted height, and returns the state and the seen commit at that
+// height, with which to bootstrap the node. The initial state provides the
+// chain ID and versions. Sync blocks until the state is restored, or fails.
+// The application can't be used once a restoration failed.
+func (ssR *StateSyncReactor) Sync(initialState sm.State) (sm.State, *types.Commit, error) {
+	trustHash, err := ssR.config.TrustHashBytes()
+	if err != nil {
+		return sm.State{}, nil, err
+	}
+
+	s := newSyncer(ssR, initialState, ssR.config.TrustHeight, trustHash)
+	ssR.setSyncer(s)
+	defer ssR.setSyncer(nil)
+
+	return s.sync()
+}
+
+// SetLogger implements service.Service.
+func (ssR *StateSyncReactor) SetLogger(l *slog.Logger) {
+	ssR.BaseService.Logger = l
+}
+
+// -----------------------------------------------------------------------------
+// Messages
+
+// StateSyncMessage is a generic message for this reactor.
+type StateSyncMessage interface {
+	ValidateBasic() error
+}
+
+func decodeMsg(bz []byte) (msg StateSyncMessage, err error) {
+	if len(bz) > maxMsgSize {
+		return msg, fmt.Errorf("msg exceeds max size (%d > %d)", len(bz), maxMsgSize)
+	}
+	err = amino.Unmarshal(bz, &msg)
+	return
+}
+
+// -------------------------------------
+
+type ssSnapshotsRequestMessage struct{}
+
+// ValidateBasic performs basic validation.
+func (m *ssSnapshotsRequestMessage) ValidateBasic() error {
+	return nil
+}
+
+func (m *ssSnapshotsRequestMessage) String() string {
+	return "[ssSnapshotsRequestMessage]"
+}
+
+type ssSnapshotsResponseMessage struct {
+	Snapshots []abci.Snapshot
+}
+
+// ValidateBasic performs basic validation.
+func (m *ssSnapshotsResponseMessage) ValidateBasic() error {
+	if len(m.Snapshots) > maxSnapshots {
+		return fmt.Errorf("too many snapshots (%d > %d)", len(m.Snapshots), maxSnapshots)
+	}
+	for _, snapshot := range m.Snapshots {
+		if snapshot.Height <= 0 {
+			return errors.New("non-positive snapshot height")
+		}
+		if snapshot.Chunks == 0 {
+			return errors.New("snapshot without chunks")
+		}
+		if len(snapshot.Hash) == 0 {
+			return errors.New("snapshot without hash")
+		}
+	}
+	return nil
+}
+
+func (m *ssSnapshotsResponseMessage) String() string {
+	return fmt.Sprintf("[ssSnapshotsResponseMessage %d]", len(m.Snapshots))
+}
+
+// -------------------------------------
+
+type ssChunkRequestMessage struct {
+	Height int64
+	Format uint32
+	Index  uint32
+}
+
+// ValidateBasic performs basic validation.
+func (m *ssChunkRequestMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("non-positive height")
+	}
+	return nil
+}
+
+func (m *ssChunkRequestMessage) String() string {
+	return fmt.Sprintf("[ssChunkRequestMessage %v/%v/%v]", m.Height, m.Format, m.Index)
+}
+
+type ssChunkResponseMessage struct {
+	Height  int64
+	Format  uint32
+	Index   uint32
+	Chunk   []byte
+	Missing bool
+}
+
+// ValidateBasic performs basic validation.
+func (m *ssChunkResponseMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("non-positive height")
+	}
+	if m.Missing && len(m.Chunk) > 0 {
+		return errors.New("missing chunk with data")
+	}
+	if len(m.Chunk) > maxChunkSize {
+		return fmt.Errorf("chunk exceeds max size (%d > %d)", len(m.Chunk), maxChunkSize)
+	}
+	return nil
+}
+
+func (m *ssChunkResponseMessage) String() string {
+	return fmt.Sprintf("[ssChunkResponseMessage %v/%v/%v missing:%v]", m.Height, m.Format, m.Index, m.Missing)
+}
+
+// -------------------------------------
+
+type ssStateRequestMessage struct {
+	Height int64
+}
+
+// ValidateBasic performs basic validation.
+func (m *ssStateRequestMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("non-positive height")
+	}
+	return nil
+}
+
+func (m *ssStateRequestMessage) String() string {
+	return fmt.Sprintf("[ssStateRequestMessage %v]", m.Height)
+}
+
+// ssStateResponseMessage holds the headers, commits, validator sets and
+// consensus params needed to verify and bootstrap the state at Height.
+type ssStateResponseMessage struct {
+	Height          int64
+	Header          *types.Header
+	NextHeader      *types.Header
+	Commit          *types.Commit
+	NextCommit      *types.Commit
+	LastValidators  *types.ValidatorSet
+	Validators      *types.ValidatorSet
+	NextValidators  *types.ValidatorSet
+	ConsensusParams abci.ConsensusParams
+	Missing         bool
+}
+
+// ValidateBasic performs basic validation.
+func (m *ssStateResponseMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("non-positive height")
+	}
+	if m.Missing {
+		return nil
+	}
+	if m.Header == nil || m.NextHeader == nil || m.Commit == nil || m.NextCommit == nil {
+		return errors.New("missing header or commit")
+	}
+	if m.LastValidators == nil || m.Validators == nil || m.NextValidators == nil {
+		return errors.New("missing validators")
+	}
+	if err := m.Commit.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid commit: %w", err)
+	}
+	if err := m.NextCommit.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid next commit: %w", err)
+	}
+	return nil
+}
+
+func (m *ssStateResponseMessage) String() string {
+	return fmt.Sprintf("[ssStateResponseMessage %v missing:%v]", m.Height, m.Missing)
+}
@@ -0,0 +1,275 @@
+package statesync
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/mempool/mock"
+	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	tmtime "github.com/gnolang/gno/tm2/pkg/bft/types/time"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/p2p"
+)
+
+const (
+	snapshotHeight = 3
+	testChainID    = "statesync-test"
+)
+
+var testChunks = [][]byte{[]byte("chunk0"), []byte("chunk1"), []byte("chunk2")}
+
+func randGenesisDoc(numValidators int) (*types.GenesisDoc, []types.PrivValidator) {
+	validators := make([]types.GenesisValidator, numValidators)
+	privValidators := make([]types.PrivValidator, numValidators)
+	for i := 0; i < numValidators; i++ {
+		val, privVal := types.RandValidator(false, 30)
+		validators[i] = types.GenesisValidator{
+			PubKey: val.PubKey,
+			Power:  val.VotingPower,
+		}
+		privValidators[i] = privVal
+	}
+	sort.Sort(types.PrivValidatorsByAddress(privValidators))
+
+	return &types.GenesisDoc{
+		GenesisTime: tmtime.Now(),
+		ChainID:     testChainID,
+		Validators:  validators,
+	}, privValidators
+}
+
+type testNode struct {
+	reactor    *StateSyncReactor
+	app        *snapshotApp
+	proxyApp   appconn.AppConns
+	stateDB    dbm.DB
+	blockStore *store.BlockStore
+	genesis    sm.State
+}
+
+// newTestNode creates a node with maxBlockHeight blocks, signed by the single
+// validator.
+func newTestNode(t *testing.T, config *cfg.Config, genDoc *types.GenesisDoc, privVal types.PrivValidator, maxBlockHeight int64) *testNode {
+	t.Helper()
+
+	app := &snapshotApp{}
+	proxyApp := appconn.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+
+	stateDB := memdb.NewMemDB()
+	blockStore := store.NewBlockStore(memdb.NewMemDB())
+	genesis, err := sm.LoadStateFromDBOrGenesisDoc(stateDB, genDoc)
+	require.NoError(t, err)
+	sm.SaveState(stateDB, genesis)
+
+	state := genesis
+	blockExec := sm.NewBlockExecutor(stateDB, log.NewNoopLogger(), proxyApp.Consensus(), mock.Mempool{})
+	for height := int64(1); height <= maxBlockHeight; height++ {
+		lastCommit := types.NewCommit(types.BlockID{}, nil)
+		if height > 1 {
+			lastBlockMeta := blockStore.LoadBlockMeta(height - 1)
+			vote, err := types.MakeVote(height-1, lastBlockMeta.BlockID, state.Validators, privVal, testChainID)
+			require.NoError(t, err)
+			lastCommit = types.NewCommit(lastBlockMeta.BlockID, []*types.CommitSig{vote.CommitSig()})
+		}
+
+		block, parts := state.MakeBlock(height, nil, lastCommit, state.Validators.GetProposer().Address)
+		blockID := types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+		state, err = blockExec.ApplyBlock(state, blockID, block)
+		require.NoError(t, err)
+
+		vote, err := types.MakeVote(height, blockID, state.LastValidators, privVal, testChainID)
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, parts, types.NewCommit(blockID, []*types.CommitSig{vote.CommitSig()}))
+	}
+
+	ssConfig := config.StateSync
+	if maxBlockHeight == 0 {
+		ssConfig.Enable = true
+		ssConfig.TrustHeight = snapshotHeight
+	}
+	reactor := NewStateSyncReactor(ssConfig, proxyApp.StateSync(), stateDB, blockStore)
+	reactor.SetLogger(log.NewTestingLogger(t))
+
+	return &testNode{
+		reactor:    reactor,
+		app:        app,
+		proxyApp:   proxyApp,
+		stateDB:    stateDB,
+		blockStore: blockStore,
+		genesis:    genesis,
+	}
+}
+
+func TestStateSync(t *testing.T) {
+	t.Parallel()
+
+	config := cfg.ResetTestRoot("statesync_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1)
+
+	server := newTestNode(t, config, genDoc, privVals[0], 5)
+	server.app.serve = true
+	client := newTestNode(t, config, genDoc, privVals[0], 0)
+	client.reactor.config.TrustHash = hex.EncodeToString(server.blockStore.LoadBlockMeta(snapshotHeight).BlockID.Hash)
+
+	nodes := []*testNode{server, client}
+	p2p.MakeConnectedSwitches(config.P2P, 2, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("STATESYNC", nodes[i].reactor)
+		return s
+	}, p2p.Connect2Switches)
+	defer func() {
+		for _, n := range nodes {
+			n.reactor.Switch.Stop()
+			n.proxyApp.Stop()
+		}
+	}()
+
+	state, commit, err := client.reactor.Sync(client.genesis)
+	require.NoError(t, err)
+	assert.Equal(t, len(testChunks), client.app.applied)
+
+	// The restored state matches the state of the server at the snapshot height.
+	expected := sm.LoadState(server.stateDB)
+	assert.EqualValues(t, snapshotHeight, state.LastBlockHeight)
+	assert.Equal(t, server.blockStore.LoadBlockMeta(snapshotHeight).BlockID, state.LastBlockID)
+	assert.Equal(t, expected.AppHash, state.AppHash)
+	assert.Equal(t, expected.Validators.Hash(), state.Validators.Hash())
+	assert.Equal(t, server.blockStore.LoadBlockCommit(snapshotHeight).Hash(), commit.Hash())
+
+	// The node can be bootstrapped with the restored state.
+	require.NoError(t, sm.BootstrapState(client.stateDB, state))
+	client.blockStore.Bootstrap(state.LastBlockHeight, commit)
+	assert.Equal(t, state.Bytes(), sm.LoadState(client.stateDB).Bytes())
+	assert.EqualValues(t, snapshotHeight, client.blockStore.Height())
+	for h := int64(snapshotHeight); h <= snapshotHeight+2; h++ {
+		vals, err := sm.LoadValidators(client.stateDB, h)
+		require.NoError(t, err)
+		assert.Equal(t, state.Validators.Hash(), vals.Hash())
+	}
+}
+
+func TestVerifyState(t *testing.T) {
+	t.Parallel()
+
+	config := cfg.ResetTestRoot("statesync_verify_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1)
+
+	server := newTestNode(t, config, genDoc, privVals[0], 5)
+	defer server.proxyApp.Stop()
+	trustHash := server.blockStore.LoadBlockMeta(snapshotHeight).BlockID.Hash
+
+	// The state can't be loaded without the next block and its commit.
+	assert.True(t, server.reactor.loadState(5).Missing)
+	msg := server.reactor.loadState(snapshotHeight)
+	require.False(t, msg.Missing)
+	require.NoError(t, msg.ValidateBasic())
+
+	_, _, err := verifyState(server.genesis, snapshotHeight, trustHash, msg)
+	require.NoError(t, err)
+
+	// Untrusted hash.
+	_, _, err = verifyState(server.genesis, snapshotHeight, []byte("untrusted"), msg)
+	assert.Error(t, err)
+
+	// Wrong height.
+	_, _, err = verifyState(server.genesis, snapshotHeight+1, trustHash, msg)
+	assert.Error(t, err)
+
+	// Tampered app hash.
+	tampered := *msg
+	next := *msg.NextHeader
+	next.AppHash = []byte("tampered")
+	tampered.NextHeader = &next
+	_, _, err = verifyState(server.genesis, snapshotHeight, trustHash, &tampered)
+	assert.Error(t, err)
+
+	// Unrelated validators.
+	tampered = *msg
+	tampered.NextValidators, _ = types.RandValidatorSet(1, 10)
+	_, _, err = verifyState(server.genesis, snapshotHeight, trustHash, &tampered)
+	assert.Error(t, err)
+}
+
+// ----------------------------------------------
+// snapshotApp
+
+// snapshotApp serves, or restores, a snapshot at snapshotHeight.
+type snapshotApp struct {
+	abci.BaseApplication
+
+	serve    bool
+	height   int64
+	appHash  []byte
+	snapshot *abci.Snapshot
+	applied  int
+}
+
+var _ abci.Application = (*snapshotApp)(nil)
+
+var testAppHash = []byte("app hash")
+
+func (app *snapshotApp) Info(req abci.RequestInfo) abci.ResponseInfo {
+	return abci.ResponseInfo{LastBlockHeight: app.height, LastBlockAppHash: app.appHash}
+}
+
+func (app *snapshotApp) Commit() abci.ResponseCommit {
+	app.height++
+	app.appHash = testAppHash
+	return abci.ResponseCommit{ResponseBase: abci.ResponseBase{Data: testAppHash}}
+}
+
+func (app *snapshotApp) ListSnapshots(req abci.RequestListSnapshots) abci.ResponseListSnapshots {
+	if !app.serve {
+		return abci.ResponseListSnapshots{}
+	}
+	return abci.ResponseListSnapshots{Snapshots: []abci.Snapshot{{
+		Height: snapshotHeight,
+		Format: 1,
+		Chunks: uint32(len(testChunks)),
+		Hash:   []byte("snapshot hash"),
+	}}}
+}
+
+func (app *snapshotApp) LoadSnapshotChunk(req abci.RequestLoadSnapshotChunk) abci.ResponseLoadSnapshotChunk {
+	if !app.serve || req.Height != snapshotHeight || int(req.Chunk) >= len(testChunks) {
+		return abci.ResponseLoadSnapshotChunk{}
+	}
+	return abci.ResponseLoadSnapshotChunk{Chunk: testChunks[req.Chunk]}
+}
+
+func (app *snapshotApp) OfferSnapshot(req abci.RequestOfferSnapshot) abci.ResponseOfferSnapshot {
+	if !bytes.Equal(req.AppHash, testAppHash) {
+		return abci.ResponseOfferSnapshot{ResponseBase: abci.ResponseBase{Error: abci.StringError("wrong app hash")}}
+	}
+	app.snapshot = &req.Snapshot
+	return abci.ResponseOfferSnapshot{}
+}
+
+func (app *snapshotApp) ApplySnapshotChunk(req abci.RequestApplySnapshotChunk) abci.ResponseApplySnapshotChunk {
+	if app.snapshot == nil || int(req.Index) != app.applied || !bytes.Equal(req.Chunk, testChunks[req.Index]) {
+		return abci.ResponseApplySnapshotChunk{ResponseBase: abci.ResponseBase{Error: abci.StringError("invalid chunk")}}
+	}
+	app.applied++
+	if app.applied < len(testChunks) {
+		return abci.ResponseApplySnapshotChunk{}
+	}
+	app.height = app.snapshot.Height
+	app.appHash = testAppHash
+	return abci.ResponseApplySnapshotChunk{Done: true}
+}
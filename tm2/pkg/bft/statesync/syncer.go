@@ -0,0 +1,439 @@
+package statesync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/p2p"
+)
+
+// maxChunkAttempts is the number of times a chunk is fetched, from different
+// peers, before the restoration fails.
+const maxChunkAttempts = 10
+
+var (
+	errQuit     = errors.New("state sync reactor stopped")
+	errTimeout  = errors.New("request timed out")
+	errMissing  = errors.New("peer doesn't have the requested data")
+	errRejected = errors.New("snapshot rejected")
+)
+
+// snapshotPeers is a snapshot, and the peers which advertised it.
+type snapshotPeers struct {
+	snapshot abci.Snapshot
+	peers    map[p2p.ID]struct{}
+}
+
+type peerChunk struct {
+	peerID p2p.ID
+	msg    *ssChunkResponseMessage
+}
+
+type peerState struct {
+	peerID p2p.ID
+	msg    *ssStateResponseMessage
+}
+
+// syncer restores the application state from the snapshots of the peers
+// at the trusted height.
+type syncer struct {
+	ssR          *StateSyncReactor
+	initialState sm.State
+	height       int64
+	trustHash    []byte
+
+	mtx       sync.Mutex
+	snapshots map[string]*snapshotPeers
+
+	chunkCh chan peerChunk
+	stateCh chan peerState
+}
+
+func newSyncer(ssR *StateSyncReactor, initialState sm.State, height int64, trustHash []byte) *syncer {
+	return &syncer{
+		ssR:          ssR,
+		initialState: initialState,
+		height:       height,
+		trustHash:    trustHash,
+		snapshots:    make(map[string]*snapshotPeers),
+		chunkCh:      make(chan peerChunk, 16),
+		stateCh:      make(chan peerState, 16),
+	}
+}
+
+// addSnapshots records the snapshots advertised by a peer, at the trusted
+// height.
+func (s *syncer) addSnapshots(peerID p2p.ID, snapshots []abci.Snapshot) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for _, snapshot := range snapshots {
+		if snapshot.Height != s.height {
+			continue
+		}
+		key := string(amino.MustMarshal(snapshot))
+		sp, ok := s.snapshots[key]
+		if !ok {
+			sp = &snapshotPeers{snapshot: snapshot, peers: make(map[p2p.ID]struct{})}
+			s.snapshots[key] = sp
+		}
+		sp.peers[peerID] = struct{}{}
+	}
+}
+
+// removePeer forgets the snapshots advertised by a peer.
+func (s *syncer) removePeer(peerID p2p.ID) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for _, sp := range s.snapshots {
+		delete(sp.peers, peerID)
+	}
+}
+
+// removeSnapshot forgets a snapshot, e.g. rejected by the application.
+func (s *syncer) removeSnapshot(snapshot abci.Snapshot) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	delete(s.snapshots, string(amino.MustMarshal(snapshot)))
+}
+
+// candidates returns the snapshots at the trusted height, the most
+// advertised first.
+func (s *syncer) candidates() []abci.Snapshot {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	sps := make([]*snapshotPeers, 0, len(s.snapshots))
+	for _, sp := range s.snapshots {
+		if len(sp.peers) > 0 {
+			sps = append(sps, sp)
+		}
+	}
+	sort.Slice(sps, func(i, j int) bool {
+		if len(sps[i].peers) != len(sps[j].peers) {
+			return len(sps[i].peers) > len(sps[j].peers)
+		}
+		return bytes.Compare(sps[i].snapshot.Hash, sps[j].snapshot.Hash) < 0
+	})
+
+	snapshots := make([]abci.Snapshot, len(sps))
+	for i, sp := range sps {
+		snapshots[i] = sp.snapshot
+	}
+	return snapshots
+}
+
+// snapshotPeers returns the connected peers which advertised a snapshot.
+func (s *syncer) snapshotPeers(snapshot abci.Snapshot) []p2p.Peer {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	sp := s.snapshots[string(amino.MustMarshal(snapshot))]
+	if sp == nil {
+		return nil
+	}
+	peers := make([]p2p.Peer, 0, len(sp.peers))
+	for peerID := range sp.peers {
+		if peer := s.ssR.Switch.Peers().Get(peerID); peer != nil {
+			peers = append(peers, peer)
+		}
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].ID() < peers[j].ID() })
+	return peers
+}
+
+// forgetPeerSnapshot forgets that a peer advertised a snapshot, e.g. when
+// it doesn't serve its chunks.
+func (s *syncer) forgetPeerSnapshot(peerID p2p.ID, snapshot abci.Snapshot) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if sp := s.snapshots[string(amino.MustMarshal(snapshot))]; sp != nil {
+		delete(sp.peers, peerID)
+	}
+}
+
+func (s *syncer) deliverChunk(peerID p2p.ID, msg *ssChunkResponseMessage) {
+	select {
+	case s.chunkCh <- peerChunk{peerID: peerID, msg: msg}:
+	default:
+		s.ssR.Logger.Debug("Dropping unexpected chunk", "peer", peerID, "msg", msg)
+	}
+}
+
+func (s *syncer) deliverState(peerID p2p.ID, msg *ssStateResponseMessage) {
+	select {
+	case s.stateCh <- peerState{peerID: peerID, msg: msg}:
+	default:
+		s.ssR.Logger.Debug("Dropping unexpected state", "peer", peerID, "msg", msg)
+	}
+}
+
+// sync discovers the snapshots of the peers, until one of them is restored.
+func (s *syncer) sync() (sm.State, *types.Commit, error) {
+	logger := s.ssR.Logger
+	logger.Info("Starting state sync", "height", s.height, "hash", fmt.Sprintf("%X", s.trustHash))
+
+	for {
+		s.ssR.Switch.Broadcast(StateSyncChannel, amino.MustMarshalAny(&ssSnapshotsRequestMessage{}))
+		select {
+		case <-time.After(s.ssR.config.DiscoveryTime):
+		case <-s.ssR.Quit():
+			return sm.State{}, nil, errQuit
+		}
+
+		candidates := s.candidates()
+		if len(candidates) == 0 {
+			logger.Info("No snapshot found at the trusted height, retrying", "height", s.height)
+			continue
+		}
+
+		state, commit, err := s.fetchState(candidates)
+		if errors.Is(err, errQuit) {
+			return sm.State{}, nil, err
+		} else if err != nil {
+			logger.Info("Failed to fetch the trusted state, retrying", "height", s.height, "err", err)
+			continue
+		}
+
+		for _, snapshot := range candidates {
+			err := s.restore(snapshot, state.AppHash)
+			switch {
+			case err == nil:
+				logger.Info("Restored snapshot", "height", snapshot.Height, "hash", fmt.Sprintf("%X", snapshot.Hash))
+				return state, commit, nil
+			case errors.Is(err, errRejected):
+				logger.Info("Snapshot rejected", "height", snapshot.Height, "hash", fmt.Sprintf("%X", snapshot.Hash), "err", err)
+				s.removeSnapshot(snapshot)
+			default:
+				return sm.State{}, nil, err
+			}
+		}
+	}
+}
+
+// fetchState fetches the state at the trusted height from the peers
+// advertising the candidate snapshots, and verifies it.
+func (s *syncer) fetchState(candidates []abci.Snapshot) (sm.State, *types.Commit, error) {
+	tried := make(map[p2p.ID]bool)
+	for _, snapshot := range candidates {
+		for _, peer := range s.snapshotPeers(snapshot) {
+			if tried[peer.ID()] {
+				continue
+			}
+			tried[peer.ID()] = true
+
+			msg, err := s.requestState(peer)
+			if errors.Is(err, errQuit) {
+				return sm.State{}, nil, err
+			} else if err != nil {
+				s.ssR.Logger.Debug("Failed to fetch state", "peer", peer.ID(), "err", err)
+				continue
+			}
+			state, commit, err := verifyState(s.initialState, s.height, s.trustHash, msg)
+			if err != nil {
+				s.ssR.Switch.StopPeerForError(peer, fmt.Errorf("invalid state sync state: %w", err))
+				continue
+			}
+			return state, commit, nil
+		}
+	}
+	return sm.State{}, nil, errors.New("no peer provided a valid state")
+}
+
+func (s *syncer) requestState(peer p2p.Peer) (*ssStateResponseMessage, error) {
+	peer.TrySend(StateSyncChannel, amino.MustMarshalAny(&ssStateRequestMessage{Height: s.height}))
+
+	timer := time.NewTimer(s.ssR.config.RequestTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case res := <-s.stateCh:
+			if res.peerID != peer.ID() || res.msg.Height != s.height {
+				continue
+			}
+			if res.msg.Missing {
+				return nil, errMissing
+			}
+			return res.msg, nil
+		case <-timer.C:
+			return nil, errTimeout
+		case <-s.ssR.Quit():
+			return nil, errQuit
+		}
+	}
+}
+
+// restore offers the snapshot to the application, and applies its chunks.
+// The snapshot is rejected with errRejected while no chunk was applied: any
+// other error leaves the application partially restored.
+func (s *syncer) restore(snapshot abci.Snapshot, appHash []byte) error {
+	res, err := s.ssR.conn.OfferSnapshotSync(abci.RequestOfferSnapshot{
+		Snapshot: snapshot,
+		AppHash:  appHash,
+	})
+	if err != nil {
+		return err
+	}
+	if res.Error != nil {
+		return fmt.Errorf("%w: %w", errRejected, res.Error)
+	}
+
+	applied := false
+	for index, attempts := uint32(0), 0; index < snapshot.Chunks; {
+		if attempts >= maxChunkAttempts {
+			err = fmt.Errorf("failed to fetch chunk %d after %d attempts", index, attempts)
+			break
+		}
+		peers := s.snapshotPeers(snapshot)
+		if len(peers) == 0 {
+			err = fmt.Errorf("no peer to fetch chunk %d from", index)
+			break
+		}
+		peer := peers[attempts%len(peers)]
+		attempts++
+
+		chunk, err := s.requestChunk(peer, snapshot, index)
+		if errors.Is(err, errQuit) {
+			return err
+		} else if err != nil {
+			s.ssR.Logger.Debug("Failed to fetch chunk", "peer", peer.ID(), "index", index, "err", err)
+			if errors.Is(err, errMissing) {
+				s.forgetPeerSnapshot(peer.ID(), snapshot)
+			}
+			continue
+		}
+
+		res, err := s.ssR.conn.ApplySnapshotChunkSync(abci.RequestApplySnapshotChunk{
+			Index:  index,
+			Chunk:  chunk,
+			Sender: string(peer.ID()),
+		})
+		if err != nil {
+			return err
+		}
+		if res.Error != nil {
+			s.ssR.Logger.Info("Chunk rejected", "peer", peer.ID(), "index", index, "err", res.Error)
+			s.forgetPeerSnapshot(peer.ID(), snapshot)
+			continue
+		}
+
+		applied = true
+		if res.Done {
+			return nil
+		}
+		index++
+		attempts = 0
+	}
+
+	if err == nil {
+		err = errors.New("all the chunks were applied, but the snapshot wasn't restored")
+	}
+	if !applied {
+		return fmt.Errorf("%w: %w", errRejected, err)
+	}
+	return err
+}
+
+func (s *syncer) requestChunk(peer p2p.Peer, snapshot abci.Snapshot, index uint32) ([]byte, error) {
+	peer.TrySend(StateSyncChannel, amino.MustMarshalAny(&ssChunkRequestMessage{
+		Height: snapshot.Height,
+		Format: snapshot.Format,
+		Index:  index,
+	}))
+
+	timer := time.NewTimer(s.ssR.config.RequestTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case res := <-s.chunkCh:
+			msg := res.msg
+			if res.peerID != peer.ID() || msg.Height != snapshot.Height ||
+				msg.Format != snapshot.Format || msg.Index != index {
+				continue
+			}
+			if msg.Missing {
+				return nil, errMissing
+			}
+			return msg.Chunk, nil
+		case <-timer.C:
+			return nil, errTimeout
+		case <-s.ssR.Quit():
+			return nil, errQuit
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Verification
+
+// verifyState verifies the state at height sent by a peer, from the trusted
+// hash of the block at height, and returns the state to bootstrap the node
+// with, and the commit of the block at height.
+//
+// The header at height is trusted by its hash, and the header at height+1,
+// which holds the app hash of the state at height, is verified by the commit
+// of the validators of height+1, trusted by the header at height.
+func verifyState(initialState sm.State, height int64, trustHash []byte, m *ssStateResponseMessage) (sm.State, *types.Commit, error) {
+	chainID := initialState.ChainID
+	header, next := m.Header, m.NextHeader
+
+	switch {
+	case header.Height != height || next.Height != height+1:
+		return sm.State{}, nil, fmt.Errorf("wrong header heights %d and %d", header.Height, next.Height)
+	case header.ChainID != chainID || next.ChainID != chainID:
+		return sm.State{}, nil, fmt.Errorf("wrong chain ID, expected %q", chainID)
+	case !bytes.Equal(header.Hash(), trustHash):
+		return sm.State{}, nil, fmt.Errorf("header hash %X doesn't match the trusted hash %X", header.Hash(), trustHash)
+	case !bytes.Equal(next.LastBlockID.Hash, trustHash):
+		return sm.State{}, nil, errors.New("next header doesn't follow the trusted header")
+	case !bytes.Equal(m.LastValidators.Hash(), header.ValidatorsHash):
+		return sm.State{}, nil, errors.New("last validators don't match the trusted header")
+	case !bytes.Equal(m.Validators.Hash(), header.NextValidatorsHash):
+		return sm.State{}, nil, errors.New("validators don't match the trusted header")
+	case !bytes.Equal(m.Validators.Hash(), next.ValidatorsHash):
+		return sm.State{}, nil, errors.New("validators don't match the next header")
+	case !bytes.Equal(m.NextValidators.Hash(), next.NextValidatorsHash):
+		return sm.State{}, nil, errors.New("next validators don't match the next header")
+	case !bytes.Equal(m.ConsensusParams.Hash(), next.ConsensusHash):
+		return sm.State{}, nil, errors.New("consensus params don't match the next header")
+	}
+
+	nextBlockID := m.NextCommit.BlockID
+	if !bytes.Equal(nextBlockID.Hash, next.Hash()) {
+		return sm.State{}, nil, errors.New("next commit isn't for the next header")
+	}
+	if err := m.Validators.VerifyCommit(chainID, nextBlockID, height+1, m.NextCommit); err != nil {
+		return sm.State{}, nil, fmt.Errorf("invalid next commit: %w", err)
+	}
+	if err := m.LastValidators.VerifyCommit(chainID, next.LastBlockID, height, m.Commit); err != nil {
+		return sm.State{}, nil, fmt.Errorf("invalid commit: %w", err)
+	}
+
+	state := initialState.Copy()
+	state.AppVersion = next.AppVersion
+	state.LastBlockHeight = height
+	state.LastBlockTotalTx = header.TotalTxs
+	state.LastBlockID = next.LastBlockID
+	state.LastBlockTime = header.Time
+	state.LastValidators = m.LastValidators
+	state.Validators = m.Validators
+	state.NextValidators = m.NextValidators
+	state.LastHeightValidatorsChanged = height + 2
+	state.ConsensusParams = m.ConsensusParams
+	state.LastHeightConsensusParamsChanged = height + 1
+	state.LastResultsHash = next.LastResultsHash
+	state.AppHash = next.AppHash
+
+	return state, m.Commit, nil
+}
@@ -187,6 +187,27 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	bs.db.SetSync(nil, nil)
 }
 
+// Bootstrap sets the height of an empty BlockStore, e.g. for a node
+// bootstrapped by state sync, so that it saves the blocks from height+1.
+// No block is stored at height: only its seen commit, which the consensus
+// needs to start at height+1.
+func (bs *BlockStore) Bootstrap(height int64, seenCommit *types.Commit) {
+	if g := bs.Height(); g != 0 {
+		panic(fmt.Sprintf("BlockStore can only be bootstrapped when empty, got height %v", g))
+	}
+	if seenCommit == nil || seenCommit.Height() != height {
+		panic(fmt.Sprintf("BlockStore must be bootstrapped with the commit for height %v", height))
+	}
+
+	seenCommitBytes := amino.MustMarshal(seenCommit)
+	bs.db.Set(calcSeenCommitKey(height), seenCommitBytes)
+	BlockStoreStateJSON{Height: height}.Save(bs.db)
+
+	bs.mtx.Lock()
+	bs.height = height
+	bs.mtx.Unlock()
+}
+
 func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part) {
 	if height != bs.Height()+1 {
 		panic(fmt.Sprintf("BlockStore can only save contiguous blocks. Wanted %v, got %v", bs.Height()+1, height))
@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type MultisignCfg struct {
+	RootCfg *BaseCfg
+
+	TxPath        string
+	ChainID       string
+	AccountNumber uint64
+	Sequence      uint64
+	NameOrBech32  string
+	TxJSON        []byte
+	SigsJSON      [][]byte
+}
+
+func NewMultisignCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cfg := &MultisignCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "multisign",
+			ShortUsage: "multisign [flags] <multisig key-name or address> <signature file> [<signature file>...]",
+			ShortHelp:  "combines partial signatures into a multisig tx",
+			LongHelp: `Combines the partial signatures of a multisig key, made with
+'sign -multisig', into the signature of the multisig in the tx, and prints the
+signed tx. At least K valid partial signatures are required for a K of N
+multisig key.`,
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMultisign(cfg, args, io)
+		},
+	)
+}
+
+func (c *MultisignCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.TxPath,
+		"txpath",
+		"-",
+		"path to file of tx to sign",
+	)
+
+	fs.StringVar(
+		&c.ChainID,
+		"chainid",
+		"dev",
+		"chainid the tx was signed for",
+	)
+
+	fs.Uint64Var(
+		&c.AccountNumber,
+		"number",
+		0,
+		"account number of the multisig (required)",
+	)
+
+	fs.Uint64Var(
+		&c.Sequence,
+		"sequence",
+		0,
+		"sequence of the multisig (required)",
+	)
+}
+
+func execMultisign(cfg *MultisignCfg, args []string, io commands.IO) error {
+	var err error
+
+	if len(args) < 2 {
+		return flag.ErrHelp
+	}
+
+	cfg.NameOrBech32 = args[0]
+
+	// read tx to sign
+	txpath := cfg.TxPath
+	if txpath == "-" { // from stdin.
+		txjsonstr, err := io.GetString(
+			"Enter tx to sign, terminated by a newline.",
+		)
+		if err != nil {
+			return err
+		}
+		cfg.TxJSON = []byte(txjsonstr)
+	} else { // from file
+		cfg.TxJSON, err = os.ReadFile(txpath)
+		if err != nil {
+			return err
+		}
+	}
+
+	// read partial signatures
+	cfg.SigsJSON = nil
+	for _, sigpath := range args[1:] {
+		sigjson, err := os.ReadFile(sigpath)
+		if err != nil {
+			return err
+		}
+		cfg.SigsJSON = append(cfg.SigsJSON, sigjson)
+	}
+
+	signedTx, err := MultisignHandler(cfg)
+	if err != nil {
+		return err
+	}
+
+	signedJSON, err := amino.MarshalJSON(signedTx)
+	if err != nil {
+		return err
+	}
+	io.Println(string(signedJSON))
+
+	return nil
+}
+
+// MultisignHandler combines the partial signatures cfg.SigsJSON of the
+// multisig key cfg.NameOrBech32 into the tx.
+func MultisignHandler(cfg *MultisignCfg) (*std.Tx, error) {
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	if err != nil {
+		return nil, err
+	}
+
+	multiPub, err := getMultisigPubKey(kb, cfg.NameOrBech32)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, signbz, err := txToSign(cfg.TxJSON, cfg.ChainID, cfg.AccountNumber, cfg.Sequence)
+	if err != nil {
+		return nil, err
+	}
+
+	mSig := multisig.NewMultisig(len(multiPub.PubKeys))
+	for i, sigjson := range cfg.SigsJSON {
+		var sig std.Signature
+		if err := amino.UnmarshalJSON(sigjson, &sig); err != nil {
+			return nil, errors.Wrap(err, "unable to decode signature #%d", i+1)
+		}
+		if sig.PubKey == nil || !hasPubKey(multiPub, sig.PubKey) {
+			return nil, errors.New(
+				fmt.Sprintf("signature #%d is not from a key of multisig %s", i+1, cfg.NameOrBech32),
+			)
+		}
+		if !sig.PubKey.VerifyBytes(signbz, sig.Signature) {
+			return nil, errors.New(
+				fmt.Sprintf("signature #%d is invalid", i+1),
+			)
+		}
+		if err := mSig.AddSignatureFromPubKey(sig.Signature, sig.PubKey, multiPub.PubKeys); err != nil {
+			return nil, err
+		}
+	}
+
+	n := mSig.BitArray.NumTrueBitsBefore(len(multiPub.PubKeys))
+	if n < int(multiPub.K) {
+		return nil, errors.New(
+			fmt.Sprintf("%d signatures of multisig %s, %d required", n, cfg.NameOrBech32, multiPub.K),
+		)
+	}
+
+	addr := multiPub.Address()
+	if !setSignature(&tx, addr, std.Signature{
+		PubKey:    multiPub,
+		Signature: mSig.Marshal(),
+	}) {
+		return nil, errors.New(
+			fmt.Sprintf("multisig addr %v (%s) not in signer set", addr, cfg.NameOrBech32),
+		)
+	}
+
+	return &tx, nil
+}
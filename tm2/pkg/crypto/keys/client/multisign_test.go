@@ -0,0 +1,142 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+	sdkutils "github.com/gnolang/gno/tm2/pkg/sdk/testutils"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/testutils"
+)
+
+func Test_execMultisign(t *testing.T) {
+	t.Parallel()
+
+	// make new test dir
+	kbHome, kbCleanUp := testutils.NewTestCaseDir(t)
+	assert.NotNil(t, kbHome)
+	defer kbCleanUp()
+
+	baseCfg := &BaseCfg{
+		BaseOptions: BaseOptions{
+			Home:                  kbHome,
+			InsecurePasswordStdin: true,
+		},
+	}
+	encPassword := "12345678"
+
+	// add the keys of a 2 of 3 multisig, and another key.
+	kb, err := keys.NewKeyBaseFromDir(kbHome)
+	require.NoError(t, err)
+	var pubs []crypto.PubKey
+	for i := 0; i < 4; i++ {
+		info, err := kb.CreateAccount(fmt.Sprintf("key%d", i), testMnemonic, "", encPassword, 0, uint32(i))
+		require.NoError(t, err)
+		pubs = append(pubs, info.GetPubKey())
+	}
+	multiPub := multisig.NewPubKeyMultisigThreshold(2, pubs[:3])
+	_, err = kb.CreateMulti("multi", multiPub)
+	require.NoError(t, err)
+	kb.CloseDB()
+
+	// create a tx to sign.
+	msg := sdkutils.NewTestMsg(multiPub.Address())
+	fee := std.NewFee(1, std.Coin{Denom: "ugnot", Amount: 1000000})
+	tx := std.NewTx([]std.Msg{msg}, fee, nil, "")
+	txjson := string(amino.MustMarshalJSON(tx))
+
+	// sign the tx with the keys of the multisig.
+	sign := func(key string) (string, error) {
+		t.Helper()
+
+		cfg := &SignCfg{
+			RootCfg:       baseCfg,
+			TxPath:        "-",
+			ChainID:       "dev",
+			AccountNumber: 1,
+			Sequence:      2,
+			Multisig:      "multi",
+		}
+		io := commands.NewTestIO()
+		out := new(strings.Builder)
+		io.SetOut(commands.WriteNopCloser(out))
+		io.SetIn(strings.NewReader(fmt.Sprintf("%s\n%s\n", txjson, encPassword)))
+		if err := execSign(cfg, []string{key}, io); err != nil {
+			return "", err
+		}
+		sigpath := filepath.Join(kbHome, key+".json")
+		require.NoError(t, os.WriteFile(sigpath, []byte(out.String()), 0o644))
+		return sigpath, nil
+	}
+	sig0, err := sign("key0")
+	require.NoError(t, err)
+	sig2, err := sign("key2")
+	require.NoError(t, err)
+	_, err = sign("key3")
+	assert.ErrorContains(t, err, "key key3 is not one of the keys of multisig multi")
+
+	multisign := func(sigs ...string) (*std.Tx, error) {
+		t.Helper()
+
+		cfg := &MultisignCfg{
+			RootCfg:       baseCfg,
+			TxPath:        "-",
+			ChainID:       "dev",
+			AccountNumber: 1,
+			Sequence:      2,
+		}
+		io := commands.NewTestIO()
+		out := new(strings.Builder)
+		io.SetOut(commands.WriteNopCloser(out))
+		io.SetIn(strings.NewReader(txjson + "\n"))
+		if err := execMultisign(cfg, append([]string{"multi"}, sigs...), io); err != nil {
+			return nil, err
+		}
+		var signed std.Tx
+		require.NoError(t, amino.UnmarshalJSON([]byte(out.String()), &signed))
+		return &signed, nil
+	}
+
+	// not enough signatures.
+	_, err = multisign(sig0)
+	assert.ErrorContains(t, err, "1 signatures of multisig multi, 2 required")
+	_, err = multisign(sig0, sig0)
+	assert.ErrorContains(t, err, "1 signatures of multisig multi, 2 required")
+
+	// 2 of 3 signatures.
+	signed, err := multisign(sig0, sig2)
+	require.NoError(t, err)
+	require.Len(t, signed.Signatures, 1)
+	assert.True(t, signed.Signatures[0].PubKey.Equals(multiPub))
+	signbz := signed.GetSignBytes("dev", 1, 2)
+	assert.True(t, multiPub.VerifyBytes(signbz, signed.Signatures[0].Signature))
+	assert.False(t, multiPub.VerifyBytes(signed.GetSignBytes("dev", 1, 3), signed.Signatures[0].Signature))
+
+	// signatures for another sequence are rejected.
+	cfg := &MultisignCfg{
+		RootCfg:       baseCfg,
+		ChainID:       "dev",
+		AccountNumber: 1,
+		Sequence:      3,
+		NameOrBech32:  "multi",
+		TxJSON:        []byte(txjson),
+	}
+	for _, sigpath := range []string{sig0, sig2} {
+		sigjson, err := os.ReadFile(sigpath)
+		require.NoError(t, err)
+		cfg.SigsJSON = append(cfg.SigsJSON, sigjson)
+	}
+	_, err = MultisignHandler(cfg)
+	assert.ErrorContains(t, err, "signature #1 is invalid")
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
@@ -21,6 +23,7 @@ type SignCfg struct {
 	AccountNumber uint64
 	Sequence      uint64
 	ShowSignBytes bool
+	Multisig      string
 	NameOrBech32  string
 	TxJSON        []byte
 	Pass          string
@@ -36,6 +39,11 @@ func NewSignCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
 			Name:       "sign",
 			ShortUsage: "sign [flags] <key-name or address>",
 			ShortHelp:  "signs the document",
+			LongHelp: `Signs the tx with the given key, and prints the signed tx.
+
+With the 'multisig' flag, the key is one of the keys of the given multisig key,
+and the partial signature of the multisig is printed instead. The partial
+signatures of the co-signers are combined into the tx with 'multisign'.`,
 		},
 		cfg,
 		func(_ context.Context, args []string) error {
@@ -79,6 +87,13 @@ func (c *SignCfg) RegisterFlags(fs *flag.FlagSet) {
 		false,
 		"show sign bytes and quit",
 	)
+
+	fs.StringVar(
+		&c.Multisig,
+		"multisig",
+		"",
+		"name or address of a multisig key: print a partial signature of it instead of the signed tx",
+	)
 }
 
 func execSign(cfg *SignCfg, args []string, io commands.IO) error {
@@ -122,12 +137,17 @@ func execSign(cfg *SignCfg, args []string, io commands.IO) error {
 		return err
 	}
 
-	signedTx, err := SignHandler(cfg)
+	var signed interface{}
+	if cfg.Multisig != "" {
+		signed, err = SignMultisigHandler(cfg)
+	} else {
+		signed, err = SignHandler(cfg)
+	}
 	if err != nil {
 		return err
 	}
 
-	signedJSON, err := amino.MarshalJSON(signedTx)
+	signedJSON, err := amino.MarshalJSON(signed)
 	if err != nil {
 		return err
 	}
@@ -137,27 +157,97 @@ func execSign(cfg *SignCfg, args []string, io commands.IO) error {
 }
 
 func SignHandler(cfg *SignCfg) (*std.Tx, error) {
-	var err error
-	var tx std.Tx
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, signbz, err := txToSign(cfg.TxJSON, cfg.ChainID, cfg.AccountNumber, cfg.Sequence)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ShowSignBytes {
+		fmt.Printf("sign bytes: %X\n", signbz)
+		return nil, nil
+	}
 
-	if cfg.TxJSON == nil {
-		return nil, errors.New("invalid tx content")
+	sig, pub, err := kb.Sign(cfg.NameOrBech32, cfg.Pass, signbz)
+	if err != nil {
+		return nil, err
+	}
+	addr := pub.Address()
+	if !setSignature(&tx, addr, std.Signature{
+		PubKey:    pub,
+		Signature: sig,
+	}) {
+		return nil, errors.New(
+			fmt.Sprintf("addr %v (%s) not in signer set", addr, cfg.NameOrBech32),
+		)
 	}
 
+	return &tx, nil
+}
+
+// SignMultisigHandler returns the partial signature of the tx for the
+// multisig key cfg.Multisig, made with the key cfg.NameOrBech32.
+func SignMultisigHandler(cfg *SignCfg) (*std.Signature, error) {
 	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
 	if err != nil {
 		return nil, err
 	}
 
-	err = amino.UnmarshalJSON(cfg.TxJSON, &tx)
+	multiPub, err := getMultisigPubKey(kb, cfg.Multisig)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, signbz, err := txToSign(cfg.TxJSON, cfg.ChainID, cfg.AccountNumber, cfg.Sequence)
+	if err != nil {
+		return nil, err
+	}
+	if !isSigner(tx, multiPub.Address()) {
+		return nil, errors.New(
+			fmt.Sprintf("multisig addr %v (%s) not in signer set", multiPub.Address(), cfg.Multisig),
+		)
+	}
+	if cfg.ShowSignBytes {
+		fmt.Printf("sign bytes: %X\n", signbz)
+		return nil, nil
+	}
+
+	sig, pub, err := kb.Sign(cfg.NameOrBech32, cfg.Pass, signbz)
 	if err != nil {
 		return nil, err
 	}
+	if !hasPubKey(multiPub, pub) {
+		return nil, errors.New(
+			fmt.Sprintf("key %s is not one of the keys of multisig %s", cfg.NameOrBech32, cfg.Multisig),
+		)
+	}
+
+	return &std.Signature{
+		PubKey:    pub,
+		Signature: sig,
+	}, nil
+}
+
+// txToSign decodes the tx to sign, with zero signatures if it has none yet,
+// and returns it with its sign bytes.
+func txToSign(txJSON []byte, chainID string, accountNumber, sequence uint64) (std.Tx, []byte, error) {
+	var tx std.Tx
+
+	if txJSON == nil {
+		return tx, nil, errors.New("invalid tx content")
+	}
+
+	err := amino.UnmarshalJSON(txJSON, &tx)
+	if err != nil {
+		return tx, nil, err
+	}
 
 	// fill tx signatures.
-	signers := tx.GetSigners()
 	if tx.Signatures == nil {
-		for range signers {
+		for range tx.GetSigners() {
 			tx.Signatures = append(tx.Signatures, std.Signature{
 				PubKey:    nil, // zero signature
 				Signature: nil, // zero signature
@@ -168,40 +258,56 @@ func SignHandler(cfg *SignCfg) (*std.Tx, error) {
 	// validate document to sign.
 	err = tx.ValidateBasic()
 	if err != nil {
-		return nil, err
+		return tx, nil, err
 	}
 
 	// derive sign doc bytes.
-	chainID := cfg.ChainID
-	accountNumber := cfg.AccountNumber
-	sequence := cfg.Sequence
-	signbz := tx.GetSignBytes(chainID, accountNumber, sequence)
-	if cfg.ShowSignBytes {
-		fmt.Printf("sign bytes: %X\n", signbz)
-		return nil, nil
-	}
+	return tx, tx.GetSignBytes(chainID, accountNumber, sequence), nil
+}
 
-	sig, pub, err := kb.Sign(cfg.NameOrBech32, cfg.Pass, signbz)
-	if err != nil {
-		return nil, err
-	}
-	addr := pub.Address()
+// setSignature sets sig as the signature of the signer addr of tx. It
+// returns false if addr is not a signer of tx.
+func setSignature(tx *std.Tx, addr crypto.Address, sig std.Signature) bool {
 	found := false
-	for i := range tx.Signatures {
+	for i, signer := range tx.GetSigners() {
 		// override signature for matching slot.
-		if signers[i] == addr {
+		if signer == addr {
 			found = true
-			tx.Signatures[i] = std.Signature{
-				PubKey:    pub,
-				Signature: sig,
-			}
+			tx.Signatures[i] = sig
 		}
 	}
-	if !found {
-		return nil, errors.New(
-			fmt.Sprintf("addr %v (%s) not in signer set", addr, cfg.NameOrBech32),
+	return found
+}
+
+func isSigner(tx std.Tx, addr crypto.Address) bool {
+	for _, signer := range tx.GetSigners() {
+		if signer == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// getMultisigPubKey returns the public key of the multisig key nameOrBech32.
+func getMultisigPubKey(kb keys.Keybase, nameOrBech32 string) (multisig.PubKeyMultisigThreshold, error) {
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return multisig.PubKeyMultisigThreshold{}, err
+	}
+	multiPub, ok := info.GetPubKey().(multisig.PubKeyMultisigThreshold)
+	if !ok {
+		return multisig.PubKeyMultisigThreshold{}, errors.New(
+			fmt.Sprintf("%s is not a multisig key", nameOrBech32),
 		)
 	}
+	return multiPub, nil
+}
 
-	return &tx, nil
+func hasPubKey(multiPub multisig.PubKeyMultisigThreshold, pub crypto.PubKey) bool {
+	for _, pk := range multiPub.PubKeys {
+		if pk.Equals(pub) {
+			return true
+		}
+	}
+	return false
 }
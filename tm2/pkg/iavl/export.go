@@ -0,0 +1,167 @@
+package iavl
+
+import (
+	"bytes"
+	"fmt"
+
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+// importBatchSize is the number of imported nodes written to the database
+// in a single batch.
+const importBatchSize = 10000
+
+// ExportNode is a node of an exported tree. The nodes are exported in
+// post-order, so that the tree can be rebuilt bottom-up with the exact same
+// structure, node versions and hashes.
+type ExportNode struct {
+	Key     []byte
+	Value   []byte
+	Version int64
+	Height  int8
+}
+
+// Export calls fn with the nodes of the tree, in post-order.
+// It stops at the first error returned by fn.
+func (t *ImmutableTree) Export(fn func(ExportNode) error) error {
+	if t.root == nil {
+		return nil
+	}
+	return t.root.export(t, fn)
+}
+
+func (node *Node) export(t *ImmutableTree, fn func(ExportNode) error) error {
+	if !node.isLeaf() {
+		if err := node.getLeftNode(t).export(t, fn); err != nil {
+			return err
+		}
+		if err := node.getRightNode(t).export(t, fn); err != nil {
+			return err
+		}
+	}
+	return fn(ExportNode{
+		Key:     node.key,
+		Value:   node.value,
+		Version: node.version,
+		Height:  node.height,
+	})
+}
+
+// Importer rebuilds a tree from the nodes exported by Export.
+type Importer struct {
+	tree    *MutableTree
+	version int64
+	stack   []*Node
+	batched int
+}
+
+// Import returns an Importer restoring the tree at the given version.
+// The tree must be empty.
+func (tree *MutableTree) Import(version int64) (*Importer, error) {
+	if version <= 0 {
+		return nil, fmt.Errorf("invalid import version %d", version)
+	}
+	if latest := tree.ndb.getLatestVersion(); latest != 0 {
+		return nil, fmt.Errorf("cannot import into a non-empty tree, found version %d", latest)
+	}
+	return &Importer{
+		tree:    tree,
+		version: version,
+	}, nil
+}
+
+// Add adds the next exported node to the tree.
+func (imp *Importer) Add(en ExportNode) error {
+	if en.Version > imp.version {
+		return fmt.Errorf("node version %d is newer than the imported version %d", en.Version, imp.version)
+	}
+
+	node := &Node{
+		key:     en.Key,
+		value:   en.Value,
+		version: en.Version,
+		height:  en.Height,
+		size:    1,
+	}
+
+	if en.Height < 0 {
+		return fmt.Errorf("invalid node height %d", en.Height)
+	} else if en.Height > 0 {
+		// An inner node closes the subtrees of its two children,
+		// which are on top of the stack.
+		if len(imp.stack) < 2 {
+			return errors.New("inner node without children")
+		}
+		left, right := imp.stack[len(imp.stack)-2], imp.stack[len(imp.stack)-1]
+		if en.Height != maxInt8(left.height, right.height)+1 {
+			return fmt.Errorf("inner node of height %d has children of height %d and %d",
+				en.Height, left.height, right.height)
+		}
+		if bytes.Compare(left.key, en.Key) >= 0 || bytes.Compare(en.Key, right.key) > 0 {
+			return errors.New("inner node key out of order")
+		}
+		imp.stack = imp.stack[:len(imp.stack)-2]
+		node.value = nil
+		node.leftHash = left.hash
+		node.rightHash = right.hash
+		node.size = left.size + right.size
+	}
+
+	node._hash()
+	imp.tree.ndb.SaveNode(node)
+	imp.stack = append(imp.stack, node)
+
+	imp.batched++
+	if imp.batched >= importBatchSize {
+		imp.tree.ndb.Commit()
+		imp.batched = 0
+	}
+	return nil
+}
+
+// Commit saves the imported tree at the import version, and loads it.
+func (imp *Importer) Commit() error {
+	var rootHash []byte
+	switch len(imp.stack) {
+	case 0:
+		rootHash = []byte{}
+	case 1:
+		rootHash = imp.stack[0].hash
+	default:
+		return fmt.Errorf("incomplete import, %d subtrees left", len(imp.stack))
+	}
+
+	ndb := imp.tree.ndb
+	ndb.mtx.Lock()
+	ndb.batch.Set(ndb.rootKey(imp.version), rootHash)
+	ndb.updateLatestVersion(imp.version)
+	ndb.mtx.Unlock()
+	ndb.Commit()
+
+	_, err := imp.tree.LoadVersion(imp.version)
+	return err
+}
+
+// IsNodeDBEntry returns whether the key-value pair, read from db, is a node,
+// orphan or root entry of a tree stored in db. It tells the entries of a tree
+// apart from the other entries of a database it shares.
+func IsNodeDBEntry(db dbm.DB, key, value []byte) bool {
+	switch {
+	case nodeKeyFormat.Matches(key):
+		// The node must hash to its key.
+		node, err := MakeNode(value)
+		if err != nil || (!node.isLeaf() && (len(node.leftHash) == 0 || len(node.rightHash) == 0)) {
+			return false
+		}
+		return bytes.Equal(node._hash(), key[1:])
+	case orphanKeyFormat.Matches(key):
+		// Orphans are keyed by their hash, and store it as value.
+		return bytes.Equal(value, key[1+2*int64Size:])
+	case rootKeyFormat.Matches(key):
+		// Roots store the hash of an existing node, or nothing if empty.
+		return len(value) == 0 || (len(value) == hashSize && db.Has(nodeKeyFormat.KeyBytes(value)))
+	default:
+		return false
+	}
+}
@@ -0,0 +1,155 @@
+package iavl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+)
+
+// setupExportTree builds a tree with several versions, so that its nodes
+// have different versions.
+func setupExportTree(t *testing.T) *MutableTree {
+	t.Helper()
+
+	tree := NewMutableTree(memdb.NewMemDB(), 0)
+	for v := 0; v < 5; v++ {
+		for i := 0; i < 50; i++ {
+			tree.Set([]byte(fmt.Sprintf("key%03d", (i*7+v)%100)), []byte(fmt.Sprintf("value%d-%d", v, i)))
+		}
+		tree.Remove([]byte(fmt.Sprintf("key%03d", v*3)))
+		_, _, err := tree.SaveVersion()
+		require.NoError(t, err)
+	}
+	return tree
+}
+
+func exportTree(t *testing.T, tree *MutableTree, version int64) []ExportNode {
+	t.Helper()
+
+	itree, err := tree.GetImmutable(version)
+	require.NoError(t, err)
+
+	var nodes []ExportNode
+	require.NoError(t, itree.Export(func(en ExportNode) error {
+		nodes = append(nodes, en)
+		return nil
+	}))
+	return nodes
+}
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+
+	tree := setupExportTree(t)
+	version := tree.Version() - 1
+	expected, err := tree.GetImmutable(version)
+	require.NoError(t, err)
+
+	nodes := exportTree(t, tree, version)
+	require.NotEmpty(t, nodes)
+
+	newTree := NewMutableTree(memdb.NewMemDB(), 0)
+	imp, err := newTree.Import(version)
+	require.NoError(t, err)
+	for _, en := range nodes {
+		require.NoError(t, imp.Add(en))
+	}
+	require.NoError(t, imp.Commit())
+
+	assert.Equal(t, version, newTree.Version())
+	assert.Equal(t, expected.Hash(), newTree.Hash())
+	assert.Equal(t, expected.Size(), newTree.Size())
+	expected.Iterate(func(key, value []byte) bool {
+		_, v := newTree.Get(key)
+		assert.Equal(t, value, v)
+		return false
+	})
+
+	// The imported tree can be updated as usual.
+	newTree.Set([]byte("new"), []byte("value"))
+	_, newVersion, err := newTree.SaveVersion()
+	require.NoError(t, err)
+	assert.Equal(t, version+1, newVersion)
+}
+
+func TestExportImport_Empty(t *testing.T) {
+	t.Parallel()
+
+	tree := NewMutableTree(memdb.NewMemDB(), 0)
+	_, _, err := tree.SaveVersion()
+	require.NoError(t, err)
+	assert.Empty(t, exportTree(t, tree, 1))
+
+	newTree := NewMutableTree(memdb.NewMemDB(), 0)
+	imp, err := newTree.Import(1)
+	require.NoError(t, err)
+	require.NoError(t, imp.Commit())
+	assert.EqualValues(t, 1, newTree.Version())
+	assert.Nil(t, newTree.Hash())
+}
+
+func TestImport_Errors(t *testing.T) {
+	t.Parallel()
+
+	tree := setupExportTree(t)
+	nodes := exportTree(t, tree, tree.Version())
+
+	// The tree must be empty.
+	_, err := tree.Import(10)
+	assert.Error(t, err)
+
+	// Missing the root.
+	imp, err := NewMutableTree(memdb.NewMemDB(), 0).Import(tree.Version())
+	require.NoError(t, err)
+	for _, en := range nodes[:len(nodes)-1] {
+		require.NoError(t, imp.Add(en))
+	}
+	assert.Error(t, imp.Commit())
+
+	// Inner node first.
+	imp, err = NewMutableTree(memdb.NewMemDB(), 0).Import(tree.Version())
+	require.NoError(t, err)
+	assert.Error(t, imp.Add(nodes[len(nodes)-1]))
+
+	// Node newer than the imported version.
+	imp, err = NewMutableTree(memdb.NewMemDB(), 0).Import(1)
+	require.NoError(t, err)
+	assert.Error(t, imp.Add(ExportNode{Key: []byte("k"), Value: []byte("v"), Version: 2}))
+}
+
+func TestIsNodeDBEntry(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewMemDB()
+	tree := NewMutableTree(db, 0)
+	tree.Set([]byte("a"), []byte("1"))
+	tree.Set([]byte("b"), []byte("2"))
+	_, _, err := tree.SaveVersion()
+	require.NoError(t, err)
+	tree.Set([]byte("a"), []byte("3"))
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+
+	// Entries of another store sharing the database.
+	db.Set([]byte("node:gno.land/r/demo"), []byte("value"))
+	db.Set(append([]byte{'n'}, make([]byte, hashSize)...), []byte("value"))
+	db.Set(append([]byte{'r'}, make([]byte, int64Size)...), []byte("value"))
+
+	var nodes, others int
+	itr := db.Iterator(nil, nil)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		if IsNodeDBEntry(db, itr.Key(), itr.Value()) {
+			nodes++
+		} else {
+			others++
+		}
+	}
+	// 5 nodes, 2 orphans and 2 roots.
+	assert.Equal(t, 9, nodes)
+	assert.Equal(t, 3, others)
+}
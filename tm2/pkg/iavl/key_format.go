@@ -40,6 +40,11 @@ func NewKeyFormat(prefix byte, layout ...int) *KeyFormat {
 	}
 }
 
+// Matches returns whether the key has the prefix and length of the format.
+func (kf *KeyFormat) Matches(key []byte) bool {
+	return len(key) == kf.length && key[0] == kf.prefix
+}
+
 // Format the byte segments into the key format - will panic if the segment lengths do not match the layout.
 func (kf *KeyFormat) KeyBytes(segments ...[]byte) []byte {
 	key := make([]byte, kf.length)
@@ -207,6 +207,10 @@ func (sw *Switch) OnStart() error {
 		}
 	}
 
+	if telemetry.MetricsEnabled() {
+		sw.recordPeerMetrics()
+	}
+
 	// Start accepting Peers.
 	go sw.acceptRoutine()
 
@@ -286,6 +290,15 @@ func (sw *Switch) NumPeers() (outbound, inbound, dialing int) {
 	return
 }
 
+// recordPeerMetrics records the current number of peers.
+func (sw *Switch) recordPeerMetrics() {
+	outbound, inbound, dialing := sw.NumPeers()
+
+	metrics.OutboundPeers.Set(int64(outbound))
+	metrics.InboundPeers.Set(int64(inbound))
+	metrics.DialingPeers.Set(int64(dialing))
+}
+
 // MaxNumOutboundPeers returns a maximum number of outbound peers.
 func (sw *Switch) MaxNumOutboundPeers() int {
 	return sw.config.MaxNumOutboundPeers
@@ -334,6 +347,10 @@ func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
 	// RemovePeer is finished.
 	// https://github.com/tendermint/classic/issues/3338
 	sw.peers.Remove(peer)
+
+	if telemetry.MetricsEnabled() {
+		sw.recordPeerMetrics()
+	}
 }
 
 // reconnectToPeer tries to reconnect to the addr, first repeatedly
@@ -456,7 +473,17 @@ func (sw *Switch) DialPeerWithAddress(addr *NetAddress) error {
 	}
 
 	sw.dialing.Set(addr.ID.String(), addr)
-	defer sw.dialing.Delete(addr.ID.String())
+	defer func() {
+		sw.dialing.Delete(addr.ID.String())
+
+		if telemetry.MetricsEnabled() {
+			sw.recordPeerMetrics()
+		}
+	}()
+
+	if telemetry.MetricsEnabled() {
+		sw.recordPeerMetrics()
+	}
 
 	return sw.addOutboundPeerWithConfig(addr, sw.config)
 }
@@ -712,5 +739,9 @@ func (sw *Switch) addPeer(p Peer) error {
 
 	sw.Logger.Info("Added peer", "peer", p)
 
+	if telemetry.MetricsEnabled() {
+		sw.recordPeerMetrics()
+	}
+
 	return nil
 }
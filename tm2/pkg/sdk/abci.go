@@ -16,3 +16,8 @@ type BeginBlocker func(ctx Context, req abci.RequestBeginBlock) abci.ResponseBeg
 // Note: applications which set create_empty_blocks=false will not have regular block timing and should use
 // e.g. BFT timestamps rather than block height for any periodic EndBlock logic
 type EndBlocker func(ctx Context, req abci.RequestEndBlock) abci.ResponseEndBlock
+
+// SnapshotRestorer runs after a state sync snapshot is restored, to
+// initialize the application from the restored state. The context is the
+// check context at the snapshot height.
+type SnapshotRestorer func(ctx Context) error
@@ -36,11 +36,26 @@ type AnteOptions struct {
 	// This is useful for development, and maybe production chains.
 	// Always check your settings and inspect genesis transactions.
 	VerifyGenesisSignatures bool
+
+	// If FeeDenom is set, CheckTx rejects txs whose fee is paid in another
+	// denom, as the fees reported to the mempool must be comparable.
+	FeeDenom string
+
+	// If ReplaceTxs is true, CheckTx accepts a tx signed with the sequence of
+	// a pending tx of its fee payer, to replace it in the mempool. It must
+	// only be set if the mempool evicts the replaced txs, as the priority
+	// mempool does; otherwise both txs would be included in a block.
+	ReplaceTxs bool
 }
 
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
 // numbers, checks signatures & account numbers, and deducts fees from the first
 // signer.
+//
+// If opts.ReplaceTxs is set, in CheckTx a tx may also be signed with the
+// sequence of a tx of its fee payer which was accepted since the last commit,
+// in order to replace it in the mempool: its sequence is then not incremented, and the fee of the
+// replaced tx isn't refunded until the check state is reset on Commit.
 func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer SignatureVerificationGasConsumer, opts AnteOptions) sdk.AnteHandler {
 	return func(
 		ctx sdk.Context, tx std.Tx, simulate bool,
@@ -68,6 +83,11 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 			if !res.IsOK() {
 				return ctx, res, true
 			}
+			if opts.FeeDenom != "" && !tx.Fee.GasFee.IsZero() && tx.Fee.GasFee.Denom != opts.FeeDenom {
+				return ctx, abciResult(std.ErrInvalidCoins(
+					fmt.Sprintf("fees must be paid in %s; got: %q", opts.FeeDenom, tx.Fee.GasFee),
+				)), true
+			}
 		}
 
 		newCtx = SetGasMeter(simulate, ctx, tx.Fee.GasWanted)
@@ -134,6 +154,9 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 			signerAccs[0] = ak.GetAccount(newCtx, signerAccs[0].GetAddress())
 		}
 
+		// the sequence of the tx, for the fee payer, reported to the mempool.
+		sequence := signerAccs[0].GetSequence()
+
 		// stdSigs contains the sequence number, account number, and signatures.
 		// When simulating, this would just be a 0-length slice.
 		stdSigs := tx.GetSignatures()
@@ -155,6 +178,12 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 				// Check signature
 				signBytes := GetSignBytes(newCtx.ChainID(), tx, sacc, isGenesis)
 				signerAccs[i], res = processSig(newCtx, sacc, stdSigs[i], signBytes, simulate, params, sigGasConsumer)
+				if !res.IsOK() && i == 0 && opts.ReplaceTxs && ctx.IsCheckTx() && !simulate {
+					// the tx may replace a pending tx of the fee payer.
+					if seq, ok := replacedSequence(newCtx, ak, tx, sacc, stdSigs[0], params, sigGasConsumer); ok {
+						signerAccs[0], res, sequence = sacc, sdk.Result{}, seq
+					}
+				}
 				if !res.IsOK() {
 					return newCtx, res, true
 				}
@@ -162,8 +191,19 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 			ak.SetAccount(newCtx, signerAccs[i])
 		}
 
+		if ctx.IsCheckTx() && !simulate {
+			if _, ok := ak.getPendingSequence(newCtx, signerAddrs[0]); !ok {
+				ak.setPendingSequence(newCtx, signerAddrs[0], sequence)
+			}
+		}
+
 		// TODO: tx tags (?)
-		return newCtx, sdk.Result{GasWanted: tx.Fee.GasWanted}, false // continue...
+		return newCtx, sdk.Result{
+			GasWanted: tx.Fee.GasWanted,
+			Sender:    signerAddrs[0],
+			Sequence:  sequence,
+			GasFee:    tx.Fee.GasFee.Amount,
+		}, false // continue...
 	}
 }
 
@@ -248,6 +288,40 @@ func processSig(
 	return acc, res
 }
 
+// replacedSequence returns the sequence, among the sequences of the txs of
+// acc accepted by CheckTx since the last commit, that sig was signed with. Each
+// sequence tried consumes the gas of a signature verification.
+func replacedSequence(
+	ctx sdk.Context, ak AccountKeeper, tx std.Tx, acc std.Account, sig std.Signature, params Params,
+	sigGasConsumer SignatureVerificationGasConsumer,
+) (uint64, bool) {
+	pending, ok := ak.getPendingSequence(ctx, acc.GetAddress())
+	pubKey := acc.GetPubKey()
+	if !ok || pubKey == nil {
+		return 0, false
+	}
+
+	// the sign bytes are computed with the sequence of acc, which is restored.
+	next := acc.GetSequence()
+	setSequence := func(seq uint64) {
+		if err := acc.SetSequence(seq); err != nil {
+			panic(err)
+		}
+	}
+	defer setSequence(next)
+
+	for seq := pending; seq < next; seq++ {
+		setSequence(seq)
+		if res := sigGasConsumer(ctx.GasMeter(), sig.Signature, pubKey, params); !res.IsOK() {
+			return 0, false
+		}
+		if pubKey.VerifyBytes(GetSignBytes(ctx.ChainID(), tx, acc, false), sig.Signature) {
+			return seq, true
+		}
+	}
+	return 0, false
+}
+
 func consumeSimSigGas(gasmeter store.GasMeter, pubkey crypto.PubKey, sig std.Signature, params Params) {
 	simSig := std.Signature{PubKey: pubkey}
 	if len(sig.Signature) == 0 {
@@ -306,6 +306,170 @@ func TestAnteHandlerSequences(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx, false)
 }
 
+// Test the sender, sequence and fee reported for the mempool.
+func TestAnteHandlerMempoolInfo(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	anteHandler := NewAnteHandler(env.acck, env.bank, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+	ctx := env.ctx
+
+	// keys and addresses
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+	priv2, _, addr2 := tu.KeyTestPubAddr()
+
+	// set the accounts
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(tu.NewTestCoins())
+	require.NoError(t, acc1.SetAccountNumber(0))
+	env.acck.SetAccount(ctx, acc1)
+	acc2 := env.acck.NewAccountWithAddress(ctx, addr2)
+	acc2.SetCoins(tu.NewTestCoins())
+	require.NoError(t, acc2.SetAccountNumber(1))
+	env.acck.SetAccount(ctx, acc2)
+
+	fee := tu.NewTestFee()
+	msgs := []std.Msg{tu.NewTestMsg(addr1)}
+	for seq := uint64(0); seq < 2; seq++ {
+		tx := tu.NewTestTx(ctx.ChainID(), msgs, []crypto.PrivKey{priv1}, []uint64{0}, []uint64{seq}, fee)
+		_, result, abort := anteHandler(ctx, tx, false)
+		require.False(t, abort)
+		require.Equal(t, addr1, result.Sender)
+		require.Equal(t, seq, result.Sequence)
+		require.Equal(t, fee.GasFee.Amount, result.GasFee)
+	}
+
+	// the fee payer is the first signer.
+	msgs = []std.Msg{tu.NewTestMsg(addr2, addr1)}
+	tx := tu.NewTestTx(ctx.ChainID(), msgs, []crypto.PrivKey{priv2, priv1}, []uint64{1, 0}, []uint64{0, 2}, fee)
+	_, result, abort := anteHandler(ctx, tx, false)
+	require.False(t, abort)
+	require.Equal(t, addr2, result.Sender)
+	require.Equal(t, uint64(0), result.Sequence)
+}
+
+// Test the replacement of pending txs in CheckTx.
+func TestAnteHandlerReplacement(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	opts := defaultAnteOptions()
+	opts.ReplaceTxs = true
+	anteHandler := NewAnteHandler(env.acck, env.bank, DefaultSigVerificationGasConsumer, opts)
+	ctx := env.ctx.WithMode(sdk.RunTxModeCheck)
+
+	// keys and addresses
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+
+	// set the accounts
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(tu.NewTestCoins())
+	require.NoError(t, acc1.SetAccountNumber(0))
+	require.NoError(t, acc1.SetSequence(5))
+	env.acck.SetAccount(ctx, acc1)
+
+	fee := tu.NewTestFee()
+	msgs := []std.Msg{tu.NewTestMsg(addr1)}
+	privs, accnums := []crypto.PrivKey{priv1}, []uint64{0}
+
+	// a tx replacing a tx which wasn't accepted fails.
+	tx := tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, []uint64{4}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+
+	for seq := uint64(5); seq < 8; seq++ {
+		tx = tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, []uint64{seq}, fee)
+		checkValidTx(t, anteHandler, ctx, tx, false)
+	}
+
+	// the pending txs can be replaced, without incrementing the sequence.
+	fee.GasFee.Amount++
+	for seq := uint64(5); seq < 8; seq++ {
+		tx = tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, []uint64{seq}, fee)
+		_, result, abort := anteHandler(ctx, tx, false)
+		require.False(t, abort)
+		require.Equal(t, seq, result.Sequence)
+	}
+	require.Equal(t, uint64(8), env.acck.GetAccount(ctx, addr1).GetSequence())
+
+	// but not the txs before them.
+	tx = tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, []uint64{4}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+
+	// and txs are never replaced in DeliverTx.
+	tx = tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, []uint64{7}, fee)
+	checkInvalidTx(t, anteHandler, ctx.WithMode(sdk.RunTxModeDeliver), tx, false, std.UnauthorizedError{})
+}
+
+// Test that pending txs can't be replaced if ReplaceTxs isn't set, as with
+// the FIFO mempool.
+func TestAnteHandlerNoReplacement(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	anteHandler := NewAnteHandler(env.acck, env.bank, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+	ctx := env.ctx.WithMode(sdk.RunTxModeCheck)
+
+	// keys and addresses
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+
+	// set the accounts
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(tu.NewTestCoins())
+	require.NoError(t, acc1.SetAccountNumber(0))
+	env.acck.SetAccount(ctx, acc1)
+
+	fee := tu.NewTestFee()
+	msgs := []std.Msg{tu.NewTestMsg(addr1)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []uint64{0}, []uint64{0}
+
+	tx := tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, seqs, fee)
+	checkValidTx(t, anteHandler, ctx, tx, false)
+
+	// a second tx with the same sequence is rejected.
+	fee.GasFee.Amount++
+	tx = tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, seqs, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+	require.Equal(t, uint64(1), env.acck.GetAccount(ctx, addr1).GetSequence())
+}
+
+// Test the fee denom checked in CheckTx.
+func TestAnteHandlerFeeDenom(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	opts := defaultAnteOptions()
+	opts.FeeDenom = "atom"
+	anteHandler := NewAnteHandler(env.acck, env.bank, DefaultSigVerificationGasConsumer, opts)
+	ctx := env.ctx.WithMode(sdk.RunTxModeCheck)
+
+	// keys and addresses
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+
+	// set the accounts
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(std.NewCoins(std.NewCoin("atom", 1000), std.NewCoin("btc", 1000)))
+	require.NoError(t, acc1.SetAccountNumber(0))
+	env.acck.SetAccount(ctx, acc1)
+
+	msgs := []std.Msg{tu.NewTestMsg(addr1)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []uint64{0}, []uint64{0}
+
+	fee := std.NewFee(100000, std.NewCoin("btc", 150))
+	tx := tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, seqs, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.InvalidCoinsError{})
+
+	// the fee denom is only checked in CheckTx.
+	checkValidTx(t, anteHandler, ctx.WithMode(sdk.RunTxModeDeliver), tx, false)
+
+	fee = std.NewFee(100000, std.NewCoin("atom", 150))
+	tx = tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, []uint64{1}, fee)
+	checkValidTx(t, anteHandler, ctx, tx, false)
+}
+
 // Test logic around fee deduction.
 func TestAnteHandlerFees(t *testing.T) {
 	t.Parallel()
@@ -20,6 +20,10 @@ const (
 	// AddressStoreKeyPrefix prefix for account-by-address store
 	AddressStoreKeyPrefix = "/a/"
 
+	// PendingSequenceStoreKeyPrefix prefix for the sequence of the first
+	// pending tx of an account, only recorded in the check state
+	PendingSequenceStoreKeyPrefix = "/p/"
+
 	// param key for global account number
 	GlobalAccountNumberKey = "globalAccountNumber"
 )
@@ -29,6 +33,12 @@ func AddressStoreKey(addr crypto.Address) []byte {
 	return append([]byte(AddressStoreKeyPrefix), addr.Bytes()...)
 }
 
+// PendingSequenceStoreKey turn an address to key used to get the sequence of
+// its first pending tx from the account store
+func PendingSequenceStoreKey(addr crypto.Address) []byte {
+	return append([]byte(PendingSequenceStoreKeyPrefix), addr.Bytes()...)
+}
+
 // NOTE: do not modify.
 // XXX: consider parameterization at the keeper level.
 var feeCollector crypto.Address
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"encoding/binary"
 	"fmt"
 	"log/slog"
 
@@ -150,6 +151,31 @@ func (ak AccountKeeper) GetNextAccountNumber(ctx sdk.Context) uint64 {
 	return accNumber
 }
 
+// -----------------------------------------------------------------------------
+// Pending sequences
+
+// getPendingSequence returns the sequence of the first tx of the account at
+// address accepted by CheckTx since the last commit, if any.
+func (ak AccountKeeper) getPendingSequence(ctx sdk.Context, addr crypto.Address) (uint64, bool) {
+	// Not metered, as it is only recorded in the check state.
+	stor := ctx.MultiStore().GetStore(ak.key)
+	bz := stor.Get(PendingSequenceStoreKey(addr))
+	if bz == nil {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(bz), true
+}
+
+// setPendingSequence records the sequence of the first tx of the account at
+// address accepted by CheckTx. It must only be called in CheckTx: the record
+// is discarded along with the check state on Commit.
+func (ak AccountKeeper) setPendingSequence(ctx sdk.Context, addr crypto.Address, seq uint64) {
+	stor := ctx.MultiStore().GetStore(ak.key)
+	var bz [8]byte
+	binary.BigEndian.PutUint64(bz[:], seq)
+	stor.Set(PendingSequenceStoreKey(addr), bz[:])
+}
+
 // -----------------------------------------------------------------------------
 // Misc.
 
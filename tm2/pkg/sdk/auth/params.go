@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 )
 
 type AuthParamsContextKey struct{}
@@ -18,6 +20,15 @@ const (
 	DefaultSigVerifyCostSecp256k1 int64 = 1000
 )
 
+// Parameter keys, in the params keeper.
+const (
+	ParamMaxMemoBytes           = "auth.max_memo_bytes.int64"
+	ParamTxSigLimit             = "auth.tx_sig_limit.int64"
+	ParamTxSizeCostPerByte      = "auth.tx_size_cost_per_byte.int64"
+	ParamSigVerifyCostED25519   = "auth.sig_verify_cost_ed25519.int64"
+	ParamSigVerifyCostSecp256k1 = "auth.sig_verify_cost_secp256k1.int64"
+)
+
 // Params defines the parameters for the auth module.
 type Params struct {
 	MaxMemoBytes           int64 `json:"max_memo_bytes" yaml:"max_memo_bytes"`
@@ -45,6 +56,27 @@ func (p Params) Equals(p2 Params) bool {
 	return amino.DeepEqual(p, p2)
 }
 
+// Validate returns an error if a parameter has an invalid value; all of them
+// must be positive.
+func (p Params) Validate() error {
+	if p.MaxMemoBytes <= 0 {
+		return fmt.Errorf("invalid max memo bytes: %d", p.MaxMemoBytes)
+	}
+	if p.TxSigLimit <= 0 {
+		return fmt.Errorf("invalid tx signature limit: %d", p.TxSigLimit)
+	}
+	if p.TxSizeCostPerByte <= 0 {
+		return fmt.Errorf("invalid tx size cost per byte: %d", p.TxSizeCostPerByte)
+	}
+	if p.SigVerifyCostED25519 <= 0 {
+		return fmt.Errorf("invalid ED25519 signature verification cost: %d", p.SigVerifyCostED25519)
+	}
+	if p.SigVerifyCostSecp256k1 <= 0 {
+		return fmt.Errorf("invalid Secp256k1 signature verification cost: %d", p.SigVerifyCostSecp256k1)
+	}
+	return nil
+}
+
 // DefaultParams returns a default set of parameters.
 func DefaultParams() Params {
 	return Params{
@@ -56,6 +88,18 @@ func DefaultParams() Params {
 	}
 }
 
+// LoadParams returns the parameters set in the params keeper,
+// using the default value of the parameters that are not set.
+func LoadParams(ctx sdk.Context, prmk params.ParamsKeeperI) Params {
+	p := DefaultParams()
+	prmk.GetInt64(ctx, ParamMaxMemoBytes, &p.MaxMemoBytes)
+	prmk.GetInt64(ctx, ParamTxSigLimit, &p.TxSigLimit)
+	prmk.GetInt64(ctx, ParamTxSizeCostPerByte, &p.TxSizeCostPerByte)
+	prmk.GetInt64(ctx, ParamSigVerifyCostED25519, &p.SigVerifyCostED25519)
+	prmk.GetInt64(ctx, ParamSigVerifyCostSecp256k1, &p.SigVerifyCostSecp256k1)
+	return p
+}
+
 // String implements the stringer interface.
 func (p Params) String() string {
 	var sb strings.Builder
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"testing"
+
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadParams(t *testing.T) {
+	db := memdb.NewMemDB()
+	paramsCapKey := store.NewStoreKey("paramsCapKey")
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(paramsCapKey, iavl.StoreConstructor, db)
+	ms.LoadLatestVersion()
+
+	ctx := sdk.NewContext(sdk.RunTxModeDeliver, ms, &bft.Header{Height: 1, ChainID: "test-chain-id"}, log.NewNoopLogger())
+	prmk := params.NewParamsKeeper(paramsCapKey, "params")
+
+	// Unset params use the default values.
+	assert.True(t, LoadParams(ctx, prmk).Equals(DefaultParams()))
+
+	prmk.SetInt64(ctx, ParamMaxMemoBytes, 42)
+	prmk.SetInt64(ctx, ParamSigVerifyCostSecp256k1, 10)
+
+	expected := DefaultParams()
+	expected.MaxMemoBytes = 42
+	expected.SigVerifyCostSecp256k1 = 10
+	assert.Equal(t, expected, LoadParams(ctx, prmk))
+}
+
+func TestParamsValidate(t *testing.T) {
+	assert.NoError(t, DefaultParams().Validate())
+
+	p := DefaultParams()
+	p.TxSigLimit = 0
+	assert.Error(t, p.Validate())
+
+	p = DefaultParams()
+	p.TxSizeCostPerByte = -1
+	assert.Error(t, p.Validate())
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
 	"github.com/gnolang/gno/tm2/pkg/store/iavl"
@@ -18,6 +19,7 @@ type testEnv struct {
 	ctx  sdk.Context
 	bank BankKeeper
 	acck auth.AccountKeeper
+	prmk params.ParamsKeeper
 }
 
 func setupTestEnv() testEnv {
@@ -35,6 +37,7 @@ func setupTestEnv() testEnv {
 	)
 
 	bank := NewBankKeeper(acck)
+	prmk := params.NewParamsKeeper(authCapKey, "params")
 
-	return testEnv{ctx: ctx, bank: bank, acck: acck}
+	return testEnv{ctx: ctx, bank: bank, acck: acck, prmk: prmk}
 }
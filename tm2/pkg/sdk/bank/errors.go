@@ -16,6 +16,7 @@ type NoInputsError struct{ abciError }
 type (
 	NoOutputsError           struct{ abciError }
 	InputOutputMismatchError struct{ abciError }
+	SendDisabledError        struct{ abciError }
 )
 
 func (e NoInputsError) Error() string  { return "no inputs in send transaction" }
@@ -23,6 +24,7 @@ func (e NoOutputsError) Error() string { return "no outputs in send transaction"
 func (e InputOutputMismatchError) Error() string {
 	return "sum inputs != sum outputs in send transaction"
 }
+func (e SendDisabledError) Error() string { return "send transactions are disabled" }
 
 func ErrNoInputs() error {
 	return errors.Wrap(NoInputsError{}, "")
@@ -35,3 +37,7 @@ func ErrNoOutputs() error {
 func ErrInputOutputMismatch() error {
 	return errors.Wrap(InputOutputMismatchError{}, "")
 }
+
+func ErrSendDisabled() error {
+	return errors.Wrap(SendDisabledError{}, "")
+}
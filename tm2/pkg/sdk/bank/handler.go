@@ -8,17 +8,21 @@ import (
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 type bankHandler struct {
-	bank BankKeeper
+	bank   BankKeeper
+	params params.ParamsKeeperI
 }
 
 // NewHandler returns a handler for "bank" type messages.
-func NewHandler(bank BankKeeper) bankHandler {
+// The params of the bank module are read from prmk.
+func NewHandler(bank BankKeeper, prmk params.ParamsKeeperI) bankHandler {
 	return bankHandler{
-		bank: bank,
+		bank:   bank,
+		params: prmk,
 	}
 }
 
@@ -38,10 +42,10 @@ func (bh bankHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
 
 // Handle MsgSend.
 func (bh bankHandler) handleMsgSend(ctx sdk.Context, msg MsgSend) sdk.Result {
+	if !LoadParams(ctx, bh.params).SendEnabled {
+		return abciResult(ErrSendDisabled())
+	}
 	/*
-		if !bh.bank.GetSendEnabled(ctx) {
-			return abciResult(ErrSendDisabled())
-		}
 		if bh.bank.BlacklistedAddr(msg.ToAddress) {
 			return std.ErrUnauthorized(fmt.Sprintf("%s is not allowed to receive transactions", msg.ToAddress)).Result()
 		}
@@ -67,10 +71,10 @@ func (bh bankHandler) handleMsgSend(ctx sdk.Context, msg MsgSend) sdk.Result {
 // Handle MsgMultiSend.
 func (bh bankHandler) handleMsgMultiSend(ctx sdk.Context, msg MsgMultiSend) sdk.Result {
 	// NOTE: totalIn == totalOut should already have been checked
+	if !LoadParams(ctx, bh.params).SendEnabled {
+		return abciResult(ErrSendDisabled())
+	}
 	/*
-		if !k.GetSendEnabled(ctx) {
-			return abciResult(std.ErrSendDisabled())
-		}
 		for _, out := range msg.Outputs {
 			if bh.bank.BlacklistedAddr(out.Address) {
 				return abciResult(std.ErrUnauthorized(fmt.Sprintf("%s is not allowed to receive transactions", out.Address)))
@@ -11,6 +11,7 @@ import (
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	tu "github.com/gnolang/gno/tm2/pkg/sdk/testutils"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
@@ -18,17 +19,48 @@ import (
 func TestInvalidMsg(t *testing.T) {
 	t.Parallel()
 
-	h := NewHandler(BankKeeper{})
+	h := NewHandler(BankKeeper{}, params.ParamsKeeper{})
 	res := h.Process(sdk.NewContext(sdk.RunTxModeDeliver, nil, &bft.Header{ChainID: "test-chain"}, nil), tu.NewTestMsg())
 	require.False(t, res.IsOK())
 	require.True(t, strings.Contains(res.Log, "unrecognized bank message type"))
 }
 
+func TestSendDisabled(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.bank, env.prmk)
+	_, _, addr1 := tu.KeyTestPubAddr()
+	_, _, addr2 := tu.KeyTestPubAddr()
+
+	acc := env.acck.NewAccountWithAddress(env.ctx, addr1)
+	acc.SetCoins(std.NewCoins(std.NewCoin("foo", 10)))
+	env.acck.SetAccount(env.ctx, acc)
+
+	coins := std.NewCoins(std.NewCoin("foo", 1))
+	send := NewMsgSend(addr1, addr2, coins)
+	multiSend := MsgMultiSend{
+		Inputs:  []Input{NewInput(addr1, coins)},
+		Outputs: []Output{NewOutput(addr2, coins)},
+	}
+
+	res := h.Process(env.ctx, send)
+	require.True(t, res.IsOK(), res.Log)
+
+	env.prmk.SetBool(env.ctx, ParamSendEnabled, false)
+	for _, msg := range []std.Msg{send, multiSend} {
+		res = h.Process(env.ctx, msg)
+		require.False(t, res.IsOK())
+		require.IsType(t, SendDisabledError{}, res.Error)
+	}
+	require.True(t, env.bank.GetCoins(env.ctx, addr2).IsEqual(coins))
+}
+
 func TestBalances(t *testing.T) {
 	t.Parallel()
 
 	env := setupTestEnv()
-	h := NewHandler(env.bank)
+	h := NewHandler(env.bank, env.prmk)
 	_, _, addr := tu.KeyTestPubAddr()
 
 	req := abci.RequestQuery{
@@ -58,7 +90,7 @@ func TestQuerierRouteNotFound(t *testing.T) {
 	t.Parallel()
 
 	env := setupTestEnv()
-	h := NewHandler(env.bank)
+	h := NewHandler(env.bank, env.prmk)
 	req := abci.RequestQuery{
 		Path: "bank/notfound",
 		Data: []byte{},
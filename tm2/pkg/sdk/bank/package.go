@@ -12,5 +12,6 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	NoInputsError{}, "NoInputsError",
 	NoOutputsError{}, "NoOutputsError",
 	InputOutputMismatchError{}, "InputOutputMismatchError",
+	SendDisabledError{}, "SendDisabledError",
 	MsgSend{}, "MsgSend",
 ))
@@ -0,0 +1,36 @@
+package bank
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
+)
+
+// Default parameter values
+const (
+	DefaultSendEnabled = true
+)
+
+// Parameter keys, in the params keeper.
+const (
+	ParamSendEnabled = "bank.send_enabled.bool"
+)
+
+// Params defines the parameters for the bank module.
+type Params struct {
+	SendEnabled bool `json:"send_enabled" yaml:"send_enabled"`
+}
+
+// DefaultParams returns a default set of parameters.
+func DefaultParams() Params {
+	return Params{
+		SendEnabled: DefaultSendEnabled,
+	}
+}
+
+// LoadParams returns the parameters set in the params keeper,
+// using the default value of the parameters that are not set.
+func LoadParams(ctx sdk.Context, prmk params.ParamsKeeperI) Params {
+	p := DefaultParams()
+	prmk.GetBool(ctx, ParamSendEnabled, &p.SendEnabled)
+	return p
+}
@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"bytes"
 	"fmt"
 	"log/slog"
 	"os"
@@ -16,6 +17,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 )
 
 // Key to store the consensus params in the main store.
@@ -42,6 +44,10 @@ type BaseApp struct {
 	beginBlocker BeginBlocker // logic to run before any txs
 	endBlocker   EndBlocker   // logic to run after all txs, and to determine valset changes
 
+	snapshots        *snapshots.Manager // state sync snapshots, if enabled
+	snapshotRestorer SnapshotRestorer   // logic to run after a snapshot is restored
+	snapshotAppHash  []byte             // app hash of the snapshot being restored
+
 	// --------------------
 	// Volatile state
 	// checkState is set on initialization and reset on Commit.
@@ -570,6 +576,9 @@ func (app *BaseApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx)
 		res.ResponseBase = result.ResponseBase
 		res.GasWanted = result.GasWanted
 		res.GasUsed = result.GasUsed
+		res.Sender = result.Sender
+		res.Sequence = result.Sequence
+		res.GasFee = result.GasFee
 		return
 	}
 }
@@ -707,6 +716,8 @@ func (app *BaseApp) runTx(mode RunTxMode, txBytes []byte, tx Tx) (result Result)
 	// determined by the GasMeter. We need access to the context to get the gas
 	// meter so we initialize upfront.
 	var gasWanted int64
+	// The other fields of the AnteHandler result are passed through.
+	var anteResult Result
 
 	ctx := app.getContextForTx(mode, txBytes)
 	ms := ctx.MultiStore()
@@ -819,6 +830,7 @@ func (app *BaseApp) runTx(mode RunTxMode, txBytes []byte, tx Tx) (result Result)
 			ctx = newCtx.WithMultiStore(ms)
 			msCache.MultiWrite()
 			gasWanted = result.GasWanted
+			anteResult = result
 		}
 	}
 
@@ -827,6 +839,9 @@ func (app *BaseApp) runTx(mode RunTxMode, txBytes []byte, tx Tx) (result Result)
 	runMsgCtx, msCache := app.cacheTxContext(ctx, txBytes)
 	result = app.runMsgs(runMsgCtx, msgs, mode)
 	result.GasWanted = gasWanted
+	result.Sender = anteResult.Sender
+	result.Sequence = anteResult.Sequence
+	result.GasFee = anteResult.GasFee
 
 	// Safety check: don't write the cache state unless we're in DeliverTx.
 	if mode != RunTxModeDeliver {
@@ -894,6 +909,20 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	headerBz := amino.MustMarshal(header)
 	baseStore.Set(mainLastHeaderKey, headerBz)
 
+	// Take a state sync snapshot in the background. Its view of the
+	// MultiStore is taken before the next block is committed.
+	if app.snapshots != nil && app.snapshots.ShouldTake(commitID.Version) {
+		height := commitID.Version
+		err := app.snapshots.TakeAsync(height, func(_ abci.Snapshot, err error) {
+			if err != nil {
+				app.logger.Error("failed to take state sync snapshot", "height", height, "err", err)
+			}
+		})
+		if err != nil {
+			app.logger.Error("failed to take state sync snapshot", "height", height, "err", err)
+		}
+	}
+
 	// Reset the Check state to the latest committed.
 	//
 	// NOTE: This is safe because Tendermint holds a lock on the mempool for
@@ -908,6 +937,105 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	return
 }
 
+// ListSnapshots implements the ABCI interface.
+func (app *BaseApp) ListSnapshots(req abci.RequestListSnapshots) (res abci.ResponseListSnapshots) {
+	if app.snapshots == nil {
+		return
+	}
+	list, err := app.snapshots.List()
+	if err != nil {
+		res.Error = ABCIError(err)
+		return
+	}
+	res.Snapshots = list
+	return
+}
+
+// LoadSnapshotChunk implements the ABCI interface.
+func (app *BaseApp) LoadSnapshotChunk(req abci.RequestLoadSnapshotChunk) (res abci.ResponseLoadSnapshotChunk) {
+	if app.snapshots == nil {
+		res.Error = ABCIError(errors.New("state sync snapshots are disabled"))
+		return
+	}
+	chunk, err := app.snapshots.LoadChunk(req.Height, req.Format, req.Chunk)
+	if err != nil {
+		res.Error = ABCIError(err)
+		return
+	}
+	res.Chunk = chunk
+	return
+}
+
+// OfferSnapshot implements the ABCI interface. It starts restoring the
+// snapshot, which is only possible on a fresh application.
+func (app *BaseApp) OfferSnapshot(req abci.RequestOfferSnapshot) (res abci.ResponseOfferSnapshot) {
+	if app.snapshots == nil {
+		res.Error = ABCIError(errors.New("state sync snapshots are disabled"))
+		return
+	}
+	if height := app.LastBlockHeight(); height != 0 {
+		res.Error = ABCIError(errors.New("cannot restore a snapshot, the application is at height %d", height))
+		return
+	}
+	if len(req.AppHash) == 0 {
+		res.Error = ABCIError(errors.New("missing snapshot app hash"))
+		return
+	}
+	if err := app.snapshots.Offer(req.Snapshot); err != nil {
+		res.Error = ABCIError(err)
+		return
+	}
+	app.snapshotAppHash = req.AppHash
+	return
+}
+
+// ApplySnapshotChunk implements the ABCI interface. Once the last chunk is
+// applied, the restored state is verified against the app hash of the
+// offered snapshot, and the application is initialized from it. The base
+// store isn't part of the app hash: it is only verified against its
+// checksum in the snapshot, so it is as trustworthy as the snapshot peers.
+// A restoration which failed after its chunks were accepted leaves the
+// application database in an inconsistent state, which must be discarded.
+func (app *BaseApp) ApplySnapshotChunk(req abci.RequestApplySnapshotChunk) (res abci.ResponseApplySnapshotChunk) {
+	if app.snapshots == nil {
+		res.Error = ABCIError(errors.New("state sync snapshots are disabled"))
+		return
+	}
+	done, err := app.snapshots.ApplyChunk(req.Index, req.Chunk)
+	if err != nil {
+		res.Error = ABCIError(err)
+		return
+	}
+	if !done {
+		return
+	}
+
+	commitID := app.cms.LastCommitID()
+	if !bytes.Equal(commitID.Hash, app.snapshotAppHash) {
+		res.Error = ABCIError(fmt.Errorf("restored app hash %X, expected %X", commitID.Hash, app.snapshotAppHash))
+		return
+	}
+	// Drop the state left by InitChain, if any.
+	app.deliverState = nil
+	if err := app.initFromMainStore(); err != nil {
+		res.Error = ABCIError(err)
+		return
+	}
+	if app.snapshotRestorer != nil {
+		if app.checkState == nil {
+			res.Error = ABCIError(errors.New("restored snapshot has no last header"))
+			return
+		}
+		if err := app.snapshotRestorer(app.checkState.ctx); err != nil {
+			res.Error = ABCIError(err)
+			return
+		}
+	}
+	app.logger.Info("Restored state sync snapshot", "height", commitID.Version, "hash", fmt.Sprintf("%X", commitID.Hash))
+	res.Done = true
+	return
+}
+
 // halt attempts to gracefully shutdown the node via SIGINT and SIGTERM falling
 // back on os.Exit if both fail.
 func (app *BaseApp) halt() {
@@ -932,6 +1060,10 @@ func (app *BaseApp) halt() {
 
 // TODO implement cleanup
 func (app *BaseApp) Close() error {
+	// Wait for the snapshot being taken, before the stores are closed.
+	if app.snapshots != nil {
+		app.snapshots.Wait()
+	}
 	return nil // XXX
 }
 
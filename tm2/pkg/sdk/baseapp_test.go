@@ -21,6 +21,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
 	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 	store "github.com/gnolang/gno/tm2/pkg/store/types"
 )
 
@@ -1169,3 +1170,83 @@ func TestGetMaximumBlockGas(t *testing.T) {
 	app.setConsensusParams(&abci.ConsensusParams{Block: &abci.BlockParams{MaxGas: -5000000}})
 	require.Panics(t, func() { app.getMaximumBlockGas() })
 }
+
+// setupSnapshotBaseApp mounts a base store which can be snapshotted.
+func setupSnapshotBaseApp(t *testing.T, options ...func(*BaseApp)) *BaseApp {
+	t.Helper()
+
+	app := NewBaseApp(t.Name(), defaultLogger(), memdb.NewMemDB(), baseKey, mainKey, options...)
+	app.MountStoreWithDB(baseKey, dbadapter.StoreConstructor, nil)
+	app.MountStoreWithDB(mainKey, iavl.StoreConstructor, nil)
+	require.Nil(t, app.LoadLatestVersion())
+	return app
+}
+
+func TestStateSyncSnapshot(t *testing.T) {
+	t.Parallel()
+
+	snapshotOpt := SetSnapshotOptions(t.TempDir(), snapshots.Options{Interval: 2})
+	app := setupSnapshotBaseApp(t, snapshotOpt)
+	appHashes := make(map[int64][]byte)
+	for height := int64(1); height <= 3; height++ {
+		header := &bft.Header{ChainID: "test-chain", Height: height}
+		app.BeginBlock(abci.RequestBeginBlock{Header: header})
+		main := app.deliverState.ctx.Store(mainKey)
+		main.Set([]byte(fmt.Sprintf("key%d", height)), []byte("value"))
+		base := app.deliverState.ctx.Store(baseKey)
+		base.Set([]byte(fmt.Sprintf("base%d", height)), []byte("value"))
+		appHashes[height] = app.Commit().Data
+		// The snapshot is taken in the background.
+		app.snapshots.Wait()
+	}
+
+	// A snapshot was taken at height 2.
+	list := app.ListSnapshots(abci.RequestListSnapshots{})
+	require.Nil(t, list.Error)
+	require.Len(t, list.Snapshots, 1)
+	snapshot := list.Snapshots[0]
+	require.EqualValues(t, 2, snapshot.Height)
+
+	restoreApp := func(appHash []byte) (*BaseApp, *abci.ResponseApplySnapshotChunk) {
+		var restoredHeader int64
+		restored := setupSnapshotBaseApp(t, SetSnapshotOptions(t.TempDir(), snapshots.Options{}))
+		restored.sealed = false
+		restored.SetSnapshotRestorer(func(ctx Context) error {
+			restoredHeader = ctx.BlockHeight()
+			return nil
+		})
+
+		offer := restored.OfferSnapshot(abci.RequestOfferSnapshot{Snapshot: snapshot, AppHash: appHash})
+		require.Nil(t, offer.Error)
+		var res abci.ResponseApplySnapshotChunk
+		for i := uint32(0); i < snapshot.Chunks; i++ {
+			chunk := app.LoadSnapshotChunk(abci.RequestLoadSnapshotChunk{
+				Height: snapshot.Height, Format: snapshot.Format, Chunk: i,
+			})
+			require.Nil(t, chunk.Error)
+			res = restored.ApplySnapshotChunk(abci.RequestApplySnapshotChunk{Index: i, Chunk: chunk.Chunk})
+		}
+		if res.Done {
+			require.EqualValues(t, 2, restoredHeader)
+		}
+		return restored, &res
+	}
+
+	// The snapshot doesn't match the expected app hash.
+	_, res := restoreApp([]byte("wrong hash"))
+	require.NotNil(t, res.Error)
+	require.False(t, res.Done)
+
+	restored, res := restoreApp(appHashes[2])
+	require.Nil(t, res.Error)
+	require.True(t, res.Done)
+	require.EqualValues(t, 2, restored.LastBlockHeight())
+	require.Equal(t, []byte("value"), restored.checkState.ctx.Store(mainKey).Get([]byte("key2")))
+	require.Nil(t, restored.checkState.ctx.Store(mainKey).Get([]byte("key3")))
+	require.Equal(t, []byte("value"), restored.checkState.ctx.Store(baseKey).Get([]byte("base2")))
+	require.Nil(t, restored.checkState.ctx.Store(baseKey).Get([]byte("base3")))
+
+	// A snapshot can only be restored by a fresh application.
+	offer := app.OfferSnapshot(abci.RequestOfferSnapshot{Snapshot: snapshot, AppHash: []byte("hash")})
+	require.NotNil(t, offer.Error)
+}
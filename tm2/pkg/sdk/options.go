@@ -5,6 +5,7 @@ import (
 
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
 	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 )
 
 // File for storing in-package BaseApp optional functions,
@@ -100,3 +101,22 @@ func (app *BaseApp) SetAnteHandler(ah AnteHandler) {
 	}
 	app.anteHandler = ah
 }
+
+// SetSnapshotOptions returns a BaseApp option function that enables the state
+// sync snapshots, stored in dir.
+func SetSnapshotOptions(dir string, opts snapshots.Options) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		m, err := snapshots.NewManager(dir, bap.cms, opts)
+		if err != nil {
+			panic(fmt.Sprintf("invalid snapshot options: %v", err))
+		}
+		bap.snapshots = m
+	}
+}
+
+func (app *BaseApp) SetSnapshotRestorer(restorer SnapshotRestorer) {
+	if app.sealed {
+		panic("SetSnapshotRestorer() on sealed BaseApp")
+	}
+	app.snapshotRestorer = restorer
+}
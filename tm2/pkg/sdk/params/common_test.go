@@ -0,0 +1,32 @@
+package params
+
+// DONTCOVER
+
+import (
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+)
+
+type testEnv struct {
+	ctx    sdk.Context
+	keeper ParamsKeeper
+}
+
+func setupTestEnv() testEnv {
+	db := memdb.NewMemDB()
+	paramsCapKey := store.NewStoreKey("paramsCapKey")
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(paramsCapKey, iavl.StoreConstructor, db)
+	ms.LoadLatestVersion()
+
+	prefix := "params_test"
+	keeper := NewParamsKeeper(paramsCapKey, prefix)
+
+	ctx := sdk.NewContext(sdk.RunTxModeDeliver, ms, &bft.Header{Height: 1, ChainID: "test-chain-id"}, log.NewNoopLogger())
+
+	return testEnv{ctx: ctx, keeper: keeper}
+}
@@ -0,0 +1,11 @@
+// Package params provides a lightweight implementation inspired by the x/params
+// module of the Cosmos SDK.
+//
+// It includes a keeper for managing key-value pairs with module identifiers as
+// prefixes, along with a global querier for retrieving any key from any module.
+//
+// Changes: This version removes the concepts of subspaces and proposals,
+// allowing the creation of multiple keepers identified by a provided prefix.
+// Keys are typed, their suffix specifies the type of their value, ie.
+// "auth.max_memo_bytes.int64".
+package params
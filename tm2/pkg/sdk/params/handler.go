@@ -0,0 +1,59 @@
+package params
+
+import (
+	"fmt"
+	"strings"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type paramsHandler struct {
+	params ParamsKeeper
+}
+
+// NewHandler returns a handler for the "params" queries.
+// There are no "params" messages: parameters are set at genesis,
+// or by modules with access to the keeper.
+func NewHandler(params ParamsKeeper) paramsHandler {
+	return paramsHandler{
+		params: params,
+	}
+}
+
+func (bh paramsHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
+	errMsg := fmt.Sprintf("unrecognized params message type: %T", msg)
+	return abciResult(std.ErrUnknownRequest(errMsg))
+}
+
+//----------------------------------------
+// Query
+
+// Query returns the amino JSON encoded value of the key in
+// "params/<key>", or an empty response if the key is unset.
+func (bh paramsHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	parts := strings.SplitN(req.Path, "/", 2)
+	if len(parts) != 2 || parts[0] != ModuleName {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownRequest(fmt.Sprintf("invalid params query path %s", req.Path)))
+		return
+	}
+
+	key := parts[1]
+	if _, err := KeyKind(key); err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownRequest(err.Error()))
+		return
+	}
+
+	res.Data = bh.params.GetRaw(ctx, key)
+	return
+}
+
+//----------------------------------------
+// misc
+
+func abciResult(err error) sdk.Result {
+	return sdk.ABCIResultFromError(err)
+}
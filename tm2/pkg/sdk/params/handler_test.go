@@ -0,0 +1,42 @@
+package params
+
+import (
+	"testing"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	env := setupTestEnv()
+	ctx, keeper := env.ctx, env.keeper
+	h := NewHandler(keeper)
+
+	keeper.SetInt64(ctx, "auth.max_memo_bytes.int64", 42)
+	keeper.SetString(ctx, "gno.land/r/demo/foo.name.string", "foo")
+
+	testTable := []struct {
+		path         string
+		expectedData []byte
+		expectedErr  bool
+	}{
+		{"params/auth.max_memo_bytes.int64", []byte(`"42"`), false},
+		{"params/gno.land/r/demo/foo.name.string", []byte(`"foo"`), false},
+		{"params/unset.int64", nil, false},
+		{"params/invalid", nil, true},
+		{"params", nil, true},
+	}
+
+	for _, testCase := range testTable {
+		res := h.Query(ctx, abci.RequestQuery{Path: testCase.path})
+
+		if testCase.expectedErr {
+			assert.NotNil(t, res.Error, testCase.path)
+			continue
+		}
+
+		require.Nil(t, res.Error, testCase.path)
+		assert.Equal(t, testCase.expectedData, res.Data, testCase.path)
+	}
+}
@@ -0,0 +1,182 @@
+package params
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+const (
+	ModuleName = "params"
+	StoreKey   = ModuleName
+)
+
+// Supported kinds of values, used as suffixes of the keys.
+const (
+	KindString = "string"
+	KindBool   = "bool"
+	KindInt64  = "int64"
+	KindUint64 = "uint64"
+	KindBytes  = "bytes"
+)
+
+type ParamsKeeperI interface {
+	Has(ctx sdk.Context, key string) bool
+	GetRaw(ctx sdk.Context, key string) []byte
+
+	GetString(ctx sdk.Context, key string, ptr *string) bool
+	GetBool(ctx sdk.Context, key string, ptr *bool) bool
+	GetInt64(ctx sdk.Context, key string, ptr *int64) bool
+	GetUint64(ctx sdk.Context, key string, ptr *uint64) bool
+	GetBytes(ctx sdk.Context, key string, ptr *[]byte) bool
+
+	SetString(ctx sdk.Context, key string, value string)
+	SetBool(ctx sdk.Context, key string, value bool)
+	SetInt64(ctx sdk.Context, key string, value int64)
+	SetUint64(ctx sdk.Context, key string, value uint64)
+	SetBytes(ctx sdk.Context, key string, value []byte)
+}
+
+var _ ParamsKeeperI = ParamsKeeper{}
+
+// ParamsKeeper stores typed parameters in the multistore.
+type ParamsKeeper struct {
+	key    store.StoreKey
+	prefix string
+}
+
+// NewParamsKeeper returns a new ParamsKeeper, storing the parameters
+// under the given prefix of the store.
+func NewParamsKeeper(key store.StoreKey, prefix string) ParamsKeeper {
+	return ParamsKeeper{
+		key:    key,
+		prefix: prefix,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (pk ParamsKeeper) Logger(ctx sdk.Context) *slog.Logger {
+	return ctx.Logger().With("module", ModuleName)
+}
+
+// Has returns true if a value is set for the key.
+func (pk ParamsKeeper) Has(ctx sdk.Context, key string) bool {
+	stor := ctx.Store(pk.key)
+	return stor.Has(pk.storeKey(key))
+}
+
+// GetRaw returns the amino JSON encoded value of the key, or nil if unset.
+func (pk ParamsKeeper) GetRaw(ctx sdk.Context, key string) []byte {
+	stor := ctx.Store(pk.key)
+	return stor.Get(pk.storeKey(key))
+}
+
+// GetString sets ptr to the value of the key, and returns true if it is set.
+// If unset, ptr is left unchanged, so it can hold a default value.
+func (pk ParamsKeeper) GetString(ctx sdk.Context, key string, ptr *string) bool {
+	checkKind(key, KindString)
+	return pk.getIfExists(ctx, key, ptr)
+}
+
+// GetBool behaves like GetString, for bool values.
+func (pk ParamsKeeper) GetBool(ctx sdk.Context, key string, ptr *bool) bool {
+	checkKind(key, KindBool)
+	return pk.getIfExists(ctx, key, ptr)
+}
+
+// GetInt64 behaves like GetString, for int64 values.
+func (pk ParamsKeeper) GetInt64(ctx sdk.Context, key string, ptr *int64) bool {
+	checkKind(key, KindInt64)
+	return pk.getIfExists(ctx, key, ptr)
+}
+
+// GetUint64 behaves like GetString, for uint64 values.
+func (pk ParamsKeeper) GetUint64(ctx sdk.Context, key string, ptr *uint64) bool {
+	checkKind(key, KindUint64)
+	return pk.getIfExists(ctx, key, ptr)
+}
+
+// GetBytes behaves like GetString, for []byte values.
+func (pk ParamsKeeper) GetBytes(ctx sdk.Context, key string, ptr *[]byte) bool {
+	checkKind(key, KindBytes)
+	return pk.getIfExists(ctx, key, ptr)
+}
+
+// SetString sets the value of the key, which must have the ".string" suffix.
+func (pk ParamsKeeper) SetString(ctx sdk.Context, key string, value string) {
+	checkKind(key, KindString)
+	pk.set(ctx, key, value)
+}
+
+// SetBool sets the value of the key, which must have the ".bool" suffix.
+func (pk ParamsKeeper) SetBool(ctx sdk.Context, key string, value bool) {
+	checkKind(key, KindBool)
+	pk.set(ctx, key, value)
+}
+
+// SetInt64 sets the value of the key, which must have the ".int64" suffix.
+func (pk ParamsKeeper) SetInt64(ctx sdk.Context, key string, value int64) {
+	checkKind(key, KindInt64)
+	pk.set(ctx, key, value)
+}
+
+// SetUint64 sets the value of the key, which must have the ".uint64" suffix.
+func (pk ParamsKeeper) SetUint64(ctx sdk.Context, key string, value uint64) {
+	checkKind(key, KindUint64)
+	pk.set(ctx, key, value)
+}
+
+// SetBytes sets the value of the key, which must have the ".bytes" suffix.
+func (pk ParamsKeeper) SetBytes(ctx sdk.Context, key string, value []byte) {
+	checkKind(key, KindBytes)
+	pk.set(ctx, key, value)
+}
+
+func (pk ParamsKeeper) getIfExists(ctx sdk.Context, key string, ptr interface{}) bool {
+	stor := ctx.Store(pk.key)
+	bz := stor.Get(pk.storeKey(key))
+	if bz == nil {
+		return false
+	}
+	amino.MustUnmarshalJSON(bz, ptr)
+	return true
+}
+
+func (pk ParamsKeeper) set(ctx sdk.Context, key string, value interface{}) {
+	stor := ctx.Store(pk.key)
+	bz := amino.MustMarshalJSON(value)
+	stor.Set(pk.storeKey(key), bz)
+}
+
+func (pk ParamsKeeper) storeKey(key string) []byte {
+	return []byte(fmt.Sprintf("/%s/%s", pk.prefix, key))
+}
+
+// KeyKind returns the kind of value of the key, ie. "int64" for
+// "auth.max_memo_bytes.int64", or an error if the key is invalid.
+func KeyKind(key string) (string, error) {
+	idx := strings.LastIndex(key, ".")
+	if idx <= 0 {
+		return "", fmt.Errorf("invalid param key %q: missing kind suffix", key)
+	}
+	switch kind := key[idx+1:]; kind {
+	case KindString, KindBool, KindInt64, KindUint64, KindBytes:
+		return kind, nil
+	default:
+		return "", fmt.Errorf("invalid param key %q: unknown kind %q", key, kind)
+	}
+}
+
+func checkKind(key string, kind string) {
+	keyKind, err := KeyKind(key)
+	if err != nil {
+		panic(err)
+	}
+	if keyKind != kind {
+		panic(fmt.Sprintf("invalid param key %q: expected kind %q", key, kind))
+	}
+}
@@ -0,0 +1,124 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeeper(t *testing.T) {
+	env := setupTestEnv()
+	ctx, keeper := env.ctx, env.keeper
+
+	require.False(t, keeper.Has(ctx, "param1.string"))
+	require.False(t, keeper.Has(ctx, "param2.bool"))
+	require.False(t, keeper.Has(ctx, "param3.uint64"))
+	require.False(t, keeper.Has(ctx, "param4.int64"))
+	require.False(t, keeper.Has(ctx, "param5.bytes"))
+
+	// initial set
+	require.NotPanics(t, func() { keeper.SetString(ctx, "param1.string", "foo") })
+	require.NotPanics(t, func() { keeper.SetBool(ctx, "param2.bool", true) })
+	require.NotPanics(t, func() { keeper.SetUint64(ctx, "param3.uint64", 42) })
+	require.NotPanics(t, func() { keeper.SetInt64(ctx, "param4.int64", -1337) })
+	require.NotPanics(t, func() { keeper.SetBytes(ctx, "param5.bytes", []byte("hello world!")) })
+
+	require.True(t, keeper.Has(ctx, "param1.string"))
+	require.True(t, keeper.Has(ctx, "param2.bool"))
+	require.True(t, keeper.Has(ctx, "param3.uint64"))
+	require.True(t, keeper.Has(ctx, "param4.int64"))
+	require.True(t, keeper.Has(ctx, "param5.bytes"))
+
+	var (
+		param1 string
+		param2 bool
+		param3 uint64
+		param4 int64
+		param5 []byte
+	)
+
+	require.True(t, keeper.GetString(ctx, "param1.string", &param1))
+	require.True(t, keeper.GetBool(ctx, "param2.bool", &param2))
+	require.True(t, keeper.GetUint64(ctx, "param3.uint64", &param3))
+	require.True(t, keeper.GetInt64(ctx, "param4.int64", &param4))
+	require.True(t, keeper.GetBytes(ctx, "param5.bytes", &param5))
+
+	require.Equal(t, param1, "foo")
+	require.Equal(t, param2, true)
+	require.Equal(t, param3, uint64(42))
+	require.Equal(t, param4, int64(-1337))
+	require.Equal(t, param5, []byte("hello world!"))
+
+	// reset
+	require.NotPanics(t, func() { keeper.SetString(ctx, "param1.string", "bar") })
+	require.NotPanics(t, func() { keeper.SetInt64(ctx, "param4.int64", 0) })
+
+	require.True(t, keeper.GetString(ctx, "param1.string", &param1))
+	require.True(t, keeper.GetInt64(ctx, "param4.int64", &param4))
+
+	require.Equal(t, param1, "bar")
+	require.Equal(t, param4, int64(0))
+}
+
+func TestKeeper_Defaults(t *testing.T) {
+	env := setupTestEnv()
+	ctx, keeper := env.ctx, env.keeper
+
+	// unset keys keep the default value
+	value := int64(10)
+	assert.False(t, keeper.GetInt64(ctx, "unset.int64", &value))
+	assert.Equal(t, int64(10), value)
+	assert.Nil(t, keeper.GetRaw(ctx, "unset.int64"))
+}
+
+func TestKeeper_InvalidKeys(t *testing.T) {
+	env := setupTestEnv()
+	ctx, keeper := env.ctx, env.keeper
+
+	var value string
+
+	// missing kind
+	assert.Panics(t, func() { keeper.SetString(ctx, "param1", "foo") })
+	// unknown kind
+	assert.Panics(t, func() { keeper.SetString(ctx, "param1.float64", "foo") })
+	// mismatching kind
+	assert.Panics(t, func() { keeper.SetString(ctx, "param1.int64", "foo") })
+	assert.Panics(t, func() { keeper.GetString(ctx, "param1.int64", &value) })
+}
+
+func TestKeyKind(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		key          string
+		expectedKind string
+		expectedErr  bool
+	}{
+		{"auth.max_memo_bytes.int64", KindInt64, false},
+		{"gno.land/r/demo/foo.name.string", KindString, false},
+		{"flag.bool", KindBool, false},
+		{"counter.uint64", KindUint64, false},
+		{"blob.bytes", KindBytes, false},
+		{"nokind", "", true},
+		{".int64", "", true},
+		{"param.float64", "", true},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.key, func(t *testing.T) {
+			t.Parallel()
+
+			kind, err := KeyKind(testCase.key)
+			if testCase.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedKind, kind)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package dbadapter
 
 import (
+	"errors"
+
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
 
 	"github.com/gnolang/gno/tm2/pkg/store/cache"
@@ -69,3 +71,69 @@ func (dsa Store) LoadVersion(ver int64) error {
 
 // dbm.DB implements Store.
 var _ types.Store = Store{}
+
+// ----------------------------------------
+// Snapshots
+
+// importBatchSize is the number of imported items written in a single batch.
+const importBatchSize = 10000
+
+var _ types.Snapshotter = Store{}
+
+// Implements types.Snapshotter.
+// The store isn't versioned: the version is ignored and the current content
+// is exported, from a database iterator created by Export. It is read-only as
+// long as the iterators of the database backend read from a snapshot of the
+// database, like goleveldb's.
+func (dsa Store) Export(_ int64) (types.SnapshotExporter, error) {
+	return &exporter{itr: dsa.DB.Iterator(nil, nil)}, nil
+}
+
+// Implements types.Snapshotter.
+func (dsa Store) Import(_ int64) (types.SnapshotImporter, error) {
+	return &importer{db: dsa.DB, batch: dsa.DB.NewBatch()}, nil
+}
+
+type exporter struct {
+	itr dbm.Iterator
+}
+
+func (exp *exporter) Export(fn func(types.SnapshotItem) error) error {
+	for ; exp.itr.Valid(); exp.itr.Next() {
+		if err := fn(types.SnapshotItem{Key: exp.itr.Key(), Value: exp.itr.Value()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (exp *exporter) Close() {
+	exp.itr.Close()
+}
+
+type importer struct {
+	db      dbm.DB
+	batch   dbm.Batch
+	batched int
+}
+
+func (imp *importer) Add(item types.SnapshotItem) error {
+	if item.Value == nil {
+		return errors.New("cannot import a nil value")
+	}
+	imp.batch.Set(item.Key, item.Value)
+	imp.batched++
+	if imp.batched >= importBatchSize {
+		imp.batch.Write()
+		imp.batch.Close()
+		imp.batch = imp.db.NewBatch()
+		imp.batched = 0
+	}
+	return nil
+}
+
+func (imp *importer) Commit() error {
+	imp.batch.WriteSync()
+	imp.batch.Close()
+	return nil
+}
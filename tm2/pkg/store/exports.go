@@ -24,6 +24,9 @@ type (
 	GasConfig              = types.GasConfig
 	OutOfGasException      = types.OutOfGasException
 	GasOverflowException   = types.GasOverflowException
+	SnapshotItem           = types.SnapshotItem
+	Snapshotter            = types.Snapshotter
+	SnapshotImporter       = types.SnapshotImporter
 )
 
 var (
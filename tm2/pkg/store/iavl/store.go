@@ -35,12 +35,19 @@ var (
 	_ types.Store       = (*Store)(nil)
 	_ types.CommitStore = (*Store)(nil)
 	_ types.Queryable   = (*Store)(nil)
+	_ types.Snapshotter = (*Store)(nil)
 )
 
 // Store Implements types.Store and CommitStore.
 type Store struct {
 	tree Tree
 	opts types.StoreOptions
+
+	// The versions released while snapshots are being exported are only
+	// deleted once the exports are done.
+	exportMtx sync.Mutex
+	exporting int
+	released  []int64
 }
 
 func UnsafeNewStore(tree *iavl.MutableTree, opts types.StoreOptions) *Store {
@@ -89,10 +96,7 @@ func (st *Store) Commit() types.CommitID {
 	if st.opts.KeepRecent < previous {
 		toRelease := previous - st.opts.KeepRecent
 		if st.opts.KeepEvery == 0 || toRelease%st.opts.KeepEvery != 0 {
-			err := st.tree.DeleteVersion(toRelease)
-			if errCause := errors.Cause(err); errCause != nil && !goerrors.Is(errCause, iavl.ErrVersionDoesNotExist) {
-				panic(err)
-			}
+			st.release(toRelease)
 		}
 	}
 
@@ -102,6 +106,26 @@ func (st *Store) Commit() types.CommitID {
 	}
 }
 
+// release deletes a version of history, or defers its deletion until no
+// snapshot is being exported: the exports read the nodes of the database
+// this store shares with others.
+func (st *Store) release(version int64) {
+	st.exportMtx.Lock()
+	defer st.exportMtx.Unlock()
+
+	st.released = append(st.released, version)
+	if st.exporting > 0 {
+		return
+	}
+	for _, version := range st.released {
+		err := st.tree.DeleteVersion(version)
+		if errCause := errors.Cause(err); errCause != nil && !goerrors.Is(errCause, iavl.ErrVersionDoesNotExist) {
+			panic(err)
+		}
+	}
+	st.released = nil
+}
+
 // Implements Committer.
 func (st *Store) LastCommitID() types.CommitID {
 	return types.CommitID{
@@ -304,6 +328,73 @@ func (st *Store) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 	return
 }
 
+// ----------------------------------------
+// Snapshots
+
+// Implements types.Snapshotter.
+// The versions of history aren't deleted until the exporter is closed.
+func (st *Store) Export(version int64) (types.SnapshotExporter, error) {
+	tree, err := st.tree.GetImmutable(version)
+	if err != nil {
+		return nil, err
+	}
+
+	st.exportMtx.Lock()
+	st.exporting++
+	st.exportMtx.Unlock()
+	return &exporter{st: st, tree: tree}, nil
+}
+
+// exporter exports an immutable tree of a Store.
+type exporter struct {
+	st   *Store
+	tree *iavl.ImmutableTree
+}
+
+func (exp *exporter) Export(fn func(types.SnapshotItem) error) error {
+	return exp.tree.Export(func(node iavl.ExportNode) error {
+		return fn(types.SnapshotItem{
+			Key:     node.Key,
+			Value:   node.Value,
+			Version: node.Version,
+			Height:  node.Height,
+		})
+	})
+}
+
+func (exp *exporter) Close() {
+	exp.st.exportMtx.Lock()
+	exp.st.exporting--
+	exp.st.exportMtx.Unlock()
+}
+
+// Implements types.Snapshotter.
+func (st *Store) Import(version int64) (types.SnapshotImporter, error) {
+	tree, ok := st.tree.(*iavl.MutableTree)
+	if !ok {
+		return nil, errors.New("cannot import into an immutable IAVL store")
+	}
+	imp, err := tree.Import(version)
+	if err != nil {
+		return nil, err
+	}
+	return importer{imp}, nil
+}
+
+// importer adapts an iavl.Importer to types.SnapshotImporter.
+type importer struct {
+	*iavl.Importer
+}
+
+func (imp importer) Add(item types.SnapshotItem) error {
+	return imp.Importer.Add(iavl.ExportNode{
+		Key:     item.Key,
+		Value:   item.Value,
+		Version: item.Version,
+		Height:  item.Height,
+	})
+}
+
 // ----------------------------------------
 
 // Implements types.Iterator.
@@ -0,0 +1,333 @@
+package rootmulti
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	goerrors "errors"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	tiavl "github.com/gnolang/gno/tm2/pkg/iavl"
+
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+// maxSnapshotItemSize is the maximum size of an encoded snapshot item.
+const maxSnapshotItemSize = 64 << 20
+
+// snapshotItem is an item of a multistore snapshot, which starts with the
+// commit info of the snapshot version. An item with a store name marks the
+// start of the items of that store.
+//
+// The items of a store whose commit ID doesn't commit to its content, like a
+// dbadapter store, are followed by an item with their checksum. It only
+// verifies the integrity of the snapshot: unlike the other stores, these
+// aren't verified against the app hash.
+type snapshotItem struct {
+	Store    string
+	Item     types.SnapshotItem
+	Checksum []byte
+}
+
+// Implements CommitMultiStore.
+// Stores which aren't versioned can only be exported at their current state,
+// so the version must be the last committed one.
+func (ms *multiStore) Snapshot(version int64) (types.MultiStoreSnapshot, error) {
+	if version != ms.lastCommitID.Version {
+		return nil, fmt.Errorf("cannot snapshot version %d, last committed version is %d",
+			version, ms.lastCommitID.Version)
+	}
+	cInfo, err := getCommitInfo(ms.db, version)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stores verified by the commit info are written last, so that once
+	// restored, they overwrite the entries of the stores sharing their
+	// database.
+	infos := append([]storeInfo(nil), cInfo.StoreInfos...)
+	sort.Slice(infos, func(i, j int) bool {
+		if vi, vj := infos[i].verified(), infos[j].verified(); vi != vj {
+			return vj
+		}
+		return infos[i].Name < infos[j].Name
+	})
+	snap := &snapshot{cInfo: cInfo}
+	for _, info := range infos {
+		key := ms.keysByName[info.Name]
+		if key == nil {
+			snap.Close()
+			return nil, fmt.Errorf("unknown store %q in commit info", info.Name)
+		}
+		snapshotter, ok := ms.stores[key].(types.Snapshotter)
+		if !ok {
+			snap.Close()
+			return nil, fmt.Errorf("store %q doesn't support snapshots", info.Name)
+		}
+		exporter, err := snapshotter.Export(version)
+		if err != nil {
+			snap.Close()
+			return nil, errors.Wrap(err, "exporting store %q", info.Name)
+		}
+		snap.stores = append(snap.stores, storeSnapshot{
+			name:     info.Name,
+			exporter: exporter,
+			skip:     ms.sharedTreeEntries(key),
+			checksum: !info.verified(),
+		})
+	}
+	return snap, nil
+}
+
+// snapshot is a read-only view of the stores of a multistore at a version.
+type snapshot struct {
+	cInfo  commitInfo
+	stores []storeSnapshot
+}
+
+type storeSnapshot struct {
+	name     string
+	exporter types.SnapshotExporter
+	skip     func(types.SnapshotItem) bool
+	checksum bool
+}
+
+// Implements types.MultiStoreSnapshot.
+func (snap *snapshot) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if _, err := amino.MarshalSizedWriter(bw, snap.cInfo); err != nil {
+		return err
+	}
+
+	for _, store := range snap.stores {
+		if _, err := amino.MarshalSizedWriter(bw, snapshotItem{Store: store.name}); err != nil {
+			return err
+		}
+		sum := sha256.New()
+		err := store.exporter.Export(func(item types.SnapshotItem) error {
+			if store.skip(item) {
+				return nil
+			}
+			hashItem(sum, item)
+			_, err := amino.MarshalSizedWriter(bw, snapshotItem{Item: item})
+			return err
+		})
+		if err != nil {
+			return errors.Wrap(err, "exporting store %q", store.name)
+		}
+		if store.checksum {
+			if _, err := amino.MarshalSizedWriter(bw, snapshotItem{Checksum: sum.Sum(nil)}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// Implements types.MultiStoreSnapshot.
+func (snap *snapshot) Close() {
+	for _, store := range snap.stores {
+		store.exporter.Close()
+	}
+	snap.stores = nil
+}
+
+// sharedTreeEntries returns a filter of the items exported by the store of
+// key which belong to an IAVL store mounted on the same database: they are
+// exported, and verified, with the IAVL tree itself.
+func (ms *multiStore) sharedTreeEntries(key types.StoreKey) func(types.SnapshotItem) bool {
+	shared := ms.sharedTreeEntry(key)
+	if shared == nil {
+		return func(types.SnapshotItem) bool { return false }
+	}
+	return func(item types.SnapshotItem) bool {
+		return shared(item.Key, item.Value)
+	}
+}
+
+// sharedTreeEntry returns a filter of the entries of the database of the
+// store of key which belong to an IAVL store mounted on the same database,
+// or nil if there is none.
+func (ms *multiStore) sharedTreeEntry(key types.StoreKey) func(key, value []byte) bool {
+	params := ms.storesParams[key]
+	if params.db == nil {
+		return nil
+	}
+	if _, ok := ms.stores[key].(*iavl.Store); ok {
+		return nil
+	}
+	for other, otherParams := range ms.storesParams {
+		if other == key || otherParams.db != params.db {
+			continue
+		}
+		if _, ok := ms.stores[other].(*iavl.Store); ok {
+			db := ms.storeDB(params)
+			return func(key, value []byte) bool {
+				return tiavl.IsNodeDBEntry(db, key, value)
+			}
+		}
+	}
+	return nil
+}
+
+// Implements CommitMultiStore.
+// The restored stores are loaded, which verifies their commit IDs against
+// the commit info of the snapshot. It is up to the caller to verify the
+// resulting commit hash. The stores which aren't part of it are verified
+// against their checksum in the snapshot. On error, the database is left
+// partially restored and must be discarded.
+func (ms *multiStore) Restore(version int64, r io.Reader) error {
+	if latest := getLatestVersion(ms.db); latest != 0 {
+		return fmt.Errorf("cannot restore a snapshot into a non-empty multistore, found version %d", latest)
+	}
+
+	br := bufio.NewReader(r)
+	var cInfo commitInfo
+	if _, err := amino.UnmarshalSizedReader(br, &cInfo, maxSnapshotItemSize); err != nil {
+		return errors.Wrap(err, "reading commit info")
+	}
+	if cInfo.Version != version {
+		return fmt.Errorf("snapshot of version %d, expected %d", cInfo.Version, version)
+	}
+	infos := make(map[string]storeInfo, len(cInfo.StoreInfos))
+	for _, info := range cInfo.StoreInfos {
+		infos[info.Name] = info
+	}
+
+	var (
+		store    *storeRestore
+		restored = make(map[string]bool)
+	)
+	for {
+		var item snapshotItem
+		_, err := amino.UnmarshalSizedReader(br, &item, maxSnapshotItemSize)
+		if goerrors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return errors.Wrap(err, "reading snapshot item")
+		}
+
+		if item.Store == "" {
+			if store == nil {
+				return errors.New("snapshot item outside of a store")
+			}
+			if err := store.add(item); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Start of a new store.
+		if store != nil {
+			if err := store.commit(); err != nil {
+				return err
+			}
+		}
+		info, ok := infos[item.Store]
+		if !ok {
+			return fmt.Errorf("store %q isn't in the commit info", item.Store)
+		}
+		if restored[item.Store] {
+			return fmt.Errorf("store %q restored twice", item.Store)
+		}
+		restored[item.Store] = true
+		importer, err := ms.storeImporter(item.Store, version)
+		if err != nil {
+			return err
+		}
+		store = &storeRestore{name: item.Store, importer: importer}
+		if !info.verified() {
+			store.sum = sha256.New()
+		}
+	}
+	if store != nil {
+		if err := store.commit(); err != nil {
+			return err
+		}
+	}
+	for name := range infos {
+		if !restored[name] {
+			return fmt.Errorf("store %q missing from the snapshot", name)
+		}
+	}
+
+	// Persist the commit info, and load the restored version.
+	batch := ms.db.NewBatch()
+	defer batch.Close()
+	setCommitInfo(batch, version, cInfo)
+	setLatestVersion(batch, version)
+	batch.WriteSync()
+
+	return ms.LoadVersion(version)
+}
+
+// storeRestore is a store being restored. The items of a store which isn't
+// verified by the commit info are hashed into sum, and must be followed by
+// their checksum.
+type storeRestore struct {
+	name     string
+	importer types.SnapshotImporter
+	sum      hash.Hash
+	checked  bool
+}
+
+func (sr *storeRestore) add(item snapshotItem) error {
+	if sr.checked {
+		return fmt.Errorf("store %q has items after its checksum", sr.name)
+	}
+	if item.Checksum != nil {
+		if sr.sum == nil {
+			return fmt.Errorf("unexpected checksum for store %q", sr.name)
+		}
+		if !bytes.Equal(item.Checksum, sr.sum.Sum(nil)) {
+			return fmt.Errorf("checksum mismatch for store %q", sr.name)
+		}
+		sr.checked = true
+		return nil
+	}
+	if sr.sum != nil {
+		hashItem(sr.sum, item.Item)
+	}
+	return sr.importer.Add(item.Item)
+}
+
+func (sr *storeRestore) commit() error {
+	if sr.sum != nil && !sr.checked {
+		return fmt.Errorf("missing checksum for store %q", sr.name)
+	}
+	return sr.importer.Commit()
+}
+
+// hashItem writes the key and value of a snapshot item to the checksum of
+// its store.
+func hashItem(h hash.Hash, item types.SnapshotItem) {
+	var lenbz [binary.MaxVarintLen64]byte
+	h.Write(lenbz[:binary.PutUvarint(lenbz[:], uint64(len(item.Key)))])
+	h.Write(item.Key)
+	h.Write(lenbz[:binary.PutUvarint(lenbz[:], uint64(len(item.Value)))])
+	h.Write(item.Value)
+}
+
+func (ms *multiStore) storeImporter(name string, version int64) (types.SnapshotImporter, error) {
+	key := ms.keysByName[name]
+	if key == nil {
+		return nil, fmt.Errorf("unknown store %q in snapshot", name)
+	}
+	store, err := ms.constructStore(ms.storesParams[key])
+	if err != nil {
+		return nil, err
+	}
+	snapshotter, ok := store.(types.Snapshotter)
+	if !ok {
+		return nil, fmt.Errorf("store %q doesn't support snapshots", name)
+	}
+	return snapshotter.Import(version)
+}
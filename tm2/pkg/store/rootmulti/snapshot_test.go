@@ -0,0 +1,185 @@
+package rootmulti
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/db/goleveldb"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+// newSnapshotMultiStore mounts an IAVL and a dbadapter store on the same
+// database, as the gno.land application does.
+func newSnapshotMultiStore(t *testing.T, db dbm.DB) *multiStore {
+	t.Helper()
+
+	ms := NewMultiStore(db)
+	ms.MountStoreWithDB(types.NewStoreKey("main"), iavl.StoreConstructor, db)
+	ms.MountStoreWithDB(types.NewStoreKey("base"), dbadapter.StoreConstructor, db)
+	require.NoError(t, ms.LoadLatestVersion())
+	return ms
+}
+
+func takeSnapshot(t *testing.T, ms *multiStore, version int64) []byte {
+	t.Helper()
+
+	view, err := ms.Snapshot(version)
+	require.NoError(t, err)
+	defer view.Close()
+
+	var snapshot bytes.Buffer
+	require.NoError(t, view.Write(&snapshot))
+	return snapshot.Bytes()
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	ms := newSnapshotMultiStore(t, memdb.NewMemDB())
+	for v := 0; v < 3; v++ {
+		main, base := ms.getStoreByName("main"), ms.getStoreByName("base")
+		for i := 0; i < 20; i++ {
+			main.Set([]byte(fmt.Sprintf("main%02d", i)), []byte(fmt.Sprintf("value%d", v)))
+			base.Set([]byte(fmt.Sprintf("base%02d", i+v)), []byte(fmt.Sprintf("value%d", v)))
+		}
+		ms.Commit()
+	}
+	version := ms.LastCommitID().Version
+
+	snapshot := takeSnapshot(t, ms, version)
+
+	// Only the last committed version can be exported.
+	_, err := ms.Snapshot(version - 1)
+	assert.Error(t, err)
+
+	restored := newSnapshotMultiStore(t, memdb.NewMemDB())
+	require.NoError(t, restored.Restore(version, bytes.NewReader(snapshot)))
+	assert.Equal(t, ms.LastCommitID(), restored.LastCommitID())
+
+	for _, name := range []string{"main", "base"} {
+		expected := collect(ms.getStoreByName(name))
+		assert.Equal(t, expected, collect(restored.getStoreByName(name)), name)
+	}
+
+	// The restored multistore keeps committing from the restored version.
+	restored.getStoreByName("main").Set([]byte("new"), []byte("value"))
+	assert.Equal(t, version+1, restored.Commit().Version)
+
+	// A snapshot can't be restored twice.
+	assert.Error(t, restored.Restore(version, bytes.NewReader(snapshot)))
+}
+
+func TestSnapshot_CommitWhileWriting(t *testing.T) {
+	t.Parallel()
+
+	// goleveldb iterators read from a snapshot of the database.
+	db, err := goleveldb.NewGoLevelDB("snapshot", t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	ms := newSnapshotMultiStore(t, db)
+	ms.SetStoreOptions(types.StoreOptions{PruningOptions: types.PruneEverything})
+	commit := func(v int) {
+		main, base := ms.getStoreByName("main"), ms.getStoreByName("base")
+		for i := 0; i < 20; i++ {
+			main.Set([]byte(fmt.Sprintf("main%02d", i)), []byte(fmt.Sprintf("value%d", v)))
+			base.Set([]byte(fmt.Sprintf("base%02d", i)), []byte(fmt.Sprintf("value%d", v)))
+		}
+		base.Delete([]byte(fmt.Sprintf("base%02d", v)))
+		ms.Commit()
+	}
+	for v := 0; v < 3; v++ {
+		commit(v)
+	}
+	version := ms.LastCommitID().Version
+	expected := map[string]map[string]string{
+		"main": collect(ms.getStoreByName("main")),
+		"base": collect(ms.getStoreByName("base")),
+	}
+
+	// The next versions are committed, and the snapshot version pruned,
+	// before the view is written.
+	view, err := ms.Snapshot(version)
+	require.NoError(t, err)
+	for v := 3; v < 6; v++ {
+		commit(v)
+	}
+	var snapshot bytes.Buffer
+	require.NoError(t, view.Write(&snapshot))
+	view.Close()
+
+	restored := newSnapshotMultiStore(t, memdb.NewMemDB())
+	require.NoError(t, restored.Restore(version, bytes.NewReader(snapshot.Bytes())))
+	for name, kvs := range expected {
+		assert.Equal(t, kvs, collect(restored.getStoreByName(name)), name)
+	}
+
+	// The versions released while the view was open are pruned.
+	commit(6)
+	_, err = ms.getStoreByName("main").(*iavl.Store).GetImmutable(version)
+	assert.Error(t, err)
+}
+
+func TestRestore_Invalid(t *testing.T) {
+	t.Parallel()
+
+	ms := newSnapshotMultiStore(t, memdb.NewMemDB())
+	ms.getStoreByName("main").Set([]byte("key"), []byte("mainvalue"))
+	ms.getStoreByName("base").Set([]byte("key"), []byte("basevalue"))
+	ms.Commit()
+
+	bz := takeSnapshot(t, ms, 1)
+
+	// Wrong version.
+	assert.Error(t, newSnapshotMultiStore(t, memdb.NewMemDB()).Restore(2, bytes.NewReader(bz)))
+
+	// Truncated snapshot.
+	assert.Error(t, newSnapshotMultiStore(t, memdb.NewMemDB()).Restore(1, bytes.NewReader(bz[:len(bz)-1])))
+
+	// Tampered IAVL value: the restored tree doesn't match the commit info.
+	tampered := bytes.Replace(bz, []byte("mainvalue"), []byte("MAINVALUE"), -1)
+	assert.Error(t, newSnapshotMultiStore(t, memdb.NewMemDB()).Restore(1, bytes.NewReader(tampered)))
+
+	// Tampered raw value: the restored content doesn't match its checksum.
+	tampered = bytes.Replace(bz, []byte("basevalue"), []byte("BASEVALUE"), -1)
+	assert.Error(t, newSnapshotMultiStore(t, memdb.NewMemDB()).Restore(1, bytes.NewReader(tampered)))
+
+	// Missing checksum.
+	var cInfo commitInfo
+	r := bytes.NewReader(bz)
+	_, err := amino.UnmarshalSizedReader(r, &cInfo, maxSnapshotItemSize)
+	require.NoError(t, err)
+	var stripped bytes.Buffer
+	_, err = amino.MarshalSizedWriter(&stripped, cInfo)
+	require.NoError(t, err)
+	for r.Len() > 0 {
+		var item snapshotItem
+		_, err := amino.UnmarshalSizedReader(r, &item, maxSnapshotItemSize)
+		require.NoError(t, err)
+		if item.Checksum == nil {
+			_, err = amino.MarshalSizedWriter(&stripped, item)
+			require.NoError(t, err)
+		}
+	}
+	assert.Error(t, newSnapshotMultiStore(t, memdb.NewMemDB()).Restore(1, &stripped))
+}
+
+func collect(store types.Store) map[string]string {
+	kvs := make(map[string]string)
+	itr := store.Iterator(nil, nil)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		kvs[string(itr.Key())] = string(itr.Value())
+	}
+	return kvs
+}
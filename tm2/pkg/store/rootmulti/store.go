@@ -334,12 +334,7 @@ func parsePath(path string) (storeName string, subpath string, err serrors.Error
 // ----------------------------------------
 
 func (ms *multiStore) constructStore(params storeParams) (store types.CommitStore, err error) {
-	var db dbm.DB
-	if params.db != nil {
-		db = dbm.NewPrefixDB(params.db, []byte("s/_/"))
-	} else {
-		db = dbm.NewPrefixDB(ms.db, []byte("s/k:"+params.key.Name()+"/"))
-	}
+	db := ms.storeDB(params)
 	opts := ms.storeOpts
 
 	// XXX: use these:
@@ -349,6 +344,15 @@ func (ms *multiStore) constructStore(params storeParams) (store types.CommitStor
 	return store, nil
 }
 
+// storeDB returns the database of a store. The stores mounted with the same
+// database share the same key space.
+func (ms *multiStore) storeDB(params storeParams) dbm.DB {
+	if params.db != nil {
+		return dbm.NewPrefixDB(params.db, []byte("s/_/"))
+	}
+	return dbm.NewPrefixDB(ms.db, []byte("s/k:"+params.key.Name()+"/"))
+}
+
 func (ms *multiStore) nameToKey(name string) types.StoreKey {
 	for key := range ms.storesParams {
 		if key.Name() == name {
@@ -414,6 +418,12 @@ type storeCore struct {
 	// ... maybe add more state
 }
 
+// verified returns whether the commit ID of the store commits to its content,
+// which can then be verified against the commit info.
+func (si storeInfo) verified() bool {
+	return len(si.Core.CommitID.Hash) != 0
+}
+
 // Implements merkle.Hasher.
 func (si storeInfo) Hash() []byte {
 	// Doesn't write Name, since merkle.SimpleHashFromMap() will
@@ -0,0 +1,464 @@
+// Package snapshots takes, stores and restores the state sync snapshots of
+// a multistore.
+package snapshots
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+const (
+	// Format is the format of the snapshots taken by the Manager: the
+	// multistore snapshot stream, split into chunks.
+	Format uint32 = 1
+
+	// DefaultChunkSize is the maximum size of a snapshot chunk.
+	DefaultChunkSize = 4 << 20
+
+	snapshotFile = "snapshot"
+	tmpPrefix    = "tmp-"
+)
+
+var (
+	ErrUnknownFormat    = errors.New("unknown snapshot format")
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+	ErrNoRestoration    = errors.New("no snapshot restoration in progress")
+	ErrInvalidChunk     = errors.New("invalid snapshot chunk")
+	ErrTakingSnapshot   = errors.New("a snapshot is already being taken")
+)
+
+// Options are the snapshot options of a Manager.
+type Options struct {
+	// Interval is the number of blocks between two snapshots.
+	// A value of 0 disables snapshots.
+	Interval int64
+
+	// KeepRecent is the number of recent snapshots to keep.
+	// A value of 0 keeps all snapshots.
+	KeepRecent int
+}
+
+// metadata is the metadata of a snapshot, used to verify each of its chunks
+// as they are applied.
+type metadata struct {
+	ChunkHashes [][]byte
+}
+
+// Manager takes snapshots of a multistore, stores them in a directory, and
+// restores the multistore from a snapshot.
+type Manager struct {
+	mtx       sync.Mutex
+	dir       string
+	ms        types.CommitMultiStore
+	opts      Options
+	chunkSize int
+
+	restoration *restoration
+	taking      bool
+	wg          sync.WaitGroup
+}
+
+// NewManager creates a new Manager storing its snapshots in dir.
+func NewManager(dir string, ms types.CommitMultiStore, opts Options) (*Manager, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("unable to create snapshot directory: %w", err)
+	}
+	return &Manager{
+		dir:       dir,
+		ms:        ms,
+		opts:      opts,
+		chunkSize: DefaultChunkSize,
+	}, nil
+}
+
+// ShouldTake returns whether a snapshot should be taken at height.
+func (m *Manager) ShouldTake(height int64) bool {
+	return m.opts.Interval > 0 && height > 0 && height%m.opts.Interval == 0
+}
+
+// Take takes a snapshot of the multistore at height, which must be its last
+// committed version, and prunes the old snapshots.
+func (m *Manager) Take(height int64) (abci.Snapshot, error) {
+	view, err := m.begin(height)
+	if err != nil {
+		return abci.Snapshot{}, err
+	}
+	return m.write(height, view)
+}
+
+// TakeAsync takes a snapshot of the multistore at height, which must be its
+// last committed version, in the background. The view of the multistore is
+// taken before TakeAsync returns, so the next versions can be committed
+// while the snapshot is written. done is called with the result.
+func (m *Manager) TakeAsync(height int64, done func(abci.Snapshot, error)) error {
+	view, err := m.begin(height)
+	if err != nil {
+		return err
+	}
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		done(m.write(height, view))
+	}()
+	return nil
+}
+
+// Wait waits for the snapshot being taken in the background, if any.
+func (m *Manager) Wait() {
+	m.wg.Wait()
+}
+
+// begin takes the view of the multistore at height, if no other snapshot is
+// being taken.
+func (m *Manager) begin(height int64) (types.MultiStoreSnapshot, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.taking {
+		return nil, ErrTakingSnapshot
+	}
+	view, err := m.ms.Snapshot(height)
+	if err != nil {
+		return nil, err
+	}
+	m.taking = true
+	return view, nil
+}
+
+// write writes the snapshot of the view, and closes it.
+func (m *Manager) write(height int64, view types.MultiStoreSnapshot) (abci.Snapshot, error) {
+	defer func() {
+		view.Close()
+		m.mtx.Lock()
+		m.taking = false
+		m.mtx.Unlock()
+	}()
+
+	tmpDir := filepath.Join(m.dir, tmpPrefix+snapshotDirName(height))
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return abci.Snapshot{}, err
+	}
+	if err := os.MkdirAll(tmpDir, 0o755); err != nil {
+		return abci.Snapshot{}, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cw := &chunkWriter{
+		dir:       tmpDir,
+		chunkSize: m.chunkSize,
+		hasher:    sha256.New(),
+	}
+	if err := view.Write(cw); err != nil {
+		cw.close()
+		return abci.Snapshot{}, err
+	}
+	if err := cw.close(); err != nil {
+		return abci.Snapshot{}, err
+	}
+
+	snapshot := abci.Snapshot{
+		Height:   height,
+		Format:   Format,
+		Chunks:   uint32(len(cw.chunkHashes)),
+		Hash:     cw.hasher.Sum(nil),
+		Metadata: amino.MustMarshal(metadata{ChunkHashes: cw.chunkHashes}),
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, snapshotFile), amino.MustMarshal(snapshot), 0o644); err != nil {
+		return abci.Snapshot{}, err
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	dir := filepath.Join(m.dir, snapshotDirName(height))
+	if err := os.RemoveAll(dir); err != nil {
+		return abci.Snapshot{}, err
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return abci.Snapshot{}, err
+	}
+
+	return snapshot, m.prune()
+}
+
+// prune deletes the snapshots exceeding KeepRecent.
+func (m *Manager) prune() error {
+	if m.opts.KeepRecent <= 0 {
+		return nil
+	}
+	heights, err := m.heights()
+	if err != nil {
+		return err
+	}
+	for i := m.opts.KeepRecent; i < len(heights); i++ {
+		if err := os.RemoveAll(filepath.Join(m.dir, snapshotDirName(heights[i]))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List returns the stored snapshots, most recent first.
+func (m *Manager) List() ([]abci.Snapshot, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	heights, err := m.heights()
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]abci.Snapshot, 0, len(heights))
+	for _, height := range heights {
+		snapshot, err := m.load(height)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// LoadChunk returns a chunk of a stored snapshot.
+func (m *Manager) LoadChunk(height int64, format, index uint32) ([]byte, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if format != Format {
+		return nil, ErrUnknownFormat
+	}
+	snapshot, err := m.load(height)
+	if err != nil {
+		return nil, err
+	}
+	if index >= snapshot.Chunks {
+		return nil, fmt.Errorf("%w: chunk %d of %d", ErrInvalidChunk, index, snapshot.Chunks)
+	}
+	return os.ReadFile(filepath.Join(m.dir, snapshotDirName(height), strconv.Itoa(int(index))))
+}
+
+// heights returns the heights of the stored snapshots, in descending order.
+func (m *Manager) heights() ([]int64, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return nil, err
+	}
+	var heights []int64
+	for _, entry := range entries {
+		height, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil || !entry.IsDir() {
+			continue // temporary or foreign entry.
+		}
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+	return heights, nil
+}
+
+func (m *Manager) load(height int64) (abci.Snapshot, error) {
+	bz, err := os.ReadFile(filepath.Join(m.dir, snapshotDirName(height), snapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return abci.Snapshot{}, ErrSnapshotNotFound
+	} else if err != nil {
+		return abci.Snapshot{}, err
+	}
+	var snapshot abci.Snapshot
+	if err := amino.Unmarshal(bz, &snapshot); err != nil {
+		return abci.Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+func snapshotDirName(height int64) string {
+	return fmt.Sprintf("%020d", height)
+}
+
+// ----------------------------------------
+// Restoration
+
+// restoration is a snapshot being restored. The chunks are applied in order,
+// and streamed to the multistore restore.
+type restoration struct {
+	snapshot    abci.Snapshot
+	chunkHashes [][]byte
+	next        uint32
+	hasher      hash.Hash
+	pw          *io.PipeWriter
+	done        chan error
+}
+
+// Offer starts the restoration of the multistore from a snapshot,
+// aborting the restoration in progress, if any.
+func (m *Manager) Offer(snapshot abci.Snapshot) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if snapshot.Format != Format {
+		return ErrUnknownFormat
+	}
+	var meta metadata
+	if err := amino.Unmarshal(snapshot.Metadata, &meta); err != nil {
+		return fmt.Errorf("invalid snapshot metadata: %w", err)
+	}
+	if snapshot.Chunks == 0 || int(snapshot.Chunks) != len(meta.ChunkHashes) {
+		return fmt.Errorf("invalid snapshot metadata: %d chunks, %d chunk hashes",
+			snapshot.Chunks, len(meta.ChunkHashes))
+	}
+
+	m.abortRestoration()
+
+	pr, pw := io.Pipe()
+	r := &restoration{
+		snapshot:    snapshot,
+		chunkHashes: meta.ChunkHashes,
+		hasher:      sha256.New(),
+		pw:          pw,
+		done:        make(chan error, 1),
+	}
+	go func() {
+		err := m.ms.Restore(snapshot.Height, pr)
+		// Unblock the pending chunk writes, if the restore failed early.
+		pr.CloseWithError(err)
+		r.done <- err
+	}()
+	m.restoration = r
+	return nil
+}
+
+// ApplyChunk applies the next chunk of the offered snapshot. Once its last
+// chunk is applied, the multistore is restored, and ApplyChunk returns true.
+// A chunk which doesn't match the snapshot metadata is rejected with
+// ErrInvalidChunk, and can be applied again, e.g. fetched from another peer.
+func (m *Manager) ApplyChunk(index uint32, chunk []byte) (bool, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	r := m.restoration
+	if r == nil {
+		return false, ErrNoRestoration
+	}
+	if index != r.next {
+		return false, fmt.Errorf("%w: expected chunk %d, got %d", ErrInvalidChunk, r.next, index)
+	}
+	if sum := sha256.Sum256(chunk); !bytes.Equal(sum[:], r.chunkHashes[index]) {
+		return false, fmt.Errorf("%w: hash mismatch for chunk %d", ErrInvalidChunk, index)
+	}
+
+	r.hasher.Write(chunk)
+	if _, err := r.pw.Write(chunk); err != nil {
+		m.restoration = nil
+		return false, fmt.Errorf("restoring snapshot: %w", <-r.done)
+	}
+	r.next++
+	if r.next < r.snapshot.Chunks {
+		return false, nil
+	}
+
+	// Last chunk.
+	m.restoration = nil
+	if !bytes.Equal(r.hasher.Sum(nil), r.snapshot.Hash) {
+		r.pw.CloseWithError(errors.New("snapshot hash mismatch"))
+		<-r.done
+		return false, errors.New("snapshot hash mismatch")
+	}
+	r.pw.Close()
+	if err := <-r.done; err != nil {
+		return false, fmt.Errorf("restoring snapshot: %w", err)
+	}
+	return true, nil
+}
+
+// abortRestoration aborts the restoration in progress, if any.
+func (m *Manager) abortRestoration() {
+	if m.restoration == nil {
+		return
+	}
+	m.restoration.pw.CloseWithError(errors.New("restoration aborted"))
+	<-m.restoration.done
+	m.restoration = nil
+}
+
+// ----------------------------------------
+// chunkWriter
+
+// chunkWriter splits the written data into chunk files.
+type chunkWriter struct {
+	dir         string
+	chunkSize   int
+	hasher      hash.Hash
+	chunkHashes [][]byte
+
+	file        *os.File
+	chunkHasher hash.Hash
+	written     int
+}
+
+func (cw *chunkWriter) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		if cw.file == nil {
+			if err := cw.openChunk(); err != nil {
+				return n, err
+			}
+		}
+		size := len(p)
+		if left := cw.chunkSize - cw.written; size > left {
+			size = left
+		}
+		if _, err := cw.file.Write(p[:size]); err != nil {
+			return n, err
+		}
+		cw.hasher.Write(p[:size])
+		cw.chunkHasher.Write(p[:size])
+		cw.written += size
+		n += size
+		p = p[size:]
+
+		if cw.written == cw.chunkSize {
+			if err := cw.closeChunk(); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+func (cw *chunkWriter) openChunk() error {
+	name := filepath.Join(cw.dir, strconv.Itoa(len(cw.chunkHashes)))
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	cw.file = file
+	cw.chunkHasher = sha256.New()
+	cw.written = 0
+	return nil
+}
+
+func (cw *chunkWriter) closeChunk() error {
+	err := cw.file.Close()
+	cw.file = nil
+	cw.chunkHashes = append(cw.chunkHashes, cw.chunkHasher.Sum(nil))
+	return err
+}
+
+func (cw *chunkWriter) close() error {
+	if cw.file == nil {
+		return nil
+	}
+	return cw.closeChunk()
+}
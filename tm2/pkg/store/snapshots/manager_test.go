@@ -0,0 +1,165 @@
+package snapshots
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/rootmulti"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+var (
+	mainKey = types.NewStoreKey("main")
+	baseKey = types.NewStoreKey("base")
+)
+
+func newMultiStore(t *testing.T, db dbm.DB) types.CommitMultiStore {
+	t.Helper()
+
+	ms := rootmulti.NewMultiStore(db)
+	ms.MountStoreWithDB(mainKey, iavl.StoreConstructor, db)
+	ms.MountStoreWithDB(baseKey, dbadapter.StoreConstructor, db)
+	require.NoError(t, ms.LoadLatestVersion())
+	return ms
+}
+
+func newManager(t *testing.T, ms types.CommitMultiStore, opts Options) *Manager {
+	t.Helper()
+
+	m, err := NewManager(t.TempDir(), ms, opts)
+	require.NoError(t, err)
+	// Small chunks, to exercise the chunking.
+	m.chunkSize = 256
+	return m
+}
+
+func commitBlocks(ms types.CommitMultiStore, n int) {
+	for i := 0; i < n; i++ {
+		main, base := ms.GetStore(mainKey), ms.GetStore(baseKey)
+		for j := 0; j < 10; j++ {
+			main.Set([]byte(fmt.Sprintf("main%d-%d", i, j)), []byte("value"))
+			base.Set([]byte(fmt.Sprintf("base%d", j)), []byte(fmt.Sprintf("value%d", i)))
+		}
+		ms.Commit()
+	}
+}
+
+func TestManager_TakeRestore(t *testing.T) {
+	t.Parallel()
+
+	ms := newMultiStore(t, memdb.NewMemDB())
+	m := newManager(t, ms, Options{Interval: 2, KeepRecent: 2})
+
+	for height := int64(1); height <= 6; height++ {
+		commitBlocks(ms, 1)
+		if m.ShouldTake(height) {
+			_, err := m.Take(height)
+			require.NoError(t, err)
+		}
+	}
+
+	// Only the 2 most recent snapshots are kept.
+	snapshots, err := m.List()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.EqualValues(t, 6, snapshots[0].Height)
+	assert.EqualValues(t, 4, snapshots[1].Height)
+
+	snapshot := snapshots[0]
+	assert.Greater(t, snapshot.Chunks, uint32(1))
+
+	restoredMS := newMultiStore(t, memdb.NewMemDB())
+	restored := newManager(t, restoredMS, Options{})
+	require.NoError(t, restored.Offer(snapshot))
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk, err := m.LoadChunk(snapshot.Height, snapshot.Format, i)
+		require.NoError(t, err)
+		done, err := restored.ApplyChunk(i, chunk)
+		require.NoError(t, err)
+		assert.Equal(t, i == snapshot.Chunks-1, done)
+	}
+	assert.Equal(t, ms.LastCommitID(), restoredMS.LastCommitID())
+}
+
+func TestManager_TakeAsync(t *testing.T) {
+	t.Parallel()
+
+	ms := newMultiStore(t, memdb.NewMemDB())
+	commitBlocks(ms, 2)
+	m := newManager(t, ms, Options{Interval: 1})
+
+	// Only one snapshot is taken at a time.
+	view, err := m.begin(2)
+	require.NoError(t, err)
+	assert.ErrorIs(t, m.TakeAsync(2, nil), ErrTakingSnapshot)
+	_, err = m.write(2, view)
+	require.NoError(t, err)
+
+	var (
+		taken abci.Snapshot
+		err2  error
+	)
+	require.NoError(t, m.TakeAsync(2, func(snapshot abci.Snapshot, err error) {
+		taken, err2 = snapshot, err
+	}))
+	m.Wait()
+	require.NoError(t, err2)
+
+	snapshots, err := m.List()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, taken, snapshots[0])
+
+	// Only the last committed version can be snapshotted.
+	assert.Error(t, m.TakeAsync(1, nil))
+}
+
+func TestManager_InvalidChunks(t *testing.T) {
+	t.Parallel()
+
+	ms := newMultiStore(t, memdb.NewMemDB())
+	commitBlocks(ms, 3)
+	m := newManager(t, ms, Options{Interval: 1})
+	snapshot, err := m.Take(3)
+	require.NoError(t, err)
+	require.Greater(t, snapshot.Chunks, uint32(1))
+
+	_, err = m.LoadChunk(3, Format+1, 0)
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+	_, err = m.LoadChunk(3, Format, snapshot.Chunks)
+	assert.ErrorIs(t, err, ErrInvalidChunk)
+	_, err = m.LoadChunk(2, Format, 0)
+	assert.ErrorIs(t, err, ErrSnapshotNotFound)
+
+	restored := newManager(t, newMultiStore(t, memdb.NewMemDB()), Options{})
+	_, err = restored.ApplyChunk(0, nil)
+	assert.ErrorIs(t, err, ErrNoRestoration)
+
+	require.NoError(t, restored.Offer(snapshot))
+	chunk0, err := m.LoadChunk(3, Format, 0)
+	require.NoError(t, err)
+	chunk1, err := m.LoadChunk(3, Format, 1)
+	require.NoError(t, err)
+
+	// Out of order.
+	_, err = restored.ApplyChunk(1, chunk1)
+	assert.ErrorIs(t, err, ErrInvalidChunk)
+
+	// Tampered, then valid: the restoration goes on.
+	tampered := append([]byte(nil), chunk0...)
+	tampered[len(tampered)-1]++
+	_, err = restored.ApplyChunk(0, tampered)
+	assert.ErrorIs(t, err, ErrInvalidChunk)
+	done, err := restored.ApplyChunk(0, chunk0)
+	require.NoError(t, err)
+	assert.False(t, done)
+}
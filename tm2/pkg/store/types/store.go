@@ -3,6 +3,7 @@ package types
 import (
 	"bytes"
 	"fmt"
+	"io"
 
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
@@ -114,6 +115,15 @@ type CommitMultiStore interface {
 	// (height). An error is returned if any store cannot be loaded. This
 	// should only be used for querying and iterating at past heights.
 	MultiImmutableCacheWrapWithVersion(version int64) (MultiStore, error)
+
+	// Snapshot returns a read-only view of the stores at the given version,
+	// which must be the last committed one. The view can be written while
+	// the next versions are committed, and must be closed.
+	Snapshot(version int64) (MultiStoreSnapshot, error)
+
+	// Restore restores the stores of an empty multistore from a snapshot
+	// taken at the given version, and loads them.
+	Restore(version int64, r io.Reader) error
 }
 
 // CommitID contains the tree version number and its merkle root.
@@ -167,3 +177,54 @@ func (key *storeKey) String() string {
 // KVPair
 
 type KVPair = std.KVPair
+
+// ----------------------------------------
+// Snapshots
+
+// SnapshotItem is an item of a store snapshot. IAVL stores export the nodes
+// of their tree, other stores their key-value pairs.
+type SnapshotItem struct {
+	Key     []byte
+	Value   []byte
+	Version int64
+	Height  int8
+}
+
+// Snapshotter is implemented by the CommitStores which can be exported to,
+// and restored from, a state sync snapshot. The restored stores whose commit
+// ID commits to their items are verified against the commit info, the others
+// against a checksum of their items in the snapshot.
+type Snapshotter interface {
+	// Export returns a read-only view of the store at the given version,
+	// which can be exported while the next versions are committed.
+	Export(version int64) (SnapshotExporter, error)
+
+	// Import returns a SnapshotImporter restoring the store at the given
+	// version. The store must be empty.
+	Import(version int64) (SnapshotImporter, error)
+}
+
+// SnapshotExporter exports the items of a store at a version.
+type SnapshotExporter interface {
+	// Export calls fn with the items of the store.
+	Export(fn func(SnapshotItem) error) error
+
+	// Close releases the view of the store.
+	Close()
+}
+
+// MultiStoreSnapshot is a read-only view of the stores of a multistore at a
+// version, which can be written as a state sync snapshot.
+type MultiStoreSnapshot interface {
+	// Write writes the snapshot of the stores to w.
+	Write(w io.Writer) error
+
+	// Close releases the views of the stores.
+	Close()
+}
+
+// SnapshotImporter restores a store from the items of its snapshot.
+type SnapshotImporter interface {
+	Add(SnapshotItem) error
+	Commit() error
+}